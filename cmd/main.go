@@ -12,9 +12,16 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/kamal-github/demtech/internal/api"
 	"github.com/kamal-github/demtech/internal/config"
+	"github.com/kamal-github/demtech/internal/events"
+	"github.com/kamal-github/demtech/internal/localdns"
+	"github.com/kamal-github/demtech/internal/mailexport"
 	"github.com/kamal-github/demtech/internal/repo"
 	"github.com/kamal-github/demtech/internal/service"
+	"github.com/kamal-github/demtech/internal/smtprelay"
+	"github.com/kamal-github/demtech/internal/smtpserver"
+	"github.com/kamal-github/demtech/internal/sns"
 	"github.com/kamal-github/demtech/internal/validator"
+	"github.com/kamal-github/demtech/internal/webui"
 	"github.com/redis/go-redis/v9"
 )
 
@@ -25,11 +32,39 @@ func main() {
 	redisCli := setupRedis(env)
 
 	emailStatsRepo := repo.NewEmailStatsRepo(redisCli)
-	emailStatsService := setupEmailService(env, redisCli, emailStatsRepo)
+	templateService := service.NewTemplateService(repo.NewTemplateRepo(redisCli))
+	identityService := setupIdentityService(env, redisCli)
+	sqsService := setupSQSService(env, redisCli)
+	signer, snsService := setupSNSService(env, redisCli, sqsService)
+	configurationSetService := setupConfigurationSetService(env, redisCli, identityService)
+	eventPublisher := setupEventPublisher(env, redisCli, configurationSetService, snsService, sqsService)
+	defer eventPublisher.Close()
+	suppressionService := service.NewSuppressionService(repo.NewSuppressionRepo(redisCli), configurationSetService, env.AWSSuppressedReasons)
+	reputationService := service.NewReputationService(repo.NewReputationRepo(redisCli), configurationSetService, env.ReputationWindow, env.ReputationMinSends)
+	sentEmailTracker := repo.NewRedisEmailTracker(redisCli, env.TrackingHoursForEmailsQuota)
+	sendStatisticsRepo := repo.NewSendStatisticsRepo(redisCli)
+	sendQuota := setupSendQuota(env, redisCli, emailStatsRepo)
+	productionAccessService := service.NewProductionAccessService(repo.NewAccountDetailsRepo(redisCli), service.ProductionAccessConfig{
+		Enabled:     !env.AWSIsSandBox,
+		ReviewDelay: env.ProductionAccessReviewDelay,
+	})
+	accountService := service.NewAccountService(sendQuota, sentEmailTracker, sendStatisticsRepo, reputationService, productionAccessService, service.AccountConfig{
+		SuppressedReasons: env.AWSSuppressedReasons,
+	})
+	capturedMessageService := service.NewCapturedMessageService(repo.NewCapturedMessageRepo(redisCli), env.CaptureMaxMessages)
+	emailStatsService := setupEmailService(env, redisCli, emailStatsRepo, sentEmailTracker, sendQuota, sendStatisticsRepo, templateService, identityService, configurationSetService, suppressionService, reputationService, productionAccessService, capturedMessageService, eventPublisher)
 
-	registerRoutes(router, emailStatsService, emailStatsRepo)
+	closeMessageStreams := registerRoutes(router, emailStatsService, emailStatsRepo, templateService, identityService, configurationSetService, suppressionService, accountService, productionAccessService, reputationService, capturedMessageService)
+	registerSNSRoutes(router, env, signer, snsService)
+	registerSQSRoutes(router, env, sqsService)
+
+	if env.SMTPAddr != "" || env.SMTPTLSAddr != "" {
+		smtpServer := startSMTPServer(env, emailStatsService, emailStatsRepo)
+		defer smtpServer.Close()
+	}
 
 	server := startServer(router)
+	server.RegisterOnShutdown(closeMessageStreams)
 	gracefulShutdown(server)
 }
 
@@ -69,36 +104,343 @@ func setupRedis(env config.Env) *redis.Client {
 	return redisCli
 }
 
-// setupEmailService initializes email service and its dependencies
-func setupEmailService(env config.Env, redisCli *redis.Client, emailStatsRepo repo.EmailStatsRepoImpl) service.EmailStatsService {
-	sentEmailTracker := repo.NewRedisEmailTracker(redisCli, env.TrackingHoursForEmailsQuota)
+// setupIdentityService initializes the identity store, seeded with the verified identities of the environment
+// and the recipients allowed from the sandbox
+func setupIdentityService(env config.Env, redisCli *redis.Client) service.IdentityService {
+	cfg := service.IdentityVerificationConfig{
+		BaseURL:                 env.PublicBaseURL,
+		DomainVerificationDelay: env.DomainVerificationDelay,
+	}
+	switch {
+	case env.DNSServerAddr != "":
+		cfg.Resolver = localdns.NewServerResolver(env.DNSServerAddr)
+	case env.DNSZoneFile != "":
+		cfg.Resolver = localdns.NewZoneFile(env.DNSZoneFile)
+	}
+
+	identityService := service.NewIdentityService(repo.NewIdentityRepo(redisCli), cfg)
+
+	identities := append(append([]string{}, env.AWSVerifiedSourceEmailIDs...), env.AWSSandboxAllowedDestinations...)
+	if err := identityService.SeedVerifiedIdentities(context.Background(), identities); err != nil {
+		log.Fatalf("Failed to seed verified identities: %v", err)
+	}
+
+	return identityService
+}
+
+// setupSQSService initializes the SQS queues SES events can be published to
+func setupSQSService(env config.Env, redisCli *redis.Client) service.SQSService {
+	return service.NewSQSService(repo.NewSQSRepo(redisCli), service.SQSConfig{
+		BaseURL:   env.PublicBaseURL,
+		Region:    env.AWSRegion,
+		AccountID: env.AWSAccountID,
+	})
+}
+
+// setupSNSService initializes the SNS topics SES events can be published to, along with the signer of their messages
+func setupSNSService(env config.Env, redisCli *redis.Client, sqsService service.SQSService) (*sns.Signer, service.SNSService) {
+	signer, err := sns.NewSigner(env.PublicBaseURL + "/sns/" + sns.CertificateFile)
+	if err != nil {
+		log.Fatalf("Failed to generate the SNS signing certificate: %v", err)
+	}
+
+	return signer, service.NewSNSService(repo.NewSNSRepo(redisCli), signer, service.SNSConfig{
+		BaseURL:   env.PublicBaseURL,
+		Region:    env.AWSRegion,
+		AccountID: env.AWSAccountID,
+	}, service.WithQueueSender(sqsService))
+}
+
+// setupConfigurationSetService initializes the configuration sets, seeded with those of the environment and their event destinations
+func setupConfigurationSetService(env config.Env, redisCli *redis.Client, identityService service.IdentityService) service.ConfigurationSetService {
+	configurationSetService := service.NewConfigurationSetService(repo.NewConfigurationSetRepo(redisCli), identityService)
+
+	destinations := events.StaticDestinations{}
+	if env.EventDestinationsFile != "" {
+		var err error
+		if destinations, err = events.LoadDestinations(env.EventDestinationsFile); err != nil {
+			log.Fatalf("Failed to load event destinations: %v", err)
+		}
+	}
+	for _, name := range env.AWSConfigurationSets {
+		if _, ok := destinations[name]; !ok {
+			destinations[name] = nil
+		}
+	}
 
+	for name, dests := range destinations {
+		if err := configurationSetService.SeedConfigurationSet(context.Background(), name, dests); err != nil {
+			log.Fatalf("Failed to seed configuration set %s: %v", name, err)
+		}
+	}
+
+	return configurationSetService
+}
+
+// setupEventPublisher initializes the publishing of SES events to the event destinations of the configuration sets
+func setupEventPublisher(env config.Env, redisCli *redis.Client, configurationSetService service.ConfigurationSetService, snsService service.SNSService, sqsService service.SQSService) *events.Publisher {
+	return events.NewPublisher(configurationSetService, repo.NewEventStreamRepo(redisCli), env.AWSAccountID,
+		events.WithTopicPublisher(snsService),
+		events.WithQueueSender(sqsService),
+	)
+}
+
+// setupEmailService initializes email service and its dependencies
+func setupEmailService(env config.Env, redisCli *redis.Client, emailStatsRepo repo.EmailStatsRepoImpl, sentEmailTracker *repo.RedisEmailTracker, sendQuota validator.SendQuotaGetter, sendStatisticsRepo repo.SendStatisticsRepoImpl, templateService service.TemplateService, identityService service.IdentityService, configurationSetService service.ConfigurationSetService, suppressionService service.SuppressionService, reputationService service.ReputationService, productionAccessService service.ProductionAccessService, capturedMessageService service.CapturedMessageService, eventPublisher service.EventPublisher) service.EmailStatsService {
+	// Account level checks, they fail a bulk send as a whole.
 	validators := []service.Validator{
-		validator.NewEmailValidator(),
 		validator.NewMaxBodySizeValidator(env.AWSMaxEmailSizeAllowedBytes),
+		validator.NewAttachmentTypeValidator(),
 		validator.NewMaxDestinationsValidator(env.AWSMaxDestinations),
-		validator.NewSandboxValidator(env.AWSIsSandBox, env.AWSSandboxAllowedDestinations),
-		validator.NewVerifiedEmailValidator(env.AWSVerifiedSourceEmailIDs),
-		validator.NewQuotaValidator(sentEmailTracker, env.AWSEmailsQuotaForLastNHours),
+		validator.NewAccountSendingValidator(reputationService),
+		validator.NewVerifiedEmailValidator(identityService, env.AWSRegion),
+		validator.NewConfigurationSetValidator(configurationSetService),
+		validator.NewQuotaValidator(sentEmailTracker, sendQuota),
+	}
+
+	// Recipient level checks, they are run for each destination of a bulk send.
+	recipientValidators := []service.Validator{
+		validator.NewEmailValidator(),
+		validator.NewSandboxValidator(productionAccessService, identityService, env.AWSRegion),
+		validator.NewSuppressionListValidator(suppressionService),
+		// The send rate is checked last, so that only the recipients actually sent to use it up.
+		validator.NewSendRateValidator(sendQuota, repo.NewRedisSendRateLimiter(redisCli), env.AWSMaxSendBurst, env.AWSMaxDestinations),
+	}
+
+	opts := []service.Option{
+		service.WithRecipientValidators(recipientValidators...),
+		service.WithTemplateRenderer(templateService),
+		service.WithEventsStatsUpdater(emailStatsRepo),
+		service.WithEventPublisher(eventPublisher),
+		service.WithSuppressionList(suppressionService),
+		service.WithReputationTracker(reputationService),
+		service.WithSendStatistics(sendStatisticsRepo),
+	}
+	if env.CaptureMessages {
+		opts = append(opts, service.WithMessageCapture(capturedMessageService))
+	}
+	if env.MessageSinkDir != "" {
+		opts = append(opts, service.WithMessageCapture(setupMessageSink(env)))
+	}
+	if env.SMTPRelayAddr != "" {
+		opts = append(opts, service.WithMessageDelivery(smtprelay.NewRelay(smtprelay.Config{
+			Addr:               env.SMTPRelayAddr,
+			StartTLS:           env.SMTPRelayStartTLS,
+			InsecureSkipVerify: env.SMTPRelayInsecureSkipVerify,
+			Username:           env.SMTPRelayUsername,
+			Password:           env.SMTPRelayPassword,
+			Timeout:            env.SMTPRelayTimeout,
+		})))
 	}
 
 	emailService := service.NewEmailService(validators, sentEmailTracker, service.FailureConfig{
 		FailRandomly:   env.FailRandomly,
 		FailPercentage: env.FailPercentage,
-	})
+	}, opts...)
 
 	// Wrap email service with stats tracking
 	return service.NewEmailStatsService(emailService, emailStatsRepo, emailStatsRepo)
 }
 
-// registerRoutes sets up API routes
-func registerRoutes(router *gin.Engine, emailStatsService service.EmailStatsService, emailStatsRepo repo.EmailStatsRepoImpl) {
+// setupSendQuota gives the send quota of the account, which follows the warm-up
+// schedule when there is one.
+func setupSendQuota(env config.Env, redisCli *redis.Client, emailStatsRepo repo.EmailStatsRepoImpl) validator.SendQuotaGetter {
+	if len(env.WarmUpSchedule) == 0 {
+		return service.FixedSendQuota{Max24HourSend: env.AWSEmailsQuotaForLastNHours, MaxSendRate: env.AWSMaxSendRate}
+	}
+
+	schedule, err := service.ParseWarmUpSchedule(env.WarmUpSchedule)
+	if err != nil {
+		log.Fatalf("Failed to parse the warm-up schedule: %v", err)
+	}
+	if env.WarmUpDayDuration <= 0 {
+		log.Fatalf("Failed to configure the warm-up: WARMUP_DAY_DURATION (%s) must be positive", env.WarmUpDayDuration)
+	}
+	return service.NewWarmUpService(repo.NewWarmUpRepo(redisCli), emailStatsRepo, service.WarmUpConfig{
+		Schedule:         schedule,
+		DayDuration:      env.WarmUpDayDuration,
+		MaxBounceRate:    env.WarmUpMaxBounceRate,
+		MaxComplaintRate: env.WarmUpMaxComplaintRate,
+	})
+}
+
+// setupMessageSink creates the directory every accepted message is written to
+func setupMessageSink(env config.Env) mailexport.Sink {
+	format, err := mailexport.ParseFormat(env.MessageSinkFormat)
+	if err != nil {
+		log.Fatalf("Failed to parse the message sink format: %v", err)
+	}
+	sink, err := mailexport.NewSink(env.MessageSinkDir, format)
+	if err != nil {
+		log.Fatalf("Failed to create the message sink: %v", err)
+	}
+	return sink
+}
+
+// registerRoutes sets up API routes, it returns what ends the message streams of the web inbox
+func registerRoutes(router *gin.Engine, emailStatsService service.EmailStatsService, emailStatsRepo repo.EmailStatsRepoImpl, templateService service.TemplateService, identityService service.IdentityService, configurationSetService service.ConfigurationSetService, suppressionService service.SuppressionService, accountService service.AccountService, productionAccessService service.ProductionAccessService, reputationService service.ReputationService, capturedMessageService service.CapturedMessageService) func() {
 	apiGroup := router.Group("/api/v1")
 	emailHandler := api.NewEmailHandler(emailStatsService, emailStatsRepo)
 	emailStatsHandler := api.NewEmailStatsHandler(emailStatsService)
 
 	apiGroup.POST("/send-email", emailHandler.SendEmailHandler)
 	apiGroup.GET("/email-stats", emailStatsHandler.GetEmailStats)
+
+	identityHandler := api.NewIdentityHandler(identityService)
+
+	apiGroup.GET("/identities/:identity", identityHandler.GetIdentity)
+	apiGroup.GET("/identities/:identity/verify", identityHandler.Verify)
+	apiGroup.PUT("/identities/:identity/verification-status", identityHandler.SetVerificationStatus)
+
+	accountHandler := api.NewAccountHandler(productionAccessService)
+
+	apiGroup.PUT("/account/production-access-review", accountHandler.ReviewProductionAccess)
+
+	reputationHandler := api.NewReputationHandler(reputationService)
+
+	apiGroup.GET("/configuration-sets/:name/reputation", reputationHandler.GetConfigurationSetReputation)
+
+	messageHandler := api.NewMessageHandler(capturedMessageService)
+
+	apiGroup.GET("/messages", messageHandler.ListMessages)
+	apiGroup.DELETE("/messages", messageHandler.DeleteMessages)
+	apiGroup.GET("/messages/search", messageHandler.SearchMessages)
+	apiGroup.GET("/messages/wait", messageHandler.WaitForMessage)
+	apiGroup.GET("/messages/stream", messageHandler.StreamMessages)
+	apiGroup.GET("/messages/export", messageHandler.ExportMessages)
+	apiGroup.GET("/messages/:id", messageHandler.GetMessage)
+	apiGroup.GET("/messages/:id/raw", messageHandler.GetRawMessage)
+	apiGroup.GET("/messages/:id/export", messageHandler.ExportMessage)
+	apiGroup.GET("/messages/:id/attachments/:index", messageHandler.GetAttachment)
+	apiGroup.DELETE("/messages/:id", messageHandler.DeleteMessage)
+	apiGroup.GET("/inboxes", messageHandler.ListInboxes)
+
+	// Web inbox, browsing the messages above
+	router.StaticFS("/ui", webui.FS())
+
+	// SES v1 Query API, as spoken by the AWS SDKs
+	queryRouter := api.NewQueryRouter()
+	emailQueryHandler := api.NewEmailQueryHandler(emailStatsService, emailStatsRepo)
+
+	queryRouter.Register("SendEmail", emailQueryHandler.SendEmail)
+	queryRouter.Register("SendRawEmail", emailQueryHandler.SendRawEmail)
+	queryRouter.Register("SendTemplatedEmail", emailQueryHandler.SendTemplatedEmail)
+	queryRouter.Register("SendBulkTemplatedEmail", emailQueryHandler.SendBulkTemplatedEmail)
+
+	templateQueryHandler := api.NewTemplateQueryHandler(templateService)
+
+	queryRouter.Register("CreateTemplate", templateQueryHandler.CreateTemplate)
+	queryRouter.Register("GetTemplate", templateQueryHandler.GetTemplate)
+	queryRouter.Register("UpdateTemplate", templateQueryHandler.UpdateTemplate)
+	queryRouter.Register("DeleteTemplate", templateQueryHandler.DeleteTemplate)
+	queryRouter.Register("ListTemplates", templateQueryHandler.ListTemplates)
+
+	identityQueryHandler := api.NewIdentityQueryHandler(identityService)
+
+	queryRouter.Register("VerifyEmailIdentity", identityQueryHandler.VerifyEmailIdentity)
+	queryRouter.Register("VerifyDomainIdentity", identityQueryHandler.VerifyDomainIdentity)
+	queryRouter.Register("VerifyDomainDkim", identityQueryHandler.VerifyDomainDkim)
+	queryRouter.Register("ListIdentities", identityQueryHandler.ListIdentities)
+	queryRouter.Register("GetIdentityVerificationAttributes", identityQueryHandler.GetIdentityVerificationAttributes)
+	queryRouter.Register("GetIdentityDkimAttributes", identityQueryHandler.GetIdentityDkimAttributes)
+	queryRouter.Register("DeleteIdentity", identityQueryHandler.DeleteIdentity)
+
+	configurationSetQueryHandler := api.NewConfigurationSetQueryHandler(configurationSetService)
+
+	queryRouter.Register("CreateConfigurationSet", configurationSetQueryHandler.CreateConfigurationSet)
+	queryRouter.Register("DescribeConfigurationSet", configurationSetQueryHandler.DescribeConfigurationSet)
+	queryRouter.Register("DeleteConfigurationSet", configurationSetQueryHandler.DeleteConfigurationSet)
+	queryRouter.Register("ListConfigurationSets", configurationSetQueryHandler.ListConfigurationSets)
+	queryRouter.Register("CreateConfigurationSetEventDestination", configurationSetQueryHandler.CreateConfigurationSetEventDestination)
+	queryRouter.Register("UpdateConfigurationSetEventDestination", configurationSetQueryHandler.UpdateConfigurationSetEventDestination)
+	queryRouter.Register("DeleteConfigurationSetEventDestination", configurationSetQueryHandler.DeleteConfigurationSetEventDestination)
+	queryRouter.Register("CreateConfigurationSetTrackingOptions", configurationSetQueryHandler.CreateConfigurationSetTrackingOptions)
+	queryRouter.Register("UpdateConfigurationSetTrackingOptions", configurationSetQueryHandler.UpdateConfigurationSetTrackingOptions)
+	queryRouter.Register("DeleteConfigurationSetTrackingOptions", configurationSetQueryHandler.DeleteConfigurationSetTrackingOptions)
+	queryRouter.Register("UpdateConfigurationSetReputationMetricsEnabled", configurationSetQueryHandler.UpdateConfigurationSetReputationMetricsEnabled)
+	queryRouter.Register("UpdateConfigurationSetSendingEnabled", configurationSetQueryHandler.UpdateConfigurationSetSendingEnabled)
+
+	accountQueryHandler := api.NewAccountQueryHandler(accountService)
+
+	queryRouter.Register("GetSendQuota", accountQueryHandler.GetSendQuota)
+	queryRouter.Register("GetSendStatistics", accountQueryHandler.GetSendStatistics)
+	queryRouter.Register("GetAccountSendingEnabled", accountQueryHandler.GetAccountSendingEnabled)
+	queryRouter.Register("UpdateAccountSendingEnabled", accountQueryHandler.UpdateAccountSendingEnabled)
+	router.POST("/", queryRouter.Handle)
+
+	// SESv2 REST API
+	v2Group := router.Group("/v2/email")
+	emailV2Handler := api.NewEmailV2Handler(emailStatsService, emailStatsRepo)
+
+	v2Group.POST("/outbound-emails", emailV2Handler.SendEmail)
+
+	configurationSetV2Handler := api.NewConfigurationSetV2Handler(configurationSetService)
+
+	v2Group.POST("/configuration-sets", configurationSetV2Handler.CreateConfigurationSet)
+	v2Group.GET("/configuration-sets", configurationSetV2Handler.ListConfigurationSets)
+	v2Group.GET("/configuration-sets/:name", configurationSetV2Handler.GetConfigurationSet)
+	v2Group.DELETE("/configuration-sets/:name", configurationSetV2Handler.DeleteConfigurationSet)
+	v2Group.GET("/configuration-sets/:name/event-destinations", configurationSetV2Handler.GetEventDestinations)
+	v2Group.POST("/configuration-sets/:name/event-destinations", configurationSetV2Handler.CreateEventDestination)
+	v2Group.PUT("/configuration-sets/:name/event-destinations/:destination", configurationSetV2Handler.UpdateEventDestination)
+	v2Group.DELETE("/configuration-sets/:name/event-destinations/:destination", configurationSetV2Handler.DeleteEventDestination)
+	v2Group.PUT("/configuration-sets/:name/tracking-options", configurationSetV2Handler.PutTrackingOptions)
+	v2Group.PUT("/configuration-sets/:name/reputation-options", configurationSetV2Handler.PutReputationOptions)
+	v2Group.PUT("/configuration-sets/:name/sending", configurationSetV2Handler.PutSendingOptions)
+	v2Group.PUT("/configuration-sets/:name/suppression-options", configurationSetV2Handler.PutSuppressionOptions)
+
+	suppressionV2Handler := api.NewSuppressionV2Handler(suppressionService)
+
+	v2Group.PUT("/suppression/addresses", suppressionV2Handler.PutSuppressedDestination)
+	v2Group.GET("/suppression/addresses", suppressionV2Handler.ListSuppressedDestinations)
+	v2Group.GET("/suppression/addresses/:email", suppressionV2Handler.GetSuppressedDestination)
+	v2Group.DELETE("/suppression/addresses/:email", suppressionV2Handler.DeleteSuppressedDestination)
+
+	accountV2Handler := api.NewAccountV2Handler(accountService)
+
+	v2Group.GET("/account", accountV2Handler.GetAccount)
+	v2Group.PUT("/account/sending", accountV2Handler.PutAccountSendingAttributes)
+	v2Group.POST("/account/details", accountV2Handler.PutAccountDetails)
+
+	return messageHandler.CloseStreams
+}
+
+// registerSNSRoutes sets up the SNS Query API, which also serves the SubscribeURL and UnsubscribeURL of SNS messages
+func registerSNSRoutes(router *gin.Engine, env config.Env, signer *sns.Signer, snsService service.SNSService) {
+	snsQueryRouter := api.NewSNSQueryRouter()
+	snsQueryHandler := api.NewSNSQueryHandler(snsService, env.AWSAccountID)
+
+	snsQueryRouter.Register("CreateTopic", snsQueryHandler.CreateTopic)
+	snsQueryRouter.Register("DeleteTopic", snsQueryHandler.DeleteTopic)
+	snsQueryRouter.Register("ListTopics", snsQueryHandler.ListTopics)
+	snsQueryRouter.Register("Subscribe", snsQueryHandler.Subscribe)
+	snsQueryRouter.Register("ConfirmSubscription", snsQueryHandler.ConfirmSubscription)
+	snsQueryRouter.Register("Unsubscribe", snsQueryHandler.Unsubscribe)
+	snsQueryRouter.Register("ListSubscriptionsByTopic", snsQueryHandler.ListSubscriptionsByTopic)
+	snsQueryRouter.Register("Publish", snsQueryHandler.Publish)
+
+	router.POST("/sns", snsQueryRouter.Handle)
+	router.GET("/sns", snsQueryRouter.Handle)
+	router.GET("/sns/"+sns.CertificateFile, api.SigningCertificate(signer.CertificatePEM()))
+}
+
+// registerSQSRoutes sets up the SQS Query and JSON APIs, Query requests may also be sent to the queue URLs
+func registerSQSRoutes(router *gin.Engine, env config.Env, sqsService service.SQSService) {
+	sqsQueryRouter := api.NewSQSQueryRouter()
+	sqsQueryHandler := api.NewSQSQueryHandler(sqsService, env.AWSAccountID)
+
+	sqsQueryRouter.Register("CreateQueue", sqsQueryHandler.CreateQueue)
+	sqsQueryRouter.Register("GetQueueUrl", sqsQueryHandler.GetQueueURL)
+	sqsQueryRouter.Register("SendMessage", sqsQueryHandler.SendMessage)
+	sqsQueryRouter.Register("ReceiveMessage", sqsQueryHandler.ReceiveMessage)
+	sqsQueryRouter.Register("DeleteMessage", sqsQueryHandler.DeleteMessage)
+	sqsQueryRouter.Register("GetQueueAttributes", sqsQueryHandler.GetQueueAttributes)
+
+	endpoint := api.SQSEndpoint(sqsQueryRouter, api.NewSQSJSONHandler(sqsService, env.AWSAccountID))
+	router.POST("/sqs", endpoint)
+	router.GET("/sqs", endpoint)
+	router.POST("/sqs/:account/:queue", endpoint)
+	router.GET("/sqs/:account/:queue", endpoint)
 }
 
 // startServer initializes and starts the HTTP server
@@ -107,7 +449,7 @@ func startServer(router *gin.Engine) *http.Server {
 		Addr:           ":8080",
 		Handler:        router,
 		ReadTimeout:    10 * time.Second,
-		WriteTimeout:   10 * time.Second,
+		WriteTimeout:   30 * time.Second, // SQS long polling holds a request up to 20s
 		IdleTimeout:    60 * time.Second,
 		MaxHeaderBytes: 1 << 20, // 1MB
 	}
@@ -122,6 +464,43 @@ func startServer(router *gin.Engine) *http.Server {
 	return server
 }
 
+// startSMTPServer starts the SES SMTP interface, in front of the same email service as the APIs
+func startSMTPServer(env config.Env, emailStatsService service.EmailStatsService, emailStatsRepo repo.EmailStatsRepoImpl) *smtpserver.Server {
+	credentials, err := smtpserver.ParseAccessKeys(env.SMTPAccessKeys, env.AWSRegion)
+	if err != nil {
+		log.Fatalf("Failed to parse the SMTP access keys: %v", err)
+	}
+	if env.SMTPRelayAddr != "" && env.SMTPSendTimeout <= env.SMTPRelayTimeout {
+		log.Fatalf("Failed to configure the SMTP server: SMTP_SEND_TIMEOUT (%s) must exceed SMTP_RELAY_TIMEOUT (%s)", env.SMTPSendTimeout, env.SMTPRelayTimeout)
+	}
+
+	server, err := smtpserver.NewServer(smtpserver.Config{
+		Hostname:    "email-smtp." + env.AWSRegion + ".amazonaws.com",
+		Addr:        env.SMTPAddr,
+		TLSAddr:     env.SMTPTLSAddr,
+		Credentials: credentials,
+		SendTimeout: env.SMTPSendTimeout,
+	}, emailStatsService, emailStatsRepo)
+	if err != nil {
+		log.Fatalf("Failed to create the SMTP server: %v", err)
+	}
+
+	serve := func(addr string, listenAndServe func() error) {
+		log.Printf("Starting SMTP server on %s...", addr)
+		if err := listenAndServe(); err != nil && err != smtpserver.ErrServerClosed {
+			log.Fatalf("SMTP server error: %v", err)
+		}
+	}
+	if env.SMTPAddr != "" {
+		go serve(env.SMTPAddr, server.ListenAndServe)
+	}
+	if env.SMTPTLSAddr != "" {
+		go serve(env.SMTPTLSAddr, server.ListenAndServeTLS)
+	}
+
+	return server
+}
+
 // gracefulShutdown handles cleanup and graceful termination
 func gracefulShutdown(server *http.Server) {
 	quit := make(chan os.Signal, 1)
@@ -134,7 +513,10 @@ func gracefulShutdown(server *http.Server) {
 	defer cancel()
 
 	if err := server.Shutdown(ctx); err != nil {
-		log.Fatalf("Server forced to shutdown: %v", err)
+		// Returning rather than exiting still closes the SMTP server and the event publisher.
+		log.Printf("Server forced to shutdown: %v", err)
+		server.Close()
+		return
 	}
 
 	log.Println("Server exited properly")
@@ -6,12 +6,14 @@ import (
 	"bytes"
 	"encoding/json"
 	"net/http"
+	"net/url"
 	"os"
 	"testing"
 	"time"
 
 	"github.com/kamal-github/demtech/internal/model"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestSendEmailAPI(t *testing.T) {
@@ -37,6 +39,7 @@ func TestSendEmailAPI(t *testing.T) {
 			{Name: "userId", Value: "12345"},
 		},
 	}
+	start := time.Now().UTC()
 	body, err := json.Marshal(emailReq)
 	assert.NoError(t, err)
 	req, err := http.NewRequest("POST", apiBaseURL+"/api/v1/send-email", bytes.NewBuffer(body))
@@ -46,6 +49,24 @@ func TestSendEmailAPI(t *testing.T) {
 
 	client := &http.Client{Timeout: 15 * time.Second}
 	resp, err := client.Do(req)
-	assert.NoError(t, err)
+	require.NoError(t, err)
 	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	query := url.Values{
+		"Recipient":            {"recipient@example.com"},
+		"SubjectRegex":         {"^Test Email"},
+		"Tag":                  {"campaign:welcome-email"},
+		"ConfigurationSetName": {"default-config"},
+		"StartDate":            {start.Format(time.RFC3339)},
+		"WaitTimeSeconds":      {"10"},
+	}
+	resp, err = client.Get(apiBaseURL + "/api/v1/messages/wait?" + query.Encode())
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var captured model.CapturedMessage
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&captured))
+	assert.Equal(t, "Test Email Subject", captured.Subject)
+	assert.Equal(t, "This is the email body.", captured.TextBody)
 }
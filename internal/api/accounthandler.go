@@ -0,0 +1,43 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ProductionAccessReviewer interface {
+	ReviewProductionAccess(ctx context.Context, status string) error
+}
+
+// AccountHandler reviews production access requests, which the SES support
+// team does out of band.
+type AccountHandler struct {
+	service ProductionAccessReviewer
+}
+
+// NewAccountHandler creates a new AccountHandler
+func NewAccountHandler(s ProductionAccessReviewer) *AccountHandler {
+	return &AccountHandler{service: s}
+}
+
+type reviewProductionAccessRequest struct {
+	Status string `json:"Status" binding:"required"`
+}
+
+// ReviewProductionAccess grants or denies the pending production access request
+func (h *AccountHandler) ReviewProductionAccess(c *gin.Context) {
+	var req reviewProductionAccessRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "InvalidParameterValue", "message": err.Error()})
+		return
+	}
+
+	if err := h.service.ReviewProductionAccess(c.Request.Context(), req.Status); err != nil {
+		writeAPIError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
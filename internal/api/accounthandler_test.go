@@ -0,0 +1,73 @@
+package api_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/kamal-github/demtech/internal/api"
+	"github.com/kamal-github/demtech/internal/api/mocks"
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccountHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockProductionAccessReviewer(ctrl)
+	h := api.NewAccountHandler(mockService)
+
+	router := gin.New()
+	router.PUT("/api/v1/account/production-access-review", h.ReviewProductionAccess)
+
+	tests := []struct {
+		name       string
+		body       string
+		mockSetup  func()
+		expectCode int
+	}{
+		{
+			name: "Grant production access",
+			body: `{"Status":"GRANTED"}`,
+			mockSetup: func() {
+				mockService.EXPECT().ReviewProductionAccess(gomock.Any(), model.ReviewStatusGranted).Return(nil)
+			},
+			expectCode: http.StatusNoContent,
+		},
+		{
+			name: "No pending request",
+			body: `{"Status":"DENIED"}`,
+			mockSetup: func() {
+				mockService.EXPECT().ReviewProductionAccess(gomock.Any(), model.ReviewStatusDenied).
+					Return(&model.SESError{Code: "NotFoundException", Message: "No production access request is pending."})
+			},
+			expectCode: http.StatusNotFound,
+		},
+		{
+			name:       "Review without status",
+			body:       `{}`,
+			mockSetup:  func() {},
+			expectCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPut, "/api/v1/account/production-access-review", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectCode, w.Code)
+		})
+	}
+}
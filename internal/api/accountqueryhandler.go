@@ -0,0 +1,108 @@
+package api
+
+import (
+	"context"
+	"encoding/xml"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kamal-github/demtech/internal/model"
+)
+
+type AccountService interface {
+	GetAccount(ctx context.Context) (model.Account, error)
+	GetSendQuota(ctx context.Context) (model.SendQuota, error)
+	GetSendStatistics(ctx context.Context) ([]model.SendDataPoint, error)
+	UpdateAccountSendingEnabled(ctx context.Context, enabled bool) error
+	PutAccountDetails(ctx context.Context, details model.AccountDetails, productionAccessEnabled bool) error
+}
+
+// AccountQueryHandler serves the account actions of the SES v1 Query API.
+type AccountQueryHandler struct {
+	service AccountService
+}
+
+// NewAccountQueryHandler creates a new AccountQueryHandler
+func NewAccountQueryHandler(s AccountService) *AccountQueryHandler {
+	return &AccountQueryHandler{service: s}
+}
+
+type getAccountSendingEnabledResult struct {
+	XMLName xml.Name `xml:"GetAccountSendingEnabledResult"`
+	Enabled bool     `xml:"Enabled"`
+}
+
+type getSendQuotaResult struct {
+	XMLName         xml.Name `xml:"GetSendQuotaResult"`
+	Max24HourSend   float64  `xml:"Max24HourSend"`
+	MaxSendRate     float64  `xml:"MaxSendRate"`
+	SentLast24Hours float64  `xml:"SentLast24Hours"`
+}
+
+type getSendStatisticsResult struct {
+	XMLName        xml.Name           `xml:"GetSendStatisticsResult"`
+	SendDataPoints []xmlSendDataPoint `xml:"SendDataPoints>member"`
+}
+
+type xmlSendDataPoint struct {
+	Timestamp        time.Time `xml:"Timestamp"`
+	DeliveryAttempts int64     `xml:"DeliveryAttempts"`
+	Bounces          int64     `xml:"Bounces"`
+	Complaints       int64     `xml:"Complaints"`
+	Rejects          int64     `xml:"Rejects"`
+}
+
+// GetAccountSendingEnabled handles Action=GetAccountSendingEnabled
+func (h *AccountQueryHandler) GetAccountSendingEnabled(c *gin.Context, _ url.Values) (any, error) {
+	account, err := h.service.GetAccount(c.Request.Context())
+	if err != nil {
+		return nil, err
+	}
+	return getAccountSendingEnabledResult{Enabled: account.SendingEnabled}, nil
+}
+
+// GetSendQuota handles Action=GetSendQuota
+func (h *AccountQueryHandler) GetSendQuota(c *gin.Context, _ url.Values) (any, error) {
+	quota, err := h.service.GetSendQuota(c.Request.Context())
+	if err != nil {
+		return nil, err
+	}
+	return getSendQuotaResult{
+		Max24HourSend:   float64(quota.Max24HourSend),
+		MaxSendRate:     quota.MaxSendRate,
+		SentLast24Hours: float64(quota.SentLast24Hours),
+	}, nil
+}
+
+// GetSendStatistics handles Action=GetSendStatistics
+func (h *AccountQueryHandler) GetSendStatistics(c *gin.Context, _ url.Values) (any, error) {
+	points, err := h.service.GetSendStatistics(c.Request.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	result := getSendStatisticsResult{SendDataPoints: make([]xmlSendDataPoint, 0, len(points))}
+	for _, p := range points {
+		result.SendDataPoints = append(result.SendDataPoints, xmlSendDataPoint(p))
+	}
+	return result, nil
+}
+
+// UpdateAccountSendingEnabled handles Action=UpdateAccountSendingEnabled
+func (h *AccountQueryHandler) UpdateAccountSendingEnabled(c *gin.Context, form url.Values) (any, error) {
+	v := form.Get("Enabled")
+	if v == "" {
+		return nil, missingParameter("Enabled")
+	}
+	enabled, err := strconv.ParseBool(v)
+	if err != nil {
+		return nil, &model.SESError{Code: "InvalidParameterValue", Message: "Value " + v + " for parameter Enabled is invalid. Reason: must be a boolean."}
+	}
+
+	if err := h.service.UpdateAccountSendingEnabled(c.Request.Context(), enabled); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
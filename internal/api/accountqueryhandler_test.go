@@ -0,0 +1,127 @@
+package api_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/kamal-github/demtech/internal/api"
+	"github.com/kamal-github/demtech/internal/api/mocks"
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccountQueryHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAccountService := mocks.NewMockAccountService(ctrl)
+	h := api.NewAccountQueryHandler(mockAccountService)
+
+	queryRouter := api.NewQueryRouter()
+	queryRouter.Register("GetSendQuota", h.GetSendQuota)
+	queryRouter.Register("GetSendStatistics", h.GetSendStatistics)
+	queryRouter.Register("GetAccountSendingEnabled", h.GetAccountSendingEnabled)
+	queryRouter.Register("UpdateAccountSendingEnabled", h.UpdateAccountSendingEnabled)
+
+	router := gin.New()
+	router.POST("/", queryRouter.Handle)
+
+	tests := []struct {
+		name         string
+		form         url.Values
+		mockSetup    func()
+		expectCode   int
+		expectInBody []string
+	}{
+		{
+			name: "Get send quota",
+			form: url.Values{"Action": {"GetSendQuota"}},
+			mockSetup: func() {
+				mockAccountService.EXPECT().GetSendQuota(gomock.Any()).Return(model.SendQuota{Max24HourSend: 200, MaxSendRate: 1.5, SentLast24Hours: 12}, nil)
+			},
+			expectCode:   http.StatusOK,
+			expectInBody: []string{`<GetSendQuotaResult><Max24HourSend>200</Max24HourSend><MaxSendRate>1.5</MaxSendRate><SentLast24Hours>12</SentLast24Hours></GetSendQuotaResult>`},
+		},
+		{
+			name: "Get send statistics",
+			form: url.Values{"Action": {"GetSendStatistics"}},
+			mockSetup: func() {
+				mockAccountService.EXPECT().GetSendStatistics(gomock.Any()).Return([]model.SendDataPoint{
+					{Timestamp: time.Date(2024, 1, 2, 3, 15, 0, 0, time.UTC), DeliveryAttempts: 10, Bounces: 1, Complaints: 2, Rejects: 3},
+				}, nil)
+			},
+			expectCode: http.StatusOK,
+			expectInBody: []string{
+				`<GetSendStatisticsResult><SendDataPoints><member><Timestamp>2024-01-02T03:15:00Z</Timestamp><DeliveryAttempts>10</DeliveryAttempts><Bounces>1</Bounces><Complaints>2</Complaints><Rejects>3</Rejects></member></SendDataPoints></GetSendStatisticsResult>`,
+			},
+		},
+		{
+			name: "Get send statistics fails",
+			form: url.Values{"Action": {"GetSendStatistics"}},
+			mockSetup: func() {
+				mockAccountService.EXPECT().GetSendStatistics(gomock.Any()).Return(nil, assert.AnError)
+			},
+			expectCode:   http.StatusInternalServerError,
+			expectInBody: []string{`<Code>InternalFailure</Code>`},
+		},
+		{
+			name: "Get account sending enabled",
+			form: url.Values{"Action": {"GetAccountSendingEnabled"}},
+			mockSetup: func() {
+				mockAccountService.EXPECT().GetAccount(gomock.Any()).
+					Return(model.Account{SendingEnabled: false, EnforcementStatus: model.EnforcementStatusShutdown}, nil)
+			},
+			expectCode:   http.StatusOK,
+			expectInBody: []string{`<GetAccountSendingEnabledResult><Enabled>false</Enabled></GetAccountSendingEnabledResult>`},
+		},
+		{
+			name: "Resume account sending",
+			form: url.Values{"Action": {"UpdateAccountSendingEnabled"}, "Enabled": {"true"}},
+			mockSetup: func() {
+				mockAccountService.EXPECT().UpdateAccountSendingEnabled(gomock.Any(), true).Return(nil)
+			},
+			expectCode:   http.StatusOK,
+			expectInBody: []string{`<UpdateAccountSendingEnabledResponse xmlns="http://ses.amazonaws.com/doc/2010-12-01/"><ResponseMetadata>`},
+		},
+		{
+			name:         "Update account sending without Enabled",
+			form:         url.Values{"Action": {"UpdateAccountSendingEnabled"}},
+			mockSetup:    func() {},
+			expectCode:   http.StatusBadRequest,
+			expectInBody: []string{`<Message>Missing required parameter Enabled.</Message>`},
+		},
+		{
+			name:         "Update account sending with invalid Enabled",
+			form:         url.Values{"Action": {"UpdateAccountSendingEnabled"}, "Enabled": {"maybe"}},
+			mockSetup:    func() {},
+			expectCode:   http.StatusBadRequest,
+			expectInBody: []string{`<Code>InvalidParameterValue</Code>`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			tt.mockSetup()
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/", strings.NewReader(tt.form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(tt.expectCode, w.Code)
+			for _, s := range tt.expectInBody {
+				assert.Contains(w.Body.String(), s)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kamal-github/demtech/internal/model"
+)
+
+// AccountV2Handler serves the account operations of the SESv2 REST API
+// (/v2/email/account).
+type AccountV2Handler struct {
+	service AccountService
+}
+
+// NewAccountV2Handler creates a new AccountV2Handler
+func NewAccountV2Handler(s AccountService) *AccountV2Handler {
+	return &AccountV2Handler{service: s}
+}
+
+type v2SendQuota struct {
+	Max24HourSend   float64 `json:"Max24HourSend"`
+	MaxSendRate     float64 `json:"MaxSendRate"`
+	SentLast24Hours float64 `json:"SentLast24Hours"`
+}
+
+type v2ReviewDetails struct {
+	CaseId string `json:"CaseId"`
+	Status string `json:"Status"`
+}
+
+type v2AccountDetails struct {
+	AdditionalContactEmailAddresses []string         `json:"AdditionalContactEmailAddresses,omitempty"`
+	ContactLanguage                 string           `json:"ContactLanguage,omitempty"`
+	MailType                        string           `json:"MailType"`
+	ReviewDetails                   *v2ReviewDetails `json:"ReviewDetails,omitempty"`
+	UseCaseDescription              string           `json:"UseCaseDescription,omitempty"`
+	WebsiteURL                      string           `json:"WebsiteURL"`
+}
+
+type v2Account struct {
+	DedicatedIpAutoWarmupEnabled bool                     `json:"DedicatedIpAutoWarmupEnabled"`
+	Details                      *v2AccountDetails        `json:"Details,omitempty"`
+	EnforcementStatus            string                   `json:"EnforcementStatus"`
+	ProductionAccessEnabled      bool                     `json:"ProductionAccessEnabled"`
+	SendQuota                    v2SendQuota              `json:"SendQuota"`
+	SendingEnabled               bool                     `json:"SendingEnabled"`
+	SuppressionAttributes        model.SuppressionOptions `json:"SuppressionAttributes"`
+}
+
+// GetAccount handles GET /v2/email/account
+func (h *AccountV2Handler) GetAccount(c *gin.Context) {
+	account, err := h.service.GetAccount(c.Request.Context())
+	if err != nil {
+		writeV2Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, v2Account{
+		Details:                 encodeV2AccountDetails(account.Details),
+		EnforcementStatus:       account.EnforcementStatus,
+		ProductionAccessEnabled: account.ProductionAccessEnabled,
+		SendQuota: v2SendQuota{
+			Max24HourSend:   float64(account.SendQuota.Max24HourSend),
+			MaxSendRate:     account.SendQuota.MaxSendRate,
+			SentLast24Hours: float64(account.SendQuota.SentLast24Hours),
+		},
+		SendingEnabled:        account.SendingEnabled,
+		SuppressionAttributes: model.SuppressionOptions{SuppressedReasons: account.SuppressedReasons},
+	})
+}
+
+// PutAccountSendingAttributes handles PUT /v2/email/account/sending
+func (h *AccountV2Handler) PutAccountSendingAttributes(c *gin.Context) {
+	var body v2SendingOptions
+	if !decodeV2Body(c, &body) {
+		return
+	}
+
+	if err := h.service.UpdateAccountSendingEnabled(c.Request.Context(), body.SendingEnabled); err != nil {
+		writeV2Error(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+type v2PutAccountDetailsRequest struct {
+	AdditionalContactEmailAddresses []string `json:"AdditionalContactEmailAddresses"`
+	ContactLanguage                 string   `json:"ContactLanguage"`
+	MailType                        string   `json:"MailType"`
+	ProductionAccessEnabled         bool     `json:"ProductionAccessEnabled"`
+	UseCaseDescription              string   `json:"UseCaseDescription"`
+	WebsiteURL                      string   `json:"WebsiteURL"`
+}
+
+// PutAccountDetails handles POST /v2/email/account/details
+func (h *AccountV2Handler) PutAccountDetails(c *gin.Context) {
+	var body v2PutAccountDetailsRequest
+	if !decodeV2Body(c, &body) {
+		return
+	}
+
+	details := model.AccountDetails{
+		MailType:                        body.MailType,
+		WebsiteURL:                      body.WebsiteURL,
+		ContactLanguage:                 body.ContactLanguage,
+		UseCaseDescription:              body.UseCaseDescription,
+		AdditionalContactEmailAddresses: body.AdditionalContactEmailAddresses,
+	}
+	if err := h.service.PutAccountDetails(c.Request.Context(), details, body.ProductionAccessEnabled); err != nil {
+		writeV2Error(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+func encodeV2AccountDetails(d *model.AccountDetails) *v2AccountDetails {
+	if d == nil {
+		return nil
+	}
+
+	details := &v2AccountDetails{
+		AdditionalContactEmailAddresses: d.AdditionalContactEmailAddresses,
+		ContactLanguage:                 d.ContactLanguage,
+		MailType:                        d.MailType,
+		UseCaseDescription:              d.UseCaseDescription,
+		WebsiteURL:                      d.WebsiteURL,
+	}
+	if d.ReviewDetails != nil {
+		details.ReviewDetails = &v2ReviewDetails{CaseId: d.ReviewDetails.CaseId, Status: d.ReviewDetails.Status}
+	}
+	return details
+}
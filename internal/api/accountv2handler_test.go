@@ -0,0 +1,149 @@
+package api_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/kamal-github/demtech/internal/api"
+	"github.com/kamal-github/demtech/internal/api/mocks"
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccountV2Handler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAccountService := mocks.NewMockAccountService(ctrl)
+	h := api.NewAccountV2Handler(mockAccountService)
+
+	router := gin.New()
+	account := router.Group("/v2/email/account")
+	account.GET("", h.GetAccount)
+	account.PUT("/sending", h.PutAccountSendingAttributes)
+	account.POST("/details", h.PutAccountDetails)
+
+	tests := []struct {
+		name            string
+		method          string
+		path            string
+		body            string
+		mockSetup       func()
+		expectCode      int
+		expectErrorType string
+		expectInBody    []string
+	}{
+		{
+			name:   "Get account",
+			method: http.MethodGet,
+			path:   "/v2/email/account",
+			mockSetup: func() {
+				mockAccountService.EXPECT().GetAccount(gomock.Any()).Return(model.Account{
+					SendQuota:               model.SendQuota{Max24HourSend: 200, MaxSendRate: 1, SentLast24Hours: 3},
+					SendingEnabled:          true,
+					EnforcementStatus:       model.EnforcementStatusProbation,
+					ProductionAccessEnabled: false,
+					SuppressedReasons:       []string{"BOUNCE", "COMPLAINT"},
+				}, nil)
+			},
+			expectCode: http.StatusOK,
+			expectInBody: []string{
+				`"EnforcementStatus":"PROBATION"`,
+				`"ProductionAccessEnabled":false`,
+				`"SendQuota":{"Max24HourSend":200,"MaxSendRate":1,"SentLast24Hours":3}`,
+				`"SendingEnabled":true`,
+				`"SuppressionAttributes":{"SuppressedReasons":["BOUNCE","COMPLAINT"]}`,
+			},
+		},
+		{
+			name:   "Resume account sending",
+			method: http.MethodPut,
+			path:   "/v2/email/account/sending",
+			body:   `{"SendingEnabled":true}`,
+			mockSetup: func() {
+				mockAccountService.EXPECT().UpdateAccountSendingEnabled(gomock.Any(), true).Return(nil)
+			},
+			expectCode: http.StatusOK,
+		},
+		{
+			name:   "Get account with details under review",
+			method: http.MethodGet,
+			path:   "/v2/email/account",
+			mockSetup: func() {
+				mockAccountService.EXPECT().GetAccount(gomock.Any()).Return(model.Account{
+					Details: &model.AccountDetails{
+						MailType:      model.MailTypeTransactional,
+						WebsiteURL:    "https://example.com",
+						ReviewDetails: &model.ReviewDetails{Status: model.ReviewStatusPending, CaseId: "case-1"},
+					},
+				}, nil)
+			},
+			expectCode: http.StatusOK,
+			expectInBody: []string{
+				`"Details":{"MailType":"TRANSACTIONAL","ReviewDetails":{"CaseId":"case-1","Status":"PENDING"},"WebsiteURL":"https://example.com"}`,
+			},
+		},
+		{
+			name:   "Request production access",
+			method: http.MethodPost,
+			path:   "/v2/email/account/details",
+			body:   `{"MailType":"MARKETING","WebsiteURL":"https://example.com","ContactLanguage":"EN","AdditionalContactEmailAddresses":["ops@example.com"],"ProductionAccessEnabled":true}`,
+			mockSetup: func() {
+				mockAccountService.EXPECT().PutAccountDetails(gomock.Any(), model.AccountDetails{
+					MailType:                        model.MailTypeMarketing,
+					WebsiteURL:                      "https://example.com",
+					ContactLanguage:                 model.ContactLanguageEN,
+					AdditionalContactEmailAddresses: []string{"ops@example.com"},
+				}, true).Return(nil)
+			},
+			expectCode:   http.StatusOK,
+			expectInBody: []string{`{}`},
+		},
+		{
+			name:   "Request production access while under review",
+			method: http.MethodPost,
+			path:   "/v2/email/account/details",
+			body:   `{"MailType":"MARKETING","WebsiteURL":"https://example.com","ProductionAccessEnabled":true}`,
+			mockSetup: func() {
+				mockAccountService.EXPECT().PutAccountDetails(gomock.Any(), gomock.Any(), true).
+					Return(&model.SESError{Code: "ConflictException", Message: "Account details are under review, case case-1."})
+			},
+			expectCode:      http.StatusConflict,
+			expectErrorType: "ConflictException",
+		},
+		{
+			name:            "Update account sending with invalid body",
+			method:          http.MethodPut,
+			path:            "/v2/email/account/sending",
+			body:            `{`,
+			mockSetup:       func() {},
+			expectCode:      http.StatusBadRequest,
+			expectErrorType: "BadRequestException",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			tt.mockSetup()
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(tt.expectCode, w.Code)
+			assert.Equal(tt.expectErrorType, w.Header().Get("x-amzn-ErrorType"))
+			for _, s := range tt.expectInBody {
+				assert.Contains(w.Body.String(), s)
+			}
+		})
+	}
+}
@@ -0,0 +1,361 @@
+package api
+
+import (
+	"context"
+	"encoding/xml"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kamal-github/demtech/internal/model"
+)
+
+type ConfigurationSetService interface {
+	CreateConfigurationSet(ctx context.Context, set model.ConfigurationSet) error
+	GetConfigurationSet(ctx context.Context, name string) (model.ConfigurationSet, error)
+	DeleteConfigurationSet(ctx context.Context, name string) error
+	ListConfigurationSets(ctx context.Context, maxItems int, nextToken string) ([]model.ConfigurationSet, string, error)
+	CreateEventDestination(ctx context.Context, configurationSetName string, d model.EventDestination) error
+	UpdateEventDestination(ctx context.Context, configurationSetName string, d model.EventDestination) error
+	DeleteEventDestination(ctx context.Context, configurationSetName, eventDestinationName string) error
+	CreateTrackingOptions(ctx context.Context, configurationSetName string, opts model.TrackingOptions) error
+	UpdateTrackingOptions(ctx context.Context, configurationSetName string, opts model.TrackingOptions) error
+	DeleteTrackingOptions(ctx context.Context, configurationSetName string) error
+	PutTrackingOptions(ctx context.Context, configurationSetName string, opts *model.TrackingOptions) error
+	PutReputationOptions(ctx context.Context, configurationSetName string, enabled bool) error
+	PutSendingOptions(ctx context.Context, configurationSetName string, enabled bool) error
+	PutSuppressionOptions(ctx context.Context, configurationSetName string, opts *model.SuppressionOptions) error
+}
+
+// ConfigurationSetQueryHandler serves the configuration set actions of the SES v1 Query API.
+type ConfigurationSetQueryHandler struct {
+	service ConfigurationSetService
+}
+
+// NewConfigurationSetQueryHandler creates a new ConfigurationSetQueryHandler
+func NewConfigurationSetQueryHandler(s ConfigurationSetService) *ConfigurationSetQueryHandler {
+	return &ConfigurationSetQueryHandler{service: s}
+}
+
+type xmlConfigurationSet struct {
+	Name string `xml:"Name"`
+}
+
+type xmlEventDestination struct {
+	Name                   string                        `xml:"Name"`
+	Enabled                bool                          `xml:"Enabled"`
+	MatchingEventTypes     []string                      `xml:"MatchingEventTypes>member"`
+	WebhookDestination     *model.WebhookDestination     `xml:"WebhookDestination,omitempty"`
+	FileDestination        *model.FileDestination        `xml:"FileDestination,omitempty"`
+	RedisStreamDestination *model.RedisStreamDestination `xml:"RedisStreamDestination,omitempty"`
+	SNSDestination         *model.SNSDestination         `xml:"SNSDestination,omitempty"`
+	SQSDestination         *model.SQSDestination         `xml:"SQSDestination,omitempty"`
+}
+
+// xmlEventDestinations is only set when eventDestinations were asked for, in
+// which case an empty list is still returned.
+type xmlEventDestinations struct {
+	Members []xmlEventDestination `xml:"member"`
+}
+
+type xmlReputationOptions struct {
+	SendingEnabled           bool       `xml:"SendingEnabled"`
+	ReputationMetricsEnabled bool       `xml:"ReputationMetricsEnabled"`
+	LastFreshStart           *time.Time `xml:"LastFreshStart,omitempty"`
+}
+
+type createConfigurationSetResult struct {
+	XMLName xml.Name `xml:"CreateConfigurationSetResult"`
+}
+
+type deleteConfigurationSetResult struct {
+	XMLName xml.Name `xml:"DeleteConfigurationSetResult"`
+}
+
+type describeConfigurationSetResult struct {
+	XMLName           xml.Name               `xml:"DescribeConfigurationSetResult"`
+	ConfigurationSet  xmlConfigurationSet    `xml:"ConfigurationSet"`
+	EventDestinations *xmlEventDestinations  `xml:"EventDestinations,omitempty"`
+	TrackingOptions   *model.TrackingOptions `xml:"TrackingOptions,omitempty"`
+	ReputationOptions *xmlReputationOptions  `xml:"ReputationOptions,omitempty"`
+}
+
+type listConfigurationSetsResult struct {
+	XMLName           xml.Name              `xml:"ListConfigurationSetsResult"`
+	ConfigurationSets []xmlConfigurationSet `xml:"ConfigurationSets>member"`
+	NextToken         string                `xml:"NextToken,omitempty"`
+}
+
+type createConfigurationSetEventDestinationResult struct {
+	XMLName xml.Name `xml:"CreateConfigurationSetEventDestinationResult"`
+}
+
+type updateConfigurationSetEventDestinationResult struct {
+	XMLName xml.Name `xml:"UpdateConfigurationSetEventDestinationResult"`
+}
+
+type deleteConfigurationSetEventDestinationResult struct {
+	XMLName xml.Name `xml:"DeleteConfigurationSetEventDestinationResult"`
+}
+
+type createConfigurationSetTrackingOptionsResult struct {
+	XMLName xml.Name `xml:"CreateConfigurationSetTrackingOptionsResult"`
+}
+
+type updateConfigurationSetTrackingOptionsResult struct {
+	XMLName xml.Name `xml:"UpdateConfigurationSetTrackingOptionsResult"`
+}
+
+type deleteConfigurationSetTrackingOptionsResult struct {
+	XMLName xml.Name `xml:"DeleteConfigurationSetTrackingOptionsResult"`
+}
+
+// CreateConfigurationSet handles Action=CreateConfigurationSet
+func (h *ConfigurationSetQueryHandler) CreateConfigurationSet(c *gin.Context, form url.Values) (any, error) {
+	name := form.Get("ConfigurationSet.Name")
+	if name == "" {
+		return nil, missingParameter("ConfigurationSet.Name")
+	}
+
+	if err := h.service.CreateConfigurationSet(c.Request.Context(), model.ConfigurationSet{Name: name, SendingEnabled: true}); err != nil {
+		return nil, err
+	}
+	return createConfigurationSetResult{}, nil
+}
+
+// DescribeConfigurationSet handles Action=DescribeConfigurationSet, only the
+// attributes listed in ConfigurationSetAttributeNames are returned.
+func (h *ConfigurationSetQueryHandler) DescribeConfigurationSet(c *gin.Context, form url.Values) (any, error) {
+	name := form.Get("ConfigurationSetName")
+	if name == "" {
+		return nil, missingParameter("ConfigurationSetName")
+	}
+
+	set, err := h.service.GetConfigurationSet(c.Request.Context(), name)
+	if err != nil {
+		return nil, err
+	}
+
+	result := describeConfigurationSetResult{ConfigurationSet: xmlConfigurationSet{Name: set.Name}}
+	for _, attr := range memberList(form, "ConfigurationSetAttributeNames") {
+		switch attr {
+		case "eventDestinations":
+			result.EventDestinations = &xmlEventDestinations{}
+			for _, d := range set.EventDestinations {
+				result.EventDestinations.Members = append(result.EventDestinations.Members, xmlEventDestination(d))
+			}
+		case "trackingOptions":
+			result.TrackingOptions = set.TrackingOptions
+		case "reputationOptions":
+			result.ReputationOptions = &xmlReputationOptions{
+				SendingEnabled:           set.SendingEnabled,
+				ReputationMetricsEnabled: set.ReputationMetricsEnabled,
+				LastFreshStart:           set.LastFreshStart,
+			}
+		case "deliveryOptions":
+			// TLS policies do not apply to the mock
+		default:
+			return nil, &model.SESError{Code: "InvalidParameterValue", Message: "Invalid configuration set attribute name " + attr + "."}
+		}
+	}
+	return result, nil
+}
+
+// DeleteConfigurationSet handles Action=DeleteConfigurationSet
+func (h *ConfigurationSetQueryHandler) DeleteConfigurationSet(c *gin.Context, form url.Values) (any, error) {
+	name := form.Get("ConfigurationSetName")
+	if name == "" {
+		return nil, missingParameter("ConfigurationSetName")
+	}
+
+	if err := h.service.DeleteConfigurationSet(c.Request.Context(), name); err != nil {
+		return nil, err
+	}
+	return deleteConfigurationSetResult{}, nil
+}
+
+// ListConfigurationSets handles Action=ListConfigurationSets
+func (h *ConfigurationSetQueryHandler) ListConfigurationSets(c *gin.Context, form url.Values) (any, error) {
+	maxItems, err := optionalInt(form, "MaxItems")
+	if err != nil {
+		return nil, err
+	}
+	if maxItems == nil {
+		maxItems = new(int)
+	}
+
+	list, nextToken, err := h.service.ListConfigurationSets(c.Request.Context(), *maxItems, form.Get("NextToken"))
+	if err != nil {
+		return nil, err
+	}
+
+	result := listConfigurationSetsResult{NextToken: nextToken}
+	for _, set := range list {
+		result.ConfigurationSets = append(result.ConfigurationSets, xmlConfigurationSet{Name: set.Name})
+	}
+	return result, nil
+}
+
+// CreateConfigurationSetEventDestination handles Action=CreateConfigurationSetEventDestination
+func (h *ConfigurationSetQueryHandler) CreateConfigurationSetEventDestination(c *gin.Context, form url.Values) (any, error) {
+	name, d, err := decodeEventDestination(form)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.service.CreateEventDestination(c.Request.Context(), name, d); err != nil {
+		return nil, err
+	}
+	return createConfigurationSetEventDestinationResult{}, nil
+}
+
+// UpdateConfigurationSetEventDestination handles Action=UpdateConfigurationSetEventDestination
+func (h *ConfigurationSetQueryHandler) UpdateConfigurationSetEventDestination(c *gin.Context, form url.Values) (any, error) {
+	name, d, err := decodeEventDestination(form)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.service.UpdateEventDestination(c.Request.Context(), name, d); err != nil {
+		return nil, err
+	}
+	return updateConfigurationSetEventDestinationResult{}, nil
+}
+
+// DeleteConfigurationSetEventDestination handles Action=DeleteConfigurationSetEventDestination
+func (h *ConfigurationSetQueryHandler) DeleteConfigurationSetEventDestination(c *gin.Context, form url.Values) (any, error) {
+	for _, p := range []string{"ConfigurationSetName", "EventDestinationName"} {
+		if form.Get(p) == "" {
+			return nil, missingParameter(p)
+		}
+	}
+
+	if err := h.service.DeleteEventDestination(c.Request.Context(), form.Get("ConfigurationSetName"), form.Get("EventDestinationName")); err != nil {
+		return nil, err
+	}
+	return deleteConfigurationSetEventDestinationResult{}, nil
+}
+
+// CreateConfigurationSetTrackingOptions handles Action=CreateConfigurationSetTrackingOptions
+func (h *ConfigurationSetQueryHandler) CreateConfigurationSetTrackingOptions(c *gin.Context, form url.Values) (any, error) {
+	name := form.Get("ConfigurationSetName")
+	if name == "" {
+		return nil, missingParameter("ConfigurationSetName")
+	}
+
+	opts := model.TrackingOptions{CustomRedirectDomain: form.Get("TrackingOptions.CustomRedirectDomain")}
+	if err := h.service.CreateTrackingOptions(c.Request.Context(), name, opts); err != nil {
+		return nil, err
+	}
+	return createConfigurationSetTrackingOptionsResult{}, nil
+}
+
+// UpdateConfigurationSetTrackingOptions handles Action=UpdateConfigurationSetTrackingOptions
+func (h *ConfigurationSetQueryHandler) UpdateConfigurationSetTrackingOptions(c *gin.Context, form url.Values) (any, error) {
+	name := form.Get("ConfigurationSetName")
+	if name == "" {
+		return nil, missingParameter("ConfigurationSetName")
+	}
+
+	opts := model.TrackingOptions{CustomRedirectDomain: form.Get("TrackingOptions.CustomRedirectDomain")}
+	if err := h.service.UpdateTrackingOptions(c.Request.Context(), name, opts); err != nil {
+		return nil, err
+	}
+	return updateConfigurationSetTrackingOptionsResult{}, nil
+}
+
+// DeleteConfigurationSetTrackingOptions handles Action=DeleteConfigurationSetTrackingOptions
+func (h *ConfigurationSetQueryHandler) DeleteConfigurationSetTrackingOptions(c *gin.Context, form url.Values) (any, error) {
+	name := form.Get("ConfigurationSetName")
+	if name == "" {
+		return nil, missingParameter("ConfigurationSetName")
+	}
+
+	if err := h.service.DeleteTrackingOptions(c.Request.Context(), name); err != nil {
+		return nil, err
+	}
+	return deleteConfigurationSetTrackingOptionsResult{}, nil
+}
+
+// UpdateConfigurationSetReputationMetricsEnabled handles Action=UpdateConfigurationSetReputationMetricsEnabled
+func (h *ConfigurationSetQueryHandler) UpdateConfigurationSetReputationMetricsEnabled(c *gin.Context, form url.Values) (any, error) {
+	name, enabled, err := decodeEnabled(form)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.service.PutReputationOptions(c.Request.Context(), name, enabled); err != nil {
+		return nil, err
+	}
+	// SES answers these two actions without a Result element
+	return nil, nil
+}
+
+// UpdateConfigurationSetSendingEnabled handles Action=UpdateConfigurationSetSendingEnabled
+func (h *ConfigurationSetQueryHandler) UpdateConfigurationSetSendingEnabled(c *gin.Context, form url.Values) (any, error) {
+	name, enabled, err := decodeEnabled(form)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.service.PutSendingOptions(c.Request.Context(), name, enabled); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+// decodeEventDestination decodes the configuration set name and the
+// `EventDestination.*` parameters. Next to SNSDestination, the mock accepts its
+// own destination types, e.g. `EventDestination.WebhookDestination.URL`.
+func decodeEventDestination(form url.Values) (string, model.EventDestination, *model.SESError) {
+	name := form.Get("ConfigurationSetName")
+	if name == "" {
+		return "", model.EventDestination{}, missingParameter("ConfigurationSetName")
+	}
+
+	d := model.EventDestination{
+		Name:               form.Get("EventDestination.Name"),
+		Enabled:            strings.EqualFold(form.Get("EventDestination.Enabled"), "true"),
+		MatchingEventTypes: memberList(form, "EventDestination.MatchingEventTypes"),
+	}
+	if d.Name == "" {
+		return "", model.EventDestination{}, missingParameter("EventDestination.Name")
+	}
+
+	if v := form.Get("EventDestination.SNSDestination.TopicARN"); v != "" {
+		d.SNSDestination = &model.SNSDestination{TopicARN: v}
+	}
+	if v := form.Get("EventDestination.WebhookDestination.URL"); v != "" {
+		d.WebhookDestination = &model.WebhookDestination{URL: v}
+	}
+	if v := form.Get("EventDestination.FileDestination.Path"); v != "" {
+		d.FileDestination = &model.FileDestination{Path: v}
+	}
+	if v := form.Get("EventDestination.RedisStreamDestination.Stream"); v != "" {
+		d.RedisStreamDestination = &model.RedisStreamDestination{Stream: v}
+	}
+	if v := form.Get("EventDestination.SQSDestination.QueueURL"); v != "" {
+		d.SQSDestination = &model.SQSDestination{QueueURL: v}
+	}
+
+	return name, d, nil
+}
+
+func decodeEnabled(form url.Values) (string, bool, *model.SESError) {
+	name := form.Get("ConfigurationSetName")
+	if name == "" {
+		return "", false, missingParameter("ConfigurationSetName")
+	}
+
+	v := form.Get("Enabled")
+	if v == "" {
+		return "", false, missingParameter("Enabled")
+	}
+	enabled, err := strconv.ParseBool(v)
+	if err != nil {
+		return "", false, &model.SESError{Code: "InvalidParameterValue", Message: "Value " + v + " for parameter Enabled is invalid. Reason: must be a boolean."}
+	}
+	return name, enabled, nil
+}
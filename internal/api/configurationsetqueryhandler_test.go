@@ -0,0 +1,198 @@
+package api_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/kamal-github/demtech/internal/api"
+	"github.com/kamal-github/demtech/internal/api/mocks"
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigurationSetQueryHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSetService := mocks.NewMockConfigurationSetService(ctrl)
+	h := api.NewConfigurationSetQueryHandler(mockSetService)
+
+	queryRouter := api.NewQueryRouter()
+	queryRouter.Register("CreateConfigurationSet", h.CreateConfigurationSet)
+	queryRouter.Register("DescribeConfigurationSet", h.DescribeConfigurationSet)
+	queryRouter.Register("DeleteConfigurationSet", h.DeleteConfigurationSet)
+	queryRouter.Register("ListConfigurationSets", h.ListConfigurationSets)
+	queryRouter.Register("CreateConfigurationSetEventDestination", h.CreateConfigurationSetEventDestination)
+	queryRouter.Register("DeleteConfigurationSetEventDestination", h.DeleteConfigurationSetEventDestination)
+	queryRouter.Register("CreateConfigurationSetTrackingOptions", h.CreateConfigurationSetTrackingOptions)
+	queryRouter.Register("UpdateConfigurationSetSendingEnabled", h.UpdateConfigurationSetSendingEnabled)
+
+	router := gin.New()
+	router.POST("/", queryRouter.Handle)
+
+	set := model.ConfigurationSet{
+		Name:            "default-config",
+		SendingEnabled:  true,
+		TrackingOptions: &model.TrackingOptions{CustomRedirectDomain: "track.example.com"},
+		EventDestinations: []model.EventDestination{
+			{Name: "topic", Enabled: true, MatchingEventTypes: []string{"send", "bounce"}, SNSDestination: &model.SNSDestination{TopicARN: "arn:aws:sns:us-east-1:123456789012:ses-events"}},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		form         url.Values
+		mockSetup    func()
+		expectCode   int
+		expectInBody []string
+	}{
+		{
+			name: "Create configuration set",
+			form: url.Values{"Action": {"CreateConfigurationSet"}, "ConfigurationSet.Name": {"default-config"}},
+			mockSetup: func() {
+				mockSetService.EXPECT().CreateConfigurationSet(gomock.Any(), model.ConfigurationSet{Name: "default-config", SendingEnabled: true}).Return(nil)
+			},
+			expectCode:   http.StatusOK,
+			expectInBody: []string{`<CreateConfigurationSetResult></CreateConfigurationSetResult>`},
+		},
+		{
+			name:         "Create configuration set without name",
+			form:         url.Values{"Action": {"CreateConfigurationSet"}},
+			mockSetup:    func() {},
+			expectCode:   http.StatusBadRequest,
+			expectInBody: []string{`<Message>Missing required parameter ConfigurationSet.Name.</Message>`},
+		},
+		{
+			name: "Describe configuration set with attributes",
+			form: url.Values{
+				"Action":               {"DescribeConfigurationSet"},
+				"ConfigurationSetName": {"default-config"},
+				"ConfigurationSetAttributeNames.member.1": {"eventDestinations"},
+				"ConfigurationSetAttributeNames.member.2": {"trackingOptions"},
+				"ConfigurationSetAttributeNames.member.3": {"reputationOptions"},
+			},
+			mockSetup: func() {
+				mockSetService.EXPECT().GetConfigurationSet(gomock.Any(), "default-config").Return(set, nil)
+			},
+			expectCode: http.StatusOK,
+			expectInBody: []string{
+				`<ConfigurationSet><Name>default-config</Name></ConfigurationSet>`,
+				`<EventDestinations><member><Name>topic</Name><Enabled>true</Enabled><MatchingEventTypes><member>send</member><member>bounce</member></MatchingEventTypes><SNSDestination><TopicARN>arn:aws:sns:us-east-1:123456789012:ses-events</TopicARN></SNSDestination></member></EventDestinations>`,
+				`<TrackingOptions><CustomRedirectDomain>track.example.com</CustomRedirectDomain></TrackingOptions>`,
+				`<ReputationOptions><SendingEnabled>true</SendingEnabled><ReputationMetricsEnabled>false</ReputationMetricsEnabled></ReputationOptions>`,
+			},
+		},
+		{
+			name: "Describe unknown configuration set",
+			form: url.Values{"Action": {"DescribeConfigurationSet"}, "ConfigurationSetName": {"unknown"}},
+			mockSetup: func() {
+				mockSetService.EXPECT().GetConfigurationSet(gomock.Any(), "unknown").
+					Return(model.ConfigurationSet{}, &model.SESError{Code: "ConfigurationSetDoesNotExist", Message: "Configuration set unknown does not exist."})
+			},
+			expectCode:   http.StatusBadRequest,
+			expectInBody: []string{`<Code>ConfigurationSetDoesNotExist</Code>`},
+		},
+		{
+			name: "Delete configuration set",
+			form: url.Values{"Action": {"DeleteConfigurationSet"}, "ConfigurationSetName": {"default-config"}},
+			mockSetup: func() {
+				mockSetService.EXPECT().DeleteConfigurationSet(gomock.Any(), "default-config").Return(nil)
+			},
+			expectCode:   http.StatusOK,
+			expectInBody: []string{`<DeleteConfigurationSetResult></DeleteConfigurationSetResult>`},
+		},
+		{
+			name: "List configuration sets",
+			form: url.Values{"Action": {"ListConfigurationSets"}, "MaxItems": {"1"}},
+			mockSetup: func() {
+				mockSetService.EXPECT().ListConfigurationSets(gomock.Any(), 1, "").Return([]model.ConfigurationSet{set}, "default-config", nil)
+			},
+			expectCode: http.StatusOK,
+			expectInBody: []string{
+				`<ConfigurationSets><member><Name>default-config</Name></member></ConfigurationSets>`,
+				`<NextToken>default-config</NextToken>`,
+			},
+		},
+		{
+			name: "Create event destination",
+			form: url.Values{
+				"Action":                   {"CreateConfigurationSetEventDestination"},
+				"ConfigurationSetName":     {"default-config"},
+				"EventDestination.Name":    {"stream"},
+				"EventDestination.Enabled": {"true"},
+				"EventDestination.MatchingEventTypes.member.1":   {"send"},
+				"EventDestination.RedisStreamDestination.Stream": {"ses-events"},
+			},
+			mockSetup: func() {
+				mockSetService.EXPECT().CreateEventDestination(gomock.Any(), "default-config", model.EventDestination{
+					Name:                   "stream",
+					Enabled:                true,
+					MatchingEventTypes:     []string{"send"},
+					RedisStreamDestination: &model.RedisStreamDestination{Stream: "ses-events"},
+				}).Return(nil)
+			},
+			expectCode:   http.StatusOK,
+			expectInBody: []string{`<CreateConfigurationSetEventDestinationResult></CreateConfigurationSetEventDestinationResult>`},
+		},
+		{
+			name: "Delete unknown event destination",
+			form: url.Values{"Action": {"DeleteConfigurationSetEventDestination"}, "ConfigurationSetName": {"default-config"}, "EventDestinationName": {"webhook"}},
+			mockSetup: func() {
+				mockSetService.EXPECT().DeleteEventDestination(gomock.Any(), "default-config", "webhook").
+					Return(&model.SESError{Code: "EventDestinationDoesNotExist", Message: "Event destination webhook does not exist in configuration set default-config."})
+			},
+			expectCode:   http.StatusBadRequest,
+			expectInBody: []string{`<Code>EventDestinationDoesNotExist</Code>`},
+		},
+		{
+			name: "Create tracking options",
+			form: url.Values{"Action": {"CreateConfigurationSetTrackingOptions"}, "ConfigurationSetName": {"default-config"}, "TrackingOptions.CustomRedirectDomain": {"track.example.com"}},
+			mockSetup: func() {
+				mockSetService.EXPECT().CreateTrackingOptions(gomock.Any(), "default-config", model.TrackingOptions{CustomRedirectDomain: "track.example.com"}).Return(nil)
+			},
+			expectCode:   http.StatusOK,
+			expectInBody: []string{`<CreateConfigurationSetTrackingOptionsResult></CreateConfigurationSetTrackingOptionsResult>`},
+		},
+		{
+			name: "Pause sending",
+			form: url.Values{"Action": {"UpdateConfigurationSetSendingEnabled"}, "ConfigurationSetName": {"default-config"}, "Enabled": {"false"}},
+			mockSetup: func() {
+				mockSetService.EXPECT().PutSendingOptions(gomock.Any(), "default-config", false).Return(nil)
+			},
+			expectCode:   http.StatusOK,
+			expectInBody: []string{`<UpdateConfigurationSetSendingEnabledResponse xmlns="http://ses.amazonaws.com/doc/2010-12-01/"><ResponseMetadata>`},
+		},
+		{
+			name:         "Pause sending with invalid Enabled",
+			form:         url.Values{"Action": {"UpdateConfigurationSetSendingEnabled"}, "ConfigurationSetName": {"default-config"}, "Enabled": {"maybe"}},
+			mockSetup:    func() {},
+			expectCode:   http.StatusBadRequest,
+			expectInBody: []string{`<Code>InvalidParameterValue</Code>`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			tt.mockSetup()
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/", strings.NewReader(tt.form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(tt.expectCode, w.Code)
+			for _, s := range tt.expectInBody {
+				assert.Contains(w.Body.String(), s)
+			}
+		})
+	}
+}
@@ -0,0 +1,304 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kamal-github/demtech/internal/model"
+)
+
+// ConfigurationSetV2Handler serves the configuration set operations of the
+// SESv2 REST API (/v2/email/configuration-sets).
+type ConfigurationSetV2Handler struct {
+	service ConfigurationSetService
+}
+
+// NewConfigurationSetV2Handler creates a new ConfigurationSetV2Handler
+func NewConfigurationSetV2Handler(s ConfigurationSetService) *ConfigurationSetV2Handler {
+	return &ConfigurationSetV2Handler{service: s}
+}
+
+type v2ReputationOptions struct {
+	ReputationMetricsEnabled bool         `json:"ReputationMetricsEnabled"`
+	LastFreshStart           *v2Timestamp `json:"LastFreshStart,omitempty"`
+}
+
+type v2SendingOptions struct {
+	SendingEnabled bool `json:"SendingEnabled"`
+}
+
+type v2ConfigurationSet struct {
+	ConfigurationSetName string                    `json:"ConfigurationSetName"`
+	TrackingOptions      *model.TrackingOptions    `json:"TrackingOptions,omitempty"`
+	ReputationOptions    *v2ReputationOptions      `json:"ReputationOptions,omitempty"`
+	SendingOptions       *v2SendingOptions         `json:"SendingOptions,omitempty"`
+	SuppressionOptions   *model.SuppressionOptions `json:"SuppressionOptions,omitempty"`
+}
+
+type v2SnsDestination struct {
+	TopicArn string `json:"TopicArn"`
+}
+
+type v2EventDestination struct {
+	Name                   string                        `json:"Name,omitempty"`
+	Enabled                bool                          `json:"Enabled"`
+	MatchingEventTypes     []string                      `json:"MatchingEventTypes"`
+	SnsDestination         *v2SnsDestination             `json:"SnsDestination,omitempty"`
+	WebhookDestination     *model.WebhookDestination     `json:"WebhookDestination,omitempty"`
+	FileDestination        *model.FileDestination        `json:"FileDestination,omitempty"`
+	RedisStreamDestination *model.RedisStreamDestination `json:"RedisStreamDestination,omitempty"`
+	SQSDestination         *model.SQSDestination         `json:"SQSDestination,omitempty"`
+}
+
+// CreateConfigurationSet handles POST /v2/email/configuration-sets
+func (h *ConfigurationSetV2Handler) CreateConfigurationSet(c *gin.Context) {
+	var body v2ConfigurationSet
+	if !decodeV2Body(c, &body) {
+		return
+	}
+	if body.ConfigurationSetName == "" {
+		writeV2Error(c, badRequest("ConfigurationSetName is required."))
+		return
+	}
+
+	set := model.ConfigurationSet{
+		Name:               body.ConfigurationSetName,
+		SendingEnabled:     true,
+		TrackingOptions:    body.TrackingOptions,
+		SuppressionOptions: body.SuppressionOptions,
+	}
+	if body.ReputationOptions != nil {
+		set.ReputationMetricsEnabled = body.ReputationOptions.ReputationMetricsEnabled
+	}
+	if body.SendingOptions != nil {
+		set.SendingEnabled = body.SendingOptions.SendingEnabled
+	}
+
+	if err := h.service.CreateConfigurationSet(c.Request.Context(), set); err != nil {
+		writeV2Error(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// GetConfigurationSet handles GET /v2/email/configuration-sets/:name
+func (h *ConfigurationSetV2Handler) GetConfigurationSet(c *gin.Context) {
+	set, err := h.service.GetConfigurationSet(c.Request.Context(), c.Param("name"))
+	if err != nil {
+		writeV2Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, v2ConfigurationSet{
+		ConfigurationSetName: set.Name,
+		TrackingOptions:      set.TrackingOptions,
+		ReputationOptions:    &v2ReputationOptions{ReputationMetricsEnabled: set.ReputationMetricsEnabled, LastFreshStart: (*v2Timestamp)(set.LastFreshStart)},
+		SendingOptions:       &v2SendingOptions{SendingEnabled: set.SendingEnabled},
+		SuppressionOptions:   set.SuppressionOptions,
+	})
+}
+
+// DeleteConfigurationSet handles DELETE /v2/email/configuration-sets/:name
+func (h *ConfigurationSetV2Handler) DeleteConfigurationSet(c *gin.Context) {
+	if err := h.service.DeleteConfigurationSet(c.Request.Context(), c.Param("name")); err != nil {
+		writeV2Error(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// ListConfigurationSets handles GET /v2/email/configuration-sets
+func (h *ConfigurationSetV2Handler) ListConfigurationSets(c *gin.Context) {
+	pageSize := 0
+	if v := c.Query("PageSize"); v != "" {
+		var err error
+		if pageSize, err = strconv.Atoi(v); err != nil || pageSize < 1 {
+			writeV2Error(c, badRequest("PageSize must be a positive number."))
+			return
+		}
+	}
+
+	list, nextToken, err := h.service.ListConfigurationSets(c.Request.Context(), pageSize, c.Query("NextToken"))
+	if err != nil {
+		writeV2Error(c, err)
+		return
+	}
+
+	names := make([]string, 0, len(list))
+	for _, set := range list {
+		names = append(names, set.Name)
+	}
+	resp := gin.H{"ConfigurationSets": names}
+	if nextToken != "" {
+		resp["NextToken"] = nextToken
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetEventDestinations handles GET /v2/email/configuration-sets/:name/event-destinations
+func (h *ConfigurationSetV2Handler) GetEventDestinations(c *gin.Context) {
+	set, err := h.service.GetConfigurationSet(c.Request.Context(), c.Param("name"))
+	if err != nil {
+		writeV2Error(c, err)
+		return
+	}
+
+	destinations := make([]v2EventDestination, 0, len(set.EventDestinations))
+	for _, d := range set.EventDestinations {
+		destinations = append(destinations, encodeV2EventDestination(d))
+	}
+	c.JSON(http.StatusOK, gin.H{"EventDestinations": destinations})
+}
+
+// CreateEventDestination handles POST /v2/email/configuration-sets/:name/event-destinations
+func (h *ConfigurationSetV2Handler) CreateEventDestination(c *gin.Context) {
+	var body struct {
+		EventDestinationName string             `json:"EventDestinationName"`
+		EventDestination     v2EventDestination `json:"EventDestination"`
+	}
+	if !decodeV2Body(c, &body) {
+		return
+	}
+	if body.EventDestinationName == "" {
+		writeV2Error(c, badRequest("EventDestinationName is required."))
+		return
+	}
+
+	d := decodeV2EventDestination(body.EventDestinationName, body.EventDestination)
+	if err := h.service.CreateEventDestination(c.Request.Context(), c.Param("name"), d); err != nil {
+		writeV2Error(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// UpdateEventDestination handles PUT /v2/email/configuration-sets/:name/event-destinations/:destination
+func (h *ConfigurationSetV2Handler) UpdateEventDestination(c *gin.Context) {
+	var body struct {
+		EventDestination v2EventDestination `json:"EventDestination"`
+	}
+	if !decodeV2Body(c, &body) {
+		return
+	}
+
+	d := decodeV2EventDestination(c.Param("destination"), body.EventDestination)
+	if err := h.service.UpdateEventDestination(c.Request.Context(), c.Param("name"), d); err != nil {
+		writeV2Error(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// DeleteEventDestination handles DELETE /v2/email/configuration-sets/:name/event-destinations/:destination
+func (h *ConfigurationSetV2Handler) DeleteEventDestination(c *gin.Context) {
+	if err := h.service.DeleteEventDestination(c.Request.Context(), c.Param("name"), c.Param("destination")); err != nil {
+		writeV2Error(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// PutTrackingOptions handles PUT /v2/email/configuration-sets/:name/tracking-options,
+// an empty CustomRedirectDomain removes the tracking options.
+func (h *ConfigurationSetV2Handler) PutTrackingOptions(c *gin.Context) {
+	var body model.TrackingOptions
+	if !decodeV2Body(c, &body) {
+		return
+	}
+
+	var opts *model.TrackingOptions
+	if body.CustomRedirectDomain != "" {
+		opts = &body
+	}
+	if err := h.service.PutTrackingOptions(c.Request.Context(), c.Param("name"), opts); err != nil {
+		writeV2Error(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// PutReputationOptions handles PUT /v2/email/configuration-sets/:name/reputation-options
+func (h *ConfigurationSetV2Handler) PutReputationOptions(c *gin.Context) {
+	var body v2ReputationOptions
+	if !decodeV2Body(c, &body) {
+		return
+	}
+
+	if err := h.service.PutReputationOptions(c.Request.Context(), c.Param("name"), body.ReputationMetricsEnabled); err != nil {
+		writeV2Error(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// PutSendingOptions handles PUT /v2/email/configuration-sets/:name/sending
+func (h *ConfigurationSetV2Handler) PutSendingOptions(c *gin.Context) {
+	var body v2SendingOptions
+	if !decodeV2Body(c, &body) {
+		return
+	}
+
+	if err := h.service.PutSendingOptions(c.Request.Context(), c.Param("name"), body.SendingEnabled); err != nil {
+		writeV2Error(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// PutSuppressionOptions handles PUT /v2/email/configuration-sets/:name/suppression-options
+func (h *ConfigurationSetV2Handler) PutSuppressionOptions(c *gin.Context) {
+	var body model.SuppressionOptions
+	if !decodeV2Body(c, &body) {
+		return
+	}
+
+	if err := h.service.PutSuppressionOptions(c.Request.Context(), c.Param("name"), &body); err != nil {
+		writeV2Error(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// decodeV2Body decodes the JSON body of a request into v, answering with a
+// BadRequestException when it is not valid JSON.
+func decodeV2Body(c *gin.Context, v any) bool {
+	if err := json.NewDecoder(c.Request.Body).Decode(v); err != nil {
+		writeV2Error(c, badRequest("Request body is not valid JSON."))
+		return false
+	}
+	return true
+}
+
+func decodeV2EventDestination(name string, d v2EventDestination) model.EventDestination {
+	dest := model.EventDestination{
+		Name:                   name,
+		Enabled:                d.Enabled,
+		MatchingEventTypes:     d.MatchingEventTypes,
+		WebhookDestination:     d.WebhookDestination,
+		FileDestination:        d.FileDestination,
+		RedisStreamDestination: d.RedisStreamDestination,
+		SQSDestination:         d.SQSDestination,
+	}
+	if d.SnsDestination != nil {
+		dest.SNSDestination = &model.SNSDestination{TopicARN: d.SnsDestination.TopicArn}
+	}
+	return dest
+}
+
+func encodeV2EventDestination(d model.EventDestination) v2EventDestination {
+	dest := v2EventDestination{
+		Name:                   d.Name,
+		Enabled:                d.Enabled,
+		MatchingEventTypes:     d.MatchingEventTypes,
+		WebhookDestination:     d.WebhookDestination,
+		FileDestination:        d.FileDestination,
+		RedisStreamDestination: d.RedisStreamDestination,
+		SQSDestination:         d.SQSDestination,
+	}
+	if d.SNSDestination != nil {
+		dest.SnsDestination = &v2SnsDestination{TopicArn: d.SNSDestination.TopicARN}
+	}
+	return dest
+}
@@ -0,0 +1,215 @@
+package api_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/kamal-github/demtech/internal/api"
+	"github.com/kamal-github/demtech/internal/api/mocks"
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigurationSetV2Handler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSetService := mocks.NewMockConfigurationSetService(ctrl)
+	h := api.NewConfigurationSetV2Handler(mockSetService)
+
+	router := gin.New()
+	sets := router.Group("/v2/email/configuration-sets")
+	sets.POST("", h.CreateConfigurationSet)
+	sets.GET("", h.ListConfigurationSets)
+	sets.GET("/:name", h.GetConfigurationSet)
+	sets.DELETE("/:name", h.DeleteConfigurationSet)
+	sets.GET("/:name/event-destinations", h.GetEventDestinations)
+	sets.POST("/:name/event-destinations", h.CreateEventDestination)
+	sets.PUT("/:name/event-destinations/:destination", h.UpdateEventDestination)
+	sets.PUT("/:name/sending", h.PutSendingOptions)
+	sets.PUT("/:name/tracking-options", h.PutTrackingOptions)
+	sets.PUT("/:name/suppression-options", h.PutSuppressionOptions)
+
+	topicARN := "arn:aws:sns:us-east-1:123456789012:ses-events"
+	set := model.ConfigurationSet{
+		Name:               "default-config",
+		SendingEnabled:     true,
+		SuppressionOptions: &model.SuppressionOptions{SuppressedReasons: []string{"BOUNCE"}},
+		EventDestinations: []model.EventDestination{
+			{Name: "topic", Enabled: true, MatchingEventTypes: []string{"SEND"}, SNSDestination: &model.SNSDestination{TopicARN: topicARN}},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		method          string
+		path            string
+		body            string
+		mockSetup       func()
+		expectCode      int
+		expectErrorType string
+		expectInBody    []string
+	}{
+		{
+			name:   "Create configuration set",
+			method: http.MethodPost,
+			path:   "/v2/email/configuration-sets",
+			body:   `{"ConfigurationSetName":"marketing","SendingOptions":{"SendingEnabled":false},"ReputationOptions":{"ReputationMetricsEnabled":true},"SuppressionOptions":{"SuppressedReasons":["COMPLAINT"]}}`,
+			mockSetup: func() {
+				mockSetService.EXPECT().CreateConfigurationSet(gomock.Any(), model.ConfigurationSet{
+					Name:                     "marketing",
+					SendingEnabled:           false,
+					ReputationMetricsEnabled: true,
+					SuppressionOptions:       &model.SuppressionOptions{SuppressedReasons: []string{"COMPLAINT"}},
+				}).Return(nil)
+			},
+			expectCode: http.StatusOK,
+		},
+		{
+			name:   "Create existing configuration set",
+			method: http.MethodPost,
+			path:   "/v2/email/configuration-sets",
+			body:   `{"ConfigurationSetName":"default-config"}`,
+			mockSetup: func() {
+				mockSetService.EXPECT().CreateConfigurationSet(gomock.Any(), model.ConfigurationSet{Name: "default-config", SendingEnabled: true}).
+					Return(&model.SESError{Code: "ConfigurationSetAlreadyExists", Message: "Configuration set default-config already exists."})
+			},
+			expectCode:      http.StatusBadRequest,
+			expectErrorType: "AlreadyExistsException",
+		},
+		{
+			name:            "Create configuration set with invalid body",
+			method:          http.MethodPost,
+			path:            "/v2/email/configuration-sets",
+			body:            `{`,
+			mockSetup:       func() {},
+			expectCode:      http.StatusBadRequest,
+			expectErrorType: "BadRequestException",
+		},
+		{
+			name:   "Get configuration set",
+			method: http.MethodGet,
+			path:   "/v2/email/configuration-sets/default-config",
+			mockSetup: func() {
+				mockSetService.EXPECT().GetConfigurationSet(gomock.Any(), "default-config").Return(set, nil)
+			},
+			expectCode: http.StatusOK,
+			expectInBody: []string{
+				`"ConfigurationSetName":"default-config"`,
+				`"SendingOptions":{"SendingEnabled":true}`,
+				`"SuppressionOptions":{"SuppressedReasons":["BOUNCE"]}`,
+			},
+		},
+		{
+			name:   "Get unknown configuration set",
+			method: http.MethodGet,
+			path:   "/v2/email/configuration-sets/unknown",
+			mockSetup: func() {
+				mockSetService.EXPECT().GetConfigurationSet(gomock.Any(), "unknown").
+					Return(model.ConfigurationSet{}, &model.SESError{Code: "ConfigurationSetDoesNotExist", Message: "Configuration set unknown does not exist."})
+			},
+			expectCode:      http.StatusNotFound,
+			expectErrorType: "NotFoundException",
+		},
+		{
+			name:   "List configuration sets",
+			method: http.MethodGet,
+			path:   "/v2/email/configuration-sets?PageSize=1",
+			mockSetup: func() {
+				mockSetService.EXPECT().ListConfigurationSets(gomock.Any(), 1, "").Return([]model.ConfigurationSet{set}, "default-config", nil)
+			},
+			expectCode:   http.StatusOK,
+			expectInBody: []string{`"ConfigurationSets":["default-config"]`, `"NextToken":"default-config"`},
+		},
+		{
+			name:   "Get event destinations",
+			method: http.MethodGet,
+			path:   "/v2/email/configuration-sets/default-config/event-destinations",
+			mockSetup: func() {
+				mockSetService.EXPECT().GetConfigurationSet(gomock.Any(), "default-config").Return(set, nil)
+			},
+			expectCode:   http.StatusOK,
+			expectInBody: []string{`{"EventDestinations":[{"Name":"topic","Enabled":true,"MatchingEventTypes":["SEND"],"SnsDestination":{"TopicArn":"` + topicARN + `"}}]}`},
+		},
+		{
+			name:   "Create event destination",
+			method: http.MethodPost,
+			path:   "/v2/email/configuration-sets/default-config/event-destinations",
+			body:   `{"EventDestinationName":"topic","EventDestination":{"Enabled":true,"MatchingEventTypes":["SEND"],"SnsDestination":{"TopicArn":"` + topicARN + `"}}}`,
+			mockSetup: func() {
+				mockSetService.EXPECT().CreateEventDestination(gomock.Any(), "default-config", set.EventDestinations[0]).Return(nil)
+			},
+			expectCode: http.StatusOK,
+		},
+		{
+			name:   "Update unknown event destination",
+			method: http.MethodPut,
+			path:   "/v2/email/configuration-sets/default-config/event-destinations/webhook",
+			body:   `{"EventDestination":{"Enabled":false,"MatchingEventTypes":["BOUNCE"],"WebhookDestination":{"URL":"http://localhost:9000"}}}`,
+			mockSetup: func() {
+				mockSetService.EXPECT().UpdateEventDestination(gomock.Any(), "default-config", model.EventDestination{
+					Name:               "webhook",
+					MatchingEventTypes: []string{"BOUNCE"},
+					WebhookDestination: &model.WebhookDestination{URL: "http://localhost:9000"},
+				}).Return(&model.SESError{Code: "EventDestinationDoesNotExist", Message: "Event destination webhook does not exist in configuration set default-config."})
+			},
+			expectCode:      http.StatusNotFound,
+			expectErrorType: "NotFoundException",
+		},
+		{
+			name:   "Pause sending",
+			method: http.MethodPut,
+			path:   "/v2/email/configuration-sets/default-config/sending",
+			body:   `{"SendingEnabled":false}`,
+			mockSetup: func() {
+				mockSetService.EXPECT().PutSendingOptions(gomock.Any(), "default-config", false).Return(nil)
+			},
+			expectCode: http.StatusOK,
+		},
+		{
+			name:   "Remove tracking options",
+			method: http.MethodPut,
+			path:   "/v2/email/configuration-sets/default-config/tracking-options",
+			body:   `{}`,
+			mockSetup: func() {
+				mockSetService.EXPECT().PutTrackingOptions(gomock.Any(), "default-config", nil).Return(nil)
+			},
+			expectCode: http.StatusOK,
+		},
+		{
+			name:   "Put suppression options",
+			method: http.MethodPut,
+			path:   "/v2/email/configuration-sets/default-config/suppression-options",
+			body:   `{"SuppressedReasons":["BOUNCE","COMPLAINT"]}`,
+			mockSetup: func() {
+				mockSetService.EXPECT().PutSuppressionOptions(gomock.Any(), "default-config", &model.SuppressionOptions{SuppressedReasons: []string{"BOUNCE", "COMPLAINT"}}).Return(nil)
+			},
+			expectCode: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			tt.mockSetup()
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(tt.expectCode, w.Code)
+			assert.Equal(tt.expectErrorType, w.Header().Get("x-amzn-ErrorType"))
+			for _, s := range tt.expectInBody {
+				assert.Contains(w.Body.String(), s)
+			}
+		})
+	}
+}
@@ -13,6 +13,7 @@ import (
 // EmailService defines the interface for sending emails
 type EmailService interface {
 	SendEmail(ctx context.Context, req model.EmailRequest) (*model.SESResponse, error)
+	SendBulkTemplatedEmail(ctx context.Context, req model.BulkEmailRequest) ([]model.BulkEmailDestinationStatus, error)
 }
 
 type EmailsStatsUpdater interface {
@@ -0,0 +1,290 @@
+package api
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"log"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/kamal-github/demtech/internal/rawmail"
+)
+
+// maxBulkDestinations is the number of destinations SendBulkTemplatedEmail accepts per call
+const maxBulkDestinations = 50
+
+// EmailQueryHandler serves the sending actions of the SES v1 Query API, which is
+// what the AWS SDKs use when talking to SES v1.
+type EmailQueryHandler struct {
+	service      EmailService
+	statsUpdater EmailsStatsUpdater
+}
+
+// NewEmailQueryHandler creates a new EmailQueryHandler
+func NewEmailQueryHandler(s EmailService, u EmailsStatsUpdater) *EmailQueryHandler {
+	return &EmailQueryHandler{service: s, statsUpdater: u}
+}
+
+type sendEmailResult struct {
+	XMLName   xml.Name `xml:"SendEmailResult"`
+	MessageID string   `xml:"MessageId"`
+}
+
+type sendRawEmailResult struct {
+	XMLName   xml.Name `xml:"SendRawEmailResult"`
+	MessageID string   `xml:"MessageId"`
+}
+
+type sendTemplatedEmailResult struct {
+	XMLName   xml.Name `xml:"SendTemplatedEmailResult"`
+	MessageID string   `xml:"MessageId"`
+}
+
+type bulkEmailDestinationStatus struct {
+	Status    string `xml:"Status"`
+	Error     string `xml:"Error,omitempty"`
+	MessageID string `xml:"MessageId,omitempty"`
+}
+
+type sendBulkTemplatedEmailResult struct {
+	XMLName xml.Name                     `xml:"SendBulkTemplatedEmailResult"`
+	Status  []bulkEmailDestinationStatus `xml:"Status>member"`
+}
+
+// SendEmail handles Action=SendEmail
+func (h *EmailQueryHandler) SendEmail(c *gin.Context, form url.Values) (any, error) {
+	emailReq, sesErr := decodeSendEmail(form)
+	if sesErr != nil {
+		h.statsUpdater.IncrementError(c.Request.Context(), sesErr.Code)
+		return nil, sesErr
+	}
+
+	resp, err := h.send(c, emailReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return sendEmailResult{MessageID: resp.MessageID}, nil
+}
+
+// SendRawEmail handles Action=SendRawEmail
+func (h *EmailQueryHandler) SendRawEmail(c *gin.Context, form url.Values) (any, error) {
+	emailReq, sesErr := decodeSendRawEmail(form)
+	if sesErr != nil {
+		h.statsUpdater.IncrementError(c.Request.Context(), sesErr.Code)
+		return nil, sesErr
+	}
+
+	resp, err := h.send(c, emailReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return sendRawEmailResult{MessageID: resp.MessageID}, nil
+}
+
+// SendTemplatedEmail handles Action=SendTemplatedEmail
+func (h *EmailQueryHandler) SendTemplatedEmail(c *gin.Context, form url.Values) (any, error) {
+	emailReq, sesErr := decodeSendTemplatedEmail(form)
+	if sesErr != nil {
+		h.statsUpdater.IncrementError(c.Request.Context(), sesErr.Code)
+		return nil, sesErr
+	}
+
+	resp, err := h.send(c, emailReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return sendTemplatedEmailResult{MessageID: resp.MessageID}, nil
+}
+
+// SendBulkTemplatedEmail handles Action=SendBulkTemplatedEmail
+func (h *EmailQueryHandler) SendBulkTemplatedEmail(c *gin.Context, form url.Values) (any, error) {
+	bulkReq, sesErr := decodeSendBulkTemplatedEmail(form)
+	if sesErr != nil {
+		h.statsUpdater.IncrementError(c.Request.Context(), sesErr.Code)
+		return nil, sesErr
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	statuses, err := h.service.SendBulkTemplatedEmail(ctx, bulkReq)
+	if err != nil {
+		log.Printf("Failed to send bulk email: %v", err)
+		return nil, err
+	}
+
+	result := sendBulkTemplatedEmailResult{}
+	for _, s := range statuses {
+		result.Status = append(result.Status, bulkEmailDestinationStatus(s))
+	}
+	return result, nil
+}
+
+func (h *EmailQueryHandler) send(c *gin.Context, emailReq model.EmailRequest) (*model.SESResponse, error) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.service.SendEmail(ctx, emailReq)
+	if err != nil {
+		log.Printf("Failed to send email: %v", err)
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func decodeSendEmail(form url.Values) (model.EmailRequest, *model.SESError) {
+	req := model.EmailRequest{
+		Source:      form.Get("Source"),
+		Destination: decodeDestination(form, "Destination"),
+		Message: model.Message{
+			Subject: model.Subject{
+				Data:    form.Get("Message.Subject.Data"),
+				Charset: form.Get("Message.Subject.Charset"),
+			},
+			Body: model.Body{
+				Text: model.TextBody{
+					Data:    form.Get("Message.Body.Text.Data"),
+					Charset: form.Get("Message.Body.Text.Charset"),
+				},
+			},
+		},
+		ConfigurationSetName: form.Get("ConfigurationSetName"),
+		ReplyToAddresses:     memberList(form, "ReplyToAddresses"),
+		ReturnPath:           form.Get("ReturnPath"),
+		ReturnPathArn:        form.Get("ReturnPathArn"),
+		SourceArn:            form.Get("SourceArn"),
+		Tags:                 tagList(form, "Tags"),
+	}
+	if _, ok := form["Message.Body.Html.Data"]; ok {
+		req.Message.Body.Html = &model.HtmlBody{
+			Data:    form.Get("Message.Body.Html.Data"),
+			Charset: form.Get("Message.Body.Html.Charset"),
+		}
+	}
+
+	switch {
+	case req.Source == "":
+		return model.EmailRequest{}, missingParameter("Source")
+	case len(req.Destination.All()) == 0:
+		return model.EmailRequest{}, missingParameter("Destination")
+	case req.Message.Subject.Data == "":
+		return model.EmailRequest{}, missingParameter("Message.Subject")
+	case req.Message.Body.Text.Data == "" && req.Message.Body.Html == nil:
+		return model.EmailRequest{}, missingParameter("Message.Body")
+	}
+
+	return req, nil
+}
+
+func decodeSendRawEmail(form url.Values) (model.EmailRequest, *model.SESError) {
+	if form.Get("RawMessage.Data") == "" {
+		return model.EmailRequest{}, missingParameter("RawMessage.Data")
+	}
+	data, err := base64.StdEncoding.DecodeString(form.Get("RawMessage.Data"))
+	if err != nil {
+		return model.EmailRequest{}, &model.SESError{Code: "InvalidParameterValue", Message: "RawMessage.Data must be base64 encoded."}
+	}
+
+	req, err := rawmail.NewEmailRequest(data, form.Get("Source"), memberList(form, "Destinations"))
+	if err != nil {
+		var sesErr *model.SESError
+		if errors.As(err, &sesErr) {
+			return model.EmailRequest{}, sesErr
+		}
+		return model.EmailRequest{}, &model.SESError{Code: "InvalidParameterValue", Message: err.Error()}
+	}
+	if len(req.Destination.All()) == 0 {
+		return model.EmailRequest{}, missingParameter("Destinations")
+	}
+
+	req.ConfigurationSetName = form.Get("ConfigurationSetName")
+	req.SourceArn = form.Get("SourceArn")
+	req.ReturnPathArn = form.Get("ReturnPathArn")
+	req.Tags = tagList(form, "Tags")
+
+	return req, nil
+}
+
+func decodeSendTemplatedEmail(form url.Values) (model.EmailRequest, *model.SESError) {
+	req := model.EmailRequest{
+		Source:               form.Get("Source"),
+		Destination:          decodeDestination(form, "Destination"),
+		ConfigurationSetName: form.Get("ConfigurationSetName"),
+		ReplyToAddresses:     memberList(form, "ReplyToAddresses"),
+		ReturnPath:           form.Get("ReturnPath"),
+		ReturnPathArn:        form.Get("ReturnPathArn"),
+		SourceArn:            form.Get("SourceArn"),
+		Tags:                 tagList(form, "Tags"),
+		Template:             form.Get("Template"),
+		TemplateArn:          form.Get("TemplateArn"),
+		TemplateData:         form.Get("TemplateData"),
+	}
+
+	switch {
+	case req.Source == "":
+		return model.EmailRequest{}, missingParameter("Source")
+	case len(req.Destination.All()) == 0:
+		return model.EmailRequest{}, missingParameter("Destination")
+	case req.Template == "" && req.TemplateArn == "":
+		return model.EmailRequest{}, missingParameter("Template")
+	case req.TemplateData == "":
+		return model.EmailRequest{}, missingParameter("TemplateData")
+	}
+
+	return req, nil
+}
+
+func decodeSendBulkTemplatedEmail(form url.Values) (model.BulkEmailRequest, *model.SESError) {
+	req := model.BulkEmailRequest{
+		Source:               form.Get("Source"),
+		SourceArn:            form.Get("SourceArn"),
+		ReplyToAddresses:     memberList(form, "ReplyToAddresses"),
+		ReturnPath:           form.Get("ReturnPath"),
+		ReturnPathArn:        form.Get("ReturnPathArn"),
+		ConfigurationSetName: form.Get("ConfigurationSetName"),
+		DefaultTags:          tagList(form, "DefaultTags"),
+		Template:             form.Get("Template"),
+		TemplateArn:          form.Get("TemplateArn"),
+		DefaultTemplateData:  form.Get("DefaultTemplateData"),
+	}
+	for i := 1; ; i++ {
+		prefix := "Destinations.member." + strconv.Itoa(i)
+		if !hasPrefix(form, prefix+".") {
+			break
+		}
+		req.Destinations = append(req.Destinations, model.BulkEmailDestination{
+			Destination:             decodeDestination(form, prefix+".Destination"),
+			ReplacementTags:         tagList(form, prefix+".ReplacementTags"),
+			ReplacementTemplateData: form.Get(prefix + ".ReplacementTemplateData"),
+		})
+	}
+
+	switch {
+	case req.Source == "":
+		return model.BulkEmailRequest{}, missingParameter("Source")
+	case req.Template == "" && req.TemplateArn == "":
+		return model.BulkEmailRequest{}, missingParameter("Template")
+	case req.DefaultTemplateData == "":
+		return model.BulkEmailRequest{}, missingParameter("DefaultTemplateData")
+	case len(req.Destinations) == 0:
+		return model.BulkEmailRequest{}, missingParameter("Destinations")
+	case len(req.Destinations) > maxBulkDestinations:
+		return model.BulkEmailRequest{}, &model.SESError{Code: "LimitExceededException", Message: "Too many destinations"}
+	}
+	for i, d := range req.Destinations {
+		if len(d.Destination.All()) == 0 {
+			return model.BulkEmailRequest{}, missingParameter("Destinations.member." + strconv.Itoa(i+1) + ".Destination")
+		}
+	}
+
+	return req, nil
+}
@@ -0,0 +1,472 @@
+package api_test
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/kamal-github/demtech/internal/api"
+	"github.com/kamal-github/demtech/internal/api/mocks"
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmailQueryHandler_SendEmail(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEmailService := mocks.NewMockEmailService(ctrl)
+	mockStatsUpdater := mocks.NewMockEmailsStatsUpdater(ctrl)
+
+	queryRouter := api.NewQueryRouter()
+	queryRouter.Register("SendEmail", api.NewEmailQueryHandler(mockEmailService, mockStatsUpdater).SendEmail)
+
+	router := gin.New()
+	router.POST("/", queryRouter.Handle)
+
+	validForm := url.Values{
+		"Action":                              {"SendEmail"},
+		"Source":                              {"sender@example.com"},
+		"Destination.ToAddresses.member.1":    {"to.1@example.com"},
+		"Destination.ToAddresses.member.2":    {"to.2@example.com"},
+		"Destination.BccAddresses.member.1":   {"bcc@example.com"},
+		"Message.Subject.Data":                {"Test Subject"},
+		"Message.Body.Text.Data":              {"Test Body"},
+		"Message.Body.Html.Data":              {"<p>Test Body</p>"},
+		"ReplyToAddresses.member.1":           {"reply@example.com"},
+		"Tags.member.1.Name":                  {"campaign"},
+		"Tags.member.1.Value":                 {"welcome"},
+		"ConfigurationSetName":                {"default-config"},
+		"Destination.ToAddresses.member.4":    {"skipped@example.com"},
+		"Destination.CcAddresses.member.none": {"ignored@example.com"},
+	}
+
+	expectedReq := model.EmailRequest{
+		Source: "sender@example.com",
+		Destination: model.Destination{
+			ToAddresses:  []string{"to.1@example.com", "to.2@example.com"},
+			BccAddresses: []string{"bcc@example.com"},
+		},
+		Message: model.Message{
+			Subject: model.Subject{Data: "Test Subject"},
+			Body: model.Body{
+				Text: model.TextBody{Data: "Test Body"},
+				Html: &model.HtmlBody{Data: "<p>Test Body</p>"},
+			},
+		},
+		ConfigurationSetName: "default-config",
+		ReplyToAddresses:     []string{"reply@example.com"},
+		Tags:                 []model.Tag{{Name: "campaign", Value: "welcome"}},
+	}
+
+	tests := []struct {
+		name         string
+		form         func() url.Values
+		mockSetup    func()
+		expectCode   int
+		expectInBody []string
+	}{
+		{
+			name: "Successful email send",
+			form: func() url.Values { return validForm },
+			mockSetup: func() {
+				mockEmailService.EXPECT().
+					SendEmail(gomock.Any(), expectedReq).
+					Return(&model.SESResponse{MessageID: "123"}, nil)
+			},
+			expectCode: http.StatusOK,
+			expectInBody: []string{
+				`<SendEmailResponse xmlns="http://ses.amazonaws.com/doc/2010-12-01/">`,
+				`<SendEmailResult><MessageId>123</MessageId></SendEmailResult>`,
+				`<ResponseMetadata><RequestId>`,
+			},
+		},
+		{
+			name: "SES error from service",
+			form: func() url.Values { return validForm },
+			mockSetup: func() {
+				mockEmailService.EXPECT().
+					SendEmail(gomock.Any(), gomock.Any()).
+					Return(nil, &model.SESError{Code: "MessageRejected", Message: "Message rejected."})
+			},
+			expectCode: http.StatusBadRequest,
+			expectInBody: []string{
+				`<ErrorResponse xmlns="http://ses.amazonaws.com/doc/2010-12-01/">`,
+				`<Error><Type>Sender</Type><Code>MessageRejected</Code><Message>Message rejected.</Message></Error>`,
+				`<RequestId>`,
+			},
+		},
+		{
+			name: "Unexpected error from service",
+			form: func() url.Values { return validForm },
+			mockSetup: func() {
+				mockEmailService.EXPECT().
+					SendEmail(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("redis down"))
+			},
+			expectCode:   http.StatusInternalServerError,
+			expectInBody: []string{`<Type>Receiver</Type><Code>InternalFailure</Code>`},
+		},
+		{
+			name: "Missing Source",
+			form: func() url.Values {
+				f := url.Values{}
+				for k, v := range validForm {
+					f[k] = v
+				}
+				f.Del("Source")
+				return f
+			},
+			mockSetup: func() {
+				mockStatsUpdater.EXPECT().IncrementError(gomock.Any(), "MissingParameter").Times(1)
+			},
+			expectCode:   http.StatusBadRequest,
+			expectInBody: []string{`<Code>MissingParameter</Code><Message>Missing required parameter Source.</Message>`},
+		},
+		{
+			name:         "Unknown action",
+			form:         func() url.Values { return url.Values{"Action": {"SendCarrierPigeon"}} },
+			mockSetup:    func() {},
+			expectCode:   http.StatusBadRequest,
+			expectInBody: []string{`<Code>InvalidAction</Code>`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			tt.mockSetup()
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/", strings.NewReader(tt.form().Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(tt.expectCode, w.Code)
+			assert.NotEmpty(w.Header().Get("x-amzn-RequestId"))
+			for _, s := range tt.expectInBody {
+				assert.Contains(w.Body.String(), s)
+			}
+		})
+	}
+}
+
+func TestEmailQueryHandler_SendRawEmail(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEmailService := mocks.NewMockEmailService(ctrl)
+	mockStatsUpdater := mocks.NewMockEmailsStatsUpdater(ctrl)
+
+	queryRouter := api.NewQueryRouter()
+	queryRouter.Register("SendRawEmail", api.NewEmailQueryHandler(mockEmailService, mockStatsUpdater).SendRawEmail)
+
+	router := gin.New()
+	router.POST("/", queryRouter.Handle)
+
+	raw := "From: Team <team@example.com>\r\nTo: to@example.com\r\nSubject: Hi\r\n\r\nHello"
+
+	tests := []struct {
+		name         string
+		form         url.Values
+		mockSetup    func()
+		expectCode   int
+		expectInBody string
+	}{
+		{
+			name: "Successful raw email send",
+			form: url.Values{
+				"Action":                 {"SendRawEmail"},
+				"RawMessage.Data":        {base64.StdEncoding.EncodeToString([]byte(raw))},
+				"Destinations.member.1":  {"extra@example.com"},
+				"ConfigurationSetName":   {"default-config"},
+				"Tags.member.1.Name":     {"campaign"},
+				"Tags.member.1.Value":    {"welcome"},
+				"Destinations.member.02": {"ignored@example.com"},
+			},
+			mockSetup: func() {
+				mockEmailService.EXPECT().
+					SendEmail(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, req model.EmailRequest) (*model.SESResponse, error) {
+						assert.Equal(t, "team@example.com", req.Source)
+						assert.Equal(t, []string{"to@example.com", "extra@example.com"}, req.Destination.All())
+						assert.Equal(t, "Hello", req.Message.Body.Text.Data)
+						assert.Equal(t, "default-config", req.ConfigurationSetName)
+						assert.Equal(t, []model.Tag{{Name: "campaign", Value: "welcome"}}, req.Tags)
+						assert.Equal(t, []byte(raw), req.RawMessage.Data)
+						return &model.SESResponse{MessageID: "123"}, nil
+					})
+			},
+			expectCode:   http.StatusOK,
+			expectInBody: `<SendRawEmailResult><MessageId>123</MessageId></SendRawEmailResult>`,
+		},
+		{
+			name: "Missing From header",
+			form: url.Values{
+				"Action":          {"SendRawEmail"},
+				"RawMessage.Data": {base64.StdEncoding.EncodeToString([]byte("To: to@example.com\r\n\r\nHello"))},
+			},
+			mockSetup: func() {
+				mockStatsUpdater.EXPECT().IncrementError(gomock.Any(), "InvalidParameterValue").Times(1)
+			},
+			expectCode:   http.StatusBadRequest,
+			expectInBody: `<Code>InvalidParameterValue</Code><Message>Missing required header &#39;From&#39;.</Message>`,
+		},
+		{
+			name: "Data is not base64",
+			form: url.Values{
+				"Action":          {"SendRawEmail"},
+				"RawMessage.Data": {raw},
+			},
+			mockSetup: func() {
+				mockStatsUpdater.EXPECT().IncrementError(gomock.Any(), "InvalidParameterValue").Times(1)
+			},
+			expectCode:   http.StatusBadRequest,
+			expectInBody: `<Code>InvalidParameterValue</Code>`,
+		},
+		{
+			name: "Missing raw message",
+			form: url.Values{"Action": {"SendRawEmail"}},
+			mockSetup: func() {
+				mockStatsUpdater.EXPECT().IncrementError(gomock.Any(), "MissingParameter").Times(1)
+			},
+			expectCode:   http.StatusBadRequest,
+			expectInBody: `<Code>MissingParameter</Code>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			tt.mockSetup()
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/", strings.NewReader(tt.form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(tt.expectCode, w.Code)
+			assert.Contains(w.Body.String(), tt.expectInBody)
+		})
+	}
+}
+
+func TestEmailQueryHandler_SendTemplatedEmail(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEmailService := mocks.NewMockEmailService(ctrl)
+	mockStatsUpdater := mocks.NewMockEmailsStatsUpdater(ctrl)
+
+	queryRouter := api.NewQueryRouter()
+	queryRouter.Register("SendTemplatedEmail", api.NewEmailQueryHandler(mockEmailService, mockStatsUpdater).SendTemplatedEmail)
+
+	router := gin.New()
+	router.POST("/", queryRouter.Handle)
+
+	tests := []struct {
+		name         string
+		form         url.Values
+		mockSetup    func()
+		expectCode   int
+		expectInBody string
+	}{
+		{
+			name: "Successful templated email send",
+			form: url.Values{
+				"Action":                           {"SendTemplatedEmail"},
+				"Source":                           {"sender@example.com"},
+				"Destination.ToAddresses.member.1": {"to@example.com"},
+				"Template":                         {"welcome"},
+				"TemplateData":                     {`{"name":"Alex"}`},
+				"ConfigurationSetName":             {"default-config"},
+			},
+			mockSetup: func() {
+				mockEmailService.EXPECT().
+					SendEmail(gomock.Any(), model.EmailRequest{
+						Source:               "sender@example.com",
+						Destination:          model.Destination{ToAddresses: []string{"to@example.com"}},
+						ConfigurationSetName: "default-config",
+						Template:             "welcome",
+						TemplateData:         `{"name":"Alex"}`,
+					}).
+					Return(&model.SESResponse{MessageID: "123"}, nil)
+			},
+			expectCode:   http.StatusOK,
+			expectInBody: `<SendTemplatedEmailResult><MessageId>123</MessageId></SendTemplatedEmailResult>`,
+		},
+		{
+			name: "Unknown template",
+			form: url.Values{
+				"Action":                           {"SendTemplatedEmail"},
+				"Source":                           {"sender@example.com"},
+				"Destination.ToAddresses.member.1": {"to@example.com"},
+				"Template":                         {"missing"},
+				"TemplateData":                     {`{}`},
+			},
+			mockSetup: func() {
+				mockEmailService.EXPECT().
+					SendEmail(gomock.Any(), gomock.Any()).
+					Return(nil, &model.SESError{Code: "TemplateDoesNotExistException", Message: "Template missing does not exist."})
+			},
+			expectCode:   http.StatusBadRequest,
+			expectInBody: `<Code>TemplateDoesNotExistException</Code>`,
+		},
+		{
+			name: "Missing template data",
+			form: url.Values{
+				"Action":                           {"SendTemplatedEmail"},
+				"Source":                           {"sender@example.com"},
+				"Destination.ToAddresses.member.1": {"to@example.com"},
+				"Template":                         {"welcome"},
+			},
+			mockSetup: func() {
+				mockStatsUpdater.EXPECT().IncrementError(gomock.Any(), "MissingParameter").Times(1)
+			},
+			expectCode:   http.StatusBadRequest,
+			expectInBody: `<Message>Missing required parameter TemplateData.</Message>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			tt.mockSetup()
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/", strings.NewReader(tt.form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(tt.expectCode, w.Code)
+			assert.Contains(w.Body.String(), tt.expectInBody)
+		})
+	}
+}
+
+func TestEmailQueryHandler_SendBulkTemplatedEmail(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEmailService := mocks.NewMockEmailService(ctrl)
+	mockStatsUpdater := mocks.NewMockEmailsStatsUpdater(ctrl)
+
+	queryRouter := api.NewQueryRouter()
+	queryRouter.Register("SendBulkTemplatedEmail", api.NewEmailQueryHandler(mockEmailService, mockStatsUpdater).SendBulkTemplatedEmail)
+
+	router := gin.New()
+	router.POST("/", queryRouter.Handle)
+
+	validForm := url.Values{
+		"Action":                     {"SendBulkTemplatedEmail"},
+		"Source":                     {"sender@example.com"},
+		"Template":                   {"welcome"},
+		"DefaultTemplateData":        {`{"name":"friend"}`},
+		"DefaultTags.member.1.Name":  {"campaign"},
+		"DefaultTags.member.1.Value": {"welcome"},
+		"Destinations.member.1.Destination.ToAddresses.member.1":  {"alex@example.com"},
+		"Destinations.member.1.ReplacementTemplateData":           {`{"name":"Alex"}`},
+		"Destinations.member.1.ReplacementTags.member.1.Name":     {"tier"},
+		"Destinations.member.1.ReplacementTags.member.1.Value":    {"pro"},
+		"Destinations.member.2.Destination.BccAddresses.member.1": {"sam@example.com"},
+		"Destinations.member.4.Destination.ToAddresses.member.1":  {"skipped@example.com"},
+	}
+
+	tests := []struct {
+		name         string
+		form         url.Values
+		mockSetup    func()
+		expectCode   int
+		expectInBody string
+	}{
+		{
+			name: "Per destination status",
+			form: validForm,
+			mockSetup: func() {
+				mockEmailService.EXPECT().
+					SendBulkTemplatedEmail(gomock.Any(), model.BulkEmailRequest{
+						Source:              "sender@example.com",
+						Template:            "welcome",
+						DefaultTemplateData: `{"name":"friend"}`,
+						DefaultTags:         []model.Tag{{Name: "campaign", Value: "welcome"}},
+						Destinations: []model.BulkEmailDestination{
+							{
+								Destination:             model.Destination{ToAddresses: []string{"alex@example.com"}},
+								ReplacementTags:         []model.Tag{{Name: "tier", Value: "pro"}},
+								ReplacementTemplateData: `{"name":"Alex"}`,
+							},
+							{Destination: model.Destination{BccAddresses: []string{"sam@example.com"}}},
+						},
+					}).
+					Return([]model.BulkEmailDestinationStatus{
+						{Status: model.BulkStatusSuccess, MessageID: "123"},
+						{Status: model.BulkStatusMessageRejected, Error: "Cannot send emails outside sandbox"},
+					}, nil)
+			},
+			expectCode: http.StatusOK,
+			expectInBody: `<SendBulkTemplatedEmailResult><Status>` +
+				`<member><Status>Success</Status><MessageId>123</MessageId></member>` +
+				`<member><Status>MessageRejected</Status><Error>Cannot send emails outside sandbox</Error></member>` +
+				`</Status></SendBulkTemplatedEmailResult>`,
+		},
+		{
+			name: "Account level failure",
+			form: validForm,
+			mockSetup: func() {
+				mockEmailService.EXPECT().
+					SendBulkTemplatedEmail(gomock.Any(), gomock.Any()).
+					Return(nil, &model.SESError{Code: "MessageRejected", Message: "Email address is not verified."})
+			},
+			expectCode:   http.StatusBadRequest,
+			expectInBody: `<Code>MessageRejected</Code>`,
+		},
+		{
+			name: "Missing destinations",
+			form: url.Values{
+				"Action":              {"SendBulkTemplatedEmail"},
+				"Source":              {"sender@example.com"},
+				"Template":            {"welcome"},
+				"DefaultTemplateData": {`{}`},
+			},
+			mockSetup: func() {
+				mockStatsUpdater.EXPECT().IncrementError(gomock.Any(), "MissingParameter").Times(1)
+			},
+			expectCode:   http.StatusBadRequest,
+			expectInBody: `<Message>Missing required parameter Destinations.</Message>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			tt.mockSetup()
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/", strings.NewReader(tt.form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(tt.expectCode, w.Code)
+			assert.Contains(w.Body.String(), tt.expectInBody)
+		})
+	}
+}
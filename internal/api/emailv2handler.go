@@ -0,0 +1,197 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/kamal-github/demtech/internal/rawmail"
+)
+
+// EmailV2Handler serves the SESv2 REST API (POST /v2/email/outbound-emails).
+type EmailV2Handler struct {
+	service      EmailService
+	statsUpdater EmailsStatsUpdater
+}
+
+// NewEmailV2Handler creates a new EmailV2Handler
+func NewEmailV2Handler(s EmailService, u EmailsStatsUpdater) *EmailV2Handler {
+	return &EmailV2Handler{service: s, statsUpdater: u}
+}
+
+// SendEmail handles the SESv2 SendEmail operation
+func (h *EmailV2Handler) SendEmail(c *gin.Context) {
+	var v2Req model.SendEmailV2Request
+
+	// The v2 shape is decoded without gin's binding rules as, unlike v1, every
+	// part of the message body is optional.
+	if err := json.NewDecoder(c.Request.Body).Decode(&v2Req); err != nil {
+		h.statsUpdater.IncrementError(c.Request.Context(), "BadRequestException")
+		writeV2Error(c, &model.SESError{Code: "BadRequestException", Message: "Request body is not valid JSON."})
+		return
+	}
+
+	emailReq, sesErr := decodeSendEmailV2(v2Req)
+	if sesErr != nil {
+		h.statsUpdater.IncrementError(c.Request.Context(), sesErr.Code)
+		writeV2Error(c, sesErr)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	resp, err := h.service.SendEmail(ctx, emailReq)
+	if err != nil {
+		log.Printf("Failed to send email: %v", err)
+		writeV2Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func decodeSendEmailV2(v2Req model.SendEmailV2Request) (model.EmailRequest, *model.SESError) {
+	var req model.EmailRequest
+
+	content := v2Req.Content
+	switch {
+	case countContents(content) != 1:
+		return model.EmailRequest{}, badRequest("Content must contain exactly one of Simple, Raw or Template.")
+	case content.Simple != nil:
+		if content.Simple.Subject.Data == "" {
+			return model.EmailRequest{}, badRequest("Subject is required for Simple content.")
+		}
+		if content.Simple.Body.Text.Data == "" && content.Simple.Body.Html == nil {
+			return model.EmailRequest{}, badRequest("Body is required for Simple content.")
+		}
+		req = model.EmailRequest{
+			Source:      v2Req.FromEmailAddress,
+			Destination: v2Req.Destination,
+			Message: model.Message{
+				Subject: content.Simple.Subject,
+				Body:    content.Simple.Body,
+				Headers: content.Simple.Headers,
+			},
+		}
+	case content.Raw != nil:
+		rawReq, err := rawmail.NewEmailRequest(content.Raw.Data, v2Req.FromEmailAddress, v2Req.Destination.All())
+		if err != nil {
+			var sesErr *model.SESError
+			if errors.As(err, &sesErr) {
+				return model.EmailRequest{}, badRequest(sesErr.Message)
+			}
+			return model.EmailRequest{}, badRequest(err.Error())
+		}
+		req = rawReq
+	case content.Template != nil:
+		if content.Template.TemplateName == "" && content.Template.TemplateArn == "" {
+			return model.EmailRequest{}, badRequest("TemplateName or TemplateArn is required for Template content.")
+		}
+		req = model.EmailRequest{
+			Source:       v2Req.FromEmailAddress,
+			Destination:  v2Req.Destination,
+			Message:      model.Message{Headers: content.Template.Headers},
+			Template:     content.Template.TemplateName,
+			TemplateArn:  content.Template.TemplateArn,
+			TemplateData: content.Template.TemplateData,
+		}
+	}
+
+	req.SourceArn = v2Req.FromEmailAddressIdentityArn
+	req.ConfigurationSetName = v2Req.ConfigurationSetName
+	req.ReplyToAddresses = append(req.ReplyToAddresses, v2Req.ReplyToAddresses...)
+	if v2Req.FeedbackForwardingEmailAddress != "" {
+		req.ReturnPath = v2Req.FeedbackForwardingEmailAddress
+	}
+	req.ReturnPathArn = v2Req.FeedbackForwardingEmailAddressIdentityArn
+	req.Tags = v2Req.EmailTags
+	req.ListManagementOptions = v2Req.ListManagementOptions
+
+	if req.Source == "" {
+		return model.EmailRequest{}, badRequest("FromEmailAddress is required.")
+	}
+	if len(req.Destination.All()) == 0 {
+		return model.EmailRequest{}, badRequest("Destination must contain at least one recipient.")
+	}
+
+	return req, nil
+}
+
+func countContents(content model.EmailContent) int {
+	n := 0
+	if content.Simple != nil {
+		n++
+	}
+	if content.Raw != nil {
+		n++
+	}
+	if content.Template != nil {
+		n++
+	}
+	return n
+}
+
+func badRequest(msg string) *model.SESError {
+	return &model.SESError{Code: "BadRequestException", Message: msg}
+}
+
+// v2ErrorType translates the SES v1 error codes used throughout the mock into
+// the error types of SESv2, alongside the matching HTTP status.
+func v2ErrorType(code string) (string, int) {
+	switch code {
+	case "InvalidParameterValue", "MissingParameter", "ValidationError", "MessageTooLong", "BadRequestException",
+		"InvalidConfigurationSet", "InvalidTrackingOptions":
+		return "BadRequestException", http.StatusBadRequest
+	case "ConfigurationSetAlreadyExists", "EventDestinationAlreadyExists", "AlreadyExistsException":
+		return "AlreadyExistsException", http.StatusBadRequest
+	case "Throttling", "ThrottlingException", "TooManyRequestsException":
+		return "TooManyRequestsException", http.StatusTooManyRequests
+	case "AccountSendingPaused", "AccountSendingPausedException", "ConfigurationSetSendingPausedException":
+		return "SendingPausedException", http.StatusBadRequest
+	case "ConflictException":
+		return "ConflictException", http.StatusConflict
+	case "TemplateDoesNotExist", "TemplateDoesNotExistException",
+		"ConfigurationSetDoesNotExist", "ConfigurationSetDoesNotExistException",
+		"EventDestinationDoesNotExist", "NotFoundException":
+		return "NotFoundException", http.StatusNotFound
+	case "InternalFailure", "ServiceUnavailable":
+		return "InternalFailure", sesErrorStatus(code)
+	default:
+		return code, sesErrorStatus(code)
+	}
+}
+
+func writeV2Error(c *gin.Context, err error) {
+	var sesErr *model.SESError
+	if !errors.As(err, &sesErr) {
+		sesErr = &model.SESError{Code: "InternalFailure", Message: "Unexpected internal error occurred."}
+	}
+
+	errType, status := v2ErrorType(sesErr.Code)
+	c.Header("x-amzn-ErrorType", errType)
+	c.JSON(status, gin.H{"message": sesErr.Message})
+}
+
+// v2Timestamp is a time in the epoch seconds the SESv2 REST API uses, in
+// responses as well as in request bodies.
+type v2Timestamp time.Time
+
+func (t v2Timestamp) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.FormatFloat(float64(time.Time(t).UnixMilli())/1000, 'f', -1, 64)), nil
+}
+
+func (t *v2Timestamp) UnmarshalJSON(data []byte) error {
+	seconds, err := strconv.ParseFloat(string(data), 64)
+	if err != nil {
+		return err
+	}
+	*t = v2Timestamp(time.UnixMilli(int64(seconds * 1000)).UTC())
+	return nil
+}
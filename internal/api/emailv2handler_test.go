@@ -0,0 +1,181 @@
+package api_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/kamal-github/demtech/internal/api"
+	"github.com/kamal-github/demtech/internal/api/mocks"
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmailV2Handler_SendEmail(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEmailService := mocks.NewMockEmailService(ctrl)
+	mockStatsUpdater := mocks.NewMockEmailsStatsUpdater(ctrl)
+
+	h := api.NewEmailV2Handler(mockEmailService, mockStatsUpdater)
+
+	simpleReq := model.SendEmailV2Request{
+		FromEmailAddress: "sender@example.com",
+		Destination:      model.Destination{ToAddresses: []string{"recipient@example.com"}},
+		Content: model.EmailContent{
+			Simple: &model.SimpleContent{
+				Subject: model.Subject{Data: "Test Subject"},
+				Body:    model.Body{Html: &model.HtmlBody{Data: "<p>Test Body</p>"}},
+			},
+		},
+		EmailTags:             []model.Tag{{Name: "campaign", Value: "welcome"}},
+		ConfigurationSetName:  "default-config",
+		ListManagementOptions: &model.ListManagementOptions{ContactListName: "newsletter"},
+	}
+
+	tests := []struct {
+		name            string
+		requestBody     any
+		mockSetup       func()
+		expectCode      int
+		expectErrorType string
+		expectBody      string
+	}{
+		{
+			name:        "Successful email send with Simple content",
+			requestBody: simpleReq,
+			mockSetup: func() {
+				mockEmailService.EXPECT().
+					SendEmail(gomock.Any(), model.EmailRequest{
+						Source:      "sender@example.com",
+						Destination: model.Destination{ToAddresses: []string{"recipient@example.com"}},
+						Message: model.Message{
+							Subject: model.Subject{Data: "Test Subject"},
+							Body:    model.Body{Html: &model.HtmlBody{Data: "<p>Test Body</p>"}},
+						},
+						ConfigurationSetName:  "default-config",
+						Tags:                  []model.Tag{{Name: "campaign", Value: "welcome"}},
+						ListManagementOptions: &model.ListManagementOptions{ContactListName: "newsletter"},
+					}).
+					Return(&model.SESResponse{MessageID: "123"}, nil)
+			},
+			expectCode: http.StatusOK,
+			expectBody: `{"MessageId":"123"}`,
+		},
+		{
+			name:        "SES error is translated to the v2 error type",
+			requestBody: simpleReq,
+			mockSetup: func() {
+				mockEmailService.EXPECT().
+					SendEmail(gomock.Any(), gomock.Any()).
+					Return(nil, &model.SESError{Code: "ThrottlingException", Message: "Rate limit exceeded."})
+			},
+			expectCode:      http.StatusTooManyRequests,
+			expectErrorType: "TooManyRequestsException",
+			expectBody:      `{"message":"Rate limit exceeded."}`,
+		},
+		{
+			name:        "Unexpected error",
+			requestBody: simpleReq,
+			mockSetup: func() {
+				mockEmailService.EXPECT().
+					SendEmail(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("redis down"))
+			},
+			expectCode:      http.StatusInternalServerError,
+			expectErrorType: "InternalFailure",
+		},
+		{
+			name: "Successful email send with Raw content",
+			requestBody: model.SendEmailV2Request{
+				Destination: model.Destination{BccAddresses: []string{"hidden@example.com"}},
+				Content: model.EmailContent{
+					Raw: &model.RawContent{Data: []byte("From: team@example.com\r\nTo: to@example.com\r\nSubject: Hi\r\n\r\nHello")},
+				},
+			},
+			mockSetup: func() {
+				mockEmailService.EXPECT().
+					SendEmail(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, req model.EmailRequest) (*model.SESResponse, error) {
+						assert.Equal(t, "team@example.com", req.Source)
+						assert.Equal(t, []string{"to@example.com", "hidden@example.com"}, req.Destination.All())
+						assert.Equal(t, "Hi", req.Message.Subject.Data)
+						assert.NotNil(t, req.RawMessage)
+						return &model.SESResponse{MessageID: "456"}, nil
+					})
+			},
+			expectCode: http.StatusOK,
+			expectBody: `{"MessageId":"456"}`,
+		},
+		{
+			name: "Raw content without From header",
+			requestBody: model.SendEmailV2Request{
+				Content: model.EmailContent{
+					Raw: &model.RawContent{Data: []byte("To: to@example.com\r\n\r\nHello")},
+				},
+			},
+			mockSetup: func() {
+				mockStatsUpdater.EXPECT().IncrementError(gomock.Any(), "BadRequestException").Times(1)
+			},
+			expectCode:      http.StatusBadRequest,
+			expectErrorType: "BadRequestException",
+			expectBody:      `{"message":"Missing required header 'From'."}`,
+		},
+		{
+			name: "Missing content",
+			requestBody: model.SendEmailV2Request{
+				FromEmailAddress: "sender@example.com",
+				Destination:      model.Destination{ToAddresses: []string{"recipient@example.com"}},
+			},
+			mockSetup: func() {
+				mockStatsUpdater.EXPECT().IncrementError(gomock.Any(), "BadRequestException").Times(1)
+			},
+			expectCode:      http.StatusBadRequest,
+			expectErrorType: "BadRequestException",
+		},
+		{
+			name:        "Malformed JSON",
+			requestBody: "{",
+			mockSetup: func() {
+				mockStatsUpdater.EXPECT().IncrementError(gomock.Any(), "BadRequestException").Times(1)
+			},
+			expectCode:      http.StatusBadRequest,
+			expectErrorType: "BadRequestException",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			tt.mockSetup()
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+
+			body, ok := tt.requestBody.(string)
+			if !ok {
+				jsonBody, _ := json.Marshal(tt.requestBody)
+				body = string(jsonBody)
+			}
+			c.Request, _ = http.NewRequest(http.MethodPost, "/v2/email/outbound-emails", bytes.NewBufferString(body))
+			c.Request.Header.Set("Content-Type", "application/json")
+
+			h.SendEmail(c)
+
+			assert.Equal(tt.expectCode, w.Code)
+			assert.Equal(tt.expectErrorType, w.Header().Get("x-amzn-ErrorType"))
+			if tt.expectBody != "" {
+				assert.JSONEq(tt.expectBody, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kamal-github/demtech/internal/model"
+)
+
+type IdentityVerificationService interface {
+	GetIdentity(ctx context.Context, identity string) (model.Identity, error)
+	VerificationLink(id model.Identity) string
+	ConfirmEmailIdentity(ctx context.Context, identity, token string) error
+	SetVerificationStatus(ctx context.Context, identity, status string) error
+}
+
+// IdentityHandler drives the verification of identities, which SES does
+// out of band: following the link of a verification mail, or DNS checks.
+type IdentityHandler struct {
+	service IdentityVerificationService
+}
+
+// NewIdentityHandler creates a new IdentityHandler
+func NewIdentityHandler(s IdentityVerificationService) *IdentityHandler {
+	return &IdentityHandler{service: s}
+}
+
+type identityResponse struct {
+	model.Identity
+	VerificationLink string `json:"VerificationLink,omitempty"`
+}
+
+type setVerificationStatusRequest struct {
+	VerificationStatus string `json:"VerificationStatus" binding:"required"`
+}
+
+// GetIdentity returns an identity along with its verification link
+func (h *IdentityHandler) GetIdentity(c *gin.Context) {
+	id, err := h.service.GetIdentity(c.Request.Context(), c.Param("identity"))
+	if err != nil {
+		writeAPIError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, identityResponse{Identity: id, VerificationLink: h.service.VerificationLink(id)})
+}
+
+// Verify is the target of the verification link of an email identity
+func (h *IdentityHandler) Verify(c *gin.Context) {
+	identity := c.Param("identity")
+	if err := h.service.ConfirmEmailIdentity(c.Request.Context(), identity, c.Query("token")); err != nil {
+		writeAPIError(c, err)
+		return
+	}
+
+	c.String(http.StatusOK, "Congratulations! You have successfully verified the email address %s.", identity)
+}
+
+// SetVerificationStatus moves an identity to the requested verification status
+func (h *IdentityHandler) SetVerificationStatus(c *gin.Context) {
+	var req setVerificationStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "InvalidParameterValue", "message": err.Error()})
+		return
+	}
+
+	if err := h.service.SetVerificationStatus(c.Request.Context(), c.Param("identity"), req.VerificationStatus); err != nil {
+		writeAPIError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func writeAPIError(c *gin.Context, err error) {
+	var sesErr *model.SESError
+	if !errors.As(err, &sesErr) {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	status := http.StatusBadRequest
+	if sesErr.Code == "NotFoundException" {
+		status = http.StatusNotFound
+	}
+	c.JSON(status, gin.H{"error": sesErr.Code, "message": sesErr.Message})
+}
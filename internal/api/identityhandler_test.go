@@ -0,0 +1,118 @@
+package api_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/kamal-github/demtech/internal/api"
+	"github.com/kamal-github/demtech/internal/api/mocks"
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdentityHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockIdentityVerificationService(ctrl)
+	h := api.NewIdentityHandler(mockService)
+
+	router := gin.New()
+	router.GET("/api/v1/identities/:identity", h.GetIdentity)
+	router.GET("/api/v1/identities/:identity/verify", h.Verify)
+	router.PUT("/api/v1/identities/:identity/verification-status", h.SetVerificationStatus)
+
+	pending := model.Identity{Identity: "new@example.com", Type: model.IdentityTypeEmailAddress, VerificationStatus: model.VerificationStatusPending}
+
+	tests := []struct {
+		name         string
+		method       string
+		path         string
+		body         string
+		mockSetup    func()
+		expectCode   int
+		expectInBody string
+	}{
+		{
+			name:   "Get identity with its verification link",
+			method: http.MethodGet,
+			path:   "/api/v1/identities/new@example.com",
+			mockSetup: func() {
+				mockService.EXPECT().GetIdentity(gomock.Any(), "new@example.com").Return(pending, nil)
+				mockService.EXPECT().VerificationLink(pending).Return("http://localhost:8080/api/v1/identities/new@example.com/verify?token=abc")
+			},
+			expectCode:   http.StatusOK,
+			expectInBody: `"VerificationLink":"http://localhost:8080/api/v1/identities/new@example.com/verify?token=abc"`,
+		},
+		{
+			name:   "Get unknown identity",
+			method: http.MethodGet,
+			path:   "/api/v1/identities/unknown@example.com",
+			mockSetup: func() {
+				mockService.EXPECT().GetIdentity(gomock.Any(), "unknown@example.com").
+					Return(model.Identity{}, &model.SESError{Code: "NotFoundException", Message: "Identity unknown@example.com does not exist."})
+			},
+			expectCode: http.StatusNotFound,
+		},
+		{
+			name:   "Follow verification link",
+			method: http.MethodGet,
+			path:   "/api/v1/identities/new@example.com/verify?token=abc",
+			mockSetup: func() {
+				mockService.EXPECT().ConfirmEmailIdentity(gomock.Any(), "new@example.com", "abc").Return(nil)
+			},
+			expectCode:   http.StatusOK,
+			expectInBody: "successfully verified the email address new@example.com",
+		},
+		{
+			name:   "Follow invalid verification link",
+			method: http.MethodGet,
+			path:   "/api/v1/identities/new@example.com/verify?token=guess",
+			mockSetup: func() {
+				mockService.EXPECT().ConfirmEmailIdentity(gomock.Any(), "new@example.com", "guess").
+					Return(&model.SESError{Code: "InvalidParameterValue", Message: "Invalid verification link."})
+			},
+			expectCode: http.StatusBadRequest,
+		},
+		{
+			name:   "Set verification status",
+			method: http.MethodPut,
+			path:   "/api/v1/identities/example.com/verification-status",
+			body:   `{"VerificationStatus":"Failed"}`,
+			mockSetup: func() {
+				mockService.EXPECT().SetVerificationStatus(gomock.Any(), "example.com", "Failed").Return(nil)
+			},
+			expectCode: http.StatusNoContent,
+		},
+		{
+			name:       "Set verification status without status",
+			method:     http.MethodPut,
+			path:       "/api/v1/identities/example.com/verification-status",
+			body:       `{}`,
+			mockSetup:  func() {},
+			expectCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			tt.mockSetup()
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(tt.method, tt.path, bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(tt.expectCode, w.Code)
+			assert.Contains(w.Body.String(), tt.expectInBody)
+		})
+	}
+}
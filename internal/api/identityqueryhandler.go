@@ -0,0 +1,211 @@
+package api
+
+import (
+	"context"
+	"encoding/xml"
+	"net/url"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kamal-github/demtech/internal/model"
+)
+
+type IdentityService interface {
+	VerifyEmailIdentity(ctx context.Context, email string) error
+	VerifyDomainIdentity(ctx context.Context, domain string) (string, error)
+	VerifyDomainDkim(ctx context.Context, domain string) ([]string, error)
+	ListIdentities(ctx context.Context, identityType string, maxItems int, nextToken string) ([]string, string, error)
+	GetIdentityVerificationAttributes(ctx context.Context, identities []string) ([]model.Identity, error)
+	DeleteIdentity(ctx context.Context, identity string) error
+}
+
+// IdentityQueryHandler serves the identity management actions of the SES v1 Query API.
+type IdentityQueryHandler struct {
+	service IdentityService
+}
+
+// NewIdentityQueryHandler creates a new IdentityQueryHandler
+func NewIdentityQueryHandler(s IdentityService) *IdentityQueryHandler {
+	return &IdentityQueryHandler{service: s}
+}
+
+type verifyEmailIdentityResult struct {
+	XMLName xml.Name `xml:"VerifyEmailIdentityResult"`
+}
+
+type verifyDomainIdentityResult struct {
+	XMLName           xml.Name `xml:"VerifyDomainIdentityResult"`
+	VerificationToken string   `xml:"VerificationToken"`
+}
+
+type verifyDomainDkimResult struct {
+	XMLName    xml.Name `xml:"VerifyDomainDkimResult"`
+	DkimTokens []string `xml:"DkimTokens>member"`
+}
+
+type listIdentitiesResult struct {
+	XMLName    xml.Name `xml:"ListIdentitiesResult"`
+	Identities []string `xml:"Identities>member"`
+	NextToken  string   `xml:"NextToken,omitempty"`
+}
+
+type verificationAttributes struct {
+	VerificationStatus string `xml:"VerificationStatus"`
+	VerificationToken  string `xml:"VerificationToken,omitempty"`
+}
+
+type verificationAttributesEntry struct {
+	Key   string                 `xml:"key"`
+	Value verificationAttributes `xml:"value"`
+}
+
+type getIdentityVerificationAttributesResult struct {
+	XMLName                xml.Name                      `xml:"GetIdentityVerificationAttributesResult"`
+	VerificationAttributes []verificationAttributesEntry `xml:"VerificationAttributes>entry"`
+}
+
+type dkimAttributes struct {
+	DkimEnabled            bool     `xml:"DkimEnabled"`
+	DkimVerificationStatus string   `xml:"DkimVerificationStatus"`
+	DkimTokens             []string `xml:"DkimTokens>member"`
+}
+
+type dkimAttributesEntry struct {
+	Key   string         `xml:"key"`
+	Value dkimAttributes `xml:"value"`
+}
+
+type getIdentityDkimAttributesResult struct {
+	XMLName        xml.Name              `xml:"GetIdentityDkimAttributesResult"`
+	DkimAttributes []dkimAttributesEntry `xml:"DkimAttributes>entry"`
+}
+
+type deleteIdentityResult struct {
+	XMLName xml.Name `xml:"DeleteIdentityResult"`
+}
+
+// VerifyEmailIdentity handles Action=VerifyEmailIdentity
+func (h *IdentityQueryHandler) VerifyEmailIdentity(c *gin.Context, form url.Values) (any, error) {
+	email := form.Get("EmailAddress")
+	if email == "" {
+		return nil, missingParameter("EmailAddress")
+	}
+
+	if err := h.service.VerifyEmailIdentity(c.Request.Context(), email); err != nil {
+		return nil, err
+	}
+	return verifyEmailIdentityResult{}, nil
+}
+
+// VerifyDomainIdentity handles Action=VerifyDomainIdentity
+func (h *IdentityQueryHandler) VerifyDomainIdentity(c *gin.Context, form url.Values) (any, error) {
+	domain := form.Get("Domain")
+	if domain == "" {
+		return nil, missingParameter("Domain")
+	}
+
+	token, err := h.service.VerifyDomainIdentity(c.Request.Context(), domain)
+	if err != nil {
+		return nil, err
+	}
+	return verifyDomainIdentityResult{VerificationToken: token}, nil
+}
+
+// VerifyDomainDkim handles Action=VerifyDomainDkim
+func (h *IdentityQueryHandler) VerifyDomainDkim(c *gin.Context, form url.Values) (any, error) {
+	domain := form.Get("Domain")
+	if domain == "" {
+		return nil, missingParameter("Domain")
+	}
+
+	tokens, err := h.service.VerifyDomainDkim(c.Request.Context(), domain)
+	if err != nil {
+		return nil, err
+	}
+	return verifyDomainDkimResult{DkimTokens: tokens}, nil
+}
+
+// ListIdentities handles Action=ListIdentities
+func (h *IdentityQueryHandler) ListIdentities(c *gin.Context, form url.Values) (any, error) {
+	maxItems := 0
+	if v := form.Get("MaxItems"); v != "" {
+		var err error
+		if maxItems, err = strconv.Atoi(v); err != nil || maxItems < 1 {
+			return nil, &model.SESError{Code: "InvalidParameterValue", Message: "MaxItems must be a positive number."}
+		}
+	}
+
+	identities, nextToken, err := h.service.ListIdentities(c.Request.Context(), form.Get("IdentityType"), maxItems, form.Get("NextToken"))
+	if err != nil {
+		return nil, err
+	}
+	return listIdentitiesResult{Identities: identities, NextToken: nextToken}, nil
+}
+
+// GetIdentityVerificationAttributes handles Action=GetIdentityVerificationAttributes
+func (h *IdentityQueryHandler) GetIdentityVerificationAttributes(c *gin.Context, form url.Values) (any, error) {
+	identities := memberList(form, "Identities")
+	if len(identities) == 0 {
+		return nil, missingParameter("Identities")
+	}
+
+	list, err := h.service.GetIdentityVerificationAttributes(c.Request.Context(), identities)
+	if err != nil {
+		return nil, err
+	}
+
+	result := getIdentityVerificationAttributesResult{}
+	for _, id := range list {
+		result.VerificationAttributes = append(result.VerificationAttributes, verificationAttributesEntry{
+			Key: id.Identity,
+			Value: verificationAttributes{
+				VerificationStatus: id.VerificationStatus,
+				VerificationToken:  id.VerificationToken,
+			},
+		})
+	}
+	return result, nil
+}
+
+// GetIdentityDkimAttributes handles Action=GetIdentityDkimAttributes
+func (h *IdentityQueryHandler) GetIdentityDkimAttributes(c *gin.Context, form url.Values) (any, error) {
+	identities := memberList(form, "Identities")
+	if len(identities) == 0 {
+		return nil, missingParameter("Identities")
+	}
+
+	list, err := h.service.GetIdentityVerificationAttributes(c.Request.Context(), identities)
+	if err != nil {
+		return nil, err
+	}
+
+	result := getIdentityDkimAttributesResult{}
+	for _, id := range list {
+		status := id.DkimVerificationStatus
+		if status == "" {
+			status = model.VerificationStatusNotStarted
+		}
+		result.DkimAttributes = append(result.DkimAttributes, dkimAttributesEntry{
+			Key: id.Identity,
+			Value: dkimAttributes{
+				DkimEnabled:            id.DkimEnabled,
+				DkimVerificationStatus: status,
+				DkimTokens:             id.DkimTokens,
+			},
+		})
+	}
+	return result, nil
+}
+
+// DeleteIdentity handles Action=DeleteIdentity
+func (h *IdentityQueryHandler) DeleteIdentity(c *gin.Context, form url.Values) (any, error) {
+	identity := form.Get("Identity")
+	if identity == "" {
+		return nil, missingParameter("Identity")
+	}
+
+	if err := h.service.DeleteIdentity(c.Request.Context(), identity); err != nil {
+		return nil, err
+	}
+	return deleteIdentityResult{}, nil
+}
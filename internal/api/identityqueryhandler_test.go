@@ -0,0 +1,167 @@
+package api_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/kamal-github/demtech/internal/api"
+	"github.com/kamal-github/demtech/internal/api/mocks"
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdentityQueryHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockIdentityService := mocks.NewMockIdentityService(ctrl)
+	h := api.NewIdentityQueryHandler(mockIdentityService)
+
+	queryRouter := api.NewQueryRouter()
+	queryRouter.Register("VerifyEmailIdentity", h.VerifyEmailIdentity)
+	queryRouter.Register("VerifyDomainIdentity", h.VerifyDomainIdentity)
+	queryRouter.Register("ListIdentities", h.ListIdentities)
+	queryRouter.Register("GetIdentityVerificationAttributes", h.GetIdentityVerificationAttributes)
+	queryRouter.Register("DeleteIdentity", h.DeleteIdentity)
+	queryRouter.Register("VerifyDomainDkim", h.VerifyDomainDkim)
+	queryRouter.Register("GetIdentityDkimAttributes", h.GetIdentityDkimAttributes)
+
+	router := gin.New()
+	router.POST("/", queryRouter.Handle)
+
+	tests := []struct {
+		name         string
+		form         url.Values
+		mockSetup    func()
+		expectCode   int
+		expectInBody []string
+	}{
+		{
+			name: "Verify email identity",
+			form: url.Values{"Action": {"VerifyEmailIdentity"}, "EmailAddress": {"sender@example.com"}},
+			mockSetup: func() {
+				mockIdentityService.EXPECT().VerifyEmailIdentity(gomock.Any(), "sender@example.com").Return(nil)
+			},
+			expectCode:   http.StatusOK,
+			expectInBody: []string{`<VerifyEmailIdentityResponse xmlns="http://ses.amazonaws.com/doc/2010-12-01/"><VerifyEmailIdentityResult></VerifyEmailIdentityResult>`},
+		},
+		{
+			name:         "Verify email identity without address",
+			form:         url.Values{"Action": {"VerifyEmailIdentity"}},
+			mockSetup:    func() {},
+			expectCode:   http.StatusBadRequest,
+			expectInBody: []string{`<Code>MissingParameter</Code>`},
+		},
+		{
+			name: "Verify domain identity",
+			form: url.Values{"Action": {"VerifyDomainIdentity"}, "Domain": {"example.com"}},
+			mockSetup: func() {
+				mockIdentityService.EXPECT().VerifyDomainIdentity(gomock.Any(), "example.com").Return("token=", nil)
+			},
+			expectCode:   http.StatusOK,
+			expectInBody: []string{`<VerifyDomainIdentityResult><VerificationToken>token=</VerificationToken></VerifyDomainIdentityResult>`},
+		},
+		{
+			name: "Verify invalid domain",
+			form: url.Values{"Action": {"VerifyDomainIdentity"}, "Domain": {"example"}},
+			mockSetup: func() {
+				mockIdentityService.EXPECT().VerifyDomainIdentity(gomock.Any(), "example").
+					Return("", &model.SESError{Code: "InvalidParameterValue", Message: "Invalid domain name example."})
+			},
+			expectCode:   http.StatusBadRequest,
+			expectInBody: []string{`<Code>InvalidParameterValue</Code>`},
+		},
+		{
+			name: "List identities",
+			form: url.Values{"Action": {"ListIdentities"}, "IdentityType": {"EmailAddress"}, "MaxItems": {"1"}},
+			mockSetup: func() {
+				mockIdentityService.EXPECT().ListIdentities(gomock.Any(), "EmailAddress", 1, "").
+					Return([]string{"a@example.com"}, "a@example.com", nil)
+			},
+			expectCode: http.StatusOK,
+			expectInBody: []string{
+				`<Identities><member>a@example.com</member></Identities>`,
+				`<NextToken>a@example.com</NextToken>`,
+			},
+		},
+		{
+			name: "Get identity verification attributes",
+			form: url.Values{
+				"Action":              {"GetIdentityVerificationAttributes"},
+				"Identities.member.1": {"example.com"},
+				"Identities.member.2": {"unknown@example.com"},
+			},
+			mockSetup: func() {
+				mockIdentityService.EXPECT().GetIdentityVerificationAttributes(gomock.Any(), []string{"example.com", "unknown@example.com"}).
+					Return([]model.Identity{{Identity: "example.com", VerificationStatus: "Success", VerificationToken: "token="}}, nil)
+			},
+			expectCode: http.StatusOK,
+			expectInBody: []string{
+				`<VerificationAttributes><entry><key>example.com</key><value><VerificationStatus>Success</VerificationStatus><VerificationToken>token=</VerificationToken></value></entry></VerificationAttributes>`,
+			},
+		},
+		{
+			name: "Verify domain DKIM",
+			form: url.Values{"Action": {"VerifyDomainDkim"}, "Domain": {"example.com"}},
+			mockSetup: func() {
+				mockIdentityService.EXPECT().VerifyDomainDkim(gomock.Any(), "example.com").Return([]string{"aaa", "bbb", "ccc"}, nil)
+			},
+			expectCode:   http.StatusOK,
+			expectInBody: []string{`<VerifyDomainDkimResult><DkimTokens><member>aaa</member><member>bbb</member><member>ccc</member></DkimTokens></VerifyDomainDkimResult>`},
+		},
+		{
+			name: "Get identity DKIM attributes",
+			form: url.Values{
+				"Action":              {"GetIdentityDkimAttributes"},
+				"Identities.member.1": {"example.com"},
+				"Identities.member.2": {"sender@example.com"},
+			},
+			mockSetup: func() {
+				mockIdentityService.EXPECT().GetIdentityVerificationAttributes(gomock.Any(), []string{"example.com", "sender@example.com"}).
+					Return([]model.Identity{
+						{Identity: "example.com", DkimEnabled: true, DkimVerificationStatus: "Pending", DkimTokens: []string{"aaa"}},
+						{Identity: "sender@example.com"},
+					}, nil)
+			},
+			expectCode: http.StatusOK,
+			expectInBody: []string{
+				`<entry><key>example.com</key><value><DkimEnabled>true</DkimEnabled><DkimVerificationStatus>Pending</DkimVerificationStatus><DkimTokens><member>aaa</member></DkimTokens></value></entry>`,
+				`<entry><key>sender@example.com</key><value><DkimEnabled>false</DkimEnabled><DkimVerificationStatus>NotStarted</DkimVerificationStatus><DkimTokens></DkimTokens></value></entry>`,
+			},
+		},
+		{
+			name: "Delete identity",
+			form: url.Values{"Action": {"DeleteIdentity"}, "Identity": {"example.com"}},
+			mockSetup: func() {
+				mockIdentityService.EXPECT().DeleteIdentity(gomock.Any(), "example.com").Return(nil)
+			},
+			expectCode:   http.StatusOK,
+			expectInBody: []string{`<DeleteIdentityResult></DeleteIdentityResult>`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			tt.mockSetup()
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/", strings.NewReader(tt.form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(tt.expectCode, w.Code)
+			for _, s := range tt.expectInBody {
+				assert.Contains(w.Body.String(), s)
+			}
+		})
+	}
+}
@@ -0,0 +1,359 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kamal-github/demtech/internal/mailexport"
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/kamal-github/demtech/internal/rawmail"
+)
+
+const (
+	defaultMessageWaitTime = 20 * time.Second
+	messageStreamHeartbeat = 15 * time.Second
+)
+
+type CapturedMessageService interface {
+	GetCapturedMessage(ctx context.Context, messageID string) (model.CapturedMessage, error)
+	ListCapturedMessages(ctx context.Context, filter model.CapturedMessageFilter, pageSize int, nextToken string) ([]model.CapturedMessage, string, error)
+	DeleteCapturedMessage(ctx context.Context, messageID string) error
+	DeleteCapturedMessages(ctx context.Context, filter model.CapturedMessageFilter) (int64, error)
+	WaitForCapturedMessage(ctx context.Context, filter model.CapturedMessageFilter, wait time.Duration) (model.CapturedMessage, error)
+	SubscribeCapturedMessages(ctx context.Context) (ids <-chan string, unsubscribe func() error, err error)
+	ListInboxes(ctx context.Context) ([]model.Inbox, error)
+	FindCapturedMessages(ctx context.Context, filter model.CapturedMessageFilter) ([]model.CapturedMessage, error)
+}
+
+// MessageHandler lets tests inspect the messages the mock accepted, which SES
+// would have delivered.
+type MessageHandler struct {
+	service CapturedMessageService
+	// closing ends the message streams, which would otherwise hold up the
+	// shutdown of the server.
+	closing   chan struct{}
+	closeOnce sync.Once
+}
+
+// NewMessageHandler creates a new MessageHandler
+func NewMessageHandler(s CapturedMessageService) *MessageHandler {
+	return &MessageHandler{service: s, closing: make(chan struct{})}
+}
+
+// CloseStreams ends the open message streams, e.g. when the server shuts down
+func (h *MessageHandler) CloseStreams() {
+	h.closeOnce.Do(func() { close(h.closing) })
+}
+
+// ListMessages returns one page of the captured messages matching the
+// Recipient, Source, Subject, SubjectRegex, Tag (Name or Name:Value),
+// ConfigurationSetName, StartDate and EndDate query parameters, newest first
+func (h *MessageHandler) ListMessages(c *gin.Context) {
+	filter, ok := messageFilter(c)
+	if !ok {
+		return
+	}
+	h.list(c, filter)
+}
+
+// SearchMessages is ListMessages narrowed down to the messages whose addresses,
+// subject or bodies contain the Query parameter
+func (h *MessageHandler) SearchMessages(c *gin.Context) {
+	filter, ok := messageFilter(c)
+	if !ok {
+		return
+	}
+	if filter.Text = c.Query("Query"); filter.Text == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "MissingParameter", "message": "Query is required."})
+		return
+	}
+	h.list(c, filter)
+}
+
+// WaitForMessage returns the first message captured matching the same query
+// parameters as ListMessages, waiting up to WaitTimeSeconds (20 by default)
+// for one to arrive. A StartDate looks back at the messages sent since then.
+// It responds 404 when none arrived.
+func (h *MessageHandler) WaitForMessage(c *gin.Context) {
+	filter, ok := messageFilter(c)
+	if !ok {
+		return
+	}
+
+	wait := defaultMessageWaitTime
+	if v := c.Query("WaitTimeSeconds"); v != "" {
+		seconds, err := strconv.Atoi(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "InvalidParameterValue", "message": "WaitTimeSeconds must be a number."})
+			return
+		}
+		wait = time.Duration(seconds) * time.Second
+	}
+
+	m, err := h.service.WaitForCapturedMessage(c.Request.Context(), filter, wait)
+	if err != nil {
+		writeAPIError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, m)
+}
+
+// GetMessage returns a captured message by its message ID
+func (h *MessageHandler) GetMessage(c *gin.Context) {
+	m, err := h.service.GetCapturedMessage(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		writeAPIError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, m)
+}
+
+// GetRawMessage returns the MIME message of a captured message, as it was
+// sent raw or as SES would have delivered it
+func (h *MessageHandler) GetRawMessage(c *gin.Context) {
+	m, err := h.service.GetCapturedMessage(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		writeAPIError(c, err)
+		return
+	}
+	c.Header("Content-Disposition", mime.FormatMediaType("inline", map[string]string{"filename": m.MessageID + ".eml"}))
+	c.Data(http.StatusOK, "message/rfc822", rawmail.Compose(m))
+}
+
+// GetAttachment downloads an attachment of a captured message by its index
+// in the message's Attachments
+func (h *MessageHandler) GetAttachment(c *gin.Context) {
+	m, err := h.service.GetCapturedMessage(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		writeAPIError(c, err)
+		return
+	}
+
+	index, err := strconv.Atoi(c.Param("index"))
+	if err != nil || index < 0 || index >= len(m.Attachments) {
+		writeAPIError(c, &model.SESError{Code: "NotFoundException", Message: "Attachment " + c.Param("index") + " of message " + m.MessageID + " does not exist."})
+		return
+	}
+	// Only messages sent raw have attachments, their content is in the raw message.
+	contents, err := rawmail.AttachmentContents(m.RawMessage)
+	if err != nil {
+		writeAPIError(c, err)
+		return
+	}
+
+	a := m.Attachments[index]
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": a.Filename}))
+	c.Data(http.StatusOK, a.ContentType, contents[index])
+}
+
+// ExportMessage downloads a captured message in the Format eml (the default),
+// mbox or maildir (a zip archive of a Maildir tree)
+func (h *MessageHandler) ExportMessage(c *gin.Context) {
+	format, ok := exportFormat(c)
+	if !ok {
+		return
+	}
+
+	m, err := h.service.GetCapturedMessage(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		writeAPIError(c, err)
+		return
+	}
+
+	if format == mailexport.FormatEML {
+		c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": m.MessageID + ".eml"}))
+		c.Data(http.StatusOK, "message/rfc822", rawmail.Compose(m))
+		return
+	}
+	writeExport(c, format, []model.CapturedMessage{m})
+}
+
+// ExportMessages downloads the captured messages matching the same query
+// parameters as ListMessages in the Format eml (a zip archive of .eml files,
+// the default), mbox or maildir (a zip archive of a Maildir tree)
+func (h *MessageHandler) ExportMessages(c *gin.Context) {
+	filter, ok := messageFilter(c)
+	if !ok {
+		return
+	}
+	format, ok := exportFormat(c)
+	if !ok {
+		return
+	}
+
+	messages, err := h.service.FindCapturedMessages(c.Request.Context(), filter)
+	if err != nil {
+		writeAPIError(c, err)
+		return
+	}
+	writeExport(c, format, messages)
+}
+
+// ListInboxes returns the recipients of the captured messages along with how
+// many messages they received
+func (h *MessageHandler) ListInboxes(c *gin.Context) {
+	inboxes, err := h.service.ListInboxes(c.Request.Context())
+	if err != nil {
+		writeAPIError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"Inboxes": inboxes})
+}
+
+// StreamMessages sends the ID of every message captured from now on as a
+// server-sent "message" event, until the client goes away or the streams are
+// closed
+func (h *MessageHandler) StreamMessages(c *gin.Context) {
+	ctx := c.Request.Context()
+	ids, unsubscribe, err := h.service.SubscribeCapturedMessages(ctx)
+	if err != nil {
+		writeAPIError(c, err)
+		return
+	}
+	defer unsubscribe()
+
+	// The stream outlives the server's write timeout, every write extends it.
+	rc := http.NewResponseController(c.Writer)
+	extendDeadline := func() { rc.SetWriteDeadline(time.Now().Add(2 * messageStreamHeartbeat)) }
+	heartbeat := time.NewTicker(messageStreamHeartbeat)
+	defer heartbeat.Stop()
+
+	extendDeadline()
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-h.closing:
+			return
+		case <-heartbeat.C:
+			extendDeadline()
+			io.WriteString(c.Writer, ": heartbeat\n\n")
+		case id, ok := <-ids:
+			if !ok {
+				return
+			}
+			extendDeadline()
+			c.SSEvent("message", id)
+		}
+		c.Writer.Flush()
+	}
+}
+
+// DeleteMessage removes a captured message
+func (h *MessageHandler) DeleteMessage(c *gin.Context) {
+	if err := h.service.DeleteCapturedMessage(c.Request.Context(), c.Param("id")); err != nil {
+		writeAPIError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// DeleteMessages removes the captured messages matching the same query
+// parameters as ListMessages, all of them without any
+func (h *MessageHandler) DeleteMessages(c *gin.Context) {
+	filter, ok := messageFilter(c)
+	if !ok {
+		return
+	}
+
+	deleted, err := h.service.DeleteCapturedMessages(c.Request.Context(), filter)
+	if err != nil {
+		writeAPIError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"Deleted": deleted})
+}
+
+func (h *MessageHandler) list(c *gin.Context, filter model.CapturedMessageFilter) {
+	pageSize := 0
+	if v := c.Query("PageSize"); v != "" {
+		var err error
+		if pageSize, err = strconv.Atoi(v); err != nil || pageSize < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "InvalidParameterValue", "message": "PageSize must be a positive number."})
+			return
+		}
+	}
+
+	messages, nextToken, err := h.service.ListCapturedMessages(c.Request.Context(), filter, pageSize, c.Query("NextToken"))
+	if err != nil {
+		writeAPIError(c, err)
+		return
+	}
+
+	resp := gin.H{"Messages": messages}
+	if nextToken != "" {
+		resp["NextToken"] = nextToken
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func exportFormat(c *gin.Context) (mailexport.Format, bool) {
+	format, err := mailexport.ParseFormat(c.DefaultQuery("Format", string(mailexport.FormatEML)))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "InvalidParameterValue", "message": "Format must be one of eml, mbox or maildir."})
+		return "", false
+	}
+	return format, true
+}
+
+func writeExport(c *gin.Context, format mailexport.Format, messages []model.CapturedMessage) {
+	var buf bytes.Buffer
+	if err := mailexport.Export(&buf, format, messages); err != nil {
+		writeAPIError(c, err)
+		return
+	}
+
+	filename, contentType := mailexport.ExportFile(format)
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	c.Data(http.StatusOK, contentType, buf.Bytes())
+}
+
+// messageFilter reads the filter of the query string, writing the error when
+// it is invalid.
+func messageFilter(c *gin.Context) (model.CapturedMessageFilter, bool) {
+	filter := model.CapturedMessageFilter{
+		Recipient:            c.Query("Recipient"),
+		Source:               c.Query("Source"),
+		Subject:              c.Query("Subject"),
+		ConfigurationSetName: c.Query("ConfigurationSetName"),
+	}
+	if v := c.Query("SubjectRegex"); v != "" {
+		re, err := regexp.Compile(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "InvalidParameterValue", "message": "SubjectRegex is not a valid regular expression."})
+			return model.CapturedMessageFilter{}, false
+		}
+		filter.SubjectPattern = re
+	}
+	if v := c.Query("Tag"); v != "" {
+		name, value, _ := strings.Cut(v, ":")
+		filter.Tag = &model.Tag{Name: name, Value: value}
+	}
+
+	for name, date := range map[string]*time.Time{"StartDate": &filter.StartDate, "EndDate": &filter.EndDate} {
+		v := c.Query(name)
+		if v == "" {
+			continue
+		}
+		t, err := parseV2QueryTimestamp(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "InvalidParameterValue", "message": name + " must be an ISO 8601 timestamp."})
+			return model.CapturedMessageFilter{}, false
+		}
+		*date = t
+	}
+	return filter, true
+}
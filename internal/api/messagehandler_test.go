@@ -0,0 +1,335 @@
+package api_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/kamal-github/demtech/internal/api"
+	"github.com/kamal-github/demtech/internal/api/mocks"
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMessageHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockCapturedMessageService(ctrl)
+	h := api.NewMessageHandler(mockService)
+
+	router := gin.New()
+	router.GET("/api/v1/messages", h.ListMessages)
+	router.DELETE("/api/v1/messages", h.DeleteMessages)
+	router.GET("/api/v1/messages/search", h.SearchMessages)
+	router.GET("/api/v1/messages/wait", h.WaitForMessage)
+	router.GET("/api/v1/messages/stream", h.StreamMessages)
+	router.GET("/api/v1/messages/export", h.ExportMessages)
+	router.GET("/api/v1/messages/:id/export", h.ExportMessage)
+	router.GET("/api/v1/messages/:id", h.GetMessage)
+	router.GET("/api/v1/messages/:id/raw", h.GetRawMessage)
+	router.GET("/api/v1/messages/:id/attachments/:index", h.GetAttachment)
+	router.GET("/api/v1/inboxes", h.ListInboxes)
+	router.DELETE("/api/v1/messages/:id", h.DeleteMessage)
+
+	message := model.CapturedMessage{
+		MessageID:   "msg-1",
+		Source:      "sender@example.com",
+		Destination: model.Destination{ToAddresses: []string{"to@example.com"}},
+		Subject:     "Welcome",
+		SentAt:      time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+	raw := "From: sender@example.com\r\n" +
+		"To: to@example.com\r\n" +
+		"Content-Type: multipart/mixed; boundary=b\r\n" +
+		"\r\n" +
+		"--b\r\n" +
+		"Content-Type: text/plain\r\n" +
+		"\r\n" +
+		"Hello\r\n" +
+		"--b\r\n" +
+		"Content-Type: text/csv\r\n" +
+		"Content-Disposition: attachment; filename=\"report.csv\"\r\n" +
+		"\r\n" +
+		"a,b\r\n" +
+		"--b--\r\n"
+	rawMessage := model.CapturedMessage{
+		MessageID:   "msg-2",
+		Attachments: []model.Attachment{{Filename: "report.csv", ContentType: "text/csv", Size: 3}},
+		RawMessage:  []byte(raw),
+	}
+
+	tests := []struct {
+		name         string
+		method       string
+		path         string
+		mockSetup    func()
+		expectCode   int
+		expectInBody []string
+	}{
+		{
+			name:   "List messages with filters",
+			method: http.MethodGet,
+			path:   "/api/v1/messages?Recipient=to@example.com&Source=sender&Subject=Welcome&Tag=campaign:welcome&StartDate=2024-01-02T00:00:00Z&PageSize=10&NextToken=abc",
+			mockSetup: func() {
+				mockService.EXPECT().ListCapturedMessages(gomock.Any(), model.CapturedMessageFilter{
+					Recipient: "to@example.com",
+					Source:    "sender",
+					Subject:   "Welcome",
+					Tag:       &model.Tag{Name: "campaign", Value: "welcome"},
+					StartDate: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+				}, 10, "abc").Return([]model.CapturedMessage{message}, "next", nil)
+			},
+			expectCode: http.StatusOK,
+			expectInBody: []string{
+				`"MessageId":"msg-1"`,
+				`"Destination":{"ToAddresses":["to@example.com"],"CcAddresses":null,"BccAddresses":null}`,
+				`"SentAt":"2024-01-02T03:04:05Z"`,
+				`"NextToken":"next"`,
+			},
+		},
+		{
+			name:         "List messages with an invalid date",
+			method:       http.MethodGet,
+			path:         "/api/v1/messages?EndDate=yesterday",
+			mockSetup:    func() {},
+			expectCode:   http.StatusBadRequest,
+			expectInBody: []string{"EndDate must be an ISO 8601 timestamp."},
+		},
+		{
+			name:   "Search messages",
+			method: http.MethodGet,
+			path:   "/api/v1/messages/search?Query=code&Tag=campaign",
+			mockSetup: func() {
+				mockService.EXPECT().ListCapturedMessages(gomock.Any(), model.CapturedMessageFilter{Text: "code", Tag: &model.Tag{Name: "campaign"}}, 0, "").
+					Return([]model.CapturedMessage{}, "", nil)
+			},
+			expectCode:   http.StatusOK,
+			expectInBody: []string{`{"Messages":[]}`},
+		},
+		{
+			name:         "Search messages without query",
+			method:       http.MethodGet,
+			path:         "/api/v1/messages/search",
+			mockSetup:    func() {},
+			expectCode:   http.StatusBadRequest,
+			expectInBody: []string{"MissingParameter"},
+		},
+		{
+			name:         "List messages with an invalid subject pattern",
+			method:       http.MethodGet,
+			path:         "/api/v1/messages?SubjectRegex=(",
+			mockSetup:    func() {},
+			expectCode:   http.StatusBadRequest,
+			expectInBody: []string{"SubjectRegex is not a valid regular expression."},
+		},
+		{
+			name:   "Wait for message",
+			method: http.MethodGet,
+			path:   "/api/v1/messages/wait?Recipient=to@example.com&SubjectRegex=^Wel&ConfigurationSetName=transactional&WaitTimeSeconds=5",
+			mockSetup: func() {
+				mockService.EXPECT().WaitForCapturedMessage(gomock.Any(), model.CapturedMessageFilter{
+					Recipient:            "to@example.com",
+					SubjectPattern:       regexp.MustCompile("^Wel"),
+					ConfigurationSetName: "transactional",
+				}, 5*time.Second).Return(message, nil)
+			},
+			expectCode:   http.StatusOK,
+			expectInBody: []string{`"MessageId":"msg-1"`},
+		},
+		{
+			name:   "Wait for message without one arriving",
+			method: http.MethodGet,
+			path:   "/api/v1/messages/wait?Recipient=nobody@example.com",
+			mockSetup: func() {
+				mockService.EXPECT().WaitForCapturedMessage(gomock.Any(), model.CapturedMessageFilter{Recipient: "nobody@example.com"}, 20*time.Second).
+					Return(model.CapturedMessage{}, &model.SESError{Code: "NotFoundException", Message: "No matching message was captured within 20s."})
+			},
+			expectCode:   http.StatusNotFound,
+			expectInBody: []string{"No matching message was captured within 20s."},
+		},
+		{
+			name:         "Wait for message with an invalid wait time",
+			method:       http.MethodGet,
+			path:         "/api/v1/messages/wait?WaitTimeSeconds=soon",
+			mockSetup:    func() {},
+			expectCode:   http.StatusBadRequest,
+			expectInBody: []string{"WaitTimeSeconds must be a number."},
+		},
+		{
+			name:   "Get message",
+			method: http.MethodGet,
+			path:   "/api/v1/messages/msg-1",
+			mockSetup: func() {
+				mockService.EXPECT().GetCapturedMessage(gomock.Any(), "msg-1").Return(message, nil)
+			},
+			expectCode:   http.StatusOK,
+			expectInBody: []string{`"Subject":"Welcome"`},
+		},
+		{
+			name:   "Get unknown message",
+			method: http.MethodGet,
+			path:   "/api/v1/messages/unknown",
+			mockSetup: func() {
+				mockService.EXPECT().GetCapturedMessage(gomock.Any(), "unknown").
+					Return(model.CapturedMessage{}, &model.SESError{Code: "NotFoundException", Message: "Message unknown does not exist."})
+			},
+			expectCode: http.StatusNotFound,
+		},
+		{
+			name:   "Get raw message",
+			method: http.MethodGet,
+			path:   "/api/v1/messages/msg-1/raw",
+			mockSetup: func() {
+				mockService.EXPECT().GetCapturedMessage(gomock.Any(), "msg-1").Return(message, nil)
+			},
+			expectCode:   http.StatusOK,
+			expectInBody: []string{"From: sender@example.com\r\n", "Message-ID: <msg-1@email.amazonses.com>\r\n", "Subject: Welcome\r\n"},
+		},
+		{
+			name:   "Get attachment",
+			method: http.MethodGet,
+			path:   "/api/v1/messages/msg-2/attachments/0",
+			mockSetup: func() {
+				mockService.EXPECT().GetCapturedMessage(gomock.Any(), "msg-2").Return(rawMessage, nil)
+			},
+			expectCode:   http.StatusOK,
+			expectInBody: []string{"a,b"},
+		},
+		{
+			name:   "Get unknown attachment",
+			method: http.MethodGet,
+			path:   "/api/v1/messages/msg-2/attachments/1",
+			mockSetup: func() {
+				mockService.EXPECT().GetCapturedMessage(gomock.Any(), "msg-2").Return(rawMessage, nil)
+			},
+			expectCode:   http.StatusNotFound,
+			expectInBody: []string{"Attachment 1 of message msg-2 does not exist."},
+		},
+		{
+			name:   "Export message",
+			method: http.MethodGet,
+			path:   "/api/v1/messages/msg-1/export",
+			mockSetup: func() {
+				mockService.EXPECT().GetCapturedMessage(gomock.Any(), "msg-1").Return(message, nil)
+			},
+			expectCode:   http.StatusOK,
+			expectInBody: []string{"Subject: Welcome\r\n"},
+		},
+		{
+			name:   "Export messages as mbox",
+			method: http.MethodGet,
+			path:   "/api/v1/messages/export?Format=mbox&Recipient=to@example.com",
+			mockSetup: func() {
+				mockService.EXPECT().FindCapturedMessages(gomock.Any(), model.CapturedMessageFilter{Recipient: "to@example.com"}).
+					Return([]model.CapturedMessage{message}, nil)
+			},
+			expectCode:   http.StatusOK,
+			expectInBody: []string{"From sender@example.com Tue Jan  2 03:04:05 2024\nFrom: sender@example.com\n"},
+		},
+		{
+			name:         "Export messages in an unknown format",
+			method:       http.MethodGet,
+			path:         "/api/v1/messages/export?Format=pst",
+			mockSetup:    func() {},
+			expectCode:   http.StatusBadRequest,
+			expectInBody: []string{"Format must be one of eml, mbox or maildir."},
+		},
+		{
+			name:   "List inboxes",
+			method: http.MethodGet,
+			path:   "/api/v1/inboxes",
+			mockSetup: func() {
+				mockService.EXPECT().ListInboxes(gomock.Any()).Return([]model.Inbox{{Address: "to@example.com", MessageCount: 2, LatestSentAt: message.SentAt}}, nil)
+			},
+			expectCode:   http.StatusOK,
+			expectInBody: []string{`{"Inboxes":[{"Address":"to@example.com","MessageCount":2,"LatestSentAt":"2024-01-02T03:04:05Z"}]}`},
+		},
+		{
+			name:   "Stream messages",
+			method: http.MethodGet,
+			path:   "/api/v1/messages/stream",
+			mockSetup: func() {
+				ids := make(chan string, 1)
+				ids <- "msg-1"
+				close(ids)
+				mockService.EXPECT().SubscribeCapturedMessages(gomock.Any()).Return((<-chan string)(ids), func() error { return nil }, nil)
+			},
+			expectCode:   http.StatusOK,
+			expectInBody: []string{"event:message\ndata:msg-1\n\n"},
+		},
+		{
+			name:   "Delete message",
+			method: http.MethodDelete,
+			path:   "/api/v1/messages/msg-1",
+			mockSetup: func() {
+				mockService.EXPECT().DeleteCapturedMessage(gomock.Any(), "msg-1").Return(nil)
+			},
+			expectCode: http.StatusNoContent,
+		},
+		{
+			name:   "Delete messages to a recipient",
+			method: http.MethodDelete,
+			path:   "/api/v1/messages?Recipient=to@example.com",
+			mockSetup: func() {
+				mockService.EXPECT().DeleteCapturedMessages(gomock.Any(), model.CapturedMessageFilter{Recipient: "to@example.com"}).Return(int64(3), nil)
+			},
+			expectCode:   http.StatusOK,
+			expectInBody: []string{`{"Deleted":3}`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			tt.mockSetup()
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(tt.method, tt.path, nil)
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(tt.expectCode, w.Code)
+			for _, s := range tt.expectInBody {
+				assert.Contains(w.Body.String(), s)
+			}
+		})
+	}
+}
+
+func TestMessageHandler_CloseStreams(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockCapturedMessageService(ctrl)
+	h := api.NewMessageHandler(mockService)
+
+	router := gin.New()
+	router.GET("/api/v1/messages/stream", h.StreamMessages)
+
+	// Nothing is ever captured, the stream stays open until it is closed.
+	ids := make(chan string)
+	mockService.EXPECT().SubscribeCapturedMessages(gomock.Any()).Return((<-chan string)(ids), func() error { return nil }, nil)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		req, _ := http.NewRequest(http.MethodGet, "/api/v1/messages/stream", nil)
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	}()
+
+	h.CloseStreams()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("The stream was not closed")
+	}
+}
@@ -0,0 +1,110 @@
+package api
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/kamal-github/demtech/internal/model"
+)
+
+// memberList decodes the Query protocol list encoding, i.e.
+// `prefix.member.1=a&prefix.member.2=b`, into a slice. Decoding stops at the
+// first missing index, just like AWS does.
+func memberList(form url.Values, prefix string) []string {
+	var list []string
+	for i := 1; ; i++ {
+		key := prefix + ".member." + strconv.Itoa(i)
+		if _, ok := form[key]; !ok {
+			return list
+		}
+		list = append(list, form.Get(key))
+	}
+}
+
+// decodeDestination decodes the To/Cc/Bcc member lists of a Destination.
+func decodeDestination(form url.Values, prefix string) model.Destination {
+	return model.Destination{
+		ToAddresses:  memberList(form, prefix+".ToAddresses"),
+		CcAddresses:  memberList(form, prefix+".CcAddresses"),
+		BccAddresses: memberList(form, prefix+".BccAddresses"),
+	}
+}
+
+// hasPrefix reports whether any parameter of form starts with prefix, which is
+// how the presence of a list member of structures is detected.
+func hasPrefix(form url.Values, prefix string) bool {
+	for k := range form {
+		if strings.HasPrefix(k, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// tagList decodes `prefix.member.N.Name` / `prefix.member.N.Value` pairs.
+func tagList(form url.Values, prefix string) []model.Tag {
+	var tags []model.Tag
+	for i := 1; ; i++ {
+		key := prefix + ".member." + strconv.Itoa(i)
+		if _, ok := form[key+".Name"]; !ok {
+			return tags
+		}
+		tags = append(tags, model.Tag{Name: form.Get(key + ".Name"), Value: form.Get(key + ".Value")})
+	}
+}
+
+func missingParameter(name string) *model.SESError {
+	return &model.SESError{Code: "MissingParameter", Message: "Missing required parameter " + name + "."}
+}
+
+// attributeMap decodes `prefix.entry.N.key` / `prefix.entry.N.value` pairs.
+func attributeMap(form url.Values, prefix string) map[string]string {
+	attrs := make(map[string]string)
+	for i := 1; ; i++ {
+		key := prefix + ".entry." + strconv.Itoa(i)
+		if _, ok := form[key+".key"]; !ok {
+			return attrs
+		}
+		attrs[form.Get(key+".key")] = form.Get(key + ".value")
+	}
+}
+
+// flattenedList decodes the flattened list encoding of SQS, i.e.
+// `prefix.1=a&prefix.2=b`, into a slice.
+func flattenedList(form url.Values, prefix string) []string {
+	var list []string
+	for i := 1; ; i++ {
+		key := prefix + "." + strconv.Itoa(i)
+		if _, ok := form[key]; !ok {
+			return list
+		}
+		list = append(list, form.Get(key))
+	}
+}
+
+// flattenedAttributeMap decodes the flattened map encoding of SQS, i.e.
+// `prefix.N.Name=k&prefix.N.Value=v`, into a map.
+func flattenedAttributeMap(form url.Values, prefix string) map[string]string {
+	attrs := make(map[string]string)
+	for i := 1; ; i++ {
+		key := prefix + "." + strconv.Itoa(i)
+		if _, ok := form[key+".Name"]; !ok {
+			return attrs
+		}
+		attrs[form.Get(key+".Name")] = form.Get(key + ".Value")
+	}
+}
+
+// optionalInt decodes an optional integer parameter, nil when it is absent.
+func optionalInt(form url.Values, name string) (*int, error) {
+	v := form.Get(name)
+	if v == "" {
+		return nil, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return nil, &model.SESError{Code: "InvalidParameterValue", Message: "Value " + v + " for parameter " + name + " is invalid. Reason: must be an integer."}
+	}
+	return &n, nil
+}
@@ -0,0 +1,142 @@
+package api
+
+import (
+	"encoding/xml"
+	"errors"
+	"log"
+	"net/http"
+	"net/url"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/kamal-github/demtech/internal/model"
+)
+
+const (
+	sesXMLNamespace = "http://ses.amazonaws.com/doc/2010-12-01/"
+	snsXMLNamespace = "http://sns.amazonaws.com/doc/2010-03-31/"
+	sqsXMLNamespace = "http://queue.amazonaws.com/doc/2012-11-05/"
+)
+
+// QueryAction handles a single action of the AWS Query protocol. The returned
+// result is rendered inside the <{Action}Result> element, so it is expected to
+// carry its own XMLName.
+type QueryAction func(c *gin.Context, form url.Values) (any, error)
+
+// QueryRouter dispatches form-encoded AWS Query requests (`Action=SendEmail&...`)
+// to the registered actions and renders their results in the SES XML envelope.
+type QueryRouter struct {
+	actions map[string]QueryAction
+	xmlns   string
+}
+
+// NewQueryRouter creates a new QueryRouter for SES without any action
+func NewQueryRouter() *QueryRouter {
+	return &QueryRouter{actions: make(map[string]QueryAction), xmlns: sesXMLNamespace}
+}
+
+// NewSNSQueryRouter creates a new QueryRouter for SNS without any action
+func NewSNSQueryRouter() *QueryRouter {
+	return &QueryRouter{actions: make(map[string]QueryAction), xmlns: snsXMLNamespace}
+}
+
+// NewSQSQueryRouter creates a new QueryRouter for SQS without any action
+func NewSQSQueryRouter() *QueryRouter {
+	return &QueryRouter{actions: make(map[string]QueryAction), xmlns: sqsXMLNamespace}
+}
+
+// Register adds a handler for the given Action name
+func (r *QueryRouter) Register(action string, fn QueryAction) {
+	r.actions[action] = fn
+}
+
+// Handle is the gin handler serving every Query API request
+func (r *QueryRouter) Handle(c *gin.Context) {
+	requestID := uuid.NewString()
+	c.Header("x-amzn-RequestId", requestID)
+
+	if err := c.Request.ParseForm(); err != nil {
+		r.writeError(c, requestID, &model.SESError{Code: "MalformedQueryString", Message: err.Error()})
+		return
+	}
+
+	action := c.Request.Form.Get("Action")
+	fn, ok := r.actions[action]
+	if !ok {
+		r.writeError(c, requestID, &model.SESError{Code: "InvalidAction", Message: "Could not find operation " + action})
+		return
+	}
+
+	result, err := fn(c, c.Request.Form)
+	if err != nil {
+		r.writeError(c, requestID, err)
+		return
+	}
+
+	c.XML(http.StatusOK, queryResponse{
+		XMLName:          xml.Name{Local: action + "Response"},
+		Xmlns:            r.xmlns,
+		Result:           result,
+		ResponseMetadata: responseMetadata{RequestID: requestID},
+	})
+}
+
+type queryResponse struct {
+	XMLName          xml.Name
+	Xmlns            string           `xml:"xmlns,attr"`
+	Result           any              `xml:",omitempty"`
+	ResponseMetadata responseMetadata `xml:"ResponseMetadata"`
+}
+
+type responseMetadata struct {
+	RequestID string `xml:"RequestId"`
+}
+
+type queryErrorResponse struct {
+	XMLName   xml.Name   `xml:"ErrorResponse"`
+	Xmlns     string     `xml:"xmlns,attr"`
+	Error     queryError `xml:"Error"`
+	RequestID string     `xml:"RequestId"`
+}
+
+type queryError struct {
+	Type    string `xml:"Type"`
+	Code    string `xml:"Code"`
+	Message string `xml:"Message"`
+}
+
+func (r *QueryRouter) writeError(c *gin.Context, requestID string, err error) {
+	var sesErr *model.SESError
+	if !errors.As(err, &sesErr) {
+		log.Printf("Query request failed: %v", err)
+		sesErr = &model.SESError{Code: "InternalFailure", Message: "Unexpected internal error occurred."}
+	}
+
+	status := sesErrorStatus(sesErr.Code)
+	errType := "Sender"
+	if status >= http.StatusInternalServerError {
+		errType = "Receiver"
+	}
+
+	c.XML(status, queryErrorResponse{
+		Xmlns:     r.xmlns,
+		Error:     queryError{Type: errType, Code: sesErr.Code, Message: sesErr.Message},
+		RequestID: requestID,
+	})
+}
+
+// sesErrorStatus maps an SES error code to the HTTP status code SES answers with.
+func sesErrorStatus(code string) int {
+	switch code {
+	case "InternalFailure":
+		return http.StatusInternalServerError
+	case "ServiceUnavailable":
+		return http.StatusServiceUnavailable
+	case "AccessDeniedException", "InvalidClientTokenId", "SignatureDoesNotMatch", "RequestExpired", "AuthorizationError":
+		return http.StatusForbidden
+	case "NotFound":
+		return http.StatusNotFound
+	default:
+		return http.StatusBadRequest
+	}
+}
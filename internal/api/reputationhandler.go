@@ -0,0 +1,35 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kamal-github/demtech/internal/model"
+)
+
+type ConfigurationSetReputationGetter interface {
+	GetConfigurationSetReputation(ctx context.Context, configurationSetName string) (model.ReputationMetrics, error)
+}
+
+// ReputationHandler serves the reputation metrics of the configuration sets,
+// which SES publishes to CloudWatch.
+type ReputationHandler struct {
+	service ConfigurationSetReputationGetter
+}
+
+// NewReputationHandler creates a new ReputationHandler
+func NewReputationHandler(s ConfigurationSetReputationGetter) *ReputationHandler {
+	return &ReputationHandler{service: s}
+}
+
+// GetConfigurationSetReputation returns the bounce and complaint rates of the
+// messages sent with a configuration set whose reputation metrics are enabled
+func (h *ReputationHandler) GetConfigurationSetReputation(c *gin.Context) {
+	metrics, err := h.service.GetConfigurationSetReputation(c.Request.Context(), c.Param("name"))
+	if err != nil {
+		writeAPIError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, metrics)
+}
@@ -0,0 +1,67 @@
+package api_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/kamal-github/demtech/internal/api"
+	"github.com/kamal-github/demtech/internal/api/mocks"
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReputationHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockConfigurationSetReputationGetter(ctrl)
+	h := api.NewReputationHandler(mockService)
+
+	router := gin.New()
+	router.GET("/api/v1/configuration-sets/:name/reputation", h.GetConfigurationSetReputation)
+
+	tests := []struct {
+		name         string
+		mockSetup    func()
+		expectCode   int
+		expectInBody string
+	}{
+		{
+			name: "Reputation metrics enabled",
+			mockSetup: func() {
+				mockService.EXPECT().GetConfigurationSetReputation(gomock.Any(), "marketing").
+					Return(model.ReputationMetrics{ConfigurationSetName: "marketing", Sends: 20, BounceRate: 0.05}, nil)
+			},
+			expectCode:   http.StatusOK,
+			expectInBody: `{"ConfigurationSetName":"marketing","Sends":20,"BounceRate":0.05,"ComplaintRate":0}`,
+		},
+		{
+			name: "Reputation metrics disabled",
+			mockSetup: func() {
+				mockService.EXPECT().GetConfigurationSetReputation(gomock.Any(), "marketing").
+					Return(model.ReputationMetrics{}, &model.SESError{Code: "InvalidParameterValue", Message: "Reputation metrics are not enabled for configuration set marketing."})
+			},
+			expectCode:   http.StatusBadRequest,
+			expectInBody: `"error":"InvalidParameterValue"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/api/v1/configuration-sets/marketing/reputation", nil)
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectCode, w.Code)
+			assert.Contains(t, w.Body.String(), tt.expectInBody)
+		})
+	}
+}
@@ -0,0 +1,213 @@
+package api
+
+import (
+	"context"
+	"encoding/xml"
+	"net/http"
+	"net/url"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kamal-github/demtech/internal/model"
+)
+
+type SNSService interface {
+	CreateTopic(ctx context.Context, name string, attributes map[string]string) (string, error)
+	DeleteTopic(ctx context.Context, topicArn string) error
+	ListTopics(ctx context.Context, nextToken string) ([]string, string, error)
+	Subscribe(ctx context.Context, topicArn, protocol, endpoint string) (string, error)
+	ConfirmSubscription(ctx context.Context, topicArn, token string) (string, error)
+	Unsubscribe(ctx context.Context, subscriptionArn string) error
+	ListSubscriptionsByTopic(ctx context.Context, topicArn, nextToken string) ([]model.Subscription, string, error)
+	Publish(ctx context.Context, topicArn, subject, message string) (string, error)
+}
+
+// SNSQueryHandler serves the SNS Query API actions needed to subscribe to the
+// topics SES events are published to.
+type SNSQueryHandler struct {
+	service   SNSService
+	accountID string
+}
+
+// NewSNSQueryHandler creates a new SNSQueryHandler, accountID is the owner of
+// the subscriptions.
+func NewSNSQueryHandler(s SNSService, accountID string) *SNSQueryHandler {
+	return &SNSQueryHandler{service: s, accountID: accountID}
+}
+
+type createTopicResult struct {
+	XMLName  xml.Name `xml:"CreateTopicResult"`
+	TopicArn string   `xml:"TopicArn"`
+}
+
+type topicMember struct {
+	TopicArn string `xml:"TopicArn"`
+}
+
+type listTopicsResult struct {
+	XMLName   xml.Name      `xml:"ListTopicsResult"`
+	Topics    []topicMember `xml:"Topics>member"`
+	NextToken string        `xml:"NextToken,omitempty"`
+}
+
+type subscribeResult struct {
+	XMLName         xml.Name `xml:"SubscribeResult"`
+	SubscriptionArn string   `xml:"SubscriptionArn"`
+}
+
+type confirmSubscriptionResult struct {
+	XMLName         xml.Name `xml:"ConfirmSubscriptionResult"`
+	SubscriptionArn string   `xml:"SubscriptionArn"`
+}
+
+type subscriptionMember struct {
+	TopicArn        string `xml:"TopicArn"`
+	Protocol        string `xml:"Protocol"`
+	SubscriptionArn string `xml:"SubscriptionArn"`
+	Owner           string `xml:"Owner"`
+	Endpoint        string `xml:"Endpoint"`
+}
+
+type listSubscriptionsByTopicResult struct {
+	XMLName       xml.Name             `xml:"ListSubscriptionsByTopicResult"`
+	Subscriptions []subscriptionMember `xml:"Subscriptions>member"`
+	NextToken     string               `xml:"NextToken,omitempty"`
+}
+
+type publishResult struct {
+	XMLName   xml.Name `xml:"PublishResult"`
+	MessageID string   `xml:"MessageId"`
+}
+
+// CreateTopic handles Action=CreateTopic
+func (h *SNSQueryHandler) CreateTopic(c *gin.Context, form url.Values) (any, error) {
+	name := form.Get("Name")
+	if name == "" {
+		return nil, missingParameter("Name")
+	}
+
+	arn, err := h.service.CreateTopic(c.Request.Context(), name, attributeMap(form, "Attributes"))
+	if err != nil {
+		return nil, err
+	}
+	return createTopicResult{TopicArn: arn}, nil
+}
+
+// DeleteTopic handles Action=DeleteTopic
+func (h *SNSQueryHandler) DeleteTopic(c *gin.Context, form url.Values) (any, error) {
+	topicArn := form.Get("TopicArn")
+	if topicArn == "" {
+		return nil, missingParameter("TopicArn")
+	}
+
+	// The response of DeleteTopic has no result element.
+	return nil, h.service.DeleteTopic(c.Request.Context(), topicArn)
+}
+
+// ListTopics handles Action=ListTopics
+func (h *SNSQueryHandler) ListTopics(c *gin.Context, form url.Values) (any, error) {
+	arns, nextToken, err := h.service.ListTopics(c.Request.Context(), form.Get("NextToken"))
+	if err != nil {
+		return nil, err
+	}
+
+	result := listTopicsResult{NextToken: nextToken}
+	for _, arn := range arns {
+		result.Topics = append(result.Topics, topicMember{TopicArn: arn})
+	}
+	return result, nil
+}
+
+// Subscribe handles Action=Subscribe
+func (h *SNSQueryHandler) Subscribe(c *gin.Context, form url.Values) (any, error) {
+	for _, p := range []string{"TopicArn", "Protocol", "Endpoint"} {
+		if form.Get(p) == "" {
+			return nil, missingParameter(p)
+		}
+	}
+
+	arn, err := h.service.Subscribe(c.Request.Context(), form.Get("TopicArn"), form.Get("Protocol"), form.Get("Endpoint"))
+	if err != nil {
+		return nil, err
+	}
+	return subscribeResult{SubscriptionArn: arn}, nil
+}
+
+// ConfirmSubscription handles Action=ConfirmSubscription, it is also the
+// target of the SubscribeURL of SubscriptionConfirmation messages
+func (h *SNSQueryHandler) ConfirmSubscription(c *gin.Context, form url.Values) (any, error) {
+	for _, p := range []string{"TopicArn", "Token"} {
+		if form.Get(p) == "" {
+			return nil, missingParameter(p)
+		}
+	}
+
+	arn, err := h.service.ConfirmSubscription(c.Request.Context(), form.Get("TopicArn"), form.Get("Token"))
+	if err != nil {
+		return nil, err
+	}
+	return confirmSubscriptionResult{SubscriptionArn: arn}, nil
+}
+
+// Unsubscribe handles Action=Unsubscribe, it is also the target of the
+// UnsubscribeURL of notifications
+func (h *SNSQueryHandler) Unsubscribe(c *gin.Context, form url.Values) (any, error) {
+	subscriptionArn := form.Get("SubscriptionArn")
+	if subscriptionArn == "" {
+		return nil, missingParameter("SubscriptionArn")
+	}
+
+	// The response of Unsubscribe has no result element.
+	return nil, h.service.Unsubscribe(c.Request.Context(), subscriptionArn)
+}
+
+// ListSubscriptionsByTopic handles Action=ListSubscriptionsByTopic
+func (h *SNSQueryHandler) ListSubscriptionsByTopic(c *gin.Context, form url.Values) (any, error) {
+	topicArn := form.Get("TopicArn")
+	if topicArn == "" {
+		return nil, missingParameter("TopicArn")
+	}
+
+	subs, nextToken, err := h.service.ListSubscriptionsByTopic(c.Request.Context(), topicArn, form.Get("NextToken"))
+	if err != nil {
+		return nil, err
+	}
+
+	result := listSubscriptionsByTopicResult{NextToken: nextToken}
+	for _, s := range subs {
+		arn := s.SubscriptionArn
+		if !s.Confirmed {
+			arn = "PendingConfirmation"
+		}
+		result.Subscriptions = append(result.Subscriptions, subscriptionMember{
+			TopicArn:        s.TopicArn,
+			Protocol:        s.Protocol,
+			SubscriptionArn: arn,
+			Owner:           h.accountID,
+			Endpoint:        s.Endpoint,
+		})
+	}
+	return result, nil
+}
+
+// Publish handles Action=Publish
+func (h *SNSQueryHandler) Publish(c *gin.Context, form url.Values) (any, error) {
+	for _, p := range []string{"TopicArn", "Message"} {
+		if form.Get(p) == "" {
+			return nil, missingParameter(p)
+		}
+	}
+
+	messageID, err := h.service.Publish(c.Request.Context(), form.Get("TopicArn"), form.Get("Subject"), form.Get("Message"))
+	if err != nil {
+		return nil, err
+	}
+	return publishResult{MessageID: messageID}, nil
+}
+
+// SigningCertificate serves the certificate SNS messages are signed with, it
+// is the target of their SigningCertURL
+func SigningCertificate(certPEM []byte) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Data(http.StatusOK, "application/x-x509-ca-cert", certPEM)
+	}
+}
@@ -0,0 +1,191 @@
+package api_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/kamal-github/demtech/internal/api"
+	"github.com/kamal-github/demtech/internal/api/mocks"
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSNSQueryHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const topicArn = "arn:aws:sns:us-east-1:123456789012:ses-events"
+
+	mockSNSService := mocks.NewMockSNSService(ctrl)
+	h := api.NewSNSQueryHandler(mockSNSService, "123456789012")
+
+	queryRouter := api.NewSNSQueryRouter()
+	queryRouter.Register("CreateTopic", h.CreateTopic)
+	queryRouter.Register("DeleteTopic", h.DeleteTopic)
+	queryRouter.Register("ListTopics", h.ListTopics)
+	queryRouter.Register("Subscribe", h.Subscribe)
+	queryRouter.Register("ConfirmSubscription", h.ConfirmSubscription)
+	queryRouter.Register("Unsubscribe", h.Unsubscribe)
+	queryRouter.Register("ListSubscriptionsByTopic", h.ListSubscriptionsByTopic)
+	queryRouter.Register("Publish", h.Publish)
+
+	router := gin.New()
+	router.POST("/sns", queryRouter.Handle)
+	router.GET("/sns", queryRouter.Handle)
+	router.GET("/sns/SimpleNotificationService.pem", api.SigningCertificate([]byte("-----BEGIN CERTIFICATE-----")))
+
+	tests := []struct {
+		name         string
+		method       string
+		form         url.Values
+		mockSetup    func()
+		expectCode   int
+		expectInBody []string
+	}{
+		{
+			name: "Create topic",
+			form: url.Values{
+				"Action":                   {"CreateTopic"},
+				"Name":                     {"ses-events"},
+				"Attributes.entry.1.key":   {"SignatureVersion"},
+				"Attributes.entry.1.value": {"2"},
+			},
+			mockSetup: func() {
+				mockSNSService.EXPECT().CreateTopic(gomock.Any(), "ses-events", map[string]string{"SignatureVersion": "2"}).Return(topicArn, nil)
+			},
+			expectCode: http.StatusOK,
+			expectInBody: []string{
+				`<CreateTopicResponse xmlns="http://sns.amazonaws.com/doc/2010-03-31/">`,
+				`<CreateTopicResult><TopicArn>` + topicArn + `</TopicArn></CreateTopicResult>`,
+			},
+		},
+		{
+			name:         "Create topic without name",
+			form:         url.Values{"Action": {"CreateTopic"}},
+			mockSetup:    func() {},
+			expectCode:   http.StatusBadRequest,
+			expectInBody: []string{`<ErrorResponse xmlns="http://sns.amazonaws.com/doc/2010-03-31/">`, `<Code>MissingParameter</Code>`},
+		},
+		{
+			name: "Delete topic",
+			form: url.Values{"Action": {"DeleteTopic"}, "TopicArn": {topicArn}},
+			mockSetup: func() {
+				mockSNSService.EXPECT().DeleteTopic(gomock.Any(), topicArn).Return(nil)
+			},
+			expectCode:   http.StatusOK,
+			expectInBody: []string{`<DeleteTopicResponse xmlns="http://sns.amazonaws.com/doc/2010-03-31/"><ResponseMetadata>`},
+		},
+		{
+			name: "List topics",
+			form: url.Values{"Action": {"ListTopics"}},
+			mockSetup: func() {
+				mockSNSService.EXPECT().ListTopics(gomock.Any(), "").Return([]string{topicArn}, "", nil)
+			},
+			expectCode:   http.StatusOK,
+			expectInBody: []string{`<Topics><member><TopicArn>` + topicArn + `</TopicArn></member></Topics>`},
+		},
+		{
+			name: "Subscribe",
+			form: url.Values{"Action": {"Subscribe"}, "TopicArn": {topicArn}, "Protocol": {"http"}, "Endpoint": {"http://localhost:9000/sns"}},
+			mockSetup: func() {
+				mockSNSService.EXPECT().Subscribe(gomock.Any(), topicArn, "http", "http://localhost:9000/sns").Return("pending confirmation", nil)
+			},
+			expectCode:   http.StatusOK,
+			expectInBody: []string{`<SubscribeResult><SubscriptionArn>pending confirmation</SubscriptionArn></SubscribeResult>`},
+		},
+		{
+			name: "Subscribe to unknown topic",
+			form: url.Values{"Action": {"Subscribe"}, "TopicArn": {topicArn}, "Protocol": {"http"}, "Endpoint": {"http://localhost:9000/sns"}},
+			mockSetup: func() {
+				mockSNSService.EXPECT().Subscribe(gomock.Any(), topicArn, "http", "http://localhost:9000/sns").
+					Return("", &model.SESError{Code: "NotFound", Message: "Topic does not exist"})
+			},
+			expectCode:   http.StatusNotFound,
+			expectInBody: []string{`<Code>NotFound</Code>`},
+		},
+		{
+			name:   "Confirm subscription through the SubscribeURL",
+			method: http.MethodGet,
+			form:   url.Values{"Action": {"ConfirmSubscription"}, "TopicArn": {topicArn}, "Token": {"abc"}},
+			mockSetup: func() {
+				mockSNSService.EXPECT().ConfirmSubscription(gomock.Any(), topicArn, "abc").Return(topicArn+":1", nil)
+			},
+			expectCode:   http.StatusOK,
+			expectInBody: []string{`<ConfirmSubscriptionResult><SubscriptionArn>` + topicArn + `:1</SubscriptionArn></ConfirmSubscriptionResult>`},
+		},
+		{
+			name: "List subscriptions by topic",
+			form: url.Values{"Action": {"ListSubscriptionsByTopic"}, "TopicArn": {topicArn}},
+			mockSetup: func() {
+				mockSNSService.EXPECT().ListSubscriptionsByTopic(gomock.Any(), topicArn, "").Return([]model.Subscription{
+					{SubscriptionArn: topicArn + ":1", TopicArn: topicArn, Protocol: "http", Endpoint: "http://localhost:9000/sns", Confirmed: true},
+					{SubscriptionArn: topicArn + ":2", TopicArn: topicArn, Protocol: "https", Endpoint: "https://localhost:9443/sns"},
+				}, "", nil)
+			},
+			expectCode: http.StatusOK,
+			expectInBody: []string{
+				`<member><TopicArn>` + topicArn + `</TopicArn><Protocol>http</Protocol><SubscriptionArn>` + topicArn + `:1</SubscriptionArn><Owner>123456789012</Owner><Endpoint>http://localhost:9000/sns</Endpoint></member>`,
+				`<SubscriptionArn>PendingConfirmation</SubscriptionArn>`,
+			},
+		},
+		{
+			name:   "Unsubscribe through the UnsubscribeURL",
+			method: http.MethodGet,
+			form:   url.Values{"Action": {"Unsubscribe"}, "SubscriptionArn": {topicArn + ":1"}},
+			mockSetup: func() {
+				mockSNSService.EXPECT().Unsubscribe(gomock.Any(), topicArn+":1").Return(nil)
+			},
+			expectCode:   http.StatusOK,
+			expectInBody: []string{`<UnsubscribeResponse xmlns="http://sns.amazonaws.com/doc/2010-03-31/">`},
+		},
+		{
+			name: "Publish",
+			form: url.Values{"Action": {"Publish"}, "TopicArn": {topicArn}, "Message": {"hello"}},
+			mockSetup: func() {
+				mockSNSService.EXPECT().Publish(gomock.Any(), topicArn, "", "hello").Return("msg-1", nil)
+			},
+			expectCode:   http.StatusOK,
+			expectInBody: []string{`<PublishResult><MessageId>msg-1</MessageId></PublishResult>`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			tt.mockSetup()
+
+			w := httptest.NewRecorder()
+			var req *http.Request
+			if tt.method == http.MethodGet {
+				req, _ = http.NewRequest(http.MethodGet, "/sns?"+tt.form.Encode(), nil)
+			} else {
+				req, _ = http.NewRequest(http.MethodPost, "/sns", strings.NewReader(tt.form.Encode()))
+				req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			}
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(tt.expectCode, w.Code)
+			for _, s := range tt.expectInBody {
+				assert.Contains(w.Body.String(), s)
+			}
+		})
+	}
+
+	t.Run("Signing certificate", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/sns/SimpleNotificationService.pem", nil)
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "-----BEGIN CERTIFICATE-----", w.Body.String())
+	})
+}
@@ -0,0 +1,215 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/kamal-github/demtech/internal/model"
+)
+
+const sqsJSONTargetPrefix = "AmazonSQS."
+
+// SQSJSONHandler serves the AWS JSON 1.0 protocol of SQS, which the current
+// AWS SDKs speak. It exposes the same actions as SQSQueryHandler.
+type SQSJSONHandler struct {
+	service   SQSService
+	accountID string
+}
+
+// NewSQSJSONHandler creates a new SQSJSONHandler, accountID is reported as
+// the SenderId of the messages.
+func NewSQSJSONHandler(s SQSService, accountID string) *SQSJSONHandler {
+	return &SQSJSONHandler{service: s, accountID: accountID}
+}
+
+type sqsJSONRequest struct {
+	QueueName                   string            `json:"QueueName"`
+	QueueURL                    string            `json:"QueueUrl"`
+	Attributes                  map[string]string `json:"Attributes"`
+	AttributeNames              []string          `json:"AttributeNames"`
+	MessageSystemAttributeNames []string          `json:"MessageSystemAttributeNames"`
+	MessageBody                 string            `json:"MessageBody"`
+	DelaySeconds                *int              `json:"DelaySeconds"`
+	MaxNumberOfMessages         int               `json:"MaxNumberOfMessages"`
+	VisibilityTimeout           *int              `json:"VisibilityTimeout"`
+	WaitTimeSeconds             *int              `json:"WaitTimeSeconds"`
+	ReceiptHandle               string            `json:"ReceiptHandle"`
+}
+
+type sqsJSONMessage struct {
+	MessageID     string            `json:"MessageId"`
+	ReceiptHandle string            `json:"ReceiptHandle"`
+	MD5OfBody     string            `json:"MD5OfBody"`
+	Body          string            `json:"Body"`
+	Attributes    map[string]string `json:"Attributes,omitempty"`
+}
+
+// Handle is the gin handler serving every JSON request, the action is taken
+// from the X-Amz-Target header
+func (h *SQSJSONHandler) Handle(c *gin.Context) {
+	c.Header("x-amzn-RequestId", uuid.NewString())
+
+	var req sqsJSONRequest
+	if err := json.NewDecoder(c.Request.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		writeSQSJSONError(c, &model.SESError{Code: "SerializationException", Message: err.Error()})
+		return
+	}
+	if req.QueueURL == "" {
+		req.QueueURL = c.Param("queue")
+	}
+
+	result, err := h.dispatch(c, strings.TrimPrefix(c.GetHeader("X-Amz-Target"), sqsJSONTargetPrefix), req)
+	if err != nil {
+		writeSQSJSONError(c, err)
+		return
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		writeSQSJSONError(c, err)
+		return
+	}
+	c.Data(http.StatusOK, "application/x-amz-json-1.0", data)
+}
+
+func (h *SQSJSONHandler) dispatch(c *gin.Context, action string, req sqsJSONRequest) (any, error) {
+	ctx := c.Request.Context()
+
+	switch action {
+	case "CreateQueue":
+		if req.QueueName == "" {
+			return nil, missingParameter("QueueName")
+		}
+		queueURL, err := h.service.CreateQueue(ctx, req.QueueName, req.Attributes)
+		if err != nil {
+			return nil, err
+		}
+		return gin.H{"QueueUrl": queueURL}, nil
+
+	case "GetQueueUrl":
+		if req.QueueName == "" {
+			return nil, missingParameter("QueueName")
+		}
+		queueURL, err := h.service.GetQueueURL(ctx, req.QueueName)
+		if err != nil {
+			return nil, err
+		}
+		return gin.H{"QueueUrl": queueURL}, nil
+
+	case "SendMessage":
+		if req.QueueURL == "" {
+			return nil, missingParameter("QueueUrl")
+		}
+		if req.MessageBody == "" {
+			return nil, missingParameter("MessageBody")
+		}
+		m, err := h.service.SendMessage(ctx, req.QueueURL, req.MessageBody, req.DelaySeconds)
+		if err != nil {
+			return nil, err
+		}
+		return gin.H{"MessageId": m.MessageID, "MD5OfMessageBody": m.MD5OfBody}, nil
+
+	case "ReceiveMessage":
+		if req.QueueURL == "" {
+			return nil, missingParameter("QueueUrl")
+		}
+		messages, err := h.service.ReceiveMessage(ctx, model.ReceiveMessageRequest{
+			QueueURL:            req.QueueURL,
+			MaxNumberOfMessages: req.MaxNumberOfMessages,
+			VisibilityTimeout:   req.VisibilityTimeout,
+			WaitTimeSeconds:     req.WaitTimeSeconds,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(messages) == 0 {
+			// SQS omits Messages when nothing was received.
+			return gin.H{}, nil
+		}
+		names := append(req.AttributeNames, req.MessageSystemAttributeNames...)
+		out := make([]sqsJSONMessage, 0, len(messages))
+		for _, m := range messages {
+			out = append(out, sqsJSONMessage{
+				MessageID:     m.MessageID,
+				ReceiptHandle: m.ReceiptHandle,
+				MD5OfBody:     m.MD5OfBody,
+				Body:          m.Body,
+				Attributes:    messageSystemAttributes(m, names, h.accountID),
+			})
+		}
+		return gin.H{"Messages": out}, nil
+
+	case "DeleteMessage":
+		if req.QueueURL == "" {
+			return nil, missingParameter("QueueUrl")
+		}
+		if req.ReceiptHandle == "" {
+			return nil, missingParameter("ReceiptHandle")
+		}
+		return gin.H{}, h.service.DeleteMessage(ctx, req.QueueURL, req.ReceiptHandle)
+
+	case "GetQueueAttributes":
+		if req.QueueURL == "" {
+			return nil, missingParameter("QueueUrl")
+		}
+		attributes, err := h.service.GetQueueAttributes(ctx, req.QueueURL, req.AttributeNames)
+		if err != nil {
+			return nil, err
+		}
+		return gin.H{"Attributes": attributes}, nil
+
+	default:
+		return nil, &model.SESError{Code: "InvalidAction", Message: "Could not find operation " + action}
+	}
+}
+
+// sqsJSONErrorType translates the Query error codes of SQS into the error
+// types of its JSON protocol.
+func sqsJSONErrorType(code string) string {
+	switch code {
+	case "AWS.SimpleQueueService.NonExistentQueue":
+		return "QueueDoesNotExist"
+	case "QueueAlreadyExists":
+		return "QueueNameExists"
+	default:
+		return code
+	}
+}
+
+func writeSQSJSONError(c *gin.Context, err error) {
+	var sesErr *model.SESError
+	if !errors.As(err, &sesErr) {
+		log.Printf("SQS request failed: %v", err)
+		sesErr = &model.SESError{Code: "InternalFailure", Message: "Unexpected internal error occurred."}
+	}
+
+	status := sesErrorStatus(sesErr.Code)
+	errType := "Sender"
+	if status >= http.StatusInternalServerError {
+		errType = "Receiver"
+	}
+
+	// The SDKs read the Query error code from x-amzn-query-error, for
+	// compatibility with the codes of the Query protocol.
+	c.Header("x-amzn-query-error", sesErr.Code+";"+errType)
+	data, _ := json.Marshal(gin.H{"__type": "com.amazonaws.sqs#" + sqsJSONErrorType(sesErr.Code), "message": sesErr.Message})
+	c.Data(status, "application/x-amz-json-1.0", data)
+}
+
+// SQSEndpoint serves both protocols of SQS on the same routes: requests
+// carrying an AmazonSQS X-Amz-Target header are JSON, the others are Query.
+func SQSEndpoint(queryRouter *QueryRouter, jsonHandler *SQSJSONHandler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if strings.HasPrefix(c.GetHeader("X-Amz-Target"), sqsJSONTargetPrefix) {
+			jsonHandler.Handle(c)
+			return
+		}
+		queryRouter.Handle(c)
+	}
+}
@@ -0,0 +1,246 @@
+package api
+
+import (
+	"context"
+	"encoding/xml"
+	"net/url"
+	"sort"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kamal-github/demtech/internal/model"
+)
+
+type SQSService interface {
+	CreateQueue(ctx context.Context, name string, attributes map[string]string) (string, error)
+	GetQueueURL(ctx context.Context, name string) (string, error)
+	SendMessage(ctx context.Context, queueURL, body string, delaySeconds *int) (model.QueueMessage, error)
+	ReceiveMessage(ctx context.Context, req model.ReceiveMessageRequest) ([]model.QueueMessage, error)
+	DeleteMessage(ctx context.Context, queueURL, receiptHandle string) error
+	GetQueueAttributes(ctx context.Context, queueURL string, names []string) (map[string]string, error)
+}
+
+// SQSQueryHandler serves the SQS Query API actions needed to consume SES
+// events from a queue.
+type SQSQueryHandler struct {
+	service   SQSService
+	accountID string
+}
+
+// NewSQSQueryHandler creates a new SQSQueryHandler, accountID is reported as
+// the SenderId of the messages.
+func NewSQSQueryHandler(s SQSService, accountID string) *SQSQueryHandler {
+	return &SQSQueryHandler{service: s, accountID: accountID}
+}
+
+type createQueueResult struct {
+	XMLName  xml.Name `xml:"CreateQueueResult"`
+	QueueURL string   `xml:"QueueUrl"`
+}
+
+type getQueueURLResult struct {
+	XMLName  xml.Name `xml:"GetQueueUrlResult"`
+	QueueURL string   `xml:"QueueUrl"`
+}
+
+type sendMessageResult struct {
+	XMLName          xml.Name `xml:"SendMessageResult"`
+	MessageID        string   `xml:"MessageId"`
+	MD5OfMessageBody string   `xml:"MD5OfMessageBody"`
+}
+
+type queueAttribute struct {
+	Name  string `xml:"Name"`
+	Value string `xml:"Value"`
+}
+
+type queueMessage struct {
+	MessageID     string           `xml:"MessageId"`
+	ReceiptHandle string           `xml:"ReceiptHandle"`
+	MD5OfBody     string           `xml:"MD5OfBody"`
+	Body          string           `xml:"Body"`
+	Attributes    []queueAttribute `xml:"Attribute"`
+}
+
+type receiveMessageResult struct {
+	XMLName  xml.Name       `xml:"ReceiveMessageResult"`
+	Messages []queueMessage `xml:"Message"`
+}
+
+type getQueueAttributesResult struct {
+	XMLName    xml.Name         `xml:"GetQueueAttributesResult"`
+	Attributes []queueAttribute `xml:"Attribute"`
+}
+
+// CreateQueue handles Action=CreateQueue
+func (h *SQSQueryHandler) CreateQueue(c *gin.Context, form url.Values) (any, error) {
+	name := form.Get("QueueName")
+	if name == "" {
+		return nil, missingParameter("QueueName")
+	}
+
+	queueURL, err := h.service.CreateQueue(c.Request.Context(), name, flattenedAttributeMap(form, "Attribute"))
+	if err != nil {
+		return nil, err
+	}
+	return createQueueResult{QueueURL: queueURL}, nil
+}
+
+// GetQueueURL handles Action=GetQueueUrl
+func (h *SQSQueryHandler) GetQueueURL(c *gin.Context, form url.Values) (any, error) {
+	name := form.Get("QueueName")
+	if name == "" {
+		return nil, missingParameter("QueueName")
+	}
+
+	queueURL, err := h.service.GetQueueURL(c.Request.Context(), name)
+	if err != nil {
+		return nil, err
+	}
+	return getQueueURLResult{QueueURL: queueURL}, nil
+}
+
+// SendMessage handles Action=SendMessage
+func (h *SQSQueryHandler) SendMessage(c *gin.Context, form url.Values) (any, error) {
+	queueURL, err := queueURLParam(c, form)
+	if err != nil {
+		return nil, err
+	}
+	if form.Get("MessageBody") == "" {
+		return nil, missingParameter("MessageBody")
+	}
+	delaySeconds, err := optionalInt(form, "DelaySeconds")
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := h.service.SendMessage(c.Request.Context(), queueURL, form.Get("MessageBody"), delaySeconds)
+	if err != nil {
+		return nil, err
+	}
+	return sendMessageResult{MessageID: m.MessageID, MD5OfMessageBody: m.MD5OfBody}, nil
+}
+
+// ReceiveMessage handles Action=ReceiveMessage, it may wait up to 20 seconds
+// for a message when long polling
+func (h *SQSQueryHandler) ReceiveMessage(c *gin.Context, form url.Values) (any, error) {
+	req, err := decodeReceiveMessage(c, form)
+	if err != nil {
+		return nil, err
+	}
+
+	messages, err := h.service.ReceiveMessage(c.Request.Context(), req)
+	if err != nil {
+		return nil, err
+	}
+
+	names := append(flattenedList(form, "AttributeName"), flattenedList(form, "MessageSystemAttributeName")...)
+	result := receiveMessageResult{}
+	for _, m := range messages {
+		qm := queueMessage{MessageID: m.MessageID, ReceiptHandle: m.ReceiptHandle, MD5OfBody: m.MD5OfBody, Body: m.Body}
+		attributes := messageSystemAttributes(m, names, h.accountID)
+		for _, name := range sortedKeys(attributes) {
+			qm.Attributes = append(qm.Attributes, queueAttribute{Name: name, Value: attributes[name]})
+		}
+		result.Messages = append(result.Messages, qm)
+	}
+	return result, nil
+}
+
+// DeleteMessage handles Action=DeleteMessage
+func (h *SQSQueryHandler) DeleteMessage(c *gin.Context, form url.Values) (any, error) {
+	queueURL, err := queueURLParam(c, form)
+	if err != nil {
+		return nil, err
+	}
+	receiptHandle := form.Get("ReceiptHandle")
+	if receiptHandle == "" {
+		return nil, missingParameter("ReceiptHandle")
+	}
+
+	// The response of DeleteMessage has no result element.
+	return nil, h.service.DeleteMessage(c.Request.Context(), queueURL, receiptHandle)
+}
+
+// GetQueueAttributes handles Action=GetQueueAttributes
+func (h *SQSQueryHandler) GetQueueAttributes(c *gin.Context, form url.Values) (any, error) {
+	queueURL, err := queueURLParam(c, form)
+	if err != nil {
+		return nil, err
+	}
+
+	attributes, err := h.service.GetQueueAttributes(c.Request.Context(), queueURL, flattenedList(form, "AttributeName"))
+	if err != nil {
+		return nil, err
+	}
+
+	result := getQueueAttributesResult{}
+	for _, name := range sortedKeys(attributes) {
+		result.Attributes = append(result.Attributes, queueAttribute{Name: name, Value: attributes[name]})
+	}
+	return result, nil
+}
+
+// queueURLParam returns the QueueUrl parameter, Query requests may also be
+// sent to the queue URL itself instead.
+func queueURLParam(c *gin.Context, form url.Values) (string, error) {
+	if queueURL := form.Get("QueueUrl"); queueURL != "" {
+		return queueURL, nil
+	}
+	if queue := c.Param("queue"); queue != "" {
+		return queue, nil
+	}
+	return "", missingParameter("QueueUrl")
+}
+
+func decodeReceiveMessage(c *gin.Context, form url.Values) (model.ReceiveMessageRequest, error) {
+	queueURL, err := queueURLParam(c, form)
+	if err != nil {
+		return model.ReceiveMessageRequest{}, err
+	}
+
+	req := model.ReceiveMessageRequest{QueueURL: queueURL}
+	if max, err := optionalInt(form, "MaxNumberOfMessages"); err != nil {
+		return model.ReceiveMessageRequest{}, err
+	} else if max != nil {
+		req.MaxNumberOfMessages = *max
+	}
+	if req.VisibilityTimeout, err = optionalInt(form, "VisibilityTimeout"); err != nil {
+		return model.ReceiveMessageRequest{}, err
+	}
+	if req.WaitTimeSeconds, err = optionalInt(form, "WaitTimeSeconds"); err != nil {
+		return model.ReceiveMessageRequest{}, err
+	}
+	return req, nil
+}
+
+// messageSystemAttributes returns the requested system attributes of a
+// received message, "All" requests every one of them.
+func messageSystemAttributes(m model.QueueMessage, names []string, senderID string) map[string]string {
+	all := map[string]string{
+		"SenderId":                         senderID,
+		"SentTimestamp":                    strconv.FormatInt(m.SentTimestamp, 10),
+		"ApproximateReceiveCount":          strconv.Itoa(m.ReceiveCount),
+		"ApproximateFirstReceiveTimestamp": strconv.FormatInt(m.FirstReceiveTimestamp, 10),
+	}
+
+	attributes := make(map[string]string)
+	for _, name := range names {
+		if name == "All" {
+			return all
+		}
+		if v, ok := all[name]; ok {
+			attributes[name] = v
+		}
+	}
+	return attributes
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
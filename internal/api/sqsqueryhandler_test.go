@@ -0,0 +1,254 @@
+package api_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/kamal-github/demtech/internal/api"
+	"github.com/kamal-github/demtech/internal/api/mocks"
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+const sqsQueueURL = "http://localhost:8080/sqs/123456789012/ses-events"
+
+func newSQSRouter(s api.SQSService) *gin.Engine {
+	h := api.NewSQSQueryHandler(s, "123456789012")
+
+	queryRouter := api.NewSQSQueryRouter()
+	queryRouter.Register("CreateQueue", h.CreateQueue)
+	queryRouter.Register("GetQueueUrl", h.GetQueueURL)
+	queryRouter.Register("SendMessage", h.SendMessage)
+	queryRouter.Register("ReceiveMessage", h.ReceiveMessage)
+	queryRouter.Register("DeleteMessage", h.DeleteMessage)
+	queryRouter.Register("GetQueueAttributes", h.GetQueueAttributes)
+
+	endpoint := api.SQSEndpoint(queryRouter, api.NewSQSJSONHandler(s, "123456789012"))
+	router := gin.New()
+	router.POST("/sqs", endpoint)
+	router.POST("/sqs/:account/:queue", endpoint)
+	return router
+}
+
+func TestSQSQueryHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSQSService := mocks.NewMockSQSService(ctrl)
+	router := newSQSRouter(mockSQSService)
+
+	received := model.QueueMessage{MessageID: "m1", Body: "hello", MD5OfBody: "5d41402abc4b2a76b9719d911017c592", SentTimestamp: 1700000000000, ReceiptHandle: "rh", ReceiveCount: 1}
+
+	tests := []struct {
+		name         string
+		path         string
+		form         url.Values
+		mockSetup    func()
+		expectCode   int
+		expectInBody []string
+	}{
+		{
+			name: "Create queue",
+			path: "/sqs",
+			form: url.Values{"Action": {"CreateQueue"}, "QueueName": {"ses-events"}, "Attribute.1.Name": {"VisibilityTimeout"}, "Attribute.1.Value": {"60"}},
+			mockSetup: func() {
+				mockSQSService.EXPECT().CreateQueue(gomock.Any(), "ses-events", map[string]string{"VisibilityTimeout": "60"}).Return(sqsQueueURL, nil)
+			},
+			expectCode: http.StatusOK,
+			expectInBody: []string{
+				`<CreateQueueResponse xmlns="http://queue.amazonaws.com/doc/2012-11-05/">`,
+				`<CreateQueueResult><QueueUrl>` + sqsQueueURL + `</QueueUrl></CreateQueueResult>`,
+			},
+		},
+		{
+			name: "Send message",
+			path: "/sqs",
+			form: url.Values{"Action": {"SendMessage"}, "QueueUrl": {sqsQueueURL}, "MessageBody": {"hello"}, "DelaySeconds": {"5"}},
+			mockSetup: func() {
+				mockSQSService.EXPECT().SendMessage(gomock.Any(), sqsQueueURL, "hello", gomock.Any()).Return(received, nil)
+			},
+			expectCode:   http.StatusOK,
+			expectInBody: []string{`<SendMessageResult><MessageId>m1</MessageId><MD5OfMessageBody>5d41402abc4b2a76b9719d911017c592</MD5OfMessageBody></SendMessageResult>`},
+		},
+		{
+			name: "Receive message from the queue URL",
+			path: "/sqs/123456789012/ses-events",
+			form: url.Values{"Action": {"ReceiveMessage"}, "WaitTimeSeconds": {"20"}, "AttributeName.1": {"SentTimestamp"}},
+			mockSetup: func() {
+				wait := 20
+				mockSQSService.EXPECT().ReceiveMessage(gomock.Any(), model.ReceiveMessageRequest{QueueURL: "ses-events", WaitTimeSeconds: &wait}).
+					Return([]model.QueueMessage{received}, nil)
+			},
+			expectCode: http.StatusOK,
+			expectInBody: []string{
+				`<ReceiveMessageResult><Message><MessageId>m1</MessageId><ReceiptHandle>rh</ReceiptHandle><MD5OfBody>5d41402abc4b2a76b9719d911017c592</MD5OfBody><Body>hello</Body>`,
+				`<Attribute><Name>SentTimestamp</Name><Value>1700000000000</Value></Attribute></Message></ReceiveMessageResult>`,
+			},
+		},
+		{
+			name:         "Receive message with an invalid wait time",
+			path:         "/sqs",
+			form:         url.Values{"Action": {"ReceiveMessage"}, "QueueUrl": {sqsQueueURL}, "WaitTimeSeconds": {"soon"}},
+			mockSetup:    func() {},
+			expectCode:   http.StatusBadRequest,
+			expectInBody: []string{`<Code>InvalidParameterValue</Code>`},
+		},
+		{
+			name: "Delete message",
+			path: "/sqs",
+			form: url.Values{"Action": {"DeleteMessage"}, "QueueUrl": {sqsQueueURL}, "ReceiptHandle": {"rh"}},
+			mockSetup: func() {
+				mockSQSService.EXPECT().DeleteMessage(gomock.Any(), sqsQueueURL, "rh").Return(nil)
+			},
+			expectCode:   http.StatusOK,
+			expectInBody: []string{`<DeleteMessageResponse xmlns="http://queue.amazonaws.com/doc/2012-11-05/"><ResponseMetadata>`},
+		},
+		{
+			name: "Get queue attributes",
+			path: "/sqs",
+			form: url.Values{"Action": {"GetQueueAttributes"}, "QueueUrl": {sqsQueueURL}, "AttributeName.1": {"All"}},
+			mockSetup: func() {
+				mockSQSService.EXPECT().GetQueueAttributes(gomock.Any(), sqsQueueURL, []string{"All"}).
+					Return(map[string]string{"VisibilityTimeout": "30", "ApproximateNumberOfMessages": "2"}, nil)
+			},
+			expectCode: http.StatusOK,
+			expectInBody: []string{
+				`<Attribute><Name>ApproximateNumberOfMessages</Name><Value>2</Value></Attribute><Attribute><Name>VisibilityTimeout</Name><Value>30</Value></Attribute>`,
+			},
+		},
+		{
+			name: "Unknown queue",
+			path: "/sqs",
+			form: url.Values{"Action": {"GetQueueUrl"}, "QueueName": {"unknown"}},
+			mockSetup: func() {
+				mockSQSService.EXPECT().GetQueueURL(gomock.Any(), "unknown").
+					Return("", &model.SESError{Code: "AWS.SimpleQueueService.NonExistentQueue", Message: "The specified queue does not exist."})
+			},
+			expectCode:   http.StatusBadRequest,
+			expectInBody: []string{`<Code>AWS.SimpleQueueService.NonExistentQueue</Code>`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			tt.mockSetup()
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(tt.expectCode, w.Code)
+			for _, s := range tt.expectInBody {
+				assert.Contains(w.Body.String(), s)
+			}
+		})
+	}
+}
+
+func TestSQSJSONHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSQSService := mocks.NewMockSQSService(ctrl)
+	router := newSQSRouter(mockSQSService)
+
+	tests := []struct {
+		name         string
+		target       string
+		body         string
+		mockSetup    func()
+		expectCode   int
+		expectHeader string
+		expectInBody []string
+	}{
+		{
+			name:   "Create queue",
+			target: "AmazonSQS.CreateQueue",
+			body:   `{"QueueName": "ses-events", "Attributes": {"DelaySeconds": "5"}}`,
+			mockSetup: func() {
+				mockSQSService.EXPECT().CreateQueue(gomock.Any(), "ses-events", map[string]string{"DelaySeconds": "5"}).Return(sqsQueueURL, nil)
+			},
+			expectCode:   http.StatusOK,
+			expectInBody: []string{`{"QueueUrl":"` + sqsQueueURL + `"}`},
+		},
+		{
+			name:   "Receive message with long polling",
+			target: "AmazonSQS.ReceiveMessage",
+			body:   `{"QueueUrl": "` + sqsQueueURL + `", "MaxNumberOfMessages": 10, "WaitTimeSeconds": 20, "MessageSystemAttributeNames": ["All"]}`,
+			mockSetup: func() {
+				wait := 20
+				mockSQSService.EXPECT().ReceiveMessage(gomock.Any(), model.ReceiveMessageRequest{QueueURL: sqsQueueURL, MaxNumberOfMessages: 10, WaitTimeSeconds: &wait}).
+					Return([]model.QueueMessage{{MessageID: "m1", Body: "hello", ReceiptHandle: "rh", ReceiveCount: 2}}, nil)
+			},
+			expectCode: http.StatusOK,
+			expectInBody: []string{
+				`"MessageId":"m1","ReceiptHandle":"rh"`,
+				`"ApproximateReceiveCount":"2"`,
+				`"SenderId":"123456789012"`,
+			},
+		},
+		{
+			name:   "Receive nothing",
+			target: "AmazonSQS.ReceiveMessage",
+			body:   `{"QueueUrl": "` + sqsQueueURL + `"}`,
+			mockSetup: func() {
+				mockSQSService.EXPECT().ReceiveMessage(gomock.Any(), model.ReceiveMessageRequest{QueueURL: sqsQueueURL}).Return(nil, nil)
+			},
+			expectCode:   http.StatusOK,
+			expectInBody: []string{`{}`},
+		},
+		{
+			name:   "Unknown queue",
+			target: "AmazonSQS.GetQueueAttributes",
+			body:   `{"QueueUrl": "` + sqsQueueURL + `", "AttributeNames": ["All"]}`,
+			mockSetup: func() {
+				mockSQSService.EXPECT().GetQueueAttributes(gomock.Any(), sqsQueueURL, []string{"All"}).
+					Return(nil, &model.SESError{Code: "AWS.SimpleQueueService.NonExistentQueue", Message: "The specified queue does not exist."})
+			},
+			expectCode:   http.StatusBadRequest,
+			expectHeader: "AWS.SimpleQueueService.NonExistentQueue;Sender",
+			expectInBody: []string{`"__type":"com.amazonaws.sqs#QueueDoesNotExist"`},
+		},
+		{
+			name:         "Unknown action",
+			target:       "AmazonSQS.PurgeQueue",
+			body:         `{}`,
+			mockSetup:    func() {},
+			expectCode:   http.StatusBadRequest,
+			expectHeader: "InvalidAction;Sender",
+			expectInBody: []string{`"__type":"com.amazonaws.sqs#InvalidAction"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			tt.mockSetup()
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/sqs", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/x-amz-json-1.0")
+			req.Header.Set("X-Amz-Target", tt.target)
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(tt.expectCode, w.Code)
+			assert.Equal(tt.expectHeader, w.Header().Get("x-amzn-query-error"))
+			for _, s := range tt.expectInBody {
+				assert.Contains(w.Body.String(), s)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kamal-github/demtech/internal/model"
+)
+
+type SuppressionService interface {
+	PutSuppressedDestination(ctx context.Context, emailAddress, reason string) error
+	GetSuppressedDestination(ctx context.Context, emailAddress string) (model.SuppressedDestination, error)
+	DeleteSuppressedDestination(ctx context.Context, emailAddress string) error
+	ListSuppressedDestinations(ctx context.Context, filter model.SuppressionListFilter, pageSize int, nextToken string) ([]model.SuppressedDestination, string, error)
+}
+
+// SuppressionV2Handler serves the account level suppression list of the SESv2
+// REST API (/v2/email/suppression/addresses).
+type SuppressionV2Handler struct {
+	service SuppressionService
+}
+
+// NewSuppressionV2Handler creates a new SuppressionV2Handler
+func NewSuppressionV2Handler(s SuppressionService) *SuppressionV2Handler {
+	return &SuppressionV2Handler{service: s}
+}
+
+type v2SuppressedDestination struct {
+	EmailAddress   string                                 `json:"EmailAddress"`
+	Reason         string                                 `json:"Reason"`
+	LastUpdateTime v2Timestamp                            `json:"LastUpdateTime"`
+	Attributes     *model.SuppressedDestinationAttributes `json:"Attributes,omitempty"`
+}
+
+type v2SuppressedDestinationSummary struct {
+	EmailAddress   string      `json:"EmailAddress"`
+	Reason         string      `json:"Reason"`
+	LastUpdateTime v2Timestamp `json:"LastUpdateTime"`
+}
+
+// PutSuppressedDestination handles PUT /v2/email/suppression/addresses
+func (h *SuppressionV2Handler) PutSuppressedDestination(c *gin.Context) {
+	var body struct {
+		EmailAddress string `json:"EmailAddress"`
+		Reason       string `json:"Reason"`
+	}
+	if !decodeV2Body(c, &body) {
+		return
+	}
+	if body.EmailAddress == "" || body.Reason == "" {
+		writeV2Error(c, badRequest("EmailAddress and Reason are required."))
+		return
+	}
+
+	if err := h.service.PutSuppressedDestination(c.Request.Context(), body.EmailAddress, body.Reason); err != nil {
+		writeV2Error(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// GetSuppressedDestination handles GET /v2/email/suppression/addresses/:email
+func (h *SuppressionV2Handler) GetSuppressedDestination(c *gin.Context) {
+	d, err := h.service.GetSuppressedDestination(c.Request.Context(), c.Param("email"))
+	if err != nil {
+		writeV2Error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"SuppressedDestination": v2SuppressedDestination{
+		EmailAddress:   d.EmailAddress,
+		Reason:         d.Reason,
+		LastUpdateTime: v2Timestamp(d.LastUpdateTime),
+		Attributes:     d.Attributes,
+	}})
+}
+
+// DeleteSuppressedDestination handles DELETE /v2/email/suppression/addresses/:email
+func (h *SuppressionV2Handler) DeleteSuppressedDestination(c *gin.Context) {
+	if err := h.service.DeleteSuppressedDestination(c.Request.Context(), c.Param("email")); err != nil {
+		writeV2Error(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// ListSuppressedDestinations handles GET /v2/email/suppression/addresses,
+// filtered by the Reason, StartDate and EndDate query parameters.
+func (h *SuppressionV2Handler) ListSuppressedDestinations(c *gin.Context) {
+	filter := model.SuppressionListFilter{Reasons: c.QueryArray("Reason")}
+	for name, date := range map[string]*time.Time{"StartDate": &filter.StartDate, "EndDate": &filter.EndDate} {
+		v := c.Query(name)
+		if v == "" {
+			continue
+		}
+		t, err := parseV2QueryTimestamp(v)
+		if err != nil {
+			writeV2Error(c, badRequest(name+" must be an ISO 8601 timestamp."))
+			return
+		}
+		*date = t
+	}
+
+	pageSize := 0
+	if v := c.Query("PageSize"); v != "" {
+		var err error
+		if pageSize, err = strconv.Atoi(v); err != nil || pageSize < 1 {
+			writeV2Error(c, badRequest("PageSize must be a positive number."))
+			return
+		}
+	}
+
+	list, nextToken, err := h.service.ListSuppressedDestinations(c.Request.Context(), filter, pageSize, c.Query("NextToken"))
+	if err != nil {
+		writeV2Error(c, err)
+		return
+	}
+
+	summaries := make([]v2SuppressedDestinationSummary, 0, len(list))
+	for _, d := range list {
+		summaries = append(summaries, v2SuppressedDestinationSummary{EmailAddress: d.EmailAddress, Reason: d.Reason, LastUpdateTime: v2Timestamp(d.LastUpdateTime)})
+	}
+	resp := gin.H{"SuppressedDestinationSummaries": summaries}
+	if nextToken != "" {
+		resp["NextToken"] = nextToken
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// parseV2QueryTimestamp parses a timestamp of a query string, which the AWS
+// SDKs send in ISO 8601 rather than in epoch seconds.
+func parseV2QueryTimestamp(v string) (time.Time, error) {
+	if seconds, err := strconv.ParseFloat(v, 64); err == nil {
+		return time.UnixMilli(int64(seconds * 1000)).UTC(), nil
+	}
+	return time.Parse(time.RFC3339, v)
+}
@@ -0,0 +1,148 @@
+package api_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/kamal-github/demtech/internal/api"
+	"github.com/kamal-github/demtech/internal/api/mocks"
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSuppressionV2Handler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSuppressionService := mocks.NewMockSuppressionService(ctrl)
+	h := api.NewSuppressionV2Handler(mockSuppressionService)
+
+	router := gin.New()
+	addresses := router.Group("/v2/email/suppression/addresses")
+	addresses.PUT("", h.PutSuppressedDestination)
+	addresses.GET("", h.ListSuppressedDestinations)
+	addresses.GET("/:email", h.GetSuppressedDestination)
+	addresses.DELETE("/:email", h.DeleteSuppressedDestination)
+
+	updated := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	bounced := model.SuppressedDestination{
+		EmailAddress:   "bounced@example.com",
+		Reason:         "BOUNCE",
+		LastUpdateTime: updated,
+		Attributes:     &model.SuppressedDestinationAttributes{MessageID: "msg-1", FeedbackID: "feedback-1"},
+	}
+
+	tests := []struct {
+		name            string
+		method          string
+		path            string
+		body            string
+		mockSetup       func()
+		expectCode      int
+		expectErrorType string
+		expectInBody    []string
+	}{
+		{
+			name:   "Put suppressed destination",
+			method: http.MethodPut,
+			path:   "/v2/email/suppression/addresses",
+			body:   `{"EmailAddress":"bounced@example.com","Reason":"BOUNCE"}`,
+			mockSetup: func() {
+				mockSuppressionService.EXPECT().PutSuppressedDestination(gomock.Any(), "bounced@example.com", "BOUNCE").Return(nil)
+			},
+			expectCode: http.StatusOK,
+		},
+		{
+			name:            "Put suppressed destination without reason",
+			method:          http.MethodPut,
+			path:            "/v2/email/suppression/addresses",
+			body:            `{"EmailAddress":"bounced@example.com"}`,
+			mockSetup:       func() {},
+			expectCode:      http.StatusBadRequest,
+			expectErrorType: "BadRequestException",
+		},
+		{
+			name:   "Put suppressed destination with invalid reason",
+			method: http.MethodPut,
+			path:   "/v2/email/suppression/addresses",
+			body:   `{"EmailAddress":"bounced@example.com","Reason":"DELIVERY"}`,
+			mockSetup: func() {
+				mockSuppressionService.EXPECT().PutSuppressedDestination(gomock.Any(), "bounced@example.com", "DELIVERY").
+					Return(&model.SESError{Code: "InvalidParameterValue", Message: "Invalid suppressed reason DELIVERY. Valid values: BOUNCE, COMPLAINT."})
+			},
+			expectCode:      http.StatusBadRequest,
+			expectErrorType: "BadRequestException",
+		},
+		{
+			name:   "Get suppressed destination",
+			method: http.MethodGet,
+			path:   "/v2/email/suppression/addresses/bounced%40example.com",
+			mockSetup: func() {
+				mockSuppressionService.EXPECT().GetSuppressedDestination(gomock.Any(), "bounced@example.com").Return(bounced, nil)
+			},
+			expectCode:   http.StatusOK,
+			expectInBody: []string{`{"SuppressedDestination":{"EmailAddress":"bounced@example.com","Reason":"BOUNCE","LastUpdateTime":1704164645,"Attributes":{"MessageId":"msg-1","FeedbackId":"feedback-1"}}}`},
+		},
+		{
+			name:   "Delete unknown suppressed destination",
+			method: http.MethodDelete,
+			path:   "/v2/email/suppression/addresses/unknown@example.com",
+			mockSetup: func() {
+				mockSuppressionService.EXPECT().DeleteSuppressedDestination(gomock.Any(), "unknown@example.com").
+					Return(&model.SESError{Code: "NotFoundException", Message: "Email address unknown@example.com does not exist on your suppression list."})
+			},
+			expectCode:      http.StatusNotFound,
+			expectErrorType: "NotFoundException",
+		},
+		{
+			name:   "List suppressed destinations",
+			method: http.MethodGet,
+			path:   "/v2/email/suppression/addresses?Reason=BOUNCE&Reason=COMPLAINT&StartDate=2024-01-01T00:00:00Z&PageSize=1",
+			mockSetup: func() {
+				mockSuppressionService.EXPECT().ListSuppressedDestinations(gomock.Any(), model.SuppressionListFilter{
+					Reasons:   []string{"BOUNCE", "COMPLAINT"},
+					StartDate: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				}, 1, "").Return([]model.SuppressedDestination{bounced}, "bounced@example.com", nil)
+			},
+			expectCode: http.StatusOK,
+			expectInBody: []string{
+				`"SuppressedDestinationSummaries":[{"EmailAddress":"bounced@example.com","Reason":"BOUNCE","LastUpdateTime":1704164645}]`,
+				`"NextToken":"bounced@example.com"`,
+			},
+		},
+		{
+			name:            "List suppressed destinations with invalid date",
+			method:          http.MethodGet,
+			path:            "/v2/email/suppression/addresses?EndDate=yesterday",
+			mockSetup:       func() {},
+			expectCode:      http.StatusBadRequest,
+			expectErrorType: "BadRequestException",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			tt.mockSetup()
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(tt.expectCode, w.Code)
+			assert.Equal(tt.expectErrorType, w.Header().Get("x-amzn-ErrorType"))
+			for _, s := range tt.expectInBody {
+				assert.Contains(w.Body.String(), s)
+			}
+		})
+	}
+}
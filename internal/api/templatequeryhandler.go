@@ -0,0 +1,151 @@
+package api
+
+import (
+	"context"
+	"encoding/xml"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kamal-github/demtech/internal/model"
+)
+
+type TemplateService interface {
+	CreateTemplate(ctx context.Context, t model.Template) error
+	UpdateTemplate(ctx context.Context, t model.Template) error
+	GetTemplate(ctx context.Context, name string) (model.Template, error)
+	DeleteTemplate(ctx context.Context, name string) error
+	ListTemplates(ctx context.Context, maxItems int, nextToken string) ([]model.TemplateMetadata, string, error)
+}
+
+// TemplateQueryHandler serves the template management actions of the SES v1 Query API.
+type TemplateQueryHandler struct {
+	service TemplateService
+}
+
+// NewTemplateQueryHandler creates a new TemplateQueryHandler
+func NewTemplateQueryHandler(s TemplateService) *TemplateQueryHandler {
+	return &TemplateQueryHandler{service: s}
+}
+
+type xmlTemplate struct {
+	TemplateName string `xml:"TemplateName"`
+	SubjectPart  string `xml:"SubjectPart,omitempty"`
+	TextPart     string `xml:"TextPart,omitempty"`
+	HtmlPart     string `xml:"HtmlPart,omitempty"`
+}
+
+type createTemplateResult struct {
+	XMLName xml.Name `xml:"CreateTemplateResult"`
+}
+
+type updateTemplateResult struct {
+	XMLName xml.Name `xml:"UpdateTemplateResult"`
+}
+
+type deleteTemplateResult struct {
+	XMLName xml.Name `xml:"DeleteTemplateResult"`
+}
+
+type getTemplateResult struct {
+	XMLName  xml.Name    `xml:"GetTemplateResult"`
+	Template xmlTemplate `xml:"Template"`
+}
+
+type templateMetadata struct {
+	Name             string    `xml:"Name"`
+	CreatedTimestamp time.Time `xml:"CreatedTimestamp"`
+}
+
+type listTemplatesResult struct {
+	XMLName           xml.Name           `xml:"ListTemplatesResult"`
+	TemplatesMetadata []templateMetadata `xml:"TemplatesMetadata>member"`
+	NextToken         string             `xml:"NextToken,omitempty"`
+}
+
+// CreateTemplate handles Action=CreateTemplate
+func (h *TemplateQueryHandler) CreateTemplate(c *gin.Context, form url.Values) (any, error) {
+	t, err := decodeTemplate(form)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.service.CreateTemplate(c.Request.Context(), t); err != nil {
+		return nil, err
+	}
+	return createTemplateResult{}, nil
+}
+
+// UpdateTemplate handles Action=UpdateTemplate
+func (h *TemplateQueryHandler) UpdateTemplate(c *gin.Context, form url.Values) (any, error) {
+	t, err := decodeTemplate(form)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.service.UpdateTemplate(c.Request.Context(), t); err != nil {
+		return nil, err
+	}
+	return updateTemplateResult{}, nil
+}
+
+// GetTemplate handles Action=GetTemplate
+func (h *TemplateQueryHandler) GetTemplate(c *gin.Context, form url.Values) (any, error) {
+	name := form.Get("TemplateName")
+	if name == "" {
+		return nil, missingParameter("TemplateName")
+	}
+
+	t, err := h.service.GetTemplate(c.Request.Context(), name)
+	if err != nil {
+		return nil, err
+	}
+	return getTemplateResult{Template: xmlTemplate(t)}, nil
+}
+
+// DeleteTemplate handles Action=DeleteTemplate
+func (h *TemplateQueryHandler) DeleteTemplate(c *gin.Context, form url.Values) (any, error) {
+	name := form.Get("TemplateName")
+	if name == "" {
+		return nil, missingParameter("TemplateName")
+	}
+
+	if err := h.service.DeleteTemplate(c.Request.Context(), name); err != nil {
+		return nil, err
+	}
+	return deleteTemplateResult{}, nil
+}
+
+// ListTemplates handles Action=ListTemplates
+func (h *TemplateQueryHandler) ListTemplates(c *gin.Context, form url.Values) (any, error) {
+	maxItems := 0
+	if v := form.Get("MaxItems"); v != "" {
+		var err error
+		if maxItems, err = strconv.Atoi(v); err != nil || maxItems < 1 {
+			return nil, &model.SESError{Code: "InvalidParameterValue", Message: "MaxItems must be a positive number."}
+		}
+	}
+
+	list, nextToken, err := h.service.ListTemplates(c.Request.Context(), maxItems, form.Get("NextToken"))
+	if err != nil {
+		return nil, err
+	}
+
+	result := listTemplatesResult{NextToken: nextToken}
+	for _, t := range list {
+		result.TemplatesMetadata = append(result.TemplatesMetadata, templateMetadata(t))
+	}
+	return result, nil
+}
+
+func decodeTemplate(form url.Values) (model.Template, *model.SESError) {
+	t := model.Template{
+		TemplateName: form.Get("Template.TemplateName"),
+		SubjectPart:  form.Get("Template.SubjectPart"),
+		TextPart:     form.Get("Template.TextPart"),
+		HtmlPart:     form.Get("Template.HtmlPart"),
+	}
+	if t.TemplateName == "" {
+		return model.Template{}, missingParameter("Template.TemplateName")
+	}
+	return t, nil
+}
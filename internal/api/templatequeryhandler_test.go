@@ -0,0 +1,140 @@
+package api_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/kamal-github/demtech/internal/api"
+	"github.com/kamal-github/demtech/internal/api/mocks"
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTemplateQueryHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTemplateService := mocks.NewMockTemplateService(ctrl)
+	h := api.NewTemplateQueryHandler(mockTemplateService)
+
+	queryRouter := api.NewQueryRouter()
+	queryRouter.Register("CreateTemplate", h.CreateTemplate)
+	queryRouter.Register("GetTemplate", h.GetTemplate)
+	queryRouter.Register("DeleteTemplate", h.DeleteTemplate)
+	queryRouter.Register("ListTemplates", h.ListTemplates)
+
+	router := gin.New()
+	router.POST("/", queryRouter.Handle)
+
+	welcome := model.Template{TemplateName: "welcome", SubjectPart: "Hi {{name}}", TextPart: "Hello {{name}}"}
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		form         url.Values
+		mockSetup    func()
+		expectCode   int
+		expectInBody []string
+	}{
+		{
+			name: "Create template",
+			form: url.Values{
+				"Action":                {"CreateTemplate"},
+				"Template.TemplateName": {"welcome"},
+				"Template.SubjectPart":  {"Hi {{name}}"},
+				"Template.TextPart":     {"Hello {{name}}"},
+			},
+			mockSetup: func() {
+				mockTemplateService.EXPECT().CreateTemplate(gomock.Any(), welcome).Return(nil)
+			},
+			expectCode:   http.StatusOK,
+			expectInBody: []string{`<CreateTemplateResponse xmlns="http://ses.amazonaws.com/doc/2010-12-01/"><CreateTemplateResult></CreateTemplateResult>`},
+		},
+		{
+			name: "Create duplicate template",
+			form: url.Values{
+				"Action":                {"CreateTemplate"},
+				"Template.TemplateName": {"welcome"},
+			},
+			mockSetup: func() {
+				mockTemplateService.EXPECT().CreateTemplate(gomock.Any(), gomock.Any()).
+					Return(&model.SESError{Code: "AlreadyExistsException", Message: "Template welcome already exists."})
+			},
+			expectCode:   http.StatusBadRequest,
+			expectInBody: []string{`<Code>AlreadyExistsException</Code>`},
+		},
+		{
+			name:         "Create template without name",
+			form:         url.Values{"Action": {"CreateTemplate"}},
+			mockSetup:    func() {},
+			expectCode:   http.StatusBadRequest,
+			expectInBody: []string{`<Message>Missing required parameter Template.TemplateName.</Message>`},
+		},
+		{
+			name: "Get template",
+			form: url.Values{"Action": {"GetTemplate"}, "TemplateName": {"welcome"}},
+			mockSetup: func() {
+				mockTemplateService.EXPECT().GetTemplate(gomock.Any(), "welcome").Return(welcome, nil)
+			},
+			expectCode: http.StatusOK,
+			expectInBody: []string{
+				`<GetTemplateResult><Template><TemplateName>welcome</TemplateName><SubjectPart>Hi {{name}}</SubjectPart><TextPart>Hello {{name}}</TextPart></Template></GetTemplateResult>`,
+			},
+		},
+		{
+			name: "Delete template",
+			form: url.Values{"Action": {"DeleteTemplate"}, "TemplateName": {"welcome"}},
+			mockSetup: func() {
+				mockTemplateService.EXPECT().DeleteTemplate(gomock.Any(), "welcome").Return(nil)
+			},
+			expectCode:   http.StatusOK,
+			expectInBody: []string{`<DeleteTemplateResult></DeleteTemplateResult>`},
+		},
+		{
+			name: "List templates",
+			form: url.Values{"Action": {"ListTemplates"}, "MaxItems": {"1"}},
+			mockSetup: func() {
+				mockTemplateService.EXPECT().ListTemplates(gomock.Any(), 1, "").
+					Return([]model.TemplateMetadata{{Name: "welcome", CreatedTimestamp: created}}, "welcome", nil)
+			},
+			expectCode: http.StatusOK,
+			expectInBody: []string{
+				`<TemplatesMetadata><member><Name>welcome</Name><CreatedTimestamp>2024-01-02T03:04:05Z</CreatedTimestamp></member></TemplatesMetadata>`,
+				`<NextToken>welcome</NextToken>`,
+			},
+		},
+		{
+			name:         "List templates with invalid MaxItems",
+			form:         url.Values{"Action": {"ListTemplates"}, "MaxItems": {"zero"}},
+			mockSetup:    func() {},
+			expectCode:   http.StatusBadRequest,
+			expectInBody: []string{`<Code>InvalidParameterValue</Code>`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			tt.mockSetup()
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/", strings.NewReader(tt.form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(tt.expectCode, w.Code)
+			for _, s := range tt.expectInBody {
+				assert.Contains(w.Body.String(), s)
+			}
+		})
+	}
+}
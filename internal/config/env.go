@@ -10,15 +10,78 @@ type Env struct {
 	RedisAddr string `envconfig:"REDIS_ADDR"`
 	// Configuration for tracking sent emails count for last N hours - say last 24 hours as per AWS.
 	// but kept it configurable so as to test it realistically.
-	TrackingHoursForEmailsQuota   time.Duration `envconfig:"TRACKING_HOURS_FOR_EMAILS_QUOTA"`
-	AWSMaxEmailSizeAllowedBytes   int64         `envconfig:"AWS_MAX_EMAIL_SIZE_ALLOWED_BYTES"`
-	AWSMaxDestinations            int           `envconfig:"AWS_MAX_DESTINATIONS"`
+	TrackingHoursForEmailsQuota time.Duration `envconfig:"TRACKING_HOURS_FOR_EMAILS_QUOTA"`
+	AWSMaxEmailSizeAllowedBytes int64         `envconfig:"AWS_MAX_EMAIL_SIZE_ALLOWED_BYTES"`
+	AWSMaxDestinations          int           `envconfig:"AWS_MAX_DESTINATIONS"`
+	// AWSIsSandBox accounts can only send to verified identities until a production access request is granted, which
+	// happens after ProductionAccessReviewDelay or, when that is zero, once it is reviewed through the account API.
+	// AWSSandboxAllowedDestinations are seeded as verified identities.
 	AWSIsSandBox                  bool          `envconfig:"AWS_IS_SANDBOX"`
 	AWSSandboxAllowedDestinations []string      `envconfig:"AWS_SANDBOX_ALLOWED_DESTINATIONS"`
+	ProductionAccessReviewDelay   time.Duration `envconfig:"PRODUCTION_ACCESS_REVIEW_DELAY"`
 	AWSVerifiedSourceEmailIDs     []string      `envconfig:"AWS_VERIFIED_SOURCE_EMAIL_IDS"`
 	AWSEmailsQuotaForLastNHours   int64         `envconfig:"AWS_EMAILS_QUOTA_FOR_LAST_N_HOURS"`
 	FailRandomly                  bool          `envconfig:"FAIL_RANDOMLY"`
 	FailPercentage                int           `envconfig:"FAIL_PERCENTAGE"`
+	AWSRegion                     string        `envconfig:"AWS_REGION" default:"us-east-1"`
+	// PublicBaseURL is the address the mock is reachable at, used in the links it hands out.
+	PublicBaseURL string `envconfig:"PUBLIC_BASE_URL" default:"http://localhost:8080"`
+	// DomainVerificationDelay after which a pending domain identity is verified. When zero, domains stay
+	// pending until their verification status is set through the identities API.
+	DomainVerificationDelay time.Duration `envconfig:"DOMAIN_VERIFICATION_DELAY"`
+	// DNSServerAddr (host:port) or DNSZoneFile, when set, is where the TXT and DKIM records of domain identities
+	// are looked up. Domains are then verified once their records resolve instead of after a delay.
+	DNSServerAddr string `envconfig:"DNS_SERVER_ADDR"`
+	DNSZoneFile   string `envconfig:"DNS_ZONE_FILE"`
+	// AWSAccountID is the sending account of the published events.
+	AWSAccountID string `envconfig:"AWS_ACCOUNT_ID" default:"123456789012"`
+	// EventDestinationsFile is a JSON file with the event destinations of each configuration set.
+	EventDestinationsFile string `envconfig:"EVENT_DESTINATIONS_FILE"`
+	// AWSConfigurationSets are created at startup, on top of those of the EventDestinationsFile.
+	AWSConfigurationSets []string `envconfig:"AWS_CONFIGURATION_SETS" default:"default-config"`
+	// AWSSuppressedReasons (BOUNCE, COMPLAINT) for which recipients are added to and suppressed by the account
+	// level suppression list, unless the configuration set of a message overrides them.
+	AWSSuppressedReasons []string `envconfig:"AWS_SUPPRESSED_REASONS" default:"BOUNCE,COMPLAINT"`
+	// AWSMaxSendRate is the number of recipients that can be sent to per second, zero disables the limit.
+	// AWSMaxSendBurst is how many recipients can be sent to at once, it is at least one second worth of them and
+	// AWSMaxDestinations.
+	AWSMaxSendRate  float64 `envconfig:"AWS_MAX_SEND_RATE"`
+	AWSMaxSendBurst int64   `envconfig:"AWS_MAX_SEND_BURST"`
+	// WarmUpSchedule, when set, replaces AWSEmailsQuotaForLastNHours and AWSMaxSendRate by the stages of a warm-up,
+	// each written as Max24HourSend:MaxSendRate. The account moves to the next stage after each WarmUpDayDuration
+	// whose bounce and complaint rates stayed within WarmUpMaxBounceRate and WarmUpMaxComplaintRate.
+	WarmUpSchedule         []string      `envconfig:"WARMUP_SCHEDULE"`
+	WarmUpDayDuration      time.Duration `envconfig:"WARMUP_DAY_DURATION" default:"24h"`
+	WarmUpMaxBounceRate    float64       `envconfig:"WARMUP_MAX_BOUNCE_RATE" default:"0.05"`
+	WarmUpMaxComplaintRate float64       `envconfig:"WARMUP_MAX_COMPLAINT_RATE" default:"0.001"`
+	// ReputationWindow over which the bounce and complaint rates of the account are computed. They are only enforced
+	// once the window holds ReputationMinSends recipients.
+	ReputationWindow   time.Duration `envconfig:"REPUTATION_WINDOW" default:"24h"`
+	ReputationMinSends int           `envconfig:"REPUTATION_MIN_SENDS" default:"100"`
+	// CaptureMessages keeps the accepted messages in Redis, until deleted through the messages API or, once there are
+	// more than CaptureMaxMessages (0 for no limit), the oldest ones are removed.
+	CaptureMessages    bool `envconfig:"CAPTURE_MESSAGES" default:"true"`
+	CaptureMaxMessages int  `envconfig:"CAPTURE_MAX_MESSAGES" default:"10000"`
+	// MessageSinkDir, when set, is where every accepted message is written as well, in MessageSinkFormat: eml, mbox
+	// or maildir.
+	MessageSinkDir    string `envconfig:"MESSAGE_SINK_DIR"`
+	MessageSinkFormat string `envconfig:"MESSAGE_SINK_FORMAT" default:"eml"`
+	// SMTPRelayAddr, when set, is the host:port of an SMTP server, such as a local mail catcher, every accepted
+	// message is relayed to. Recipients it refuses bounce, a message it refuses is rejected.
+	SMTPRelayAddr               string        `envconfig:"SMTP_RELAY_ADDR"`
+	SMTPRelayStartTLS           bool          `envconfig:"SMTP_RELAY_STARTTLS"`
+	SMTPRelayInsecureSkipVerify bool          `envconfig:"SMTP_RELAY_INSECURE_SKIP_VERIFY"`
+	SMTPRelayUsername           string        `envconfig:"SMTP_RELAY_USERNAME"`
+	SMTPRelayPassword           string        `envconfig:"SMTP_RELAY_PASSWORD"`
+	SMTPRelayTimeout            time.Duration `envconfig:"SMTP_RELAY_TIMEOUT" default:"10s"`
+	// SMTPAddr and SMTPTLSAddr, when set, are where the SES SMTP interface listens, upgrading with STARTTLS (e.g.
+	// :587) and with implicit TLS (e.g. :465). Clients authenticate with the SMTP credentials of SMTPAccessKeys,
+	// written as AccessKeyId:SecretAccessKey. SMTPSendTimeout bounds the sending of a message, it has to exceed
+	// SMTPRelayTimeout.
+	SMTPAddr        string        `envconfig:"SMTP_ADDR"`
+	SMTPTLSAddr     string        `envconfig:"SMTP_TLS_ADDR"`
+	SMTPAccessKeys  []string      `envconfig:"SMTP_ACCESS_KEYS"`
+	SMTPSendTimeout time.Duration `envconfig:"SMTP_SEND_TIMEOUT" default:"30s"`
 }
 
 func Process() (Env, error) {
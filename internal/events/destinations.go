@@ -0,0 +1,92 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/kamal-github/demtech/internal/model"
+)
+
+// StaticDestinations are event destinations keyed by configuration set name,
+// as read from a JSON file by LoadDestinations.
+type StaticDestinations map[string][]model.EventDestination
+
+// LoadDestinations reads the event destinations of the configuration sets
+// from a JSON file such as:
+//
+//	{"default-config": [{"Name": "webhook", "Enabled": true,
+//	  "MatchingEventTypes": ["send", "bounce"],
+//	  "WebhookDestination": {"URL": "http://localhost:9000/events"}}]}
+func LoadDestinations(path string) (StaticDestinations, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var destinations StaticDestinations
+	if err := json.Unmarshal(data, &destinations); err != nil {
+		return nil, fmt.Errorf("invalid event destinations file %s: %w", path, err)
+	}
+
+	for configSet, dests := range destinations {
+		for _, d := range dests {
+			if err := Validate(d); err != nil {
+				return nil, fmt.Errorf("configuration set %s: %w", configSet, err)
+			}
+		}
+	}
+
+	return destinations, nil
+}
+
+func (s StaticDestinations) GetEventDestinations(_ context.Context, configurationSetName string) ([]model.EventDestination, error) {
+	return s[configurationSetName], nil
+}
+
+// Validate checks that an event destination has a name and exactly one target.
+func Validate(d model.EventDestination) error {
+	if d.Name == "" {
+		return fmt.Errorf("event destination without a name")
+	}
+
+	targets := 0
+	for _, set := range []bool{d.WebhookDestination != nil, d.FileDestination != nil, d.RedisStreamDestination != nil, d.SNSDestination != nil, d.SQSDestination != nil} {
+		if set {
+			targets++
+		}
+	}
+	if targets != 1 {
+		return fmt.Errorf("event destination %s must have exactly one destination, got %d", d.Name, targets)
+	}
+
+	if len(d.MatchingEventTypes) == 0 {
+		return fmt.Errorf("event destination %s must match at least one event type", d.Name)
+	}
+	for _, t := range d.MatchingEventTypes {
+		if !knownEventType(t) {
+			return fmt.Errorf("event destination %s has an unknown event type %s", d.Name, t)
+		}
+	}
+
+	return nil
+}
+
+// eventTypes are the event types destinations can match. Open, click, delivery
+// delay and subscription events are accepted although the mock never publishes
+// them.
+var eventTypes = []string{
+	model.EventTypeSend, model.EventTypeReject, model.EventTypeBounce, model.EventTypeComplaint,
+	model.EventTypeDelivery, model.EventTypeOpen, model.EventTypeClick, model.EventTypeRenderingFailure,
+	model.EventTypeDeliveryDelay, "Subscription",
+}
+
+func knownEventType(eventType string) bool {
+	for _, t := range eventTypes {
+		if normalize(t) == normalize(eventType) {
+			return true
+		}
+	}
+	return false
+}
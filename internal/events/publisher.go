@@ -0,0 +1,242 @@
+// Package events publishes SES events to the event destinations of the
+// configuration set a message was sent with.
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kamal-github/demtech/internal/model"
+)
+
+// DestinationStore knows the event destinations of the configuration sets.
+type DestinationStore interface {
+	GetEventDestinations(ctx context.Context, configurationSetName string) ([]model.EventDestination, error)
+}
+
+// StreamAdder adds events to Redis streams.
+type StreamAdder interface {
+	AddEvent(ctx context.Context, stream, eventType, messageID string, data []byte) error
+}
+
+// TopicPublisher publishes messages to SNS topics.
+type TopicPublisher interface {
+	Publish(ctx context.Context, topicArn, subject, message string) (string, error)
+}
+
+// QueueSender sends messages to SQS queues.
+type QueueSender interface {
+	SendMessage(ctx context.Context, queueURL, body string, delaySeconds *int) (model.QueueMessage, error)
+}
+
+const (
+	queueSize       = 1024
+	deliveryTimeout = 10 * time.Second
+)
+
+type delivery struct {
+	destination model.EventDestination
+	event       model.Event
+	data        []byte
+}
+
+// Publisher delivers events in the background, one at a time, so that the
+// events of a message reach a destination in the order they were published.
+// When destinations are too slow to keep up, events that do not fit in the
+// queue are dropped rather than holding up the sends.
+type Publisher struct {
+	store            DestinationStore
+	streams          StreamAdder
+	topics           TopicPublisher
+	queues           QueueSender
+	httpClient       *http.Client
+	sendingAccountID string
+
+	// mu guards closed, so that nothing is queued once the queue is closed.
+	mu     sync.RWMutex
+	closed bool
+	queue  chan delivery
+	done   chan struct{}
+}
+
+// Option configures an optional target of Publisher
+type Option func(*Publisher)
+
+// WithTopicPublisher enables SNS event destinations
+func WithTopicPublisher(t TopicPublisher) Option {
+	return func(p *Publisher) { p.topics = t }
+}
+
+// WithQueueSender enables SQS event destinations
+func WithQueueSender(q QueueSender) Option {
+	return func(p *Publisher) { p.queues = q }
+}
+
+func NewPublisher(store DestinationStore, streams StreamAdder, sendingAccountID string, opts ...Option) *Publisher {
+	p := &Publisher{
+		store:            store,
+		streams:          streams,
+		httpClient:       &http.Client{Timeout: deliveryTimeout},
+		sendingAccountID: sendingAccountID,
+		queue:            make(chan delivery, queueSize),
+		done:             make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	go p.run()
+	return p
+}
+
+// Publish queues event for each enabled destination of the configuration set
+// which matches its type. Failures are logged, they never fail a send.
+func (p *Publisher) Publish(ctx context.Context, configurationSetName string, event model.Event) {
+	destinations, err := p.store.GetEventDestinations(ctx, configurationSetName)
+	if err != nil {
+		log.Printf("Failed to get the event destinations of %s: %v", configurationSetName, err)
+		return
+	}
+
+	event.Mail.SendingAccountID = p.sendingAccountID
+
+	var data []byte
+	for _, d := range destinations {
+		if !d.Enabled || !Matches(d.MatchingEventTypes, event.EventType) {
+			continue
+		}
+		if data == nil {
+			if data, err = json.Marshal(event); err != nil {
+				log.Printf("Failed to encode %s event: %v", event.EventType, err)
+				return
+			}
+		}
+		p.enqueue(ctx, delivery{destination: d, event: event, data: data})
+	}
+}
+
+func (p *Publisher) enqueue(ctx context.Context, d delivery) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.closed {
+		return
+	}
+	select {
+	case p.queue <- d:
+	case <-ctx.Done():
+		log.Printf("Dropped %s event of message %s to %s: %v", d.event.EventType, d.event.Mail.MessageID, d.destination.Name, ctx.Err())
+	default:
+		log.Printf("Dropped %s event of message %s to %s: the event queue is full", d.event.EventType, d.event.Mail.MessageID, d.destination.Name)
+	}
+}
+
+// Close delivers the queued events and stops the publisher, events published
+// afterwards are ignored.
+func (p *Publisher) Close() {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.queue)
+	}
+	p.mu.Unlock()
+	<-p.done
+}
+
+func (p *Publisher) run() {
+	defer close(p.done)
+	for d := range p.queue {
+		if err := p.deliver(d); err != nil {
+			log.Printf("Failed to publish %s event of message %s to %s: %v",
+				d.event.EventType, d.event.Mail.MessageID, d.destination.Name, err)
+		}
+	}
+}
+
+func (p *Publisher) deliver(d delivery) error {
+	ctx, cancel := context.WithTimeout(context.Background(), deliveryTimeout)
+	defer cancel()
+
+	switch dest := d.destination; {
+	case dest.WebhookDestination != nil:
+		return p.post(ctx, dest.WebhookDestination.URL, d.data)
+	case dest.FileDestination != nil:
+		return appendLine(dest.FileDestination.Path, d.data)
+	case dest.RedisStreamDestination != nil:
+		return p.streams.AddEvent(ctx, dest.RedisStreamDestination.Stream, d.event.EventType, d.event.Mail.MessageID, d.data)
+	case dest.SNSDestination != nil:
+		if p.topics == nil {
+			return fmt.Errorf("SNS destinations are not enabled")
+		}
+		_, err := p.topics.Publish(ctx, dest.SNSDestination.TopicARN, "", string(d.data))
+		return err
+	case dest.SQSDestination != nil:
+		if p.queues == nil {
+			return fmt.Errorf("SQS destinations are not enabled")
+		}
+		_, err := p.queues.SendMessage(ctx, dest.SQSDestination.QueueURL, string(d.data), nil)
+		return err
+	default:
+		return fmt.Errorf("no destination configured")
+	}
+}
+
+func (p *Publisher) post(ctx context.Context, url string, data []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+// appendLine appends data as a line of an NDJSON file. Events are delivered
+// one at a time, so lines never interleave.
+func appendLine(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(data[:len(data):len(data)], '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Matches reports whether eventType is one of matchingEventTypes, which may be
+// spelled the SES v1 way ("renderingFailure") or the SESv2 way
+// ("RENDERING_FAILURE").
+func Matches(matchingEventTypes []string, eventType string) bool {
+	for _, t := range matchingEventTypes {
+		if normalize(t) == normalize(eventType) {
+			return true
+		}
+	}
+	return false
+}
+
+func normalize(eventType string) string {
+	return strings.ToLower(strings.NewReplacer("_", "", " ", "").Replace(eventType))
+}
@@ -0,0 +1,283 @@
+package events_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kamal-github/demtech/internal/events"
+	"github.com/kamal-github/demtech/internal/events/mocks"
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublisher_Publish(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	assert := assert.New(t)
+
+	var webhookEvents []model.Event
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("application/json", r.Header.Get("Content-Type"))
+		var e model.Event
+		assert.NoError(json.NewDecoder(r.Body).Decode(&e))
+		webhookEvents = append(webhookEvents, e)
+	}))
+	defer webhook.Close()
+
+	file := filepath.Join(t.TempDir(), "events", "ses.ndjson")
+
+	mockStreams := mocks.NewMockStreamAdder(ctrl)
+	mockStreams.EXPECT().AddEvent(gomock.Any(), "ses-events", "Bounce", "msg-1", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, _, _ string, data []byte) error {
+			assert.Contains(string(data), `"bounceType":"Permanent"`)
+			return nil
+		}).Times(1)
+
+	store := events.StaticDestinations{
+		"default-config": {
+			{Name: "webhook", Enabled: true, MatchingEventTypes: []string{"send", "delivery"}, WebhookDestination: &model.WebhookDestination{URL: webhook.URL}},
+			{Name: "file", Enabled: true, MatchingEventTypes: []string{"SEND", "DELIVERY", "BOUNCE", "RENDERING_FAILURE"}, FileDestination: &model.FileDestination{Path: file}},
+			{Name: "stream", Enabled: true, MatchingEventTypes: []string{"bounce"}, RedisStreamDestination: &model.RedisStreamDestination{Stream: "ses-events"}},
+			{Name: "disabled", Enabled: false, MatchingEventTypes: []string{"send"}, WebhookDestination: &model.WebhookDestination{URL: webhook.URL}},
+		},
+	}
+
+	p := events.NewPublisher(store, mockStreams, "123456789012")
+
+	mail := model.EventMail{MessageID: "msg-1", Source: "sender@example.com"}
+	p.Publish(context.Background(), "default-config", model.Event{EventType: model.EventTypeSend, Mail: mail, Send: &struct{}{}})
+	p.Publish(context.Background(), "default-config", model.Event{EventType: model.EventTypeDelivery, Mail: mail, Delivery: &model.DeliveryEvent{Recipients: []string{"test@example.com"}}})
+	p.Publish(context.Background(), "default-config", model.Event{EventType: model.EventTypeBounce, Mail: mail, Bounce: &model.BounceEvent{BounceType: "Permanent"}})
+	p.Publish(context.Background(), "other-config", model.Event{EventType: model.EventTypeSend, Mail: mail, Send: &struct{}{}})
+	p.Close()
+
+	if assert.Len(webhookEvents, 2) {
+		assert.Equal("Send", webhookEvents[0].EventType)
+		assert.Equal("Delivery", webhookEvents[1].EventType)
+		assert.Equal("123456789012", webhookEvents[0].Mail.SendingAccountID)
+	}
+
+	data, err := os.ReadFile(file)
+	assert.NoError(err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if assert.Len(lines, 3) {
+		assert.True(strings.HasPrefix(lines[0], `{"eventType":"Send"`))
+		assert.Contains(lines[0], `"send":{}`)
+		assert.True(strings.HasPrefix(lines[2], `{"eventType":"Bounce"`))
+	}
+}
+
+func TestPublisher_WebhookFailureIsLogged(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	calls := 0
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		_, _ = io.Copy(io.Discard, r.Body)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer webhook.Close()
+
+	mockStore := mocks.NewMockDestinationStore(ctrl)
+	mockStore.EXPECT().GetEventDestinations(gomock.Any(), "default-config").Return([]model.EventDestination{
+		{Name: "webhook", Enabled: true, MatchingEventTypes: []string{"send"}, WebhookDestination: &model.WebhookDestination{URL: webhook.URL}},
+	}, nil).Times(2)
+
+	p := events.NewPublisher(mockStore, nil, "123456789012")
+	p.Publish(context.Background(), "default-config", model.Event{EventType: model.EventTypeSend, Send: &struct{}{}})
+	p.Publish(context.Background(), "default-config", model.Event{EventType: model.EventTypeSend, Send: &struct{}{}})
+	p.Close()
+
+	assert.Equal(t, 2, calls)
+}
+
+func TestPublisher_BlockedDestination(t *testing.T) {
+	unblock := make(chan struct{})
+	var once sync.Once
+	release := func() { once.Do(func() { close(unblock) }) }
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-unblock
+	}))
+	defer webhook.Close()
+	defer release()
+
+	store := events.StaticDestinations{
+		"default-config": {
+			{Name: "webhook", Enabled: true, MatchingEventTypes: []string{"send"}, WebhookDestination: &model.WebhookDestination{URL: webhook.URL}},
+		},
+	}
+	p := events.NewPublisher(store, nil, "123456789012")
+
+	// Every dropped event is logged.
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	// Way more events than the queue holds, while the webhook does not answer.
+	published := make(chan struct{})
+	go func() {
+		defer close(published)
+		for i := 0; i < 2000; i++ {
+			p.Publish(context.Background(), "default-config", model.Event{EventType: model.EventTypeSend, Send: &struct{}{}})
+		}
+	}()
+	select {
+	case <-published:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Publish blocked on a slow destination")
+	}
+
+	release()
+	p.Close()
+
+	// Publishing after Close is ignored.
+	assert.NotPanics(t, func() {
+		p.Publish(context.Background(), "default-config", model.Event{EventType: model.EventTypeSend, Send: &struct{}{}})
+	})
+}
+
+func TestMatches(t *testing.T) {
+	tests := []struct {
+		name      string
+		matching  []string
+		eventType string
+		expect    bool
+	}{
+		{name: "SES v1 spelling", matching: []string{"renderingFailure"}, eventType: model.EventTypeRenderingFailure, expect: true},
+		{name: "SESv2 spelling", matching: []string{"RENDERING_FAILURE"}, eventType: model.EventTypeRenderingFailure, expect: true},
+		{name: "Delivery delay", matching: []string{"DELIVERY_DELAY"}, eventType: model.EventTypeDeliveryDelay, expect: true},
+		{name: "Delivery is not delivery delay", matching: []string{"DELIVERY_DELAY"}, eventType: model.EventTypeDelivery, expect: false},
+		{name: "No matching types", eventType: model.EventTypeSend, expect: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expect, events.Matches(tt.matching, tt.eventType))
+		})
+	}
+}
+
+func TestLoadDestinations(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name      string
+		content   string
+		expectErr bool
+	}{
+		{
+			name:    "Valid destinations",
+			content: `{"default-config": [{"Name": "file", "Enabled": true, "MatchingEventTypes": ["send"], "FileDestination": {"Path": "/tmp/ses.ndjson"}}]}`,
+		},
+		{
+			name:      "Destination without target",
+			content:   `{"default-config": [{"Name": "nowhere", "Enabled": true, "MatchingEventTypes": ["send"]}]}`,
+			expectErr: true,
+		},
+		{
+			name:      "Destination with two targets",
+			content:   `{"default-config": [{"Name": "both", "Enabled": true, "FileDestination": {"Path": "a"}, "RedisStreamDestination": {"Stream": "b"}}]}`,
+			expectErr: true,
+		},
+		{
+			name:      "Destination without event types",
+			content:   `{"default-config": [{"Name": "file", "Enabled": true, "FileDestination": {"Path": "a"}}]}`,
+			expectErr: true,
+		},
+		{
+			name:      "Unknown event type",
+			content:   `{"default-config": [{"Name": "file", "Enabled": true, "MatchingEventTypes": ["sent"], "FileDestination": {"Path": "a"}}]}`,
+			expectErr: true,
+		},
+		{
+			name:    "Event type never published",
+			content: `{"default-config": [{"Name": "file", "Enabled": true, "MatchingEventTypes": ["send", "OPEN"], "FileDestination": {"Path": "a"}}]}`,
+		},
+		{
+			name:      "Invalid JSON",
+			content:   `{"default-config": [`,
+			expectErr: true,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			path := filepath.Join(dir, strings.Repeat("x", i+1)+".json")
+			assert.NoError(os.WriteFile(path, []byte(tt.content), 0o644))
+
+			destinations, err := events.LoadDestinations(path)
+
+			if tt.expectErr {
+				assert.Error(err)
+				return
+			}
+			assert.NoError(err)
+			got, err := destinations.GetEventDestinations(context.Background(), "default-config")
+			assert.NoError(err)
+			assert.Len(got, 1)
+		})
+	}
+}
+
+func TestPublisher_SNSDestination(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := events.StaticDestinations{
+		"default-config": {
+			{Name: "sns", Enabled: true, MatchingEventTypes: []string{"bounce"}, SNSDestination: &model.SNSDestination{TopicARN: "arn:aws:sns:us-east-1:123456789012:ses-events"}},
+		},
+	}
+
+	mockTopics := mocks.NewMockTopicPublisher(ctrl)
+	mockTopics.EXPECT().Publish(gomock.Any(), "arn:aws:sns:us-east-1:123456789012:ses-events", "", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, _, message string) (string, error) {
+			var e model.Event
+			assert.NoError(t, json.Unmarshal([]byte(message), &e))
+			assert.Equal(t, "Bounce", e.EventType)
+			return "sns-message-id", nil
+		}).Times(1)
+
+	p := events.NewPublisher(store, nil, "123456789012", events.WithTopicPublisher(mockTopics))
+	p.Publish(context.Background(), "default-config", model.Event{EventType: model.EventTypeSend, Send: &struct{}{}})
+	p.Publish(context.Background(), "default-config", model.Event{EventType: model.EventTypeBounce, Bounce: &model.BounceEvent{}})
+	p.Close()
+}
+
+func TestPublisher_SQSDestination(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const queueARN = "arn:aws:sqs:us-east-1:123456789012:ses-events"
+	store := events.StaticDestinations{
+		"default-config": {
+			{Name: "sqs", Enabled: true, MatchingEventTypes: []string{"delivery"}, SQSDestination: &model.SQSDestination{QueueURL: queueARN}},
+		},
+	}
+
+	mockQueues := mocks.NewMockQueueSender(ctrl)
+	mockQueues.EXPECT().SendMessage(gomock.Any(), queueARN, gomock.Any(), nil).
+		DoAndReturn(func(_ context.Context, _, body string, _ *int) (model.QueueMessage, error) {
+			var e model.Event
+			assert.NoError(t, json.Unmarshal([]byte(body), &e))
+			assert.Equal(t, "Delivery", e.EventType)
+			return model.QueueMessage{MessageID: "sqs-message-id"}, nil
+		}).Times(1)
+
+	p := events.NewPublisher(store, nil, "123456789012", events.WithQueueSender(mockQueues))
+	p.Publish(context.Background(), "default-config", model.Event{EventType: model.EventTypeSend, Send: &struct{}{}})
+	p.Publish(context.Background(), "default-config", model.Event{EventType: model.EventTypeDelivery, Delivery: &model.DeliveryEvent{}})
+	p.Close()
+}
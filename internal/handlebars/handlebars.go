@@ -0,0 +1,470 @@
+// Package handlebars renders SES email templates. It implements the subset of
+// Handlebars that SES supports: escaped and raw expressions, dotted and parent
+// paths, comments, whitespace control, the if/unless/each/with built-in
+// helpers with their chained {{else if}} and inverse sections. Unlike
+// handlebars.js, referencing a value that is not present in the rendering data
+// is an error, as it is a RenderingFailure in SES.
+package handlebars
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MissingValueError is returned when an expression refers to a value that is
+// not present in the rendering data.
+type MissingValueError struct {
+	Path string
+}
+
+func (e *MissingValueError) Error() string {
+	return "Attribute '" + e.Path + "' is not present in the rendering data."
+}
+
+// Template is a parsed Handlebars template
+type Template struct {
+	nodes []node
+}
+
+// Parse parses a Handlebars template
+func Parse(src string) (*Template, error) {
+	tokens, err := tokenize(src)
+	if err != nil {
+		return nil, err
+	}
+
+	p := parser{tokens: tokens}
+	nodes, err := p.parse("")
+	if err != nil {
+		return nil, err
+	}
+	return &Template{nodes: nodes}, nil
+}
+
+// Render parses and executes src against data in one go
+func Render(src string, data map[string]any) (string, error) {
+	t, err := Parse(src)
+	if err != nil {
+		return "", err
+	}
+	return t.Execute(data)
+}
+
+// Execute renders the template against data
+func (t *Template) Execute(data map[string]any) (string, error) {
+	var sb strings.Builder
+	if err := renderNodes(&sb, t.nodes, &scope{value: data}); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+type tokenKind int
+
+const (
+	tokenText tokenKind = iota
+	tokenVar
+	tokenRawVar
+	tokenOpen
+	tokenElse
+	tokenClose
+)
+
+type token struct {
+	kind tokenKind
+	// name is the helper of a block tag, content the expression or text.
+	name    string
+	content string
+}
+
+func tokenize(src string) ([]token, error) {
+	var (
+		tokens   []token
+		trimNext bool
+	)
+
+	for len(src) > 0 {
+		start := strings.Index(src, "{{")
+		if start < 0 {
+			tokens = appendText(tokens, src, trimNext)
+			break
+		}
+
+		raw := strings.HasPrefix(src[start:], "{{{")
+		closing := "}}"
+		if raw {
+			closing = "}}}"
+		}
+		inner := src[start+len(closing):]
+
+		var end int
+		switch {
+		case strings.HasPrefix(inner, "!--") || strings.HasPrefix(inner, "~!--"):
+			end = strings.Index(inner, "--"+closing)
+			if end >= 0 {
+				end += 2
+			}
+		default:
+			end = strings.Index(inner, closing)
+		}
+		if end < 0 {
+			return nil, fmt.Errorf("unclosed expression at %q", truncate(src[start:]))
+		}
+
+		expr := inner[:end]
+		trimPrev := strings.HasPrefix(expr, "~")
+		expr = strings.TrimPrefix(expr, "~")
+		trim := strings.HasSuffix(expr, "~")
+		expr = strings.TrimSpace(strings.TrimSuffix(expr, "~"))
+
+		text := src[:start]
+		if trimPrev {
+			text = strings.TrimRight(text, " \t\r\n")
+		}
+		tokens = appendText(tokens, text, trimNext)
+		trimNext = trim
+		src = inner[end+len(closing):]
+
+		switch {
+		case raw:
+			tokens = append(tokens, token{kind: tokenRawVar, content: expr})
+		case strings.HasPrefix(expr, "!"):
+			// comment
+		case strings.HasPrefix(expr, "&"):
+			tokens = append(tokens, token{kind: tokenRawVar, content: strings.TrimSpace(expr[1:])})
+		case strings.HasPrefix(expr, "#"):
+			name, args, _ := strings.Cut(strings.TrimSpace(expr[1:]), " ")
+			tokens = append(tokens, token{kind: tokenOpen, name: name, content: strings.TrimSpace(args)})
+		case expr == "else" || expr == "^":
+			tokens = append(tokens, token{kind: tokenElse})
+		case strings.HasPrefix(expr, "else "):
+			// {{else if path}} chains another block, closed along with this one
+			tokens = append(tokens, token{kind: tokenElse, content: strings.TrimSpace(expr[len("else "):])})
+		case strings.HasPrefix(expr, "^"):
+			// {{^path}} is the inverse section of path, closed by {{/path}}
+			tokens = append(tokens, token{kind: tokenOpen, name: "^", content: strings.TrimSpace(expr[1:])})
+		case strings.HasPrefix(expr, "/"):
+			tokens = append(tokens, token{kind: tokenClose, name: strings.TrimSpace(expr[1:])})
+		default:
+			tokens = append(tokens, token{kind: tokenVar, content: expr})
+		}
+	}
+
+	return tokens, nil
+}
+
+func appendText(tokens []token, text string, trimLeading bool) []token {
+	if trimLeading {
+		text = strings.TrimLeft(text, " \t\r\n")
+	}
+	if text == "" {
+		return tokens
+	}
+	return append(tokens, token{kind: tokenText, content: text})
+}
+
+func truncate(s string) string {
+	if len(s) > 20 {
+		return s[:20] + "..."
+	}
+	return s
+}
+
+type node interface{}
+
+type textNode struct {
+	text string
+}
+
+type varNode struct {
+	path   string
+	escape bool
+}
+
+type blockNode struct {
+	helper  string
+	path    string
+	body    []node
+	inverse []node
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+// parse consumes tokens until the closing tag of block (or the end of input
+// for the top level) and returns the parsed nodes.
+func (p *parser) parse(block string) ([]node, error) {
+	var nodes []node
+	for p.pos < len(p.tokens) {
+		tok := p.tokens[p.pos]
+		p.pos++
+
+		switch tok.kind {
+		case tokenText:
+			nodes = append(nodes, textNode{text: tok.content})
+		case tokenVar, tokenRawVar:
+			nodes = append(nodes, varNode{path: tok.content, escape: tok.kind == tokenVar})
+		case tokenOpen:
+			helper, closer := tok.name, tok.name
+			if tok.name == "^" {
+				// The inverse section renders like #unless.
+				helper, closer = "unless", tok.content
+			}
+			n, err := p.parseBlock(helper, tok.content, closer)
+			if err != nil {
+				return nil, err
+			}
+			nodes = append(nodes, n)
+		case tokenElse:
+			if block == "" {
+				return nil, fmt.Errorf("{{else}} outside of a block")
+			}
+			return nodes, nil
+		case tokenClose:
+			if tok.name != block {
+				return nil, fmt.Errorf("unexpected {{/%s}}", tok.name)
+			}
+			return nodes, nil
+		}
+	}
+
+	if block != "" {
+		return nil, fmt.Errorf("unclosed {{#%s}}", block)
+	}
+	return nodes, nil
+}
+
+// parseBlock parses the body and inverse of a block helper on path, up to
+// the {{/closer}} tag. An {{else if path}} or {{else unless path}} makes the
+// inverse a block of its own, sharing that closing tag.
+func (p *parser) parseBlock(helper, path, closer string) (*blockNode, error) {
+	switch helper {
+	case "if", "unless", "each", "with":
+	default:
+		return nil, fmt.Errorf("unsupported helper %q", helper)
+	}
+	if path == "" {
+		return nil, fmt.Errorf("#%s requires an argument", helper)
+	}
+
+	n := &blockNode{helper: helper, path: path}
+	body, err := p.parse(closer)
+	if err != nil {
+		return nil, err
+	}
+	n.body = body
+
+	last := p.tokens[p.pos-1]
+	if last.kind != tokenElse {
+		return n, nil
+	}
+	if last.content == "" {
+		if n.inverse, err = p.parse(closer); err != nil {
+			return nil, err
+		}
+		if p.tokens[p.pos-1].kind == tokenElse {
+			return nil, fmt.Errorf("unexpected {{else}} after {{else}}")
+		}
+		return n, nil
+	}
+
+	chained, args, _ := strings.Cut(last.content, " ")
+	if chained != "if" && chained != "unless" {
+		return nil, fmt.Errorf("unsupported {{else %s}}", chained)
+	}
+	inverse, err := p.parseBlock(chained, strings.TrimSpace(args), closer)
+	if err != nil {
+		return nil, err
+	}
+	n.inverse = []node{inverse}
+	return n, nil
+}
+
+// scope is one level of the context stack, used to resolve `this`, `../` and
+// the @data variables of #each.
+type scope struct {
+	value  any
+	data   map[string]any
+	parent *scope
+}
+
+func (s *scope) lookup(path string) (any, bool) {
+	for strings.HasPrefix(path, "../") {
+		if s.parent == nil {
+			return nil, false
+		}
+		s = s.parent
+		path = path[3:]
+	}
+
+	if strings.HasPrefix(path, "@") {
+		for ; s != nil; s = s.parent {
+			if v, ok := s.data[path[1:]]; ok {
+				return v, true
+			}
+		}
+		return nil, false
+	}
+
+	switch {
+	case path == "this" || path == ".":
+		path = ""
+	case strings.HasPrefix(path, "this."):
+		path = path[len("this."):]
+	case strings.HasPrefix(path, "./"):
+		path = path[len("./"):]
+	}
+
+	v := s.value
+	if path == "" {
+		return v, true
+	}
+	for _, segment := range strings.Split(path, ".") {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if v, ok = m[segment]; !ok {
+			return nil, false
+		}
+	}
+	return v, true
+}
+
+func renderNodes(sb *strings.Builder, nodes []node, s *scope) error {
+	for _, n := range nodes {
+		switch n := n.(type) {
+		case textNode:
+			sb.WriteString(n.text)
+		case varNode:
+			v, ok := s.lookup(n.path)
+			if !ok {
+				return &MissingValueError{Path: n.path}
+			}
+			if n.escape {
+				sb.WriteString(escape(stringify(v)))
+			} else {
+				sb.WriteString(stringify(v))
+			}
+		case *blockNode:
+			if err := renderBlock(sb, n, s); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func renderBlock(sb *strings.Builder, n *blockNode, s *scope) error {
+	// Block arguments are allowed to be missing, they are falsy in that case.
+	v, _ := s.lookup(n.path)
+
+	switch n.helper {
+	case "if":
+		if truthy(v) {
+			return renderNodes(sb, n.body, s)
+		}
+		return renderNodes(sb, n.inverse, s)
+	case "unless":
+		if !truthy(v) {
+			return renderNodes(sb, n.body, s)
+		}
+		return renderNodes(sb, n.inverse, s)
+	case "with":
+		if truthy(v) {
+			return renderNodes(sb, n.body, &scope{value: v, parent: s})
+		}
+		return renderNodes(sb, n.inverse, s)
+	case "each":
+		switch items := v.(type) {
+		case []any:
+			if len(items) > 0 {
+				for i, item := range items {
+					data := map[string]any{"index": i, "first": i == 0, "last": i == len(items)-1}
+					if err := renderNodes(sb, n.body, &scope{value: item, data: data, parent: s}); err != nil {
+						return err
+					}
+				}
+				return nil
+			}
+		case map[string]any:
+			if len(items) > 0 {
+				keys := make([]string, 0, len(items))
+				for k := range items {
+					keys = append(keys, k)
+				}
+				sort.Strings(keys)
+				for i, k := range keys {
+					data := map[string]any{"key": k, "index": i, "first": i == 0, "last": i == len(keys)-1}
+					if err := renderNodes(sb, n.body, &scope{value: items[k], data: data, parent: s}); err != nil {
+						return err
+					}
+				}
+				return nil
+			}
+		}
+		return renderNodes(sb, n.inverse, s)
+	}
+
+	return nil
+}
+
+// truthy follows the JavaScript rules Handlebars applies, except that empty
+// lists are falsy as well.
+func truthy(v any) bool {
+	switch v := v.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case string:
+		return v != ""
+	case float64:
+		return v != 0
+	case int:
+		return v != 0
+	case []any:
+		return len(v) > 0
+	default:
+		return true
+	}
+}
+
+func stringify(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case []any:
+		parts := make([]string, len(v))
+		for i, item := range v {
+			parts[i] = stringify(item)
+		}
+		return strings.Join(parts, ",")
+	case map[string]any:
+		return "[object Object]"
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+var escaper = strings.NewReplacer(
+	"&", "&amp;",
+	"<", "&lt;",
+	">", "&gt;",
+	`"`, "&quot;",
+	"'", "&#x27;",
+	"`", "&#x60;",
+	"=", "&#x3D;",
+)
+
+func escape(s string) string {
+	return escaper.Replace(s)
+}
@@ -0,0 +1,82 @@
+package handlebars_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/kamal-github/demtech/internal/handlebars"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRender(t *testing.T) {
+	data := `{
+		"name": "Jane <3",
+		"thisIsVar": "x",
+		"count": 3,
+		"vip": false,
+		"address": {"city": "Berlin"},
+		"items": [{"title": "Book"}, {"title": "Pen"}],
+		"empty": [],
+		"prefs": {"b": "2", "a": "1"}
+	}`
+
+	tests := []struct {
+		name      string
+		template  string
+		expect    string
+		expectErr string
+	}{
+		{name: "Escaped expression", template: "Hi {{name}}!", expect: "Hi Jane &lt;3!"},
+		{name: "Raw expression", template: "Hi {{{name}}} {{& name}}", expect: "Hi Jane <3 Jane <3"},
+		{name: "Dotted path and numbers", template: "{{address.city}} {{count}} {{thisIsVar}}", expect: "Berlin 3 x"},
+		{name: "Comments", template: "a{{! short }}b{{!-- long }} --}}c", expect: "abc"},
+		{name: "If else", template: "{{#if vip}}VIP{{else}}regular{{/if}}", expect: "regular"},
+		{name: "Missing value in if is falsy", template: "{{#if nope}}yes{{else}}no{{/if}}", expect: "no"},
+		{name: "Unless", template: "{{#unless vip}}not vip{{/unless}}", expect: "not vip"},
+		{name: "Inverse section", template: "{{^vip}}regular{{/vip}}", expect: "regular"},
+		{name: "Inverse section closed by another tag", template: "{{^vip}}regular{{/unless}}", expectErr: "unexpected {{/unless}}"},
+		{name: "Caret as else", template: "{{#if vip}}VIP{{^}}regular{{/if}}", expect: "regular"},
+		{name: "Else if", template: "{{#if vip}}VIP{{else if count}}{{count}} items{{else}}none{{/if}}", expect: "3 items"},
+		{name: "Else unless", template: "{{#if vip}}VIP{{else unless name}}anonymous{{else}}{{name}}{{/if}}", expect: "Jane &lt;3"},
+		{name: "Unsupported else helper", template: "{{#if vip}}VIP{{else each items}}x{{/if}}", expectErr: "unsupported {{else each}}"},
+		{name: "With", template: "{{#with address}}{{city}}/{{../count}}{{/with}}", expect: "Berlin/3"},
+		{
+			name:     "Each over list",
+			template: "{{#each items}}{{@index}}:{{title}}{{#if @last}}.{{else}}, {{/if}}{{/each}}",
+			expect:   "0:Book, 1:Pen.",
+		},
+		{name: "Each over map", template: "{{#each prefs}}{{@key}}={{this}};{{/each}}", expect: "a=1;b=2;"},
+		{name: "Each else", template: "{{#each empty}}x{{else}}none{{/each}}", expect: "none"},
+		{name: "Whitespace control", template: "a   {{~name~}}   b", expect: "aJane &lt;3b"},
+		{name: "Missing value", template: "Hi {{firstName}}", expectErr: "Attribute 'firstName' is not present in the rendering data."},
+		{name: "Missing nested value", template: "{{#each items}}{{price}}{{/each}}", expectErr: "Attribute 'price' is not present in the rendering data."},
+		{name: "Unclosed block", template: "{{#if vip}}yes", expectErr: "unclosed {{#if}}"},
+		{name: "Unsupported helper", template: "{{#lookup vip}}{{/lookup}}", expectErr: `unsupported helper "lookup"`},
+	}
+
+	var values map[string]any
+	assert.NoError(t, json.Unmarshal([]byte(data), &values))
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			out, err := handlebars.Render(tt.template, values)
+
+			if tt.expectErr != "" {
+				assert.EqualError(err, tt.expectErr)
+				return
+			}
+			assert.NoError(err)
+			assert.Equal(tt.expect, out)
+		})
+	}
+}
+
+func TestRender_MissingValueError(t *testing.T) {
+	_, err := handlebars.Render("{{a.b}}", map[string]any{"a": map[string]any{}})
+
+	var missing *handlebars.MissingValueError
+	assert.ErrorAs(t, err, &missing)
+	assert.Equal(t, "a.b", missing.Path)
+}
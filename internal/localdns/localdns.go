@@ -0,0 +1,300 @@
+// Package localdns provides the DNS resolvers domain verification is checked
+// with: a DNS server of choice, e.g. a local one the DNS automation under test
+// publishes to, or a zone file.
+package localdns
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strings"
+)
+
+// NewServerResolver returns a resolver which sends every query to the DNS
+// server at addr, e.g. "127.0.0.1:5353".
+func NewServerResolver(addr string) *net.Resolver {
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, network, addr)
+		},
+	}
+}
+
+// ZoneFile resolves names out of an RFC 1035 master file. The file is read on
+// every lookup, so it can be edited while the mock is running.
+type ZoneFile struct {
+	path string
+}
+
+func NewZoneFile(path string) ZoneFile {
+	return ZoneFile{path: path}
+}
+
+type record struct {
+	name  string
+	rtype string
+	data  string
+}
+
+// LookupTXT returns the TXT records of name, the strings of a record are
+// concatenated like net.Resolver does.
+func (z ZoneFile) LookupTXT(_ context.Context, name string) ([]string, error) {
+	records, err := z.lookup(name)
+	if err != nil {
+		return nil, err
+	}
+
+	var txt []string
+	for _, r := range records {
+		if r.rtype == "TXT" {
+			txt = append(txt, r.data)
+		}
+	}
+	if len(txt) == 0 {
+		return nil, notFound(name)
+	}
+	return txt, nil
+}
+
+// LookupCNAME returns the canonical name of name, which is name itself when
+// it has records but no CNAME.
+func (z ZoneFile) LookupCNAME(_ context.Context, name string) (string, error) {
+	records, err := z.lookup(name)
+	if err != nil {
+		return "", err
+	}
+
+	for _, r := range records {
+		if r.rtype == "CNAME" {
+			return r.data, nil
+		}
+	}
+	if len(records) == 0 {
+		return "", notFound(name)
+	}
+	return fqdn(name), nil
+}
+
+func (z ZoneFile) lookup(name string) ([]record, error) {
+	f, err := os.Open(z.path)
+	if err != nil {
+		return nil, &net.DNSError{Err: err.Error(), Name: name, IsTemporary: true}
+	}
+	defer f.Close()
+
+	all, err := parseZone(f)
+	if err != nil {
+		return nil, &net.DNSError{Err: err.Error(), Name: name, IsTemporary: true}
+	}
+
+	var records []record
+	for _, r := range all {
+		if r.name == fqdn(name) {
+			records = append(records, r)
+		}
+	}
+	return records, nil
+}
+
+// parseZone parses the subset of the master file format which is needed for
+// verification records: $ORIGIN, $TTL, @, relative names, omitted owner
+// names, parentheses and quoted TXT strings.
+func parseZone(r io.Reader) ([]record, error) {
+	var (
+		records    []record
+		origin     = "."
+		owner      string
+		pending    []string
+		ownerBlank bool
+	)
+
+	scanner := bufio.NewScanner(r)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := scanner.Text()
+		fields, err := splitFields(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+
+		if len(pending) == 0 {
+			ownerBlank = strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")
+		}
+		// Parentheses continue a record over several lines.
+		if len(pending) > 0 || hasParen(fields, "(") {
+			pending = append(pending, fields...)
+			if !hasParen(pending, ")") {
+				continue
+			}
+			fields, pending = stripParens(pending), nil
+		}
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch strings.ToUpper(fields[0]) {
+		case "$ORIGIN":
+			if len(fields) < 2 {
+				return nil, fmt.Errorf("line %d: $ORIGIN without a name", lineNo)
+			}
+			origin = fqdn(fields[1])
+			continue
+		case "$TTL":
+			continue
+		}
+
+		if !ownerBlank {
+			owner = absolute(fields[0], origin)
+			fields = fields[1:]
+		}
+		if owner == "" {
+			return nil, fmt.Errorf("line %d: record without owner name", lineNo)
+		}
+
+		// Skip the optional TTL and class, which come in either order.
+		for len(fields) > 0 && (isTTL(fields[0]) || strings.EqualFold(fields[0], "IN")) {
+			fields = fields[1:]
+		}
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("line %d: incomplete record", lineNo)
+		}
+
+		rec := record{name: owner, rtype: strings.ToUpper(fields[0])}
+		switch rec.rtype {
+		case "TXT":
+			rec.data = strings.Join(unquote(fields[1:]), "")
+		case "CNAME":
+			rec.data = absolute(fields[1], origin)
+		default:
+			rec.data = strings.Join(fields[1:], " ")
+		}
+		records = append(records, rec)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(pending) > 0 {
+		return nil, fmt.Errorf("unclosed parenthesis")
+	}
+
+	return records, nil
+}
+
+// splitFields splits a line on whitespace, keeping quoted strings (with their
+// quotes) together and dropping comments.
+func splitFields(line string) ([]string, error) {
+	var (
+		fields  []string
+		current strings.Builder
+		quoted  bool
+	)
+	flush := func() {
+		if current.Len() > 0 {
+			fields = append(fields, current.String())
+			current.Reset()
+		}
+	}
+
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quoted && c == '\\' && i+1 < len(line):
+			current.WriteByte(c)
+			current.WriteByte(line[i+1])
+			i++
+		case c == '"':
+			current.WriteByte(c)
+			quoted = !quoted
+		case quoted:
+			current.WriteByte(c)
+		case c == ';':
+			flush()
+			return fields, nil
+		case c == ' ' || c == '\t':
+			flush()
+		default:
+			current.WriteByte(c)
+		}
+	}
+	if quoted {
+		return nil, fmt.Errorf("unterminated quoted string")
+	}
+	flush()
+	return fields, nil
+}
+
+func hasParen(fields []string, paren string) bool {
+	for _, f := range fields {
+		if !strings.HasPrefix(f, `"`) && strings.Contains(f, paren) {
+			return true
+		}
+	}
+	return false
+}
+
+func stripParens(fields []string) []string {
+	var out []string
+	for _, f := range fields {
+		if !strings.HasPrefix(f, `"`) {
+			f = strings.NewReplacer("(", "", ")", "").Replace(f)
+		}
+		if f != "" {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+func unquote(fields []string) []string {
+	out := make([]string, len(fields))
+	for i, f := range fields {
+		if strings.HasPrefix(f, `"`) && strings.HasSuffix(f, `"`) && len(f) >= 2 {
+			f = f[1 : len(f)-1]
+			f = strings.NewReplacer(`\"`, `"`, `\\`, `\`).Replace(f)
+		}
+		out[i] = f
+	}
+	return out
+}
+
+func absolute(name, origin string) string {
+	switch {
+	case name == "@":
+		return origin
+	case strings.HasSuffix(name, "."):
+		return strings.ToLower(name)
+	case origin == ".":
+		return strings.ToLower(name) + "."
+	default:
+		return strings.ToLower(name) + "." + origin
+	}
+}
+
+func fqdn(name string) string {
+	name = strings.ToLower(name)
+	if !strings.HasSuffix(name, ".") {
+		name += "."
+	}
+	return name
+}
+
+// isTTL reports whether s is a TTL, in seconds or with BIND's units like 1h30m.
+func isTTL(s string) bool {
+	if s == "" || s[0] < '0' || s[0] > '9' {
+		return false
+	}
+	for _, c := range strings.ToLower(s) {
+		if (c < '0' || c > '9') && !strings.ContainsRune("smhdw", c) {
+			return false
+		}
+	}
+	return true
+}
+
+func notFound(name string) *net.DNSError {
+	return &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+}
@@ -0,0 +1,109 @@
+package localdns_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kamal-github/demtech/internal/localdns"
+	"github.com/stretchr/testify/assert"
+)
+
+const zone = `$ORIGIN example.com.
+$TTL 300
+@                       IN  SOA ns.example.com. admin.example.com. (
+                                2024010101 ; serial
+                                3600 900 604800 300 )
+_amazonses              IN  TXT "pRrTq7Bl6DsIbrSg0ThFlqyGqCbWRBlw0ffzNqDGcHc="
+                        IN  TXT "v=spf1 include:amazonses.com ~all"
+abc._domainkey  1h      IN  CNAME abc.dkim.amazonses.com.
+long                        TXT ( "part one; "
+                                  "part two" )
+www                         CNAME @
+mail.example.org.       60  A   192.0.2.1
+`
+
+func TestZoneFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "zone.db")
+	assert.NoError(t, os.WriteFile(path, []byte(zone), 0o600))
+
+	ctx := context.Background()
+	z := localdns.NewZoneFile(path)
+
+	tests := []struct {
+		name        string
+		lookup      func() (any, error)
+		expect      any
+		expectNoRec bool
+	}{
+		{
+			name:   "TXT records of a relative name",
+			lookup: func() (any, error) { return z.LookupTXT(ctx, "_amazonses.example.com") },
+			expect: []string{"pRrTq7Bl6DsIbrSg0ThFlqyGqCbWRBlw0ffzNqDGcHc=", "v=spf1 include:amazonses.com ~all"},
+		},
+		{
+			name:   "TXT strings spanning lines are concatenated",
+			lookup: func() (any, error) { return z.LookupTXT(ctx, "LONG.example.com.") },
+			expect: []string{"part one; part two"},
+		},
+		{
+			name:   "CNAME with TTL",
+			lookup: func() (any, error) { return z.LookupCNAME(ctx, "abc._domainkey.example.com") },
+			expect: "abc.dkim.amazonses.com.",
+		},
+		{
+			name:   "CNAME to the origin",
+			lookup: func() (any, error) { return z.LookupCNAME(ctx, "www.example.com") },
+			expect: "example.com.",
+		},
+		{
+			name:   "CNAME of a name without one is the name itself",
+			lookup: func() (any, error) { return z.LookupCNAME(ctx, "mail.example.org") },
+			expect: "mail.example.org.",
+		},
+		{
+			name:        "Unknown name",
+			lookup:      func() (any, error) { return z.LookupTXT(ctx, "_amazonses.example.org") },
+			expectNoRec: true,
+		},
+		{
+			name:        "Name without TXT records",
+			lookup:      func() (any, error) { return z.LookupTXT(ctx, "www.example.com") },
+			expectNoRec: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			got, err := tt.lookup()
+
+			if tt.expectNoRec {
+				var dnsErr *net.DNSError
+				assert.True(errors.As(err, &dnsErr))
+				assert.True(dnsErr.IsNotFound)
+				return
+			}
+			assert.NoError(err)
+			assert.Equal(tt.expect, got)
+		})
+	}
+}
+
+func TestZoneFile_Errors(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := localdns.NewZoneFile(filepath.Join(t.TempDir(), "missing.db")).LookupTXT(ctx, "example.com")
+	var dnsErr *net.DNSError
+	assert.True(t, errors.As(err, &dnsErr))
+	assert.True(t, dnsErr.IsTemporary)
+
+	path := filepath.Join(t.TempDir(), "broken.db")
+	assert.NoError(t, os.WriteFile(path, []byte(`example.com. TXT "unterminated`), 0o600))
+	_, err = localdns.NewZoneFile(path).LookupTXT(ctx, "example.com")
+	assert.ErrorContains(t, err, "line 1: unterminated quoted string")
+}
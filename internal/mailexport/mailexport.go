@@ -0,0 +1,137 @@
+// Package mailexport writes captured messages as RFC 5322 .eml files, mbox
+// files or Maildir trees, either as one archive or continuously into a
+// directory.
+package mailexport
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"net/mail"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/kamal-github/demtech/internal/rawmail"
+)
+
+type Format string
+
+const (
+	FormatEML     Format = "eml"
+	FormatMbox    Format = "mbox"
+	FormatMaildir Format = "maildir"
+)
+
+// mboxFromLine matches the lines mboxrd quotes, since "From " starts the
+// next message.
+var mboxFromLine = regexp.MustCompile(`(?m)^(>*From )`)
+
+// ParseFormat returns the format named s, ignoring its case.
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(strings.ToLower(s)); f {
+	case FormatEML, FormatMbox, FormatMaildir:
+		return f, nil
+	}
+	return "", fmt.Errorf("unknown format %q, expected eml, mbox or maildir", s)
+}
+
+// ExportFile returns the name and media type of the file Export writes.
+func ExportFile(f Format) (filename, contentType string) {
+	switch f {
+	case FormatMbox:
+		return "messages.mbox", "application/mbox"
+	case FormatMaildir:
+		return "maildir.zip", "application/zip"
+	default:
+		return "messages.zip", "application/zip"
+	}
+}
+
+// Export writes messages oldest first as a zip archive of .eml files, a single
+// mbox file or a zip archive of a Maildir tree, with every message in new. The
+// output only depends on the messages, so that it can be diffed.
+func Export(w io.Writer, f Format, messages []model.CapturedMessage) error {
+	messages = slices.Clone(messages)
+	slices.SortFunc(messages, func(a, b model.CapturedMessage) int {
+		if c := a.SentAt.Compare(b.SentAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.MessageID, b.MessageID)
+	})
+
+	if f == FormatMbox {
+		for _, m := range messages {
+			if _, err := w.Write(mboxMessage(m)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	zw := zip.NewWriter(w)
+	if f == FormatMaildir {
+		for _, dir := range []string{"cur/", "new/", "tmp/"} {
+			if _, err := zw.CreateHeader(&zip.FileHeader{Name: dir}); err != nil {
+				return err
+			}
+		}
+	}
+	for _, m := range messages {
+		name, content := emlFilename(m), rawmail.Compose(m)
+		if f == FormatMaildir {
+			name, content = "new/"+maildirFilename(m), toLF(content)
+		}
+		fw, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: m.SentAt})
+		if err != nil {
+			return err
+		}
+		if _, err := fw.Write(content); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+func emlFilename(m model.CapturedMessage) string {
+	return m.MessageID + ".eml"
+}
+
+// maildirFilename is unique as message IDs are, and orders the messages by the
+// time they were sent at.
+func maildirFilename(m model.CapturedMessage) string {
+	return fmt.Sprintf("%d.%s.demtech", m.SentAt.Unix(), m.MessageID)
+}
+
+// mboxMessage renders m as an mboxrd entry, starting with its "From " line
+// and ending with a blank line.
+func mboxMessage(m model.CapturedMessage) []byte {
+	content := mboxFromLine.ReplaceAll(toLF(rawmail.Compose(m)), []byte(">$1"))
+	if !bytes.HasSuffix(content, []byte("\n")) {
+		content = append(content, '\n')
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From %s %s\n", envelopeSender(m), m.SentAt.UTC().Format(time.ANSIC))
+	buf.Write(content)
+	buf.WriteByte('\n')
+	return buf.Bytes()
+}
+
+func envelopeSender(m model.CapturedMessage) string {
+	for _, s := range []string{m.ReturnPath, m.Source} {
+		if a, err := mail.ParseAddress(s); err == nil {
+			return a.Address
+		}
+	}
+	return "MAILER-DAEMON"
+}
+
+// toLF converts the line endings of a MIME message to the ones of mbox and
+// Maildir files.
+func toLF(content []byte) []byte {
+	return bytes.ReplaceAll(content, []byte("\r\n"), []byte("\n"))
+}
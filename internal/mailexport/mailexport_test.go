@@ -0,0 +1,89 @@
+package mailexport_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kamal-github/demtech/internal/mailexport"
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	start   = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	welcome = model.CapturedMessage{
+		MessageID:   "msg-1",
+		Source:      "Team <team@example.com>",
+		Destination: model.Destination{ToAddresses: []string{"jane@example.com"}},
+		Subject:     "Welcome",
+		TextBody:    "Hello\nFrom the team\n>From the archive",
+		SentAt:      start,
+	}
+	receipt = model.CapturedMessage{
+		MessageID:   "msg-2",
+		Source:      "billing@example.com",
+		ReturnPath:  "bounces@example.com",
+		Destination: model.Destination{ToAddresses: []string{"joe@example.com"}},
+		Subject:     "Receipt",
+		TextBody:    "Total: 42",
+		SentAt:      start.Add(time.Minute),
+	}
+)
+
+func TestParseFormat(t *testing.T) {
+	f, err := mailexport.ParseFormat("MBOX")
+	assert.NoError(t, err)
+	assert.Equal(t, mailexport.FormatMbox, f)
+
+	_, err = mailexport.ParseFormat("pst")
+	assert.Error(t, err)
+}
+
+func TestExport(t *testing.T) {
+	t.Run("mbox", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, mailexport.Export(&buf, mailexport.FormatMbox, []model.CapturedMessage{receipt, welcome}))
+
+		out := buf.String()
+		assert.True(t, strings.HasPrefix(out, "From team@example.com Tue Jan  2 03:04:05 2024\nFrom: Team <team@example.com>\n"), out)
+		assert.Contains(t, out, "\nHello\n>From the team\n>>From the archive\n\nFrom bounces@example.com Tue Jan  2 03:05:05 2024\n")
+		assert.NotContains(t, out, "\r\n")
+		assert.True(t, strings.HasSuffix(out, "Total: 42\n\n"), out)
+	})
+
+	for _, tt := range []struct {
+		format mailexport.Format
+		expect []string
+	}{
+		{format: mailexport.FormatEML, expect: []string{"msg-1.eml", "msg-2.eml"}},
+		{format: mailexport.FormatMaildir, expect: []string{"cur/", "new/", "tmp/", "new/1704164645.msg-1.demtech", "new/1704164705.msg-2.demtech"}},
+	} {
+		t.Run(string(tt.format), func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, mailexport.Export(&buf, tt.format, []model.CapturedMessage{receipt, welcome}))
+
+			zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			require.NoError(t, err)
+			var names []string
+			for _, f := range zr.File {
+				names = append(names, f.Name)
+			}
+			assert.Equal(t, tt.expect, names)
+
+			f, err := zr.Open(tt.expect[len(tt.expect)-1])
+			require.NoError(t, err)
+			content, err := io.ReadAll(f)
+			require.NoError(t, err)
+			assert.Contains(t, string(content), "Subject: Receipt")
+
+			var again bytes.Buffer
+			require.NoError(t, mailexport.Export(&again, tt.format, []model.CapturedMessage{welcome, receipt}))
+			assert.Equal(t, buf.Bytes(), again.Bytes())
+		})
+	}
+}
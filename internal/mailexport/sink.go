@@ -0,0 +1,70 @@
+package mailexport
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/kamal-github/demtech/internal/rawmail"
+)
+
+const sinkMboxFilename = "messages.mbox"
+
+// Sink writes every message it captures into a directory: a .eml file per
+// message, all of them appended to messages.mbox, or delivered to a Maildir.
+type Sink struct {
+	dir    string
+	format Format
+	// mboxMu serializes the appends to the mbox file of this replica.
+	mboxMu *sync.Mutex
+}
+
+// NewSink creates dir, and the cur, new and tmp directories of a Maildir.
+func NewSink(dir string, f Format) (Sink, error) {
+	dirs := []string{dir}
+	if f == FormatMaildir {
+		dirs = []string{filepath.Join(dir, "cur"), filepath.Join(dir, "new"), filepath.Join(dir, "tmp")}
+	}
+	for _, d := range dirs {
+		if err := os.MkdirAll(d, 0o755); err != nil {
+			return Sink{}, err
+		}
+	}
+	return Sink{dir: dir, format: f, mboxMu: &sync.Mutex{}}, nil
+}
+
+// CaptureMessage writes m, files are complete once they show up in the
+// directory.
+func (s Sink) CaptureMessage(ctx context.Context, m model.CapturedMessage) error {
+	switch s.format {
+	case FormatMbox:
+		s.mboxMu.Lock()
+		defer s.mboxMu.Unlock()
+
+		f, err := os.OpenFile(filepath.Join(s.dir, sinkMboxFilename), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+		if err != nil {
+			return err
+		}
+		if _, err := f.Write(mboxMessage(m)); err != nil {
+			f.Close()
+			return err
+		}
+		return f.Close()
+	case FormatMaildir:
+		// Maildir delivery: written to tmp, then moved to new in one step.
+		name := maildirFilename(m)
+		return writeAndRename(filepath.Join(s.dir, "tmp", name), filepath.Join(s.dir, "new", name), toLF(rawmail.Compose(m)))
+	default:
+		path := filepath.Join(s.dir, emlFilename(m))
+		return writeAndRename(path+".tmp", path, rawmail.Compose(m))
+	}
+}
+
+func writeAndRename(tmp, path string, content []byte) error {
+	if err := os.WriteFile(tmp, content, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
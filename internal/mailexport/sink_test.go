@@ -0,0 +1,59 @@
+package mailexport_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kamal-github/demtech/internal/mailexport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSink(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("eml", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "messages")
+		sink, err := mailexport.NewSink(dir, mailexport.FormatEML)
+		require.NoError(t, err)
+		require.NoError(t, sink.CaptureMessage(ctx, welcome))
+
+		content, err := os.ReadFile(filepath.Join(dir, "msg-1.eml"))
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "Subject: Welcome\r\n")
+		entries, _ := os.ReadDir(dir)
+		assert.Len(t, entries, 1)
+	})
+
+	t.Run("mbox", func(t *testing.T) {
+		dir := t.TempDir()
+		sink, err := mailexport.NewSink(dir, mailexport.FormatMbox)
+		require.NoError(t, err)
+		require.NoError(t, sink.CaptureMessage(ctx, welcome))
+		require.NoError(t, sink.CaptureMessage(ctx, receipt))
+
+		content, err := os.ReadFile(filepath.Join(dir, "messages.mbox"))
+		require.NoError(t, err)
+		assert.Equal(t, 2, strings.Count(string(content), "\nSubject: "))
+		assert.True(t, strings.HasPrefix(string(content), "From team@example.com "))
+	})
+
+	t.Run("maildir", func(t *testing.T) {
+		dir := t.TempDir()
+		sink, err := mailexport.NewSink(dir, mailexport.FormatMaildir)
+		require.NoError(t, err)
+		require.NoError(t, sink.CaptureMessage(ctx, welcome))
+
+		content, err := os.ReadFile(filepath.Join(dir, "new", "1704164645.msg-1.demtech"))
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "Subject: Welcome\n")
+		for _, sub := range []string{"cur", "tmp"} {
+			entries, err := os.ReadDir(filepath.Join(dir, sub))
+			assert.NoError(t, err)
+			assert.Empty(t, entries)
+		}
+	})
+}
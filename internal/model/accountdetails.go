@@ -0,0 +1,39 @@
+package model
+
+import "time"
+
+// Mail types and contact languages of the account details
+const (
+	MailTypeMarketing     = "MARKETING"
+	MailTypeTransactional = "TRANSACTIONAL"
+
+	ContactLanguageEN = "EN"
+	ContactLanguageJA = "JA"
+)
+
+// Statuses of the review of a production access request
+const (
+	ReviewStatusPending = "PENDING"
+	ReviewStatusFailed  = "FAILED"
+	ReviewStatusGranted = "GRANTED"
+	ReviewStatusDenied  = "DENIED"
+)
+
+// AccountDetails describe how the account sends email, they are given along
+// with a request for production access.
+type AccountDetails struct {
+	MailType                        string   `json:"MailType"`
+	WebsiteURL                      string   `json:"WebsiteURL"`
+	ContactLanguage                 string   `json:"ContactLanguage,omitempty"`
+	UseCaseDescription              string   `json:"UseCaseDescription,omitempty"`
+	AdditionalContactEmailAddresses []string `json:"AdditionalContactEmailAddresses,omitempty"`
+	// ReviewDetails is the latest production access request, if any.
+	ReviewDetails *ReviewDetails `json:"ReviewDetails,omitempty"`
+}
+
+// ReviewDetails is where the review of a production access request stands.
+type ReviewDetails struct {
+	Status             string    `json:"Status"`
+	CaseId             string    `json:"CaseId"`
+	SubmittedTimestamp time.Time `json:"SubmittedTimestamp"`
+}
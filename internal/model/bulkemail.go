@@ -0,0 +1,46 @@
+package model
+
+// BulkEmailRequest is a SendBulkTemplatedEmail call: one template sent to many
+// destinations, each with its own template data and tags.
+type BulkEmailRequest struct {
+	Source               string                 `json:"Source"`
+	SourceArn            string                 `json:"SourceArn,omitempty"`
+	ReplyToAddresses     []string               `json:"ReplyToAddresses,omitempty"`
+	ReturnPath           string                 `json:"ReturnPath,omitempty"`
+	ReturnPathArn        string                 `json:"ReturnPathArn,omitempty"`
+	ConfigurationSetName string                 `json:"ConfigurationSetName,omitempty"`
+	DefaultTags          []Tag                  `json:"DefaultTags,omitempty"`
+	Template             string                 `json:"Template"`
+	TemplateArn          string                 `json:"TemplateArn,omitempty"`
+	DefaultTemplateData  string                 `json:"DefaultTemplateData,omitempty"`
+	Destinations         []BulkEmailDestination `json:"Destinations"`
+}
+
+type BulkEmailDestination struct {
+	Destination             Destination `json:"Destination"`
+	ReplacementTags         []Tag       `json:"ReplacementTags,omitempty"`
+	ReplacementTemplateData string      `json:"ReplacementTemplateData,omitempty"`
+}
+
+// Statuses of a single destination of a SendBulkTemplatedEmail call
+const (
+	BulkStatusSuccess                       = "Success"
+	BulkStatusMessageRejected               = "MessageRejected"
+	BulkStatusMailFromDomainNotVerified     = "MailFromDomainNotVerified"
+	BulkStatusConfigurationSetDoesNotExist  = "ConfigurationSetDoesNotExist"
+	BulkStatusTemplateDoesNotExist          = "TemplateDoesNotExist"
+	BulkStatusAccountSuspended              = "AccountSuspended"
+	BulkStatusAccountThrottled              = "AccountThrottled"
+	BulkStatusAccountDailyQuotaExceeded     = "AccountDailyQuotaExceeded"
+	BulkStatusAccountSendingPaused          = "AccountSendingPaused"
+	BulkStatusConfigurationSetSendingPaused = "ConfigurationSetSendingPaused"
+	BulkStatusInvalidParameterValue         = "InvalidParameterValue"
+	BulkStatusTransientFailure              = "TransientFailure"
+	BulkStatusFailed                        = "Failed"
+)
+
+type BulkEmailDestinationStatus struct {
+	Status    string `json:"Status"`
+	Error     string `json:"Error,omitempty"`
+	MessageID string `json:"MessageId,omitempty"`
+}
@@ -0,0 +1,53 @@
+package model
+
+import (
+	"regexp"
+	"time"
+)
+
+// CapturedMessage is a message as it was accepted, kept so that it can be
+// inspected since the mock does not deliver it anywhere.
+type CapturedMessage struct {
+	MessageID            string          `json:"MessageId"`
+	Source               string          `json:"Source"`
+	Destination          Destination     `json:"Destination"`
+	ReplyToAddresses     []string        `json:"ReplyToAddresses,omitempty"`
+	ReturnPath           string          `json:"ReturnPath,omitempty"`
+	Subject              string          `json:"Subject"`
+	TextBody             string          `json:"TextBody,omitempty"`
+	HtmlBody             string          `json:"HtmlBody,omitempty"`
+	Headers              []MessageHeader `json:"Headers,omitempty"`
+	Attachments          []Attachment    `json:"Attachments,omitempty"`
+	Tags                 []Tag           `json:"Tags,omitempty"`
+	ConfigurationSetName string          `json:"ConfigurationSetName,omitempty"`
+	Template             string          `json:"Template,omitempty"`
+	TemplateData         string          `json:"TemplateData,omitempty"`
+	// RawMessage is only set for messages sent raw, the fields above are then
+	// what was parsed out of it.
+	RawMessage []byte    `json:"RawMessage,omitempty"`
+	SentAt     time.Time `json:"SentAt"`
+}
+
+// CapturedMessageFilter narrows down the captured messages, zero values match
+// everything. Recipient is the address of one of the recipients, display
+// names aside. Source and Subject match parts of their field, Text any of the
+// addresses, the subject or the bodies, all ignoring case. A Tag without value
+// matches any value.
+type CapturedMessageFilter struct {
+	Recipient            string
+	Source               string
+	Subject              string
+	SubjectPattern       *regexp.Regexp
+	Text                 string
+	Tag                  *Tag
+	ConfigurationSetName string
+	StartDate            time.Time
+	EndDate              time.Time
+}
+
+// Inbox sums up the captured messages of one recipient.
+type Inbox struct {
+	Address      string    `json:"Address"`
+	MessageCount int       `json:"MessageCount"`
+	LatestSentAt time.Time `json:"LatestSentAt"`
+}
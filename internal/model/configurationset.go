@@ -0,0 +1,30 @@
+package model
+
+import "time"
+
+// ConfigurationSet groups the settings applied to the messages sent with it,
+// and the event destinations their events are published to.
+type ConfigurationSet struct {
+	Name             string    `json:"Name"`
+	CreatedTimestamp time.Time `json:"CreatedTimestamp"`
+	// SendingEnabled is false once sending was paused for the configuration set.
+	SendingEnabled           bool                `json:"SendingEnabled"`
+	ReputationMetricsEnabled bool                `json:"ReputationMetricsEnabled"`
+	LastFreshStart           *time.Time          `json:"LastFreshStart,omitempty"`
+	TrackingOptions          *TrackingOptions    `json:"TrackingOptions,omitempty"`
+	SuppressionOptions       *SuppressionOptions `json:"SuppressionOptions,omitempty"`
+	EventDestinations        []EventDestination  `json:"EventDestinations,omitempty"`
+}
+
+// TrackingOptions is the domain the open and click tracking links of a
+// configuration set point to.
+type TrackingOptions struct {
+	CustomRedirectDomain string `json:"CustomRedirectDomain"`
+}
+
+// SuppressionOptions overrides the reasons of the account level suppression
+// list for a configuration set, an empty list turns the suppression off.
+type SuppressionOptions struct {
+	// SuppressedReasons are BOUNCE and/or COMPLAINT.
+	SuppressedReasons []string `json:"SuppressedReasons"`
+}
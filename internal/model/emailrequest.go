@@ -10,6 +10,14 @@ type EmailRequest struct {
 	ReturnPathArn        string      `json:"ReturnPathArn,omitempty"`
 	SourceArn            string      `json:"SourceArn,omitempty"`
 	Tags                 []Tag       `json:"Tags,omitempty"`
+	// ListManagementOptions is only set by SESv2 clients.
+	ListManagementOptions *ListManagementOptions `json:"ListManagementOptions,omitempty"`
+	// RawMessage is only set for SendRawEmail, Message then holds what was parsed out of it.
+	RawMessage *RawMessage `json:"RawMessage,omitempty"`
+	// Template is only set for SendTemplatedEmail, Message is then rendered out of it.
+	Template     string `json:"Template,omitempty"`
+	TemplateArn  string `json:"TemplateArn,omitempty"`
+	TemplateData string `json:"TemplateData,omitempty"`
 }
 
 type Destination struct {
@@ -23,20 +31,48 @@ func (d Destination) All() []string {
 }
 
 type Subject struct {
-	Data string `json:"Data" binding:"required"`
+	Data    string `json:"Data" binding:"required"`
+	Charset string `json:"Charset,omitempty"`
 }
 
 type TextBody struct {
-	Data string `json:"Data" binding:"required"`
+	Data    string `json:"Data" binding:"required"`
+	Charset string `json:"Charset,omitempty"`
+}
+
+type HtmlBody struct {
+	Data    string `json:"Data"`
+	Charset string `json:"Charset,omitempty"`
 }
 
 type Body struct {
-	Text TextBody `json:"Text"`
+	Text TextBody  `json:"Text"`
+	Html *HtmlBody `json:"Html,omitempty"`
 }
 
 type Message struct {
-	Subject Subject `json:"Subject"`
-	Body    Body    `json:"Body"`
+	Subject     Subject         `json:"Subject"`
+	Body        Body            `json:"Body"`
+	Headers     []MessageHeader `json:"Headers,omitempty"`
+	Attachments []Attachment    `json:"-"`
+}
+
+type RawMessage struct {
+	Data []byte `json:"Data"`
+}
+
+// Attachment describes a MIME part of a raw message that is not one of its bodies.
+type Attachment struct {
+	Filename    string
+	ContentType string
+	ContentID   string
+	Inline      bool
+	Size        int
+}
+
+type MessageHeader struct {
+	Name  string `json:"Name"`
+	Value string `json:"Value"`
 }
 
 type Tag struct {
@@ -0,0 +1,45 @@
+package model
+
+// SendEmailV2Request is the body of the SESv2 SendEmail API
+// (POST /v2/email/outbound-emails).
+type SendEmailV2Request struct {
+	FromEmailAddress                          string                 `json:"FromEmailAddress"`
+	FromEmailAddressIdentityArn               string                 `json:"FromEmailAddressIdentityArn,omitempty"`
+	Destination                               Destination            `json:"Destination"`
+	ReplyToAddresses                          []string               `json:"ReplyToAddresses,omitempty"`
+	FeedbackForwardingEmailAddress            string                 `json:"FeedbackForwardingEmailAddress,omitempty"`
+	FeedbackForwardingEmailAddressIdentityArn string                 `json:"FeedbackForwardingEmailAddressIdentityArn,omitempty"`
+	Content                                   EmailContent           `json:"Content"`
+	EmailTags                                 []Tag                  `json:"EmailTags,omitempty"`
+	ConfigurationSetName                      string                 `json:"ConfigurationSetName,omitempty"`
+	ListManagementOptions                     *ListManagementOptions `json:"ListManagementOptions,omitempty"`
+}
+
+// EmailContent holds exactly one of the three content types of SESv2.
+type EmailContent struct {
+	Simple   *SimpleContent   `json:"Simple,omitempty"`
+	Raw      *RawContent      `json:"Raw,omitempty"`
+	Template *TemplateContent `json:"Template,omitempty"`
+}
+
+type SimpleContent struct {
+	Subject Subject         `json:"Subject"`
+	Body    Body            `json:"Body"`
+	Headers []MessageHeader `json:"Headers,omitempty"`
+}
+
+type RawContent struct {
+	Data []byte `json:"Data"`
+}
+
+type TemplateContent struct {
+	TemplateName string          `json:"TemplateName,omitempty"`
+	TemplateArn  string          `json:"TemplateArn,omitempty"`
+	TemplateData string          `json:"TemplateData,omitempty"`
+	Headers      []MessageHeader `json:"Headers,omitempty"`
+}
+
+type ListManagementOptions struct {
+	ContactListName string `json:"ContactListName"`
+	TopicName       string `json:"TopicName,omitempty"`
+}
@@ -5,4 +5,5 @@ type EmailStats struct {
 	SuccessCount    int            `json:"successCount"`
 	TotalErrCount   int            `json:"totalErrCount"`
 	Errors          map[string]int `json:"errors,omitempty"` // typeOfErr -> Count
+	Events          map[string]int `json:"events,omitempty"` // eventType -> Count, for what happened to accepted messages
 }
@@ -0,0 +1,163 @@
+package model
+
+// SES event types, as found in the eventType field of a published event
+const (
+	EventTypeSend             = "Send"
+	EventTypeReject           = "Reject"
+	EventTypeBounce           = "Bounce"
+	EventTypeComplaint        = "Complaint"
+	EventTypeDelivery         = "Delivery"
+	EventTypeOpen             = "Open"
+	EventTypeClick            = "Click"
+	EventTypeRenderingFailure = "Rendering Failure"
+	EventTypeDeliveryDelay    = "DeliveryDelay"
+)
+
+// Event is an SES event record, as published to the event destinations of a
+// configuration set. Only the object matching EventType is set.
+type Event struct {
+	EventType     string              `json:"eventType"`
+	Mail          EventMail           `json:"mail"`
+	Send          *struct{}           `json:"send,omitempty"`
+	Reject        *RejectEvent        `json:"reject,omitempty"`
+	Bounce        *BounceEvent        `json:"bounce,omitempty"`
+	Complaint     *ComplaintEvent     `json:"complaint,omitempty"`
+	Delivery      *DeliveryEvent      `json:"delivery,omitempty"`
+	Open          *OpenEvent          `json:"open,omitempty"`
+	Click         *ClickEvent         `json:"click,omitempty"`
+	Failure       *FailureEvent       `json:"failure,omitempty"`
+	DeliveryDelay *DeliveryDelayEvent `json:"deliveryDelay,omitempty"`
+}
+
+// EventMail describes the message an event is about.
+type EventMail struct {
+	Timestamp        string              `json:"timestamp"`
+	Source           string              `json:"source"`
+	SourceArn        string              `json:"sourceArn,omitempty"`
+	SendingAccountID string              `json:"sendingAccountId"`
+	MessageID        string              `json:"messageId"`
+	Destination      []string            `json:"destination"`
+	HeadersTruncated bool                `json:"headersTruncated"`
+	Headers          []EventHeader       `json:"headers"`
+	CommonHeaders    CommonHeaders       `json:"commonHeaders"`
+	Tags             map[string][]string `json:"tags"`
+}
+
+type EventHeader struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type CommonHeaders struct {
+	From      []string `json:"from"`
+	ReplyTo   []string `json:"replyTo,omitempty"`
+	To        []string `json:"to,omitempty"`
+	Cc        []string `json:"cc,omitempty"`
+	MessageID string   `json:"messageId"`
+	Subject   string   `json:"subject"`
+}
+
+type RejectEvent struct {
+	Reason string `json:"reason"`
+}
+
+type BounceEvent struct {
+	BounceType        string             `json:"bounceType"`
+	BounceSubType     string             `json:"bounceSubType"`
+	BouncedRecipients []BouncedRecipient `json:"bouncedRecipients"`
+	Timestamp         string             `json:"timestamp"`
+	FeedbackID        string             `json:"feedbackId"`
+	ReportingMTA      string             `json:"reportingMTA,omitempty"`
+}
+
+type BouncedRecipient struct {
+	EmailAddress   string `json:"emailAddress"`
+	Action         string `json:"action,omitempty"`
+	Status         string `json:"status,omitempty"`
+	DiagnosticCode string `json:"diagnosticCode,omitempty"`
+}
+
+type ComplaintEvent struct {
+	ComplainedRecipients  []ComplainedRecipient `json:"complainedRecipients"`
+	Timestamp             string                `json:"timestamp"`
+	FeedbackID            string                `json:"feedbackId"`
+	UserAgent             string                `json:"userAgent,omitempty"`
+	ComplaintFeedbackType string                `json:"complaintFeedbackType,omitempty"`
+	ArrivalDate           string                `json:"arrivalDate,omitempty"`
+}
+
+type ComplainedRecipient struct {
+	EmailAddress string `json:"emailAddress"`
+}
+
+type DeliveryEvent struct {
+	Timestamp            string   `json:"timestamp"`
+	ProcessingTimeMillis int64    `json:"processingTimeMillis"`
+	Recipients           []string `json:"recipients"`
+	SMTPResponse         string   `json:"smtpResponse"`
+	ReportingMTA         string   `json:"reportingMTA"`
+}
+
+type OpenEvent struct {
+	IPAddress string `json:"ipAddress"`
+	Timestamp string `json:"timestamp"`
+	UserAgent string `json:"userAgent"`
+}
+
+type ClickEvent struct {
+	IPAddress string              `json:"ipAddress"`
+	Timestamp string              `json:"timestamp"`
+	UserAgent string              `json:"userAgent"`
+	Link      string              `json:"link"`
+	LinkTags  map[string][]string `json:"linkTags,omitempty"`
+}
+
+// FailureEvent is set for Rendering Failure events.
+type FailureEvent struct {
+	TemplateName string `json:"templateName"`
+	ErrorMessage string `json:"errorMessage"`
+}
+
+type DeliveryDelayEvent struct {
+	Timestamp         string             `json:"timestamp"`
+	DelayType         string             `json:"delayType"`
+	ExpirationTime    string             `json:"expirationTime"`
+	DelayedRecipients []DelayedRecipient `json:"delayedRecipients"`
+}
+
+type DelayedRecipient struct {
+	EmailAddress   string `json:"emailAddress"`
+	Status         string `json:"status"`
+	DiagnosticCode string `json:"diagnosticCode"`
+}
+
+// EventDestination tells where the events of a configuration set are
+// published. Instead of Firehose, CloudWatch or SNS, the mock publishes to
+// local destinations or to the SNS topics and SQS queues of the mock.
+type EventDestination struct {
+	Name    string `json:"Name"`
+	Enabled bool   `json:"Enabled"`
+	// MatchingEventTypes as accepted by SES v1 (e.g. "send", "renderingFailure")
+	// or SESv2 (e.g. "SEND", "RENDERING_FAILURE").
+	MatchingEventTypes     []string                `json:"MatchingEventTypes"`
+	WebhookDestination     *WebhookDestination     `json:"WebhookDestination,omitempty"`
+	FileDestination        *FileDestination        `json:"FileDestination,omitempty"`
+	RedisStreamDestination *RedisStreamDestination `json:"RedisStreamDestination,omitempty"`
+	SNSDestination         *SNSDestination         `json:"SNSDestination,omitempty"`
+	SQSDestination         *SQSDestination         `json:"SQSDestination,omitempty"`
+}
+
+// WebhookDestination receives each event as JSON in the body of a POST request.
+type WebhookDestination struct {
+	URL string `json:"URL"`
+}
+
+// FileDestination appends each event as a line of JSON (NDJSON) to a file.
+type FileDestination struct {
+	Path string `json:"Path"`
+}
+
+// RedisStreamDestination adds each event to a Redis stream.
+type RedisStreamDestination struct {
+	Stream string `json:"Stream"`
+}
@@ -0,0 +1,35 @@
+package model
+
+import "time"
+
+// Types of an identity
+const (
+	IdentityTypeEmailAddress = "EmailAddress"
+	IdentityTypeDomain       = "Domain"
+)
+
+// Verification statuses of an identity
+const (
+	VerificationStatusPending          = "Pending"
+	VerificationStatusSuccess          = "Success"
+	VerificationStatusFailed           = "Failed"
+	VerificationStatusTemporaryFailure = "TemporaryFailure"
+	VerificationStatusNotStarted       = "NotStarted"
+)
+
+// Identity is an email address or a domain mail can be sent from once it is verified.
+type Identity struct {
+	Identity                     string    `json:"Identity"`
+	Type                         string    `json:"Type"`
+	VerificationStatus           string    `json:"VerificationStatus"`
+	CreatedTimestamp             time.Time `json:"CreatedTimestamp"`
+	VerificationStartedTimestamp time.Time `json:"VerificationStartedTimestamp"`
+	// VerificationToken is the TXT record value that proves ownership of a domain.
+	VerificationToken string `json:"VerificationToken,omitempty"`
+	// ConfirmationToken is part of the verification link of an email address.
+	ConfirmationToken string `json:"ConfirmationToken,omitempty"`
+	// DkimTokens are published as <token>._domainkey.<domain> CNAME records.
+	DkimEnabled            bool     `json:"DkimEnabled"`
+	DkimVerificationStatus string   `json:"DkimVerificationStatus,omitempty"`
+	DkimTokens             []string `json:"DkimTokens,omitempty"`
+}
@@ -0,0 +1,41 @@
+package model
+
+// Enforcement statuses of an account, as reported by the SESv2 GetAccount
+// operation. PROBATION is the account being under review, SHUTDOWN its
+// sending being paused.
+const (
+	EnforcementStatusHealthy   = "HEALTHY"
+	EnforcementStatusProbation = "PROBATION"
+	EnforcementStatusShutdown  = "SHUTDOWN"
+)
+
+// AccountStatus is whether the account can send, as driven by its reputation.
+type AccountStatus struct {
+	SendingEnabled    bool
+	EnforcementStatus string
+	// BounceRate and ComplaintRate over the reputation window, as of the last
+	// message sent.
+	BounceRate    float64
+	ComplaintRate float64
+}
+
+// ReputationSample counts the recipients a message was sent to, and those of
+// them that hard bounced or complained.
+type ReputationSample struct {
+	MessageID string
+	// ConfigurationSetName is the configuration set the message was sent with, if any.
+	ConfigurationSetName string
+	Sends                int
+	Bounces              int
+	Complaints           int
+}
+
+// ReputationMetrics are the rates of the messages sent with a configuration
+// set over the reputation window, kept while its reputation metrics are
+// enabled.
+type ReputationMetrics struct {
+	ConfigurationSetName string  `json:"ConfigurationSetName"`
+	Sends                int     `json:"Sends"`
+	BounceRate           float64 `json:"BounceRate"`
+	ComplaintRate        float64 `json:"ComplaintRate"`
+}
@@ -0,0 +1,46 @@
+package model
+
+import "time"
+
+// SendQuota is how many recipients an account can send to in a rolling 24
+// hours window, and per second.
+type SendQuota struct {
+	Max24HourSend int64
+	MaxSendRate   float64
+	// SentLast24Hours is only set when the quota is reported to clients.
+	SentLast24Hours int64
+}
+
+// SendDataPoint counts what happened to the messages sent during a 15 minutes
+// interval, starting at Timestamp.
+type SendDataPoint struct {
+	Timestamp        time.Time
+	DeliveryAttempts int64
+	Bounces          int64
+	Complaints       int64
+	Rejects          int64
+}
+
+// Account is what the SESv2 GetAccount operation reports about the account.
+type Account struct {
+	SendQuota               SendQuota
+	SendingEnabled          bool
+	EnforcementStatus       string
+	ProductionAccessEnabled bool
+	SuppressedReasons       []string
+	// Details are nil until they are given.
+	Details *AccountDetails
+}
+
+// WarmUpState is how far an account got in its warm-up schedule.
+type WarmUpState struct {
+	// Stage is the index of the current SendQuota in the schedule.
+	Stage     int
+	StartedAt time.Time
+	// Day is the last simulated day the account was evaluated on, the counts
+	// below being the stats at that time.
+	Day        int
+	Sends      int
+	Bounces    int
+	Complaints int
+}
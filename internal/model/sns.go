@@ -0,0 +1,49 @@
+package model
+
+// SNS message types, as found in the Type field of an SNS message
+const (
+	SNSTypeNotification             = "Notification"
+	SNSTypeSubscriptionConfirmation = "SubscriptionConfirmation"
+	SNSTypeUnsubscribeConfirmation  = "UnsubscribeConfirmation"
+)
+
+// Topic is an SNS topic SES events can be published to.
+type Topic struct {
+	TopicArn string `json:"TopicArn"`
+	Name     string `json:"Name"`
+	// SignatureVersion of the messages of the topic, "1" (SHA1) or "2" (SHA256).
+	SignatureVersion string `json:"SignatureVersion"`
+}
+
+// Subscription of an HTTP(S) endpoint to a topic. It only receives
+// notifications once confirmed.
+type Subscription struct {
+	SubscriptionArn string `json:"SubscriptionArn"`
+	TopicArn        string `json:"TopicArn"`
+	Protocol        string `json:"Protocol"`
+	Endpoint        string `json:"Endpoint"`
+	Confirmed       bool   `json:"Confirmed"`
+	// Token to pass to ConfirmSubscription, as sent in the SubscriptionConfirmation message.
+	Token string `json:"Token"`
+}
+
+// SNSMessage is the JSON document SNS posts to HTTP(S) subscribers.
+type SNSMessage struct {
+	Type             string `json:"Type"`
+	MessageID        string `json:"MessageId"`
+	Token            string `json:"Token,omitempty"`
+	TopicArn         string `json:"TopicArn"`
+	Subject          string `json:"Subject,omitempty"`
+	Message          string `json:"Message"`
+	SubscribeURL     string `json:"SubscribeURL,omitempty"`
+	Timestamp        string `json:"Timestamp"`
+	SignatureVersion string `json:"SignatureVersion"`
+	Signature        string `json:"Signature"`
+	SigningCertURL   string `json:"SigningCertURL"`
+	UnsubscribeURL   string `json:"UnsubscribeURL,omitempty"`
+}
+
+// SNSDestination publishes the events of a configuration set to an SNS topic.
+type SNSDestination struct {
+	TopicARN string `json:"TopicARN"`
+}
@@ -0,0 +1,48 @@
+package model
+
+// Queue is an SQS queue of the mock, with its configurable attributes in seconds
+// or bytes like SQS does.
+type Queue struct {
+	Name                          string `json:"Name"`
+	CreatedTimestamp              int64  `json:"CreatedTimestamp"`
+	LastModifiedTimestamp         int64  `json:"LastModifiedTimestamp"`
+	VisibilityTimeout             int    `json:"VisibilityTimeout"`
+	DelaySeconds                  int    `json:"DelaySeconds"`
+	ReceiveMessageWaitTimeSeconds int    `json:"ReceiveMessageWaitTimeSeconds"`
+	MessageRetentionPeriod        int    `json:"MessageRetentionPeriod"`
+	MaximumMessageSize            int    `json:"MaximumMessageSize"`
+}
+
+// QueueMessage is a message of an SQS queue. Timestamps are epoch milliseconds.
+type QueueMessage struct {
+	MessageID     string `json:"MessageId"`
+	Body          string `json:"Body"`
+	MD5OfBody     string `json:"MD5OfBody"`
+	SentTimestamp int64  `json:"SentTimestamp"`
+	// ReceiptHandle, ReceiveCount and FirstReceiveTimestamp are set when the message is received.
+	ReceiptHandle         string `json:"-"`
+	ReceiveCount          int    `json:"-"`
+	FirstReceiveTimestamp int64  `json:"-"`
+}
+
+// QueueCounts are the approximate number of messages of a queue by state.
+type QueueCounts struct {
+	Visible    int64
+	NotVisible int64
+	Delayed    int64
+}
+
+// SQSDestination sends the events of a configuration set to an SQS queue of
+// the mock, identified by its URL or ARN.
+type SQSDestination struct {
+	QueueURL string `json:"QueueURL"`
+}
+
+// ReceiveMessageRequest is a ReceiveMessage call, the optional settings fall
+// back to the attributes of the queue when nil.
+type ReceiveMessageRequest struct {
+	QueueURL            string
+	MaxNumberOfMessages int
+	VisibilityTimeout   *int
+	WaitTimeSeconds     *int
+}
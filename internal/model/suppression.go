@@ -0,0 +1,60 @@
+package model
+
+import (
+	"strings"
+	"time"
+)
+
+// Reasons an email address is on the suppression list
+const (
+	SuppressionReasonBounce    = "BOUNCE"
+	SuppressionReasonComplaint = "COMPLAINT"
+)
+
+// SuppressedDestination is an email address of the account level suppression
+// list, which SES does not send to anymore.
+type SuppressedDestination struct {
+	EmailAddress   string    `json:"EmailAddress"`
+	Reason         string    `json:"Reason"`
+	LastUpdateTime time.Time `json:"LastUpdateTime"`
+	// Attributes are set when the address was added because of a bounce or a complaint.
+	Attributes *SuppressedDestinationAttributes `json:"Attributes,omitempty"`
+}
+
+// SuppressedDestinationAttributes tell which message got an address suppressed.
+type SuppressedDestinationAttributes struct {
+	MessageID  string `json:"MessageId,omitempty"`
+	FeedbackID string `json:"FeedbackId,omitempty"`
+}
+
+// SuppressionListFilter narrows down ListSuppressedDestinations, zero values
+// match everything.
+type SuppressionListFilter struct {
+	Reasons   []string
+	StartDate time.Time
+	EndDate   time.Time
+}
+
+// SuppressedRecipients is returned by the suppression list validator. Unlike
+// other validation errors it does not fail a send, the recipients are bounced
+// instead of getting the message delivered.
+type SuppressedRecipients struct {
+	Addresses []string
+}
+
+func (e *SuppressedRecipients) Error() string {
+	return "recipients on the suppression list: " + strings.Join(e.Addresses, ", ")
+}
+
+// Contains reports whether address is one of the suppressed recipients.
+func (e *SuppressedRecipients) Contains(address string) bool {
+	if e == nil {
+		return false
+	}
+	for _, a := range e.Addresses {
+		if a == address {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+// Template is an SES email template, rendered with Handlebars.
+type Template struct {
+	TemplateName string `json:"TemplateName"`
+	SubjectPart  string `json:"SubjectPart,omitempty"`
+	TextPart     string `json:"TextPart,omitempty"`
+	HtmlPart     string `json:"HtmlPart,omitempty"`
+}
+
+type TemplateMetadata struct {
+	Name             string    `json:"Name"`
+	CreatedTimestamp time.Time `json:"CreatedTimestamp"`
+}
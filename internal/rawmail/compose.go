@@ -0,0 +1,83 @@
+package rawmail
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/textproto"
+	"strings"
+	"time"
+
+	"github.com/kamal-github/demtech/internal/model"
+)
+
+// Compose renders a captured message that was not sent raw as the MIME
+// message SES would have delivered, which leaves the Bcc recipients out. The
+// output only depends on the message, so that it can be diffed. Messages sent
+// raw are returned as they were.
+func Compose(m model.CapturedMessage) []byte {
+	if m.RawMessage != nil {
+		return m.RawMessage
+	}
+
+	var buf bytes.Buffer
+	set := make(map[string]struct{})
+	writeHeader := func(name, value string) {
+		if value == "" {
+			return
+		}
+		set[strings.ToLower(name)] = struct{}{}
+		fmt.Fprintf(&buf, "%s: %s\r\n", name, value)
+	}
+
+	writeHeader("From", m.Source)
+	writeHeader("To", strings.Join(m.Destination.ToAddresses, ", "))
+	writeHeader("Cc", strings.Join(m.Destination.CcAddresses, ", "))
+	writeHeader("Reply-To", strings.Join(m.ReplyToAddresses, ", "))
+	writeHeader("Subject", mime.QEncoding.Encode("UTF-8", m.Subject))
+	writeHeader("Date", m.SentAt.Format(time.RFC1123Z))
+	writeHeader("Message-ID", "<"+m.MessageID+"@email.amazonses.com>")
+	writeHeader("MIME-Version", "1.0")
+	for _, h := range m.Headers {
+		if _, ok := set[strings.ToLower(h.Name)]; !ok {
+			fmt.Fprintf(&buf, "%s: %s\r\n", h.Name, h.Value)
+		}
+	}
+
+	switch {
+	case m.TextBody != "" && m.HtmlBody != "":
+		mw := multipart.NewWriter(&buf)
+		// A valid boundary of 64 hex digits, it cannot fail.
+		mw.SetBoundary(fmt.Sprintf("%x", sha256.Sum256([]byte(m.MessageID))))
+		fmt.Fprintf(&buf, "Content-Type: multipart/alternative; boundary=%s\r\n\r\n", mw.Boundary())
+		writePart(mw, "text/plain", m.TextBody)
+		writePart(mw, "text/html", m.HtmlBody)
+		mw.Close()
+	case m.HtmlBody != "":
+		writeBody(&buf, "text/html", m.HtmlBody)
+	default:
+		writeBody(&buf, "text/plain", m.TextBody)
+	}
+	return buf.Bytes()
+}
+
+func writePart(mw *multipart.Writer, mediaType, content string) {
+	// Writes to a bytes.Buffer do not fail.
+	w, _ := mw.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {mediaType + "; charset=UTF-8"},
+		"Content-Transfer-Encoding": {"quoted-printable"},
+	})
+	qw := quotedprintable.NewWriter(w)
+	qw.Write([]byte(content))
+	qw.Close()
+}
+
+func writeBody(buf *bytes.Buffer, mediaType, content string) {
+	fmt.Fprintf(buf, "Content-Type: %s; charset=UTF-8\r\nContent-Transfer-Encoding: quoted-printable\r\n\r\n", mediaType)
+	qw := quotedprintable.NewWriter(buf)
+	qw.Write([]byte(content))
+	qw.Close()
+}
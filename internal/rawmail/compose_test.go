@@ -0,0 +1,82 @@
+package rawmail_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/kamal-github/demtech/internal/rawmail"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompose(t *testing.T) {
+	m := model.CapturedMessage{
+		MessageID: "msg-1",
+		Source:    "team@example.com",
+		Destination: model.Destination{
+			ToAddresses:  []string{"to@example.com"},
+			CcAddresses:  []string{"cc@example.com"},
+			BccAddresses: []string{"bcc@example.com"},
+		},
+		ReplyToAddresses: []string{"reply@example.com"},
+		Subject:          "Grüße",
+		TextBody:         "Hello = World",
+		HtmlBody:         "<p>Hello World</p>",
+		Headers:          []model.MessageHeader{{Name: "X-Campaign", Value: "welcome"}, {Name: "Subject", Value: "Ignored"}},
+		SentAt:           time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name      string
+		message   func() model.CapturedMessage
+		assertReq func(*assert.Assertions, model.EmailRequest)
+	}{
+		{
+			name:    "Text and HTML bodies",
+			message: func() model.CapturedMessage { return m },
+			assertReq: func(assert *assert.Assertions, req model.EmailRequest) {
+				assert.Equal("team@example.com", req.Source)
+				assert.Equal(model.Destination{ToAddresses: []string{"to@example.com"}, CcAddresses: []string{"cc@example.com"}}, req.Destination)
+				assert.Equal([]string{"reply@example.com"}, req.ReplyToAddresses)
+				assert.Equal("Grüße", req.Message.Subject.Data)
+				assert.Equal("Hello = World", req.Message.Body.Text.Data)
+				assert.Equal("<p>Hello World</p>", req.Message.Body.Html.Data)
+				assert.Contains(req.Message.Headers, model.MessageHeader{Name: "X-Campaign", Value: "welcome"})
+				assert.Contains(req.Message.Headers, model.MessageHeader{Name: "Message-Id", Value: "<msg-1@email.amazonses.com>"})
+				assert.Contains(req.Message.Headers, model.MessageHeader{Name: "Date", Value: "Tue, 02 Jan 2024 03:04:05 +0000"})
+				assert.NotContains(req.Message.Headers, model.MessageHeader{Name: "Subject", Value: "Ignored"})
+			},
+		},
+		{
+			name: "HTML body only",
+			message: func() model.CapturedMessage {
+				m := m
+				m.TextBody = ""
+				return m
+			},
+			assertReq: func(assert *assert.Assertions, req model.EmailRequest) {
+				assert.Empty(req.Message.Body.Text.Data)
+				assert.Equal("<p>Hello World</p>", req.Message.Body.Html.Data)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			req, err := rawmail.NewEmailRequest(rawmail.Compose(tt.message()), "", nil)
+			assert.NoError(err)
+			tt.assertReq(assert, req)
+		})
+	}
+
+	t.Run("Deterministic", func(t *testing.T) {
+		assert.Equal(t, rawmail.Compose(m), rawmail.Compose(m))
+	})
+
+	t.Run("Raw message", func(t *testing.T) {
+		raw := model.CapturedMessage{RawMessage: []byte(multipartMessage)}
+		assert.Equal(t, []byte(multipartMessage), rawmail.Compose(raw))
+	})
+}
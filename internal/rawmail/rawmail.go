@@ -0,0 +1,240 @@
+// Package rawmail parses the raw MIME messages accepted by SendRawEmail into
+// the EmailRequest model the validators work on, and composes the MIME message
+// of the ones that were not sent raw.
+package rawmail
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"sort"
+	"strings"
+
+	"github.com/kamal-github/demtech/internal/model"
+)
+
+var wordDecoder = mime.WordDecoder{}
+
+// NewEmailRequest parses a raw MIME message and builds the EmailRequest it
+// represents. Recipients from the To/Cc/Bcc headers are merged with the
+// explicit destinations; destinations missing from the headers are treated as
+// Bcc recipients. source, when given, takes precedence over the From header as
+// sending identity.
+func NewEmailRequest(data []byte, source string, destinations []string) (model.EmailRequest, error) {
+	msg, err := mail.ReadMessage(bytes.NewReader(data))
+	if err != nil {
+		return model.EmailRequest{}, invalidParameter("Unable to parse the raw message: " + err.Error())
+	}
+
+	if msg.Header.Get("From") == "" {
+		return model.EmailRequest{}, invalidParameter("Missing required header 'From'.")
+	}
+	from, err := mail.ParseAddress(msg.Header.Get("From"))
+	if err != nil {
+		return model.EmailRequest{}, invalidParameter("Invalid 'From' header: " + err.Error())
+	}
+
+	to, err := addressList(msg.Header, "To")
+	if err != nil {
+		return model.EmailRequest{}, err
+	}
+	cc, err := addressList(msg.Header, "Cc")
+	if err != nil {
+		return model.EmailRequest{}, err
+	}
+	bcc, err := addressList(msg.Header, "Bcc")
+	if err != nil {
+		return model.EmailRequest{}, err
+	}
+	replyTo, err := addressList(msg.Header, "Reply-To")
+	if err != nil {
+		return model.EmailRequest{}, err
+	}
+
+	dest := model.Destination{ToAddresses: to, CcAddresses: cc, BccAddresses: bcc}
+	inHeaders := make(map[string]struct{})
+	for _, d := range dest.All() {
+		inHeaders[strings.ToLower(d)] = struct{}{}
+	}
+	for _, d := range destinations {
+		if _, ok := inHeaders[strings.ToLower(d)]; !ok {
+			dest.BccAddresses = append(dest.BccAddresses, d)
+			inHeaders[strings.ToLower(d)] = struct{}{}
+		}
+	}
+
+	if source == "" {
+		source = from.Address
+	}
+
+	subject, err := wordDecoder.DecodeHeader(msg.Header.Get("Subject"))
+	if err != nil {
+		subject = msg.Header.Get("Subject")
+	}
+
+	message := model.Message{
+		Subject: model.Subject{Data: subject},
+		Headers: headers(msg.Header),
+	}
+	if err := walkPart(msg.Header, msg.Body, &message, nil); err != nil {
+		return model.EmailRequest{}, err
+	}
+
+	returnPath := ""
+	if rp, err := mail.ParseAddress(msg.Header.Get("Return-Path")); err == nil {
+		returnPath = rp.Address
+	}
+
+	return model.EmailRequest{
+		Source:           source,
+		Destination:      dest,
+		Message:          message,
+		ReplyToAddresses: replyTo,
+		ReturnPath:       returnPath,
+		RawMessage:       &model.RawMessage{Data: data},
+	}, nil
+}
+
+// AttachmentContents returns the decoded content of the attachments of a raw
+// message, in the order of the Attachments NewEmailRequest lists.
+func AttachmentContents(data []byte) ([][]byte, error) {
+	msg, err := mail.ReadMessage(bytes.NewReader(data))
+	if err != nil {
+		return nil, invalidParameter("Unable to parse the raw message: " + err.Error())
+	}
+
+	var contents [][]byte
+	if err := walkPart(msg.Header, msg.Body, &model.Message{}, &contents); err != nil {
+		return nil, err
+	}
+	return contents, nil
+}
+
+func addressList(h mail.Header, key string) ([]string, error) {
+	if h.Get(key) == "" {
+		return nil, nil
+	}
+
+	list, err := h.AddressList(key)
+	if err != nil {
+		return nil, invalidParameter("Invalid '" + key + "' header: " + err.Error())
+	}
+
+	addresses := make([]string, 0, len(list))
+	for _, a := range list {
+		addresses = append(addresses, a.Address)
+	}
+	return addresses, nil
+}
+
+// headers flattens the top level headers in a stable order.
+func headers(h mail.Header) []model.MessageHeader {
+	keys := make([]string, 0, len(h))
+	for k := range h {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var list []model.MessageHeader
+	for _, k := range keys {
+		for _, v := range h[k] {
+			list = append(list, model.MessageHeader{Name: k, Value: v})
+		}
+	}
+	return list
+}
+
+// partHeader is satisfied by both mail.Header and textproto.MIMEHeader.
+type partHeader interface {
+	Get(key string) string
+}
+
+// walkPart descends into (nested) multipart bodies and collects the first text
+// and html part as well as every attachment, and their content when contents
+// is not nil.
+func walkPart(h partHeader, body io.Reader, message *model.Message, contents *[][]byte) error {
+	contentType := h.Get("Content-Type")
+	if contentType == "" {
+		contentType = "text/plain"
+	}
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return invalidParameter("Invalid Content-Type: " + err.Error())
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		if params["boundary"] == "" {
+			return invalidParameter("Missing multipart boundary.")
+		}
+		mr := multipart.NewReader(body, params["boundary"])
+		for {
+			p, err := mr.NextRawPart()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return invalidParameter("Unable to parse the raw message: " + err.Error())
+			}
+			if err := walkPart(p.Header, p, message, contents); err != nil {
+				return err
+			}
+		}
+	}
+
+	content, err := decodeBody(h.Get("Content-Transfer-Encoding"), body)
+	if err != nil {
+		return err
+	}
+
+	disposition, dispParams, _ := mime.ParseMediaType(h.Get("Content-Disposition"))
+	filename := dispParams["filename"]
+	if filename == "" {
+		filename = params["name"]
+	}
+	if filename, err = wordDecoder.DecodeHeader(filename); err != nil {
+		return invalidParameter("Invalid attachment filename: " + err.Error())
+	}
+
+	switch {
+	case disposition == "attachment" || filename != "":
+		message.Attachments = append(message.Attachments, model.Attachment{
+			Filename:    filename,
+			ContentType: mediaType,
+			ContentID:   strings.Trim(h.Get("Content-ID"), "<>"),
+			Inline:      disposition == "inline",
+			Size:        len(content),
+		})
+		if contents != nil {
+			*contents = append(*contents, content)
+		}
+	case mediaType == "text/plain" && message.Body.Text.Data == "":
+		message.Body.Text = model.TextBody{Data: string(content), Charset: params["charset"]}
+	case mediaType == "text/html" && message.Body.Html == nil:
+		message.Body.Html = &model.HtmlBody{Data: string(content), Charset: params["charset"]}
+	}
+
+	return nil
+}
+
+func decodeBody(encoding string, body io.Reader) ([]byte, error) {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "base64":
+		body = base64.NewDecoder(base64.StdEncoding, body)
+	case "quoted-printable":
+		body = quotedprintable.NewReader(body)
+	}
+
+	content, err := io.ReadAll(body)
+	if err != nil {
+		return nil, invalidParameter("Unable to decode message part: " + err.Error())
+	}
+	return content, nil
+}
+
+func invalidParameter(msg string) *model.SESError {
+	return &model.SESError{Code: "InvalidParameterValue", Message: msg}
+}
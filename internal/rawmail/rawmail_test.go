@@ -0,0 +1,126 @@
+package rawmail_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/kamal-github/demtech/internal/rawmail"
+	"github.com/stretchr/testify/assert"
+)
+
+const multipartMessage = "From: Team <team@example.com>\r\n" +
+	"To: to@example.com\r\n" +
+	"Cc: cc.1@example.com, \"Cc Two\" <cc.2@example.com>\r\n" +
+	"Subject: =?UTF-8?B?SGVsbG8gV29ybGQ=?=\r\n" +
+	"Reply-To: reply@example.com\r\n" +
+	"MIME-Version: 1.0\r\n" +
+	"Content-Type: multipart/mixed; boundary=outer\r\n" +
+	"\r\n" +
+	"--outer\r\n" +
+	"Content-Type: multipart/alternative; boundary=inner\r\n" +
+	"\r\n" +
+	"--inner\r\n" +
+	"Content-Type: text/plain; charset=UTF-8\r\n" +
+	"Content-Transfer-Encoding: quoted-printable\r\n" +
+	"\r\n" +
+	"Hello =3D World\r\n" +
+	"--inner\r\n" +
+	"Content-Type: text/html; charset=UTF-8\r\n" +
+	"\r\n" +
+	"<p>Hello World</p>\r\n" +
+	"--inner--\r\n" +
+	"--outer\r\n" +
+	"Content-Type: application/pdf; name=\"report.pdf\"\r\n" +
+	"Content-Disposition: attachment; filename=\"report.pdf\"\r\n" +
+	"Content-Transfer-Encoding: base64\r\n" +
+	"\r\n" +
+	"JVBERi0x\r\n" +
+	"LjQK\r\n" +
+	"--outer--\r\n"
+
+func TestNewEmailRequest(t *testing.T) {
+	tests := []struct {
+		name         string
+		raw          string
+		source       string
+		destinations []string
+		expectErr    string
+		assertReq    func(*assert.Assertions, model.EmailRequest)
+	}{
+		{
+			name:         "Multipart message with attachment",
+			raw:          multipartMessage,
+			destinations: []string{"to@example.com", "hidden@example.com"},
+			assertReq: func(assert *assert.Assertions, req model.EmailRequest) {
+				assert.Equal("team@example.com", req.Source)
+				assert.Equal([]string{"to@example.com"}, req.Destination.ToAddresses)
+				assert.Equal([]string{"cc.1@example.com", "cc.2@example.com"}, req.Destination.CcAddresses)
+				assert.Equal([]string{"hidden@example.com"}, req.Destination.BccAddresses)
+				assert.Equal([]string{"reply@example.com"}, req.ReplyToAddresses)
+				assert.Equal("Hello World", req.Message.Subject.Data)
+				assert.Equal("Hello = World", req.Message.Body.Text.Data)
+				assert.Equal("UTF-8", req.Message.Body.Text.Charset)
+				assert.Equal("<p>Hello World</p>", req.Message.Body.Html.Data)
+				assert.Equal([]model.Attachment{{Filename: "report.pdf", ContentType: "application/pdf", Size: 9}}, req.Message.Attachments)
+				assert.Equal([]byte(multipartMessage), req.RawMessage.Data)
+			},
+		},
+		{
+			name:   "Explicit source takes precedence over From",
+			raw:    "From: team@example.com\r\nTo: to@example.com\r\n\r\nHello",
+			source: "bounces@example.com",
+			assertReq: func(assert *assert.Assertions, req model.EmailRequest) {
+				assert.Equal("bounces@example.com", req.Source)
+				assert.Equal("Hello", req.Message.Body.Text.Data)
+			},
+		},
+		{
+			name:      "Missing From header",
+			raw:       "To: to@example.com\r\nSubject: Hi\r\n\r\nHello",
+			expectErr: "Missing required header 'From'.",
+		},
+		{
+			name:      "Malformed headers",
+			raw:       "this is not a mime message",
+			expectErr: "Unable to parse the raw message",
+		},
+		{
+			name:      "Malformed recipient header",
+			raw:       "From: team@example.com\r\nTo: not an address\r\n\r\nHello",
+			expectErr: "Invalid 'To' header",
+		},
+		{
+			name:      "Multipart without boundary",
+			raw:       "From: team@example.com\r\nTo: to@example.com\r\nContent-Type: multipart/mixed\r\n\r\nHello",
+			expectErr: "Missing multipart boundary.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			req, err := rawmail.NewEmailRequest([]byte(tt.raw), tt.source, tt.destinations)
+
+			if tt.expectErr != "" {
+				assert.Error(err)
+				assert.IsType(&model.SESError{}, err)
+				assert.Equal("InvalidParameterValue", err.(*model.SESError).Code)
+				assert.True(strings.HasPrefix(err.(*model.SESError).Message, tt.expectErr), err.Error())
+				return
+			}
+			assert.NoError(err)
+			tt.assertReq(assert, req)
+		})
+	}
+}
+
+func TestAttachmentContents(t *testing.T) {
+	contents, err := rawmail.AttachmentContents([]byte(multipartMessage))
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("%PDF-1.4\n")}, contents)
+
+	_, err = rawmail.AttachmentContents([]byte("this is not a mime message"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,47 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/redis/go-redis/v9"
+)
+
+const accountDetailsStorageKey = "account-details"
+
+// AccountDetailsRepoImpl stores the account details as a JSON string.
+type AccountDetailsRepoImpl struct {
+	redisClient *redis.Client
+}
+
+func NewAccountDetailsRepo(c *redis.Client) AccountDetailsRepoImpl {
+	return AccountDetailsRepoImpl{redisClient: c}
+}
+
+// GetAccountDetails returns the account details or ErrNotFound
+func (r AccountDetailsRepoImpl) GetAccountDetails(ctx context.Context) (model.AccountDetails, error) {
+	data, err := r.redisClient.Get(ctx, accountDetailsStorageKey).Result()
+	if errors.Is(err, redis.Nil) {
+		return model.AccountDetails{}, ErrNotFound
+	}
+	if err != nil {
+		return model.AccountDetails{}, err
+	}
+
+	var details model.AccountDetails
+	if err := json.Unmarshal([]byte(data), &details); err != nil {
+		return model.AccountDetails{}, err
+	}
+	return details, nil
+}
+
+// PutAccountDetails creates or replaces the account details
+func (r AccountDetailsRepoImpl) PutAccountDetails(ctx context.Context, details model.AccountDetails) error {
+	data, err := json.Marshal(details)
+	if err != nil {
+		return err
+	}
+	return r.redisClient.Set(ctx, accountDetailsStorageKey, data, 0).Err()
+}
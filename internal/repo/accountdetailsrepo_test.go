@@ -0,0 +1,48 @@
+//go:build integration
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/kamal-github/demtech/internal/repo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccountDetailsRepoImpl_Integration(t *testing.T) {
+	redisClient := setupRedisClient()
+	defer redisClient.Close()
+
+	ctx := context.Background()
+	defer redisClient.FlushDB(ctx)
+
+	accountDetailsRepo := repo.NewAccountDetailsRepo(redisClient)
+
+	_, err := accountDetailsRepo.GetAccountDetails(ctx)
+	assert.ErrorIs(t, err, repo.ErrNotFound)
+
+	details := model.AccountDetails{
+		MailType:                        model.MailTypeTransactional,
+		WebsiteURL:                      "https://example.com",
+		AdditionalContactEmailAddresses: []string{"ops@example.com"},
+		ReviewDetails: &model.ReviewDetails{
+			Status:             model.ReviewStatusPending,
+			CaseId:             "case-1",
+			SubmittedTimestamp: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		},
+	}
+	assert.NoError(t, accountDetailsRepo.PutAccountDetails(ctx, details))
+
+	got, err := accountDetailsRepo.GetAccountDetails(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, details, got)
+
+	details.ReviewDetails.Status = model.ReviewStatusGranted
+	assert.NoError(t, accountDetailsRepo.PutAccountDetails(ctx, details))
+	got, err = accountDetailsRepo.GetAccountDetails(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, model.ReviewStatusGranted, got.ReviewDetails.Status)
+}
@@ -0,0 +1,164 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	capturedMessagesStorageKey       = "captured-messages"
+	capturedMessagesByTimeStorageKey = "captured-messages-by-time"
+	capturedMessagesChannel          = "captured-messages"
+)
+
+// CapturedMessageRepoImpl stores the captured messages in a Redis hash keyed
+// by message ID, along with a sorted set of their IDs scored by the time they
+// were sent at, in milliseconds. The ID of every new message is published on a
+// channel, so that all the replicas can wait for it.
+type CapturedMessageRepoImpl struct {
+	redisClient *redis.Client
+}
+
+func NewCapturedMessageRepo(c *redis.Client) CapturedMessageRepoImpl {
+	return CapturedMessageRepoImpl{redisClient: c}
+}
+
+// AddCapturedMessage stores a message, replacing one with the same ID, and
+// publishes its ID
+func (r CapturedMessageRepoImpl) AddCapturedMessage(ctx context.Context, m model.CapturedMessage) error {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.redisClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, capturedMessagesStorageKey, m.MessageID, data)
+		pipe.ZAdd(ctx, capturedMessagesByTimeStorageKey, redis.Z{Score: float64(m.SentAt.UnixMilli()), Member: m.MessageID})
+		pipe.Publish(ctx, capturedMessagesChannel, m.MessageID)
+		return nil
+	})
+	return err
+}
+
+// SubscribeCapturedMessages returns the IDs of the messages captured from now
+// on, by any replica, until unsubscribe is called
+func (r CapturedMessageRepoImpl) SubscribeCapturedMessages(ctx context.Context) (ids <-chan string, unsubscribe func() error, err error) {
+	pubsub := r.redisClient.Subscribe(ctx, capturedMessagesChannel)
+	// Once the subscription is confirmed no message can be missed.
+	if _, err := pubsub.Receive(ctx); err != nil {
+		pubsub.Close()
+		return nil, nil, err
+	}
+
+	ch := make(chan string)
+	done := make(chan struct{})
+	go func() {
+		defer close(ch)
+		for msg := range pubsub.Channel() {
+			select {
+			case ch <- msg.Payload:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return ch, func() error {
+		close(done)
+		return pubsub.Close()
+	}, nil
+}
+
+// GetCapturedMessage returns a message or ErrNotFound
+func (r CapturedMessageRepoImpl) GetCapturedMessage(ctx context.Context, messageID string) (model.CapturedMessage, error) {
+	data, err := r.redisClient.HGet(ctx, capturedMessagesStorageKey, messageID).Result()
+	if errors.Is(err, redis.Nil) {
+		return model.CapturedMessage{}, ErrNotFound
+	}
+	if err != nil {
+		return model.CapturedMessage{}, err
+	}
+
+	var m model.CapturedMessage
+	if err := json.Unmarshal([]byte(data), &m); err != nil {
+		return model.CapturedMessage{}, err
+	}
+	return m, nil
+}
+
+// ListCapturedMessages returns the messages sent between since and until,
+// newest first. Zero times leave the range open.
+func (r CapturedMessageRepoImpl) ListCapturedMessages(ctx context.Context, since, until time.Time) ([]model.CapturedMessage, error) {
+	rng := &redis.ZRangeBy{Min: "-inf", Max: "+inf"}
+	if !since.IsZero() {
+		rng.Min = strconv.FormatInt(since.UnixMilli(), 10)
+	}
+	if !until.IsZero() {
+		rng.Max = strconv.FormatInt(until.UnixMilli(), 10)
+	}
+
+	ids, err := r.redisClient.ZRevRangeByScore(ctx, capturedMessagesByTimeStorageKey, rng).Result()
+	if err != nil || len(ids) == 0 {
+		return nil, err
+	}
+
+	all, err := r.redisClient.HMGet(ctx, capturedMessagesStorageKey, ids...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]model.CapturedMessage, 0, len(all))
+	for _, data := range all {
+		// Messages deleted in the meantime.
+		s, ok := data.(string)
+		if !ok {
+			continue
+		}
+		var m model.CapturedMessage
+		if err := json.Unmarshal([]byte(s), &m); err != nil {
+			return nil, err
+		}
+		list = append(list, m)
+	}
+	return list, nil
+}
+
+// DeleteCapturedMessages removes the given messages and returns how many of
+// them existed
+func (r CapturedMessageRepoImpl) DeleteCapturedMessages(ctx context.Context, messageIDs ...string) (int64, error) {
+	if len(messageIDs) == 0 {
+		return 0, nil
+	}
+
+	members := make([]any, len(messageIDs))
+	for i, id := range messageIDs {
+		members[i] = id
+	}
+
+	var deleted *redis.IntCmd
+	_, err := r.redisClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		deleted = pipe.HDel(ctx, capturedMessagesStorageKey, messageIDs...)
+		pipe.ZRem(ctx, capturedMessagesByTimeStorageKey, members...)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return deleted.Val(), nil
+}
+
+// TrimCapturedMessages removes the oldest messages beyond the newest
+// maxMessages and returns how many there were
+func (r CapturedMessageRepoImpl) TrimCapturedMessages(ctx context.Context, maxMessages int) (int64, error) {
+	ids, err := r.redisClient.ZRange(ctx, capturedMessagesByTimeStorageKey, 0, int64(-maxMessages-1)).Result()
+	if err != nil || len(ids) == 0 {
+		return 0, err
+	}
+	return r.DeleteCapturedMessages(ctx, ids...)
+}
@@ -0,0 +1,89 @@
+//go:build integration
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/kamal-github/demtech/internal/repo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCapturedMessageRepoImpl_Integration(t *testing.T) {
+	redisClient := setupRedisClient()
+	defer redisClient.Close()
+
+	ctx := context.Background()
+	defer redisClient.FlushDB(ctx)
+
+	capturedMessageRepo := repo.NewCapturedMessageRepo(redisClient)
+
+	_, err := capturedMessageRepo.GetCapturedMessage(ctx, "msg-1")
+	assert.ErrorIs(t, err, repo.ErrNotFound)
+
+	start := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	messages := []model.CapturedMessage{
+		{
+			MessageID:   "msg-1",
+			Source:      "sender@example.com",
+			Destination: model.Destination{ToAddresses: []string{"to@example.com"}},
+			Subject:     "First",
+			TextBody:    "Hello",
+			Tags:        []model.Tag{{Name: "campaign", Value: "welcome"}},
+			SentAt:      start,
+		},
+		{MessageID: "msg-2", Subject: "Second", SentAt: start.Add(time.Minute)},
+		{MessageID: "msg-3", Subject: "Third", SentAt: start.Add(2 * time.Minute)},
+	}
+	for _, m := range messages {
+		assert.NoError(t, capturedMessageRepo.AddCapturedMessage(ctx, m))
+	}
+
+	got, err := capturedMessageRepo.GetCapturedMessage(ctx, "msg-1")
+	assert.NoError(t, err)
+	assert.Equal(t, messages[0], got)
+
+	list, err := capturedMessageRepo.ListCapturedMessages(ctx, time.Time{}, time.Time{})
+	assert.NoError(t, err)
+	assert.Equal(t, []model.CapturedMessage{messages[2], messages[1], messages[0]}, list)
+
+	list, err = capturedMessageRepo.ListCapturedMessages(ctx, start.Add(time.Minute), start.Add(time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, []model.CapturedMessage{messages[1]}, list)
+
+	deleted, err := capturedMessageRepo.DeleteCapturedMessages(ctx, "msg-1", "msg-3", "unknown")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+
+	list, err = capturedMessageRepo.ListCapturedMessages(ctx, time.Time{}, time.Time{})
+	assert.NoError(t, err)
+	assert.Equal(t, []model.CapturedMessage{messages[1]}, list)
+
+	// Trimming keeps the newest messages.
+	assert.NoError(t, capturedMessageRepo.AddCapturedMessage(ctx, messages[2]))
+	trimmed, err := capturedMessageRepo.TrimCapturedMessages(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), trimmed)
+	list, err = capturedMessageRepo.ListCapturedMessages(ctx, time.Time{}, time.Time{})
+	assert.NoError(t, err)
+	assert.Equal(t, []model.CapturedMessage{messages[2]}, list)
+
+	trimmed, err = capturedMessageRepo.TrimCapturedMessages(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), trimmed)
+
+	ids, unsubscribe, err := capturedMessageRepo.SubscribeCapturedMessages(ctx)
+	assert.NoError(t, err)
+	defer unsubscribe()
+
+	assert.NoError(t, capturedMessageRepo.AddCapturedMessage(ctx, model.CapturedMessage{MessageID: "msg-4", SentAt: start}))
+	select {
+	case id := <-ids:
+		assert.Equal(t, "msg-4", id)
+	case <-time.After(time.Second):
+		t.Fatal("Captured message was not published")
+	}
+}
@@ -0,0 +1,99 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/redis/go-redis/v9"
+)
+
+const configurationSetsStorageKey = "configuration-sets"
+
+// ConfigurationSetRepoImpl stores configuration sets, along with their event
+// destinations, in a Redis hash keyed by configuration set name.
+type ConfigurationSetRepoImpl struct {
+	redisClient *redis.Client
+}
+
+func NewConfigurationSetRepo(c *redis.Client) ConfigurationSetRepoImpl {
+	return ConfigurationSetRepoImpl{redisClient: c}
+}
+
+// CreateConfigurationSet stores a configuration set unless it already exists,
+// in which case ErrAlreadyExists is returned and the stored one is left untouched
+func (r ConfigurationSetRepoImpl) CreateConfigurationSet(ctx context.Context, set model.ConfigurationSet) error {
+	data, err := json.Marshal(set)
+	if err != nil {
+		return err
+	}
+
+	created, err := r.redisClient.HSetNX(ctx, configurationSetsStorageKey, set.Name, data).Result()
+	if err != nil {
+		return err
+	}
+	if !created {
+		return ErrAlreadyExists
+	}
+	return nil
+}
+
+// PutConfigurationSet creates or replaces a configuration set
+func (r ConfigurationSetRepoImpl) PutConfigurationSet(ctx context.Context, set model.ConfigurationSet) error {
+	data, err := json.Marshal(set)
+	if err != nil {
+		return err
+	}
+	return r.redisClient.HSet(ctx, configurationSetsStorageKey, set.Name, data).Err()
+}
+
+// GetConfigurationSet returns the configuration set or ErrNotFound
+func (r ConfigurationSetRepoImpl) GetConfigurationSet(ctx context.Context, name string) (model.ConfigurationSet, error) {
+	data, err := r.redisClient.HGet(ctx, configurationSetsStorageKey, name).Result()
+	if errors.Is(err, redis.Nil) {
+		return model.ConfigurationSet{}, ErrNotFound
+	}
+	if err != nil {
+		return model.ConfigurationSet{}, err
+	}
+
+	var set model.ConfigurationSet
+	if err := json.Unmarshal([]byte(data), &set); err != nil {
+		return model.ConfigurationSet{}, err
+	}
+	return set, nil
+}
+
+// DeleteConfigurationSet removes a configuration set or returns ErrNotFound
+func (r ConfigurationSetRepoImpl) DeleteConfigurationSet(ctx context.Context, name string) error {
+	deleted, err := r.redisClient.HDel(ctx, configurationSetsStorageKey, name).Result()
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// ListConfigurationSets returns all configuration sets ordered by name
+func (r ConfigurationSetRepoImpl) ListConfigurationSets(ctx context.Context) ([]model.ConfigurationSet, error) {
+	all, err := r.redisClient.HGetAll(ctx, configurationSetsStorageKey).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]model.ConfigurationSet, 0, len(all))
+	for _, data := range all {
+		var set model.ConfigurationSet
+		if err := json.Unmarshal([]byte(data), &set); err != nil {
+			return nil, err
+		}
+		list = append(list, set)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+
+	return list, nil
+}
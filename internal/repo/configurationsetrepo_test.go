@@ -0,0 +1,53 @@
+//go:build integration
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/kamal-github/demtech/internal/repo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigurationSetRepoImpl_Integration(t *testing.T) {
+	redisClient := setupRedisClient()
+	defer redisClient.Close()
+
+	ctx := context.Background()
+	defer redisClient.FlushDB(ctx)
+
+	setRepo := repo.NewConfigurationSetRepo(redisClient)
+	set := model.ConfigurationSet{Name: "default-config", CreatedTimestamp: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), SendingEnabled: true}
+	other := model.ConfigurationSet{Name: "marketing", SendingEnabled: true}
+
+	assert.NoError(t, setRepo.CreateConfigurationSet(ctx, set))
+	assert.NoError(t, setRepo.CreateConfigurationSet(ctx, other))
+	assert.ErrorIs(t, setRepo.CreateConfigurationSet(ctx, set), repo.ErrAlreadyExists)
+
+	got, err := setRepo.GetConfigurationSet(ctx, set.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, set, got)
+
+	// Replacing a configuration set keeps its sub-resources
+	set.SendingEnabled = false
+	set.TrackingOptions = &model.TrackingOptions{CustomRedirectDomain: "track.example.com"}
+	set.EventDestinations = []model.EventDestination{
+		{Name: "stream", Enabled: true, MatchingEventTypes: []string{"send"}, RedisStreamDestination: &model.RedisStreamDestination{Stream: "ses-events"}},
+	}
+	assert.NoError(t, setRepo.PutConfigurationSet(ctx, set))
+	got, err = setRepo.GetConfigurationSet(ctx, set.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, set, got)
+
+	sets, err := setRepo.ListConfigurationSets(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []model.ConfigurationSet{set, other}, sets)
+
+	assert.NoError(t, setRepo.DeleteConfigurationSet(ctx, set.Name))
+	assert.ErrorIs(t, setRepo.DeleteConfigurationSet(ctx, set.Name), repo.ErrNotFound)
+	_, err = setRepo.GetConfigurationSet(ctx, set.Name)
+	assert.ErrorIs(t, err, repo.ErrNotFound)
+}
@@ -40,6 +40,11 @@ func (r EmailStatsRepoImpl) IncrementError(ctx context.Context, errorType string
 	return err
 }
 
+// Increment the count of an SES event type, e.g. RenderingFailure
+func (r EmailStatsRepoImpl) IncrementEvent(ctx context.Context, eventType string) error {
+	return r.redisClient.HIncrBy(ctx, emailStatsStorageKey, "events:"+eventType, 1).Err()
+}
+
 // Retrieve EmailStats from Redis
 func (r EmailStatsRepoImpl) GetEmailStats(ctx context.Context) (model.EmailStats, error) {
 	data, err := r.redisClient.HGetAll(ctx, emailStatsStorageKey).Result()
@@ -47,11 +52,12 @@ func (r EmailStatsRepoImpl) GetEmailStats(ctx context.Context) (model.EmailStats
 		return model.EmailStats{}, err
 	}
 	if len(data) == 0 {
-		return model.EmailStats{}, fmt.Errorf("no data found for key: %s", emailStatsStorageKey)
+		return model.EmailStats{}, fmt.Errorf("no data found for key: %s: %w", emailStatsStorageKey, ErrNotFound)
 	}
 
 	stats := model.EmailStats{
 		Errors: make(map[string]int),
+		Events: make(map[string]int),
 	}
 	for field, value := range data {
 		num, err := strconv.Atoi(value)
@@ -72,6 +78,9 @@ func (r EmailStatsRepoImpl) GetEmailStats(ctx context.Context) (model.EmailStats
 				errorType := field[7:] // Extract error type
 				stats.Errors[errorType] = num
 			}
+			if len(field) > 7 && field[:7] == "events:" {
+				stats.Events[field[7:]] = num
+			}
 		}
 	}
 	return stats, nil
@@ -32,6 +32,10 @@ func TestEmailStatsRepoImpl_Integration(t *testing.T) {
 	err = repo.IncrementError(ctx, "Timeout")
 	assert.NoError(t, err)
 
+	// Test IncrementEvent
+	err = repo.IncrementEvent(ctx, "RenderingFailure")
+	assert.NoError(t, err)
+
 	// Fetch the stats and validate
 	stats, err := repo.GetEmailStats(ctx)
 	assert.NoError(t, err)
@@ -39,4 +43,5 @@ func TestEmailStatsRepoImpl_Integration(t *testing.T) {
 	assert.Equal(t, 1, stats.TotalErrCount)
 	assert.Equal(t, 2, stats.TotalEmailsSent)
 	assert.Equal(t, 1, stats.Errors["Timeout"])
+	assert.Equal(t, 1, stats.Events["RenderingFailure"])
 }
@@ -0,0 +1,10 @@
+package repo
+
+import "errors"
+
+var (
+	// ErrNotFound is returned when the requested entity does not exist.
+	ErrNotFound = errors.New("not found")
+	// ErrAlreadyExists is returned when creating an entity whose name is taken.
+	ErrAlreadyExists = errors.New("already exists")
+)
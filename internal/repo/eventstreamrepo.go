@@ -0,0 +1,30 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// EventStreamRepoImpl adds published SES events to Redis streams.
+type EventStreamRepoImpl struct {
+	redisClient *redis.Client
+}
+
+func NewEventStreamRepo(c *redis.Client) EventStreamRepoImpl {
+	return EventStreamRepoImpl{redisClient: c}
+}
+
+// AddEvent appends an event, already encoded as JSON, to stream. The event
+// type and message ID are stored alongside so that consumers can filter
+// without decoding it.
+func (r EventStreamRepoImpl) AddEvent(ctx context.Context, stream, eventType, messageID string, data []byte) error {
+	return r.redisClient.XAdd(ctx, &redis.XAddArgs{
+		Stream: stream,
+		Values: map[string]any{
+			"eventType": eventType,
+			"messageId": messageID,
+			"event":     string(data),
+		},
+	}).Err()
+}
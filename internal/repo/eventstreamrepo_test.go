@@ -0,0 +1,31 @@
+//go:build integration
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kamal-github/demtech/internal/repo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventStreamRepoImpl_Integration(t *testing.T) {
+	redisClient := setupRedisClient()
+	defer redisClient.Close()
+
+	ctx := context.Background()
+	defer redisClient.FlushDB(ctx)
+
+	eventStreamRepo := repo.NewEventStreamRepo(redisClient)
+
+	assert.NoError(t, eventStreamRepo.AddEvent(ctx, "ses-events", "Send", "msg-1", []byte(`{"eventType":"Send"}`)))
+	assert.NoError(t, eventStreamRepo.AddEvent(ctx, "ses-events", "Delivery", "msg-1", []byte(`{"eventType":"Delivery"}`)))
+
+	entries, err := redisClient.XRange(ctx, "ses-events", "-", "+").Result()
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+	assert.Equal(t, "Send", entries[0].Values["eventType"])
+	assert.Equal(t, "msg-1", entries[0].Values["messageId"])
+	assert.Equal(t, `{"eventType":"Delivery"}`, entries[1].Values["event"])
+}
@@ -0,0 +1,92 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/redis/go-redis/v9"
+)
+
+const identitiesStorageKey = "email-identities"
+
+// IdentityRepoImpl stores sending identities in a Redis hash, keyed by the
+// email address or domain.
+type IdentityRepoImpl struct {
+	redisClient *redis.Client
+}
+
+func NewIdentityRepo(c *redis.Client) IdentityRepoImpl {
+	return IdentityRepoImpl{redisClient: c}
+}
+
+// PutIdentity creates or replaces an identity
+func (r IdentityRepoImpl) PutIdentity(ctx context.Context, id model.Identity) error {
+	data, err := json.Marshal(id)
+	if err != nil {
+		return err
+	}
+	return r.redisClient.HSet(ctx, identitiesStorageKey, id.Identity, data).Err()
+}
+
+// CreateIdentity stores an identity unless it already exists, in which case
+// ErrAlreadyExists is returned and the stored one is left untouched
+func (r IdentityRepoImpl) CreateIdentity(ctx context.Context, id model.Identity) error {
+	data, err := json.Marshal(id)
+	if err != nil {
+		return err
+	}
+
+	created, err := r.redisClient.HSetNX(ctx, identitiesStorageKey, id.Identity, data).Result()
+	if err != nil {
+		return err
+	}
+	if !created {
+		return ErrAlreadyExists
+	}
+	return nil
+}
+
+// GetIdentity returns the identity or ErrNotFound
+func (r IdentityRepoImpl) GetIdentity(ctx context.Context, identity string) (model.Identity, error) {
+	data, err := r.redisClient.HGet(ctx, identitiesStorageKey, identity).Result()
+	if errors.Is(err, redis.Nil) {
+		return model.Identity{}, ErrNotFound
+	}
+	if err != nil {
+		return model.Identity{}, err
+	}
+
+	var id model.Identity
+	if err := json.Unmarshal([]byte(data), &id); err != nil {
+		return model.Identity{}, err
+	}
+	return id, nil
+}
+
+// DeleteIdentity removes an identity, deleting an unknown identity is not an error
+func (r IdentityRepoImpl) DeleteIdentity(ctx context.Context, identity string) error {
+	return r.redisClient.HDel(ctx, identitiesStorageKey, identity).Err()
+}
+
+// ListIdentities returns all identities ordered by name
+func (r IdentityRepoImpl) ListIdentities(ctx context.Context) ([]model.Identity, error) {
+	all, err := r.redisClient.HGetAll(ctx, identitiesStorageKey).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]model.Identity, 0, len(all))
+	for _, data := range all {
+		var id model.Identity
+		if err := json.Unmarshal([]byte(data), &id); err != nil {
+			return nil, err
+		}
+		list = append(list, id)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Identity < list[j].Identity })
+
+	return list, nil
+}
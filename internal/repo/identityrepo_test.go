@@ -0,0 +1,60 @@
+//go:build integration
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/kamal-github/demtech/internal/repo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdentityRepoImpl_Integration(t *testing.T) {
+	redisClient := setupRedisClient()
+	defer redisClient.Close()
+
+	ctx := context.Background()
+	defer redisClient.FlushDB(ctx)
+
+	identityRepo := repo.NewIdentityRepo(redisClient)
+	sender := model.Identity{
+		Identity:           "sender@example.com",
+		Type:               model.IdentityTypeEmailAddress,
+		VerificationStatus: model.VerificationStatusSuccess,
+		CreatedTimestamp:   time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+
+	// Create and read back
+	assert.NoError(t, identityRepo.CreateIdentity(ctx, sender))
+	assert.ErrorIs(t, identityRepo.CreateIdentity(ctx, sender), repo.ErrAlreadyExists)
+
+	got, err := identityRepo.GetIdentity(ctx, "sender@example.com")
+	assert.NoError(t, err)
+	assert.Equal(t, sender, got)
+
+	_, err = identityRepo.GetIdentity(ctx, "unknown@example.com")
+	assert.ErrorIs(t, err, repo.ErrNotFound)
+
+	// Put replaces
+	sender.VerificationStatus = model.VerificationStatusFailed
+	assert.NoError(t, identityRepo.PutIdentity(ctx, sender))
+	got, err = identityRepo.GetIdentity(ctx, "sender@example.com")
+	assert.NoError(t, err)
+	assert.Equal(t, model.VerificationStatusFailed, got.VerificationStatus)
+
+	// List is ordered by name
+	assert.NoError(t, identityRepo.PutIdentity(ctx, model.Identity{Identity: "example.com", Type: model.IdentityTypeDomain}))
+	list, err := identityRepo.ListIdentities(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, list, 2)
+	assert.Equal(t, "example.com", list[0].Identity)
+	assert.Equal(t, "sender@example.com", list[1].Identity)
+
+	// Delete
+	assert.NoError(t, identityRepo.DeleteIdentity(ctx, "sender@example.com"))
+	_, err = identityRepo.GetIdentity(ctx, "sender@example.com")
+	assert.ErrorIs(t, err, repo.ErrNotFound)
+}
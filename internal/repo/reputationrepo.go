@@ -0,0 +1,119 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	accountStatusStorageKey     = "account-status"
+	reputationSamplesStorageKey = "reputation-samples"
+)
+
+// ReputationRepoImpl stores the account status as a JSON string, and the
+// reputation samples of the sent messages in sorted sets scored by the time
+// they were sent at: one for the account, and one per configuration set whose
+// reputation metrics are enabled.
+type ReputationRepoImpl struct {
+	redisClient *redis.Client
+}
+
+func NewReputationRepo(c *redis.Client) ReputationRepoImpl {
+	return ReputationRepoImpl{redisClient: c}
+}
+
+// GetAccountStatus returns the account status or ErrNotFound
+func (r ReputationRepoImpl) GetAccountStatus(ctx context.Context) (model.AccountStatus, error) {
+	data, err := r.redisClient.Get(ctx, accountStatusStorageKey).Result()
+	if errors.Is(err, redis.Nil) {
+		return model.AccountStatus{}, ErrNotFound
+	}
+	if err != nil {
+		return model.AccountStatus{}, err
+	}
+
+	var status model.AccountStatus
+	if err := json.Unmarshal([]byte(data), &status); err != nil {
+		return model.AccountStatus{}, err
+	}
+	return status, nil
+}
+
+// PutAccountStatus creates or replaces the account status
+func (r ReputationRepoImpl) PutAccountStatus(ctx context.Context, status model.AccountStatus) error {
+	data, err := json.Marshal(status)
+	if err != nil {
+		return err
+	}
+	return r.redisClient.Set(ctx, accountStatusStorageKey, data, 0).Err()
+}
+
+// AddReputationSample records the sample of a message sent at the given time
+func (r ReputationRepoImpl) AddReputationSample(ctx context.Context, s model.ReputationSample, sentAt time.Time) error {
+	return r.addSample(ctx, reputationSamplesStorageKey, s, sentAt)
+}
+
+// SumReputationSamples adds up the samples of the messages sent since the
+// given time, the older ones being removed.
+func (r ReputationRepoImpl) SumReputationSamples(ctx context.Context, since time.Time) (model.ReputationSample, error) {
+	return r.sumSamples(ctx, reputationSamplesStorageKey, since)
+}
+
+// AddConfigurationSetReputationSample records the sample of a message sent at
+// the given time in the samples of its configuration set
+func (r ReputationRepoImpl) AddConfigurationSetReputationSample(ctx context.Context, s model.ReputationSample, sentAt time.Time) error {
+	return r.addSample(ctx, configurationSetReputationSamplesKey(s.ConfigurationSetName), s, sentAt)
+}
+
+// SumConfigurationSetReputationSamples adds up the samples of the messages sent
+// with a configuration set since the given time, the older ones being removed.
+func (r ReputationRepoImpl) SumConfigurationSetReputationSamples(ctx context.Context, configurationSetName string, since time.Time) (model.ReputationSample, error) {
+	return r.sumSamples(ctx, configurationSetReputationSamplesKey(configurationSetName), since)
+}
+
+func (r ReputationRepoImpl) addSample(ctx context.Context, key string, s model.ReputationSample, sentAt time.Time) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	return r.redisClient.ZAdd(ctx, key, redis.Z{Score: float64(sentAt.UnixMilli()), Member: data}).Err()
+}
+
+func (r ReputationRepoImpl) sumSamples(ctx context.Context, key string, since time.Time) (model.ReputationSample, error) {
+	min := fmt.Sprintf("%d", since.UnixMilli())
+	if err := r.redisClient.ZRemRangeByScore(ctx, key, "-inf", "("+min).Err(); err != nil {
+		return model.ReputationSample{}, err
+	}
+
+	members, err := r.redisClient.ZRangeByScore(ctx, key, &redis.ZRangeBy{Min: min, Max: "+inf"}).Result()
+	if err != nil {
+		return model.ReputationSample{}, err
+	}
+
+	var sum model.ReputationSample
+	for _, m := range members {
+		var s model.ReputationSample
+		if err := json.Unmarshal([]byte(m), &s); err != nil {
+			return model.ReputationSample{}, err
+		}
+		sum.Sends += s.Sends
+		sum.Bounces += s.Bounces
+		sum.Complaints += s.Complaints
+	}
+	return sum, nil
+}
+
+// DeleteReputationSamples forgets the samples of all the messages sent so far
+func (r ReputationRepoImpl) DeleteReputationSamples(ctx context.Context) error {
+	return r.redisClient.Del(ctx, reputationSamplesStorageKey).Err()
+}
+
+func configurationSetReputationSamplesKey(configurationSetName string) string {
+	return reputationSamplesStorageKey + ":" + configurationSetName
+}
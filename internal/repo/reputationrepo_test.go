@@ -0,0 +1,60 @@
+//go:build integration
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/kamal-github/demtech/internal/repo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReputationRepoImpl_Integration(t *testing.T) {
+	redisClient := setupRedisClient()
+	defer redisClient.Close()
+
+	ctx := context.Background()
+	defer redisClient.FlushDB(ctx)
+
+	reputationRepo := repo.NewReputationRepo(redisClient)
+
+	_, err := reputationRepo.GetAccountStatus(ctx)
+	assert.ErrorIs(t, err, repo.ErrNotFound)
+
+	status := model.AccountStatus{SendingEnabled: false, EnforcementStatus: model.EnforcementStatusShutdown, BounceRate: 0.12}
+	assert.NoError(t, reputationRepo.PutAccountStatus(ctx, status))
+	got, err := reputationRepo.GetAccountStatus(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, status, got)
+
+	now := time.Now()
+	assert.NoError(t, reputationRepo.AddReputationSample(ctx, model.ReputationSample{MessageID: "old", Sends: 10, Bounces: 10}, now.Add(-2*time.Hour)))
+	assert.NoError(t, reputationRepo.AddReputationSample(ctx, model.ReputationSample{MessageID: "1", Sends: 2, Bounces: 1}, now.Add(-time.Minute)))
+	assert.NoError(t, reputationRepo.AddReputationSample(ctx, model.ReputationSample{MessageID: "2", Sends: 3, Complaints: 1}, now))
+
+	sum, err := reputationRepo.SumReputationSamples(ctx, now.Add(-time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, model.ReputationSample{Sends: 5, Bounces: 1, Complaints: 1}, sum)
+
+	// The samples out of the window are gone for good.
+	sum, err = reputationRepo.SumReputationSamples(ctx, now.Add(-3*time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, model.ReputationSample{Sends: 5, Bounces: 1, Complaints: 1}, sum)
+
+	// Those of a configuration set are kept apart from the account's.
+	assert.NoError(t, reputationRepo.AddConfigurationSetReputationSample(ctx, model.ReputationSample{MessageID: "3", ConfigurationSetName: "marketing", Sends: 4, Bounces: 2}, now))
+	sum, err = reputationRepo.SumConfigurationSetReputationSamples(ctx, "marketing", now.Add(-time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, model.ReputationSample{Sends: 4, Bounces: 2}, sum)
+	sum, err = reputationRepo.SumReputationSamples(ctx, now.Add(-time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, model.ReputationSample{Sends: 5, Bounces: 1, Complaints: 1}, sum)
+
+	assert.NoError(t, reputationRepo.DeleteReputationSamples(ctx))
+	sum, err = reputationRepo.SumReputationSamples(ctx, now.Add(-time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, model.ReputationSample{}, sum)
+}
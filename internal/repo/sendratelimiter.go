@@ -0,0 +1,62 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const sendRateBucketKey = "send-rate-bucket"
+
+// takeTokensScript refills the bucket for the time elapsed since it was last
+// used, then takes the requested tokens when there are enough of them. The
+// time of the Redis server is used so that all the replicas of the mock agree
+// on it.
+//
+// KEYS[1] bucket, ARGV[1] refill rate per second, ARGV[2] capacity, ARGV[3] tokens to take.
+var takeTokensScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local capacity = tonumber(ARGV[2])
+local requested = tonumber(ARGV[3])
+
+local time = redis.call('TIME')
+local now = tonumber(time[1]) + tonumber(time[2]) / 1000000
+
+local bucket = redis.call('HMGET', KEYS[1], 'tokens', 'updated')
+local tokens = tonumber(bucket[1]) or capacity
+local updated = tonumber(bucket[2]) or now
+if now > updated then
+	tokens = math.min(capacity, tokens + (now - updated) * rate)
+end
+
+local allowed = 0
+if tokens >= requested then
+	tokens = tokens - requested
+	allowed = 1
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'updated', tostring(now))
+redis.call('PEXPIRE', KEYS[1], math.ceil(capacity / rate * 1000) + 1000)
+return allowed
+`)
+
+// RedisSendRateLimiter is a token bucket stored in Redis, shared by every
+// replica of the mock using the same Redis.
+type RedisSendRateLimiter struct {
+	client *redis.Client
+}
+
+func NewRedisSendRateLimiter(client *redis.Client) *RedisSendRateLimiter {
+	return &RedisSendRateLimiter{client: client}
+}
+
+// Take takes n tokens out of a bucket refilled with rate tokens per second and
+// holding at most capacity of them. It reports false, without taking any, when
+// there are not enough tokens left.
+func (l *RedisSendRateLimiter) Take(ctx context.Context, n int64, rate float64, capacity int64) (bool, error) {
+	allowed, err := takeTokensScript.Run(ctx, l.client, []string{sendRateBucketKey}, rate, capacity, n).Int()
+	if err != nil {
+		return false, err
+	}
+	return allowed == 1, nil
+}
@@ -0,0 +1,50 @@
+//go:build integration
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kamal-github/demtech/internal/repo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisSendRateLimiter(t *testing.T) {
+	client := setupRedisClient()
+	ctx := context.Background()
+	defer client.FlushDB(ctx)
+
+	limiter := repo.NewRedisSendRateLimiter(client)
+	otherReplica := repo.NewRedisSendRateLimiter(client)
+
+	// A full bucket allows a burst up to its capacity.
+	ok, err := limiter.Take(ctx, 3, 2, 5)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = otherReplica.Take(ctx, 2, 2, 5)
+	assert.NoError(t, err)
+	assert.True(t, ok, "Replicas should share the bucket")
+
+	ok, err = limiter.Take(ctx, 1, 2, 5)
+	assert.NoError(t, err)
+	assert.False(t, ok, "An empty bucket should not allow any send")
+
+	// More tokens than the capacity are never allowed.
+	ok, err = limiter.Take(ctx, 6, 2, 5)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	// The bucket refills at the given rate.
+	time.Sleep(1100 * time.Millisecond)
+
+	ok, err = limiter.Take(ctx, 2, 2, 5)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = limiter.Take(ctx, 1, 2, 5)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
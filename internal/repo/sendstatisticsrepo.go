@@ -0,0 +1,100 @@
+package repo
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	sendStatisticsKeyPrefix = "send-statistics:"
+	// SendDataPointInterval is the interval the data points are counted over
+	SendDataPointInterval = 15 * time.Minute
+	// SendStatisticsRetention is how long the data points are kept
+	SendStatisticsRetention = 14 * 24 * time.Hour
+)
+
+// SendStatisticsRepoImpl counts the data points in a Redis hash per 15 minutes
+// interval, keyed by the Unix time it starts at and expiring with the
+// retention.
+type SendStatisticsRepoImpl struct {
+	redisClient *redis.Client
+}
+
+func NewSendStatisticsRepo(c *redis.Client) SendStatisticsRepoImpl {
+	return SendStatisticsRepoImpl{redisClient: c}
+}
+
+// AddSendDataPoint adds the counts of p to the interval its Timestamp falls in
+func (r SendStatisticsRepoImpl) AddSendDataPoint(ctx context.Context, p model.SendDataPoint) error {
+	key := sendStatisticsKey(p.Timestamp.Truncate(SendDataPointInterval))
+	_, err := r.redisClient.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for field, n := range map[string]int64{
+			"DeliveryAttempts": p.DeliveryAttempts,
+			"Bounces":          p.Bounces,
+			"Complaints":       p.Complaints,
+			"Rejects":          p.Rejects,
+		} {
+			if n != 0 {
+				pipe.HIncrBy(ctx, key, field, n)
+			}
+		}
+		pipe.Expire(ctx, key, SendStatisticsRetention+SendDataPointInterval)
+		return nil
+	})
+	return err
+}
+
+// ListSendDataPoints returns the data points of the intervals since the given
+// time which something happened in, oldest first.
+func (r SendStatisticsRepoImpl) ListSendDataPoints(ctx context.Context, since time.Time) ([]model.SendDataPoint, error) {
+	var timestamps []time.Time
+	for t := since.Truncate(SendDataPointInterval); !t.After(time.Now()); t = t.Add(SendDataPointInterval) {
+		timestamps = append(timestamps, t)
+	}
+
+	cmds := make([]*redis.MapStringStringCmd, len(timestamps))
+	if _, err := r.redisClient.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, t := range timestamps {
+			cmds[i] = pipe.HGetAll(ctx, sendStatisticsKey(t))
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	var points []model.SendDataPoint
+	for i, cmd := range cmds {
+		data := cmd.Val()
+		if len(data) == 0 {
+			continue
+		}
+
+		p := model.SendDataPoint{Timestamp: timestamps[i].UTC()}
+		for field, value := range data {
+			n, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			switch field {
+			case "DeliveryAttempts":
+				p.DeliveryAttempts = n
+			case "Bounces":
+				p.Bounces = n
+			case "Complaints":
+				p.Complaints = n
+			case "Rejects":
+				p.Rejects = n
+			}
+		}
+		points = append(points, p)
+	}
+	return points, nil
+}
+
+func sendStatisticsKey(t time.Time) string {
+	return sendStatisticsKeyPrefix + strconv.FormatInt(t.Unix(), 10)
+}
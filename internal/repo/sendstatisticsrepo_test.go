@@ -0,0 +1,42 @@
+//go:build integration
+
+package repo_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/kamal-github/demtech/internal/repo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSendStatisticsRepoImpl_Integration(t *testing.T) {
+	redisClient := setupRedisClient()
+	defer redisClient.Close()
+
+	ctx := context.Background()
+	defer redisClient.FlushDB(ctx)
+
+	statisticsRepo := repo.NewSendStatisticsRepo(redisClient)
+	now := time.Now().UTC().Truncate(repo.SendDataPointInterval)
+	earlier := now.Add(-2 * repo.SendDataPointInterval)
+
+	assert.NoError(t, statisticsRepo.AddSendDataPoint(ctx, model.SendDataPoint{Timestamp: earlier.Add(time.Minute), DeliveryAttempts: 2, Bounces: 1}))
+	assert.NoError(t, statisticsRepo.AddSendDataPoint(ctx, model.SendDataPoint{Timestamp: earlier.Add(10 * time.Minute), DeliveryAttempts: 1, Complaints: 1}))
+	assert.NoError(t, statisticsRepo.AddSendDataPoint(ctx, model.SendDataPoint{Timestamp: now, Rejects: 1}))
+	assert.NoError(t, statisticsRepo.AddSendDataPoint(ctx, model.SendDataPoint{Timestamp: now.Add(-repo.SendStatisticsRetention - time.Hour), DeliveryAttempts: 5}))
+
+	points, err := statisticsRepo.ListSendDataPoints(ctx, now.Add(-repo.SendStatisticsRetention))
+	assert.NoError(t, err)
+	assert.Equal(t, []model.SendDataPoint{
+		{Timestamp: earlier, DeliveryAttempts: 3, Bounces: 1, Complaints: 1},
+		{Timestamp: now, Rejects: 1},
+	}, points)
+
+	ttl, err := redisClient.TTL(ctx, "send-statistics:"+strconv.FormatInt(now.Unix(), 10)).Result()
+	assert.NoError(t, err)
+	assert.Greater(t, ttl, repo.SendStatisticsRetention)
+}
@@ -0,0 +1,142 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	topicsStorageKey        = "sns-topics"
+	subscriptionsStorageKey = "sns-subscriptions"
+)
+
+// SNSRepoImpl stores SNS topics and their subscriptions in Redis hashes, keyed
+// by their ARN.
+type SNSRepoImpl struct {
+	redisClient *redis.Client
+}
+
+func NewSNSRepo(c *redis.Client) SNSRepoImpl {
+	return SNSRepoImpl{redisClient: c}
+}
+
+// CreateTopic stores a topic unless it already exists, in which case
+// ErrAlreadyExists is returned and the stored one is left untouched
+func (r SNSRepoImpl) CreateTopic(ctx context.Context, t model.Topic) error {
+	data, err := json.Marshal(t)
+	if err != nil {
+		return err
+	}
+
+	created, err := r.redisClient.HSetNX(ctx, topicsStorageKey, t.TopicArn, data).Result()
+	if err != nil {
+		return err
+	}
+	if !created {
+		return ErrAlreadyExists
+	}
+	return nil
+}
+
+// GetTopic returns the topic or ErrNotFound
+func (r SNSRepoImpl) GetTopic(ctx context.Context, topicArn string) (model.Topic, error) {
+	var t model.Topic
+	err := r.get(ctx, topicsStorageKey, topicArn, &t)
+	return t, err
+}
+
+// DeleteTopic removes a topic along with its subscriptions
+func (r SNSRepoImpl) DeleteTopic(ctx context.Context, topicArn string) error {
+	subs, err := r.ListSubscriptions(ctx, topicArn)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.redisClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HDel(ctx, topicsStorageKey, topicArn)
+		for _, s := range subs {
+			pipe.HDel(ctx, subscriptionsStorageKey, s.SubscriptionArn)
+		}
+		return nil
+	})
+	return err
+}
+
+// ListTopics returns all topics ordered by ARN
+func (r SNSRepoImpl) ListTopics(ctx context.Context) ([]model.Topic, error) {
+	all, err := r.redisClient.HGetAll(ctx, topicsStorageKey).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]model.Topic, 0, len(all))
+	for _, data := range all {
+		var t model.Topic
+		if err := json.Unmarshal([]byte(data), &t); err != nil {
+			return nil, err
+		}
+		list = append(list, t)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].TopicArn < list[j].TopicArn })
+
+	return list, nil
+}
+
+// PutSubscription creates or replaces a subscription
+func (r SNSRepoImpl) PutSubscription(ctx context.Context, s model.Subscription) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	return r.redisClient.HSet(ctx, subscriptionsStorageKey, s.SubscriptionArn, data).Err()
+}
+
+// GetSubscription returns the subscription or ErrNotFound
+func (r SNSRepoImpl) GetSubscription(ctx context.Context, subscriptionArn string) (model.Subscription, error) {
+	var s model.Subscription
+	err := r.get(ctx, subscriptionsStorageKey, subscriptionArn, &s)
+	return s, err
+}
+
+// DeleteSubscription removes a subscription, deleting an unknown one is not an error
+func (r SNSRepoImpl) DeleteSubscription(ctx context.Context, subscriptionArn string) error {
+	return r.redisClient.HDel(ctx, subscriptionsStorageKey, subscriptionArn).Err()
+}
+
+// ListSubscriptions returns the subscriptions of a topic ordered by ARN
+func (r SNSRepoImpl) ListSubscriptions(ctx context.Context, topicArn string) ([]model.Subscription, error) {
+	all, err := r.redisClient.HGetAll(ctx, subscriptionsStorageKey).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	var list []model.Subscription
+	for _, data := range all {
+		var s model.Subscription
+		if err := json.Unmarshal([]byte(data), &s); err != nil {
+			return nil, err
+		}
+		if s.TopicArn == topicArn {
+			list = append(list, s)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].SubscriptionArn < list[j].SubscriptionArn })
+
+	return list, nil
+}
+
+func (r SNSRepoImpl) get(ctx context.Context, key, field string, v any) error {
+	data, err := r.redisClient.HGet(ctx, key, field).Result()
+	if errors.Is(err, redis.Nil) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal([]byte(data), v)
+}
@@ -0,0 +1,64 @@
+//go:build integration
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/kamal-github/demtech/internal/repo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSNSRepoImpl_Integration(t *testing.T) {
+	redisClient := setupRedisClient()
+	defer redisClient.Close()
+
+	ctx := context.Background()
+	defer redisClient.FlushDB(ctx)
+
+	snsRepo := repo.NewSNSRepo(redisClient)
+	topic := model.Topic{TopicArn: "arn:aws:sns:us-east-1:123456789012:ses-events", Name: "ses-events", SignatureVersion: "1"}
+	other := model.Topic{TopicArn: "arn:aws:sns:us-east-1:123456789012:other", Name: "other", SignatureVersion: "1"}
+
+	// Topics
+	assert.NoError(t, snsRepo.CreateTopic(ctx, topic))
+	assert.NoError(t, snsRepo.CreateTopic(ctx, other))
+	assert.ErrorIs(t, snsRepo.CreateTopic(ctx, topic), repo.ErrAlreadyExists)
+
+	got, err := snsRepo.GetTopic(ctx, topic.TopicArn)
+	assert.NoError(t, err)
+	assert.Equal(t, topic, got)
+
+	topics, err := snsRepo.ListTopics(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []model.Topic{other, topic}, topics)
+
+	// Subscriptions
+	sub := model.Subscription{SubscriptionArn: topic.TopicArn + ":1", TopicArn: topic.TopicArn, Protocol: "http", Endpoint: "http://localhost:9000", Token: "t"}
+	otherSub := model.Subscription{SubscriptionArn: other.TopicArn + ":1", TopicArn: other.TopicArn, Protocol: "http", Endpoint: "http://localhost:9001"}
+	assert.NoError(t, snsRepo.PutSubscription(ctx, sub))
+	assert.NoError(t, snsRepo.PutSubscription(ctx, otherSub))
+
+	gotSub, err := snsRepo.GetSubscription(ctx, sub.SubscriptionArn)
+	assert.NoError(t, err)
+	assert.Equal(t, sub, gotSub)
+
+	subs, err := snsRepo.ListSubscriptions(ctx, topic.TopicArn)
+	assert.NoError(t, err)
+	assert.Equal(t, []model.Subscription{sub}, subs)
+
+	// Deleting a topic deletes its subscriptions
+	assert.NoError(t, snsRepo.DeleteTopic(ctx, topic.TopicArn))
+	_, err = snsRepo.GetTopic(ctx, topic.TopicArn)
+	assert.ErrorIs(t, err, repo.ErrNotFound)
+	_, err = snsRepo.GetSubscription(ctx, sub.SubscriptionArn)
+	assert.ErrorIs(t, err, repo.ErrNotFound)
+	_, err = snsRepo.GetSubscription(ctx, otherSub.SubscriptionArn)
+	assert.NoError(t, err)
+
+	assert.NoError(t, snsRepo.DeleteSubscription(ctx, otherSub.SubscriptionArn))
+	_, err = snsRepo.GetSubscription(ctx, otherSub.SubscriptionArn)
+	assert.ErrorIs(t, err, repo.ErrNotFound)
+}
@@ -0,0 +1,171 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/redis/go-redis/v9"
+)
+
+const queuesStorageKey = "sqs-queues"
+
+// receiveScript makes the messages which are visible at ARGV[1] invisible
+// until ARGV[2], counting the receive, and returns up to ARGV[3] of them as
+// (message, receive count, first receive timestamp) triples.
+var receiveScript = redis.NewScript(`
+local ids = redis.call('ZRANGEBYSCORE', KEYS[1], '-inf', ARGV[1], 'LIMIT', 0, tonumber(ARGV[3]))
+local out = {}
+for _, id in ipairs(ids) do
+	local data = redis.call('HGET', KEYS[2], id)
+	if data then
+		redis.call('ZADD', KEYS[1], ARGV[2], id)
+		local count = redis.call('HINCRBY', KEYS[3], id, 1)
+		redis.call('HSETNX', KEYS[4], id, ARGV[1])
+		table.insert(out, data)
+		table.insert(out, tostring(count))
+		table.insert(out, redis.call('HGET', KEYS[4], id))
+	else
+		redis.call('ZREM', KEYS[1], id)
+	end
+end
+return out
+`)
+
+// SQSRepoImpl stores SQS queues in Redis. The messages of a queue live in a
+// hash, and a sorted set schedules them by the time they become visible.
+type SQSRepoImpl struct {
+	redisClient *redis.Client
+}
+
+func NewSQSRepo(c *redis.Client) SQSRepoImpl {
+	return SQSRepoImpl{redisClient: c}
+}
+
+// CreateQueue stores a queue unless it already exists, in which case
+// ErrAlreadyExists is returned and the stored one is left untouched
+func (r SQSRepoImpl) CreateQueue(ctx context.Context, q model.Queue) error {
+	data, err := json.Marshal(q)
+	if err != nil {
+		return err
+	}
+
+	created, err := r.redisClient.HSetNX(ctx, queuesStorageKey, q.Name, data).Result()
+	if err != nil {
+		return err
+	}
+	if !created {
+		return ErrAlreadyExists
+	}
+	return nil
+}
+
+// GetQueue returns the queue or ErrNotFound
+func (r SQSRepoImpl) GetQueue(ctx context.Context, name string) (model.Queue, error) {
+	data, err := r.redisClient.HGet(ctx, queuesStorageKey, name).Result()
+	if errors.Is(err, redis.Nil) {
+		return model.Queue{}, ErrNotFound
+	}
+	if err != nil {
+		return model.Queue{}, err
+	}
+
+	var q model.Queue
+	if err := json.Unmarshal([]byte(data), &q); err != nil {
+		return model.Queue{}, err
+	}
+	return q, nil
+}
+
+// AddMessage adds a message to a queue, it can be received from visibleAt on
+func (r SQSRepoImpl) AddMessage(ctx context.Context, queue string, m model.QueueMessage, visibleAt time.Time) error {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.redisClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, messagesKey(queue), m.MessageID, data)
+		pipe.ZAdd(ctx, scheduleKey(queue), redis.Z{Score: float64(visibleAt.UnixMilli()), Member: m.MessageID})
+		return nil
+	})
+	return err
+}
+
+// ReceiveMessages returns up to max messages which are visible at now, and
+// hides them until visibleUntil
+func (r SQSRepoImpl) ReceiveMessages(ctx context.Context, queue string, now, visibleUntil time.Time, max int) ([]model.QueueMessage, error) {
+	keys := []string{scheduleKey(queue), messagesKey(queue), receivesKey(queue), firstReceivesKey(queue)}
+	res, err := receiveScript.Run(ctx, r.redisClient, keys, now.UnixMilli(), visibleUntil.UnixMilli(), max).StringSlice()
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make([]model.QueueMessage, 0, len(res)/3)
+	for i := 0; i+2 < len(res); i += 3 {
+		var m model.QueueMessage
+		if err := json.Unmarshal([]byte(res[i]), &m); err != nil {
+			return nil, err
+		}
+		if m.ReceiveCount, err = strconv.Atoi(res[i+1]); err != nil {
+			return nil, err
+		}
+		if m.FirstReceiveTimestamp, err = strconv.ParseInt(res[i+2], 10, 64); err != nil {
+			return nil, err
+		}
+		messages = append(messages, m)
+	}
+	return messages, nil
+}
+
+// DeleteMessage removes a message from a queue, deleting an unknown message is not an error
+func (r SQSRepoImpl) DeleteMessage(ctx context.Context, queue, messageID string) error {
+	_, err := r.redisClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZRem(ctx, scheduleKey(queue), messageID)
+		pipe.HDel(ctx, messagesKey(queue), messageID)
+		pipe.HDel(ctx, receivesKey(queue), messageID)
+		pipe.HDel(ctx, firstReceivesKey(queue), messageID)
+		return nil
+	})
+	return err
+}
+
+// CountMessages counts the messages of a queue which are visible at now, the
+// ones which are in flight and the ones which were never received yet.
+func (r SQSRepoImpl) CountMessages(ctx context.Context, queue string, now time.Time) (model.QueueCounts, error) {
+	nowMs := strconv.FormatInt(now.UnixMilli(), 10)
+
+	visible, err := r.redisClient.ZCount(ctx, scheduleKey(queue), "-inf", nowMs).Result()
+	if err != nil {
+		return model.QueueCounts{}, err
+	}
+	hidden, err := r.redisClient.ZRangeByScore(ctx, scheduleKey(queue), &redis.ZRangeBy{Min: "(" + nowMs, Max: "+inf"}).Result()
+	if err != nil {
+		return model.QueueCounts{}, err
+	}
+
+	counts := model.QueueCounts{Visible: visible}
+	if len(hidden) == 0 {
+		return counts, nil
+	}
+	receives, err := r.redisClient.HMGet(ctx, receivesKey(queue), hidden...).Result()
+	if err != nil {
+		return model.QueueCounts{}, err
+	}
+	for _, count := range receives {
+		if count == nil {
+			counts.Delayed++
+		} else {
+			counts.NotVisible++
+		}
+	}
+	return counts, nil
+}
+
+func scheduleKey(queue string) string      { return "sqs-queue:" + queue + ":schedule" }
+func messagesKey(queue string) string      { return "sqs-queue:" + queue + ":messages" }
+func receivesKey(queue string) string      { return "sqs-queue:" + queue + ":receives" }
+func firstReceivesKey(queue string) string { return "sqs-queue:" + queue + ":first-receives" }
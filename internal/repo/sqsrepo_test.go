@@ -0,0 +1,80 @@
+//go:build integration
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/kamal-github/demtech/internal/repo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSQSRepoImpl_Integration(t *testing.T) {
+	redisClient := setupRedisClient()
+	defer redisClient.Close()
+
+	ctx := context.Background()
+	defer redisClient.FlushDB(ctx)
+
+	sqsRepo := repo.NewSQSRepo(redisClient)
+	queue := model.Queue{Name: "ses-events", CreatedTimestamp: 1700000000, VisibilityTimeout: 30}
+
+	// Queues
+	assert.NoError(t, sqsRepo.CreateQueue(ctx, queue))
+	assert.ErrorIs(t, sqsRepo.CreateQueue(ctx, queue), repo.ErrAlreadyExists)
+
+	got, err := sqsRepo.GetQueue(ctx, queue.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, queue, got)
+
+	_, err = sqsRepo.GetQueue(ctx, "unknown")
+	assert.ErrorIs(t, err, repo.ErrNotFound)
+
+	// Messages
+	now := time.Now()
+	first := model.QueueMessage{MessageID: "m1", Body: "one", SentTimestamp: now.UnixMilli()}
+	second := model.QueueMessage{MessageID: "m2", Body: "two", SentTimestamp: now.UnixMilli()}
+	delayed := model.QueueMessage{MessageID: "m3", Body: "three", SentTimestamp: now.UnixMilli()}
+	assert.NoError(t, sqsRepo.AddMessage(ctx, queue.Name, first, now))
+	assert.NoError(t, sqsRepo.AddMessage(ctx, queue.Name, second, now))
+	assert.NoError(t, sqsRepo.AddMessage(ctx, queue.Name, delayed, now.Add(time.Minute)))
+
+	counts, err := sqsRepo.CountMessages(ctx, queue.Name, now)
+	assert.NoError(t, err)
+	assert.Equal(t, model.QueueCounts{Visible: 2, Delayed: 1}, counts)
+
+	received, err := sqsRepo.ReceiveMessages(ctx, queue.Name, now, now.Add(30*time.Second), 1)
+	assert.NoError(t, err)
+	if assert.Len(t, received, 1) {
+		assert.Equal(t, "one", received[0].Body)
+		assert.Equal(t, 1, received[0].ReceiveCount)
+		assert.Equal(t, now.UnixMilli(), received[0].FirstReceiveTimestamp)
+	}
+
+	counts, err = sqsRepo.CountMessages(ctx, queue.Name, now)
+	assert.NoError(t, err)
+	assert.Equal(t, model.QueueCounts{Visible: 1, NotVisible: 1, Delayed: 1}, counts)
+
+	// An in flight message is received again once its visibility timeout expired
+	later := now.Add(45 * time.Second)
+	received, err = sqsRepo.ReceiveMessages(ctx, queue.Name, later, later.Add(30*time.Second), 10)
+	assert.NoError(t, err)
+	if assert.Len(t, received, 2) {
+		// Messages come in the order they became visible
+		assert.Equal(t, "two", received[0].Body)
+		assert.Equal(t, 1, received[0].ReceiveCount)
+		assert.Equal(t, "one", received[1].Body)
+		assert.Equal(t, 2, received[1].ReceiveCount)
+		assert.Equal(t, now.UnixMilli(), received[1].FirstReceiveTimestamp)
+	}
+
+	assert.NoError(t, sqsRepo.DeleteMessage(ctx, queue.Name, "m1"))
+	assert.NoError(t, sqsRepo.DeleteMessage(ctx, queue.Name, "unknown"))
+
+	counts, err = sqsRepo.CountMessages(ctx, queue.Name, later)
+	assert.NoError(t, err)
+	assert.Equal(t, model.QueueCounts{NotVisible: 1, Delayed: 1}, counts)
+}
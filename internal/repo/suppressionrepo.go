@@ -0,0 +1,84 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/redis/go-redis/v9"
+)
+
+const suppressedDestinationsStorageKey = "suppressed-destinations"
+
+// SuppressionRepoImpl stores the account level suppression list in a Redis
+// hash, keyed by lower cased email address.
+type SuppressionRepoImpl struct {
+	redisClient *redis.Client
+}
+
+func NewSuppressionRepo(c *redis.Client) SuppressionRepoImpl {
+	return SuppressionRepoImpl{redisClient: c}
+}
+
+// PutSuppressedDestination adds an address to the suppression list or replaces its entry
+func (r SuppressionRepoImpl) PutSuppressedDestination(ctx context.Context, d model.SuppressedDestination) error {
+	data, err := json.Marshal(d)
+	if err != nil {
+		return err
+	}
+	return r.redisClient.HSet(ctx, suppressedDestinationsStorageKey, strings.ToLower(d.EmailAddress), data).Err()
+}
+
+// GetSuppressedDestination returns the entry of an address or ErrNotFound
+func (r SuppressionRepoImpl) GetSuppressedDestination(ctx context.Context, emailAddress string) (model.SuppressedDestination, error) {
+	data, err := r.redisClient.HGet(ctx, suppressedDestinationsStorageKey, strings.ToLower(emailAddress)).Result()
+	if errors.Is(err, redis.Nil) {
+		return model.SuppressedDestination{}, ErrNotFound
+	}
+	if err != nil {
+		return model.SuppressedDestination{}, err
+	}
+
+	var d model.SuppressedDestination
+	if err := json.Unmarshal([]byte(data), &d); err != nil {
+		return model.SuppressedDestination{}, err
+	}
+	return d, nil
+}
+
+// DeleteSuppressedDestination removes an address from the suppression list or returns ErrNotFound
+func (r SuppressionRepoImpl) DeleteSuppressedDestination(ctx context.Context, emailAddress string) error {
+	deleted, err := r.redisClient.HDel(ctx, suppressedDestinationsStorageKey, strings.ToLower(emailAddress)).Result()
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// ListSuppressedDestinations returns the whole suppression list ordered by email address
+func (r SuppressionRepoImpl) ListSuppressedDestinations(ctx context.Context) ([]model.SuppressedDestination, error) {
+	all, err := r.redisClient.HGetAll(ctx, suppressedDestinationsStorageKey).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]model.SuppressedDestination, 0, len(all))
+	for _, data := range all {
+		var d model.SuppressedDestination
+		if err := json.Unmarshal([]byte(data), &d); err != nil {
+			return nil, err
+		}
+		list = append(list, d)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return strings.ToLower(list[i].EmailAddress) < strings.ToLower(list[j].EmailAddress)
+	})
+
+	return list, nil
+}
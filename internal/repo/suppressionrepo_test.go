@@ -0,0 +1,47 @@
+//go:build integration
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/kamal-github/demtech/internal/repo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSuppressionRepoImpl_Integration(t *testing.T) {
+	redisClient := setupRedisClient()
+	defer redisClient.Close()
+
+	ctx := context.Background()
+	defer redisClient.FlushDB(ctx)
+
+	suppressionRepo := repo.NewSuppressionRepo(redisClient)
+	bounced := model.SuppressedDestination{
+		EmailAddress:   "Bounced@example.com",
+		Reason:         model.SuppressionReasonBounce,
+		LastUpdateTime: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Attributes:     &model.SuppressedDestinationAttributes{MessageID: "msg-1", FeedbackID: "feedback-1"},
+	}
+	complained := model.SuppressedDestination{EmailAddress: "angry@example.com", Reason: model.SuppressionReasonComplaint, LastUpdateTime: time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)}
+
+	assert.NoError(t, suppressionRepo.PutSuppressedDestination(ctx, bounced))
+	assert.NoError(t, suppressionRepo.PutSuppressedDestination(ctx, complained))
+
+	// Addresses are looked up regardless of their case
+	got, err := suppressionRepo.GetSuppressedDestination(ctx, "bounced@EXAMPLE.com")
+	assert.NoError(t, err)
+	assert.Equal(t, bounced, got)
+
+	list, err := suppressionRepo.ListSuppressedDestinations(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []model.SuppressedDestination{complained, bounced}, list)
+
+	assert.NoError(t, suppressionRepo.DeleteSuppressedDestination(ctx, "bounced@example.com"))
+	assert.ErrorIs(t, suppressionRepo.DeleteSuppressedDestination(ctx, "bounced@example.com"), repo.ErrNotFound)
+	_, err = suppressionRepo.GetSuppressedDestination(ctx, "bounced@example.com")
+	assert.ErrorIs(t, err, repo.ErrNotFound)
+}
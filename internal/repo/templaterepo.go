@@ -0,0 +1,110 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/redis/go-redis/v9"
+)
+
+const templatesStorageKey = "email-templates"
+
+// TemplateRepoImpl stores email templates in a Redis hash, keyed by template name.
+type TemplateRepoImpl struct {
+	redisClient *redis.Client
+}
+
+func NewTemplateRepo(c *redis.Client) TemplateRepoImpl {
+	return TemplateRepoImpl{redisClient: c}
+}
+
+type storedTemplate struct {
+	Template         model.Template `json:"template"`
+	CreatedTimestamp time.Time      `json:"createdTimestamp"`
+}
+
+// CreateTemplate stores a new template, failing with ErrAlreadyExists if the name is taken
+func (r TemplateRepoImpl) CreateTemplate(ctx context.Context, t model.Template) error {
+	data, err := json.Marshal(storedTemplate{Template: t, CreatedTimestamp: time.Now().UTC()})
+	if err != nil {
+		return err
+	}
+
+	created, err := r.redisClient.HSetNX(ctx, templatesStorageKey, t.TemplateName, data).Result()
+	if err != nil {
+		return err
+	}
+	if !created {
+		return ErrAlreadyExists
+	}
+	return nil
+}
+
+// UpdateTemplate replaces an existing template, failing with ErrNotFound if there is none
+func (r TemplateRepoImpl) UpdateTemplate(ctx context.Context, t model.Template) error {
+	stored, err := r.get(ctx, t.TemplateName)
+	if err != nil {
+		return err
+	}
+
+	stored.Template = t
+	data, err := json.Marshal(stored)
+	if err != nil {
+		return err
+	}
+	return r.redisClient.HSet(ctx, templatesStorageKey, t.TemplateName, data).Err()
+}
+
+// GetTemplate returns the template with the given name or ErrNotFound
+func (r TemplateRepoImpl) GetTemplate(ctx context.Context, name string) (model.Template, error) {
+	stored, err := r.get(ctx, name)
+	if err != nil {
+		return model.Template{}, err
+	}
+	return stored.Template, nil
+}
+
+// DeleteTemplate removes a template, deleting an unknown template is not an error
+func (r TemplateRepoImpl) DeleteTemplate(ctx context.Context, name string) error {
+	return r.redisClient.HDel(ctx, templatesStorageKey, name).Err()
+}
+
+// ListTemplates returns the metadata of all templates ordered by name
+func (r TemplateRepoImpl) ListTemplates(ctx context.Context) ([]model.TemplateMetadata, error) {
+	all, err := r.redisClient.HGetAll(ctx, templatesStorageKey).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]model.TemplateMetadata, 0, len(all))
+	for name, data := range all {
+		var stored storedTemplate
+		if err := json.Unmarshal([]byte(data), &stored); err != nil {
+			return nil, err
+		}
+		list = append(list, model.TemplateMetadata{Name: name, CreatedTimestamp: stored.CreatedTimestamp})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+
+	return list, nil
+}
+
+func (r TemplateRepoImpl) get(ctx context.Context, name string) (storedTemplate, error) {
+	data, err := r.redisClient.HGet(ctx, templatesStorageKey, name).Result()
+	if errors.Is(err, redis.Nil) {
+		return storedTemplate{}, ErrNotFound
+	}
+	if err != nil {
+		return storedTemplate{}, err
+	}
+
+	var stored storedTemplate
+	if err := json.Unmarshal([]byte(data), &stored); err != nil {
+		return storedTemplate{}, err
+	}
+	return stored, nil
+}
@@ -0,0 +1,54 @@
+//go:build integration
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/kamal-github/demtech/internal/repo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTemplateRepoImpl_Integration(t *testing.T) {
+	redisClient := setupRedisClient()
+	defer redisClient.Close()
+
+	ctx := context.Background()
+	defer redisClient.FlushDB(ctx)
+
+	templateRepo := repo.NewTemplateRepo(redisClient)
+	welcome := model.Template{TemplateName: "welcome", SubjectPart: "Hi {{name}}", TextPart: "Welcome {{name}}"}
+
+	// Create and read back
+	assert.NoError(t, templateRepo.CreateTemplate(ctx, welcome))
+	assert.ErrorIs(t, templateRepo.CreateTemplate(ctx, welcome), repo.ErrAlreadyExists)
+
+	got, err := templateRepo.GetTemplate(ctx, "welcome")
+	assert.NoError(t, err)
+	assert.Equal(t, welcome, got)
+
+	// Update
+	welcome.HtmlPart = "<h1>Welcome {{name}}</h1>"
+	assert.NoError(t, templateRepo.UpdateTemplate(ctx, welcome))
+	got, err = templateRepo.GetTemplate(ctx, "welcome")
+	assert.NoError(t, err)
+	assert.Equal(t, welcome, got)
+
+	assert.ErrorIs(t, templateRepo.UpdateTemplate(ctx, model.Template{TemplateName: "unknown"}), repo.ErrNotFound)
+
+	// List is ordered by name
+	assert.NoError(t, templateRepo.CreateTemplate(ctx, model.Template{TemplateName: "alert"}))
+	list, err := templateRepo.ListTemplates(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, list, 2)
+	assert.Equal(t, "alert", list[0].Name)
+	assert.Equal(t, "welcome", list[1].Name)
+	assert.False(t, list[0].CreatedTimestamp.IsZero())
+
+	// Delete
+	assert.NoError(t, templateRepo.DeleteTemplate(ctx, "welcome"))
+	_, err = templateRepo.GetTemplate(ctx, "welcome")
+	assert.ErrorIs(t, err, repo.ErrNotFound)
+}
@@ -0,0 +1,65 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/redis/go-redis/v9"
+)
+
+const warmUpStorageKey = "warm-up"
+
+// WarmUpRepoImpl stores the warm-up state of the account as a JSON string.
+type WarmUpRepoImpl struct {
+	redisClient *redis.Client
+}
+
+func NewWarmUpRepo(c *redis.Client) WarmUpRepoImpl {
+	return WarmUpRepoImpl{redisClient: c}
+}
+
+// CreateWarmUpState stores the state unless the warm-up already started, in
+// which case ErrAlreadyExists is returned and the stored one is left untouched
+func (r WarmUpRepoImpl) CreateWarmUpState(ctx context.Context, state model.WarmUpState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	created, err := r.redisClient.SetNX(ctx, warmUpStorageKey, data, 0).Result()
+	if err != nil {
+		return err
+	}
+	if !created {
+		return ErrAlreadyExists
+	}
+	return nil
+}
+
+// PutWarmUpState creates or replaces the state
+func (r WarmUpRepoImpl) PutWarmUpState(ctx context.Context, state model.WarmUpState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return r.redisClient.Set(ctx, warmUpStorageKey, data, 0).Err()
+}
+
+// GetWarmUpState returns the state or ErrNotFound
+func (r WarmUpRepoImpl) GetWarmUpState(ctx context.Context) (model.WarmUpState, error) {
+	data, err := r.redisClient.Get(ctx, warmUpStorageKey).Result()
+	if errors.Is(err, redis.Nil) {
+		return model.WarmUpState{}, ErrNotFound
+	}
+	if err != nil {
+		return model.WarmUpState{}, err
+	}
+
+	var state model.WarmUpState
+	if err := json.Unmarshal([]byte(data), &state); err != nil {
+		return model.WarmUpState{}, err
+	}
+	return state, nil
+}
@@ -0,0 +1,40 @@
+//go:build integration
+
+package repo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/kamal-github/demtech/internal/repo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWarmUpRepoImpl_Integration(t *testing.T) {
+	redisClient := setupRedisClient()
+	defer redisClient.Close()
+
+	ctx := context.Background()
+	defer redisClient.FlushDB(ctx)
+
+	warmUpRepo := repo.NewWarmUpRepo(redisClient)
+
+	_, err := warmUpRepo.GetWarmUpState(ctx)
+	assert.ErrorIs(t, err, repo.ErrNotFound)
+
+	state := model.WarmUpState{StartedAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), Sends: 10}
+	assert.NoError(t, warmUpRepo.CreateWarmUpState(ctx, state))
+	assert.ErrorIs(t, warmUpRepo.CreateWarmUpState(ctx, model.WarmUpState{Stage: 3}), repo.ErrAlreadyExists)
+
+	got, err := warmUpRepo.GetWarmUpState(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, state, got)
+
+	state.Stage, state.Day, state.Bounces = 1, 1, 2
+	assert.NoError(t, warmUpRepo.PutWarmUpState(ctx, state))
+	got, err = warmUpRepo.GetWarmUpState(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, state, got)
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/kamal-github/demtech/internal/repo"
+)
+
+// SendQuotaGetter gives the current send quota of the account, either a fixed
+// one or the one of its warm-up.
+type SendQuotaGetter interface {
+	GetSendQuota(ctx context.Context) (model.SendQuota, error)
+}
+
+// SentEmailCounter counts the recipients sent to during the quota window.
+type SentEmailCounter interface {
+	GetLastNHoursCount(ctx context.Context) (int64, error)
+}
+
+// SendStatisticsRepo lists the 15 minutes data points of the account.
+type SendStatisticsRepo interface {
+	ListSendDataPoints(ctx context.Context, since time.Time) ([]model.SendDataPoint, error)
+}
+
+// AccountSending pauses and resumes the sending of the account.
+type AccountSending interface {
+	GetAccountStatus(ctx context.Context) (model.AccountStatus, error)
+	UpdateAccountSendingEnabled(ctx context.Context, enabled bool) error
+}
+
+// ProductionAccess keeps the account details and whether the account is out
+// of the sandbox.
+type ProductionAccess interface {
+	GetAccountDetails(ctx context.Context) (*model.AccountDetails, error)
+	PutAccountDetails(ctx context.Context, details model.AccountDetails, productionAccessEnabled bool) error
+	IsProductionAccessEnabled(ctx context.Context) (bool, error)
+}
+
+// AccountConfig is what is configured rather than tracked about the account.
+type AccountConfig struct {
+	SuppressedReasons []string
+}
+
+// AccountService reports the quota, the statistics and the status of the
+// account.
+type AccountService struct {
+	sendQuota  SendQuotaGetter
+	sentEmails SentEmailCounter
+	statistics SendStatisticsRepo
+	sending    AccountSending
+	access     ProductionAccess
+	cfg        AccountConfig
+}
+
+func NewAccountService(q SendQuotaGetter, c SentEmailCounter, r SendStatisticsRepo, sending AccountSending, access ProductionAccess, cfg AccountConfig) AccountService {
+	return AccountService{sendQuota: q, sentEmails: c, statistics: r, sending: sending, access: access, cfg: cfg}
+}
+
+// GetSendQuota returns the current send quota along with the number of
+// recipients sent to during the quota window.
+func (s AccountService) GetSendQuota(ctx context.Context) (model.SendQuota, error) {
+	quota, err := s.sendQuota.GetSendQuota(ctx)
+	if err != nil {
+		return model.SendQuota{}, err
+	}
+
+	if quota.SentLast24Hours, err = s.sentEmails.GetLastNHoursCount(ctx); err != nil {
+		return model.SendQuota{}, err
+	}
+	return quota, nil
+}
+
+// GetSendStatistics returns the data points of the last two weeks, oldest first.
+func (s AccountService) GetSendStatistics(ctx context.Context) ([]model.SendDataPoint, error) {
+	return s.statistics.ListSendDataPoints(ctx, time.Now().Add(-repo.SendStatisticsRetention))
+}
+
+// GetAccount gathers the quota, the sending status and the details of the
+// account.
+func (s AccountService) GetAccount(ctx context.Context) (model.Account, error) {
+	quota, err := s.GetSendQuota(ctx)
+	if err != nil {
+		return model.Account{}, err
+	}
+	status, err := s.sending.GetAccountStatus(ctx)
+	if err != nil {
+		return model.Account{}, err
+	}
+	productionAccessEnabled, err := s.access.IsProductionAccessEnabled(ctx)
+	if err != nil {
+		return model.Account{}, err
+	}
+	details, err := s.access.GetAccountDetails(ctx)
+	if err != nil {
+		return model.Account{}, err
+	}
+
+	return model.Account{
+		SendQuota:               quota,
+		SendingEnabled:          status.SendingEnabled,
+		EnforcementStatus:       status.EnforcementStatus,
+		ProductionAccessEnabled: productionAccessEnabled,
+		SuppressedReasons:       s.cfg.SuppressedReasons,
+		Details:                 details,
+	}, nil
+}
+
+func (s AccountService) UpdateAccountSendingEnabled(ctx context.Context, enabled bool) error {
+	return s.sending.UpdateAccountSendingEnabled(ctx, enabled)
+}
+
+// PutAccountDetails updates the account details, requesting production access
+// when productionAccessEnabled is set.
+func (s AccountService) PutAccountDetails(ctx context.Context, details model.AccountDetails, productionAccessEnabled bool) error {
+	return s.access.PutAccountDetails(ctx, details, productionAccessEnabled)
+}
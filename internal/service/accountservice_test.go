@@ -0,0 +1,91 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/kamal-github/demtech/internal/repo"
+	"github.com/kamal-github/demtech/internal/service"
+	"github.com/kamal-github/demtech/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccountService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockQuota := mocks.NewMockSendQuotaGetter(ctrl)
+	mockCounter := mocks.NewMockSentEmailCounter(ctrl)
+	mockStatistics := mocks.NewMockSendStatisticsRepo(ctrl)
+	mockSending := mocks.NewMockAccountSending(ctrl)
+	mockAccess := mocks.NewMockProductionAccess(ctrl)
+
+	s := service.NewAccountService(mockQuota, mockCounter, mockStatistics, mockSending, mockAccess, service.AccountConfig{
+		SuppressedReasons: []string{"BOUNCE"},
+	})
+	ctx := context.Background()
+
+	t.Run("GetSendQuota", func(t *testing.T) {
+		mockQuota.EXPECT().GetSendQuota(gomock.Any()).Return(model.SendQuota{Max24HourSend: 200, MaxSendRate: 1}, nil)
+		mockCounter.EXPECT().GetLastNHoursCount(gomock.Any()).Return(int64(42), nil)
+
+		quota, err := s.GetSendQuota(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, model.SendQuota{Max24HourSend: 200, MaxSendRate: 1, SentLast24Hours: 42}, quota)
+	})
+
+	t.Run("GetSendQuota fails to count", func(t *testing.T) {
+		mockQuota.EXPECT().GetSendQuota(gomock.Any()).Return(model.SendQuota{Max24HourSend: 200, MaxSendRate: 1}, nil)
+		mockCounter.EXPECT().GetLastNHoursCount(gomock.Any()).Return(int64(0), assert.AnError)
+
+		_, err := s.GetSendQuota(ctx)
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+
+	t.Run("GetSendStatistics covers two weeks", func(t *testing.T) {
+		points := []model.SendDataPoint{{Timestamp: time.Now().Truncate(repo.SendDataPointInterval), DeliveryAttempts: 1}}
+		mockStatistics.EXPECT().ListSendDataPoints(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, since time.Time) ([]model.SendDataPoint, error) {
+			assert.WithinDuration(t, time.Now().Add(-14*24*time.Hour), since, time.Second)
+			return points, nil
+		})
+
+		got, err := s.GetSendStatistics(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, points, got)
+	})
+
+	t.Run("GetAccount", func(t *testing.T) {
+		mockQuota.EXPECT().GetSendQuota(gomock.Any()).Return(model.SendQuota{Max24HourSend: 200, MaxSendRate: 1}, nil)
+		mockCounter.EXPECT().GetLastNHoursCount(gomock.Any()).Return(int64(3), nil)
+		mockSending.EXPECT().GetAccountStatus(gomock.Any()).
+			Return(model.AccountStatus{SendingEnabled: true, EnforcementStatus: model.EnforcementStatusProbation, BounceRate: 0.06}, nil)
+		details := &model.AccountDetails{MailType: model.MailTypeMarketing, WebsiteURL: "https://example.com"}
+		mockAccess.EXPECT().IsProductionAccessEnabled(gomock.Any()).Return(true, nil)
+		mockAccess.EXPECT().GetAccountDetails(gomock.Any()).Return(details, nil)
+
+		account, err := s.GetAccount(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, model.Account{
+			SendQuota:               model.SendQuota{Max24HourSend: 200, MaxSendRate: 1, SentLast24Hours: 3},
+			SendingEnabled:          true,
+			EnforcementStatus:       model.EnforcementStatusProbation,
+			ProductionAccessEnabled: true,
+			SuppressedReasons:       []string{"BOUNCE"},
+			Details:                 details,
+		}, account)
+	})
+
+	t.Run("PutAccountDetails", func(t *testing.T) {
+		details := model.AccountDetails{MailType: model.MailTypeMarketing, WebsiteURL: "https://example.com"}
+		mockAccess.EXPECT().PutAccountDetails(gomock.Any(), details, true).Return(nil)
+		assert.NoError(t, s.PutAccountDetails(ctx, details, true))
+	})
+
+	t.Run("UpdateAccountSendingEnabled", func(t *testing.T) {
+		mockSending.EXPECT().UpdateAccountSendingEnabled(gomock.Any(), false).Return(nil)
+		assert.NoError(t, s.UpdateAccountSendingEnabled(ctx, false))
+	})
+}
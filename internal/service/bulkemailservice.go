@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/kamal-github/demtech/internal/model"
+)
+
+// SendBulkTemplatedEmail sends a template to each destination of req. A failing
+// account level validator fails the whole call, any other failure is reported
+// in the status of the destination it concerns.
+func (es EmailServiceImpl) SendBulkTemplatedEmail(ctx context.Context, req model.BulkEmailRequest) ([]model.BulkEmailDestinationStatus, error) {
+	statuses := make([]model.BulkEmailDestinationStatus, len(req.Destinations))
+	reqs := make([]model.EmailRequest, len(req.Destinations))
+	renderingFailures := make([]*RenderingFailure, len(req.Destinations))
+	var rendered []model.EmailRequest
+	for i, d := range req.Destinations {
+		var err error
+		if reqs[i], renderingFailures[i], err = es.render(ctx, destinationRequest(req, d)); err != nil {
+			// Such as invalid replacement data, it only concerns this destination.
+			statuses[i] = bulkEmailStatus(err)
+			continue
+		}
+		if err := validateDestination(ctx, es.validators, reqs[i]); err != nil {
+			return nil, es.reject(ctx, reqs[i], err)
+		}
+		rendered = append(rendered, reqs[i])
+	}
+	if err := validateBulk(ctx, es.validators, rendered); err != nil {
+		return nil, err
+	}
+
+	for i, r := range reqs {
+		if statuses[i].Status != "" {
+			continue
+		}
+		resp, err := es.send(ctx, r, renderingFailures[i])
+		if err != nil {
+			statuses[i] = bulkEmailStatus(err)
+			continue
+		}
+		statuses[i] = model.BulkEmailDestinationStatus{Status: model.BulkStatusSuccess, MessageID: resp.MessageID}
+	}
+	return statuses, nil
+}
+
+// validateDestination runs the validators which check each destination of a
+// bulk send, those are all but the BulkValidators.
+func validateDestination(ctx context.Context, validators []Validator, req model.EmailRequest) error {
+	var perDestination []Validator
+	for _, v := range validators {
+		if _, ok := v.(BulkValidator); !ok {
+			perDestination = append(perDestination, v)
+		}
+	}
+	return validate(ctx, perDestination, req)
+}
+
+// validateBulk runs the BulkValidators once over all the destinations of a
+// bulk send.
+func validateBulk(ctx context.Context, validators []Validator, reqs []model.EmailRequest) error {
+	if len(reqs) == 0 {
+		return nil
+	}
+	for _, v := range validators {
+		if bv, ok := v.(BulkValidator); ok {
+			if err := bv.ValidateBulk(ctx, reqs); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// destinationRequest builds the single templated send of one bulk destination.
+// Replacement data and tags take precedence over the defaults.
+func destinationRequest(req model.BulkEmailRequest, d model.BulkEmailDestination) model.EmailRequest {
+	data := d.ReplacementTemplateData
+	if data == "" {
+		data = req.DefaultTemplateData
+	}
+
+	tags := make([]model.Tag, 0, len(req.DefaultTags)+len(d.ReplacementTags))
+	for _, t := range req.DefaultTags {
+		if !hasTag(d.ReplacementTags, t.Name) {
+			tags = append(tags, t)
+		}
+	}
+	tags = append(tags, d.ReplacementTags...)
+
+	return model.EmailRequest{
+		Source:               req.Source,
+		SourceArn:            req.SourceArn,
+		Destination:          d.Destination,
+		ConfigurationSetName: req.ConfigurationSetName,
+		ReplyToAddresses:     req.ReplyToAddresses,
+		ReturnPath:           req.ReturnPath,
+		ReturnPathArn:        req.ReturnPathArn,
+		Tags:                 tags,
+		Template:             req.Template,
+		TemplateArn:          req.TemplateArn,
+		TemplateData:         data,
+	}
+}
+
+func hasTag(tags []model.Tag, name string) bool {
+	for _, t := range tags {
+		if t.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// bulkEmailStatus translates the error of a single destination into its
+// BulkEmailStatus, as SES reports them in the SendBulkTemplatedEmail response.
+func bulkEmailStatus(err error) model.BulkEmailDestinationStatus {
+	var sesErr *model.SESError
+	if !errors.As(err, &sesErr) {
+		return model.BulkEmailDestinationStatus{Status: model.BulkStatusFailed, Error: "Unexpected internal error occurred."}
+	}
+
+	status := model.BulkStatusFailed
+	switch sesErr.Code {
+	case "MessageRejected":
+		status = model.BulkStatusMessageRejected
+	case "MailFromDomainNotVerified", "MailFromDomainNotVerifiedException":
+		status = model.BulkStatusMailFromDomainNotVerified
+	case "ConfigurationSetDoesNotExist", "ConfigurationSetDoesNotExistException":
+		status = model.BulkStatusConfigurationSetDoesNotExist
+	case "TemplateDoesNotExist", "TemplateDoesNotExistException":
+		status = model.BulkStatusTemplateDoesNotExist
+	case "AccountSendingPaused", "AccountSendingPausedException":
+		status = model.BulkStatusAccountSendingPaused
+	case "ConfigurationSetSendingPaused", "ConfigurationSetSendingPausedException":
+		status = model.BulkStatusConfigurationSetSendingPaused
+	case "Throttling", "ThrottlingException", "TooManyRequestsException":
+		status = model.BulkStatusAccountThrottled
+	case "LimitExceededException":
+		status = model.BulkStatusAccountDailyQuotaExceeded
+	case "InvalidParameterValue", "MissingParameter":
+		status = model.BulkStatusInvalidParameterValue
+	case "InternalFailure", "ServiceUnavailable":
+		status = model.BulkStatusTransientFailure
+	}
+	return model.BulkEmailDestinationStatus{Status: status, Error: sesErr.Message}
+}
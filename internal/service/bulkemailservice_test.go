@@ -0,0 +1,130 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/kamal-github/demtech/internal/service"
+	"github.com/kamal-github/demtech/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmailServiceImpl_SendBulkTemplatedEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	req := model.BulkEmailRequest{
+		Source:              "sender@example.com",
+		Template:            "welcome",
+		DefaultTemplateData: `{"name":"friend"}`,
+		DefaultTags:         []model.Tag{{Name: "campaign", Value: "welcome"}, {Name: "tier", Value: "free"}},
+		Destinations: []model.BulkEmailDestination{
+			{
+				Destination:             model.Destination{ToAddresses: []string{"alex@example.com"}},
+				ReplacementTemplateData: `{"name":"Alex"}`,
+				ReplacementTags:         []model.Tag{{Name: "tier", Value: "pro"}},
+			},
+			{Destination: model.Destination{ToAddresses: []string{"outside@example.com"}}},
+			{Destination: model.Destination{ToAddresses: []string{"sam@example.com"}}},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		accountErr     error
+		renderErrs     map[string]error
+		bulkErr        error
+		recipientErrs  map[string]error
+		expectErr      bool
+		expectBulk     int
+		expectStatuses []string
+		expectError    string
+		expectTracked  int
+	}{
+		{
+			name: "Each destination gets its own status",
+			recipientErrs: map[string]error{
+				"outside@example.com": &model.SESError{Code: "MessageRejected", Message: "Cannot send emails outside sandbox"},
+				"sam@example.com":     &model.SESError{Code: "ThrottlingException", Message: "Rate limit exceeded."},
+			},
+			expectBulk:     3,
+			expectStatuses: []string{model.BulkStatusSuccess, model.BulkStatusMessageRejected, model.BulkStatusAccountThrottled},
+			expectError:    "Cannot send emails outside sandbox",
+			expectTracked:  1,
+		},
+		{
+			name:       "Account level failure fails the whole call",
+			accountErr: &model.SESError{Code: "MessageRejected", Message: "Email address is not verified."},
+			expectErr:  true,
+		},
+		{
+			name: "Rendering error only fails its destination",
+			renderErrs: map[string]error{
+				"outside@example.com": &model.SESError{Code: "InvalidParameterValue", Message: "Template data must be a valid JSON object."},
+			},
+			expectBulk:     2,
+			expectStatuses: []string{model.BulkStatusSuccess, model.BulkStatusInvalidParameterValue, model.BulkStatusSuccess},
+			expectError:    "Template data must be a valid JSON object.",
+			expectTracked:  2,
+		},
+		{
+			name:       "Quota is checked once against all destinations",
+			bulkErr:    &model.SESError{Code: "LimitExceededException", Message: "Sending quota exceeded"},
+			expectBulk: 3,
+			expectErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			mockAccountValidator := mocks.NewMockValidator(ctrl)
+			mockBulkValidator := mocks.NewMockBulkValidator(ctrl)
+			mockRecipientValidator := mocks.NewMockValidator(ctrl)
+			mockTracker := mocks.NewMockSentEmailTracker(ctrl)
+			mockRenderer := mocks.NewMockTemplateRenderer(ctrl)
+
+			mockRenderer.EXPECT().Render(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, r model.EmailRequest) (model.Message, error) {
+					if r.Destination.ToAddresses[0] == "alex@example.com" {
+						assert.Equal(`{"name":"Alex"}`, r.TemplateData)
+						assert.Equal([]model.Tag{{Name: "campaign", Value: "welcome"}, {Name: "tier", Value: "pro"}}, r.Tags)
+					} else {
+						assert.Equal(`{"name":"friend"}`, r.TemplateData)
+					}
+					return model.Message{Subject: model.Subject{Data: "Hi"}}, tt.renderErrs[r.Destination.ToAddresses[0]]
+				}).AnyTimes()
+			mockAccountValidator.EXPECT().Validate(gomock.Any(), gomock.Any()).Return(tt.accountErr).AnyTimes()
+			if tt.expectBulk > 0 {
+				mockBulkValidator.EXPECT().ValidateBulk(gomock.Any(), gomock.Len(tt.expectBulk)).Return(tt.bulkErr)
+			}
+			mockRecipientValidator.EXPECT().Validate(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, r model.EmailRequest) error {
+					return tt.recipientErrs[r.Destination.ToAddresses[0]]
+				}).AnyTimes()
+			mockTracker.EXPECT().TrackSentEmail(gomock.Any(), gomock.Any()).Return(nil).Times(tt.expectTracked)
+
+			es := service.NewEmailService([]service.Validator{mockAccountValidator, mockBulkValidator}, mockTracker, service.FailureConfig{},
+				service.WithRecipientValidators(mockRecipientValidator),
+				service.WithTemplateRenderer(mockRenderer),
+			)
+
+			statuses, err := es.SendBulkTemplatedEmail(context.Background(), req)
+
+			if tt.expectErr {
+				assert.Error(err)
+				return
+			}
+			assert.NoError(err)
+			var got []string
+			for _, s := range statuses {
+				got = append(got, s.Status)
+			}
+			assert.Equal(tt.expectStatuses, got)
+			assert.NotEmpty(statuses[0].MessageID)
+			assert.Equal(tt.expectError, statuses[1].Error)
+		})
+	}
+}
@@ -0,0 +1,267 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/kamal-github/demtech/internal/repo"
+)
+
+const (
+	defaultListCapturedMessagesPageSize = 1000
+	// MaxCapturedMessageWait matches the longest SQS long poll, within the
+	// server's write timeout.
+	MaxCapturedMessageWait = 20 * time.Second
+)
+
+type CapturedMessageRepo interface {
+	AddCapturedMessage(ctx context.Context, m model.CapturedMessage) error
+	GetCapturedMessage(ctx context.Context, messageID string) (model.CapturedMessage, error)
+	ListCapturedMessages(ctx context.Context, since, until time.Time) ([]model.CapturedMessage, error)
+	DeleteCapturedMessages(ctx context.Context, messageIDs ...string) (int64, error)
+	TrimCapturedMessages(ctx context.Context, maxMessages int) (int64, error)
+	SubscribeCapturedMessages(ctx context.Context) (ids <-chan string, unsubscribe func() error, err error)
+}
+
+// CapturedMessageService keeps the accepted messages so that tests can assert
+// on what was sent. Only the newest maxMessages are kept, unless it is 0.
+type CapturedMessageService struct {
+	messageRepo CapturedMessageRepo
+	maxMessages int
+}
+
+func NewCapturedMessageService(r CapturedMessageRepo, maxMessages int) CapturedMessageService {
+	return CapturedMessageService{messageRepo: r, maxMessages: maxMessages}
+}
+
+// CaptureMessage stores an accepted message under its message ID, and removes
+// the oldest messages beyond maxMessages.
+func (s CapturedMessageService) CaptureMessage(ctx context.Context, m model.CapturedMessage) error {
+	if err := s.messageRepo.AddCapturedMessage(ctx, m); err != nil {
+		return err
+	}
+	if s.maxMessages <= 0 {
+		return nil
+	}
+	_, err := s.messageRepo.TrimCapturedMessages(ctx, s.maxMessages)
+	return err
+}
+
+func (s CapturedMessageService) GetCapturedMessage(ctx context.Context, messageID string) (model.CapturedMessage, error) {
+	m, err := s.messageRepo.GetCapturedMessage(ctx, messageID)
+	if errors.Is(err, repo.ErrNotFound) {
+		return model.CapturedMessage{}, messageDoesNotExist(messageID)
+	}
+	return m, err
+}
+
+// ListCapturedMessages returns one page of the messages matching filter,
+// newest first. The returned token is empty on the last page.
+func (s CapturedMessageService) ListCapturedMessages(ctx context.Context, filter model.CapturedMessageFilter, pageSize int, nextToken string) ([]model.CapturedMessage, string, error) {
+	if pageSize <= 0 || pageSize > defaultListCapturedMessagesPageSize {
+		pageSize = defaultListCapturedMessagesPageSize
+	}
+
+	matching, err := s.list(ctx, filter)
+	if err != nil {
+		return nil, "", err
+	}
+
+	page, next := paginate(matching, capturedMessageKey, pageSize, nextToken)
+	return page, next, nil
+}
+
+// FindCapturedMessages returns all the messages matching filter, newest first.
+func (s CapturedMessageService) FindCapturedMessages(ctx context.Context, filter model.CapturedMessageFilter) ([]model.CapturedMessage, error) {
+	return s.list(ctx, filter)
+}
+
+// WaitForCapturedMessage returns the first message matching filter captured,
+// by any replica, within wait. The messages captured before the call are only
+// looked at when filter has a StartDate, the newest of those sent since then
+// being returned right away.
+func (s CapturedMessageService) WaitForCapturedMessage(ctx context.Context, filter model.CapturedMessageFilter, wait time.Duration) (model.CapturedMessage, error) {
+	if wait < 0 || wait > MaxCapturedMessageWait {
+		return model.CapturedMessage{}, &model.SESError{Code: "InvalidParameterValue", Message: fmt.Sprintf("WaitTimeSeconds must be between 0 and %d.", int(MaxCapturedMessageWait.Seconds()))}
+	}
+
+	// Subscribe before listing, a message captured in between is not missed.
+	ids, unsubscribe, err := s.messageRepo.SubscribeCapturedMessages(ctx)
+	if err != nil {
+		return model.CapturedMessage{}, err
+	}
+	defer unsubscribe()
+
+	if !filter.StartDate.IsZero() {
+		matching, err := s.list(ctx, filter)
+		if err != nil {
+			return model.CapturedMessage{}, err
+		}
+		if len(matching) > 0 {
+			return matching[0], nil
+		}
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return model.CapturedMessage{}, ctx.Err()
+		case <-timer.C:
+			return model.CapturedMessage{}, &model.SESError{Code: "NotFoundException", Message: fmt.Sprintf("No matching message was captured within %s.", wait)}
+		case id, ok := <-ids:
+			if !ok {
+				return model.CapturedMessage{}, errors.New("captured messages subscription closed")
+			}
+			m, err := s.messageRepo.GetCapturedMessage(ctx, id)
+			// Deleted in the meantime.
+			if errors.Is(err, repo.ErrNotFound) {
+				continue
+			}
+			if err != nil {
+				return model.CapturedMessage{}, err
+			}
+			if matchesCapturedMessage(filter, m) {
+				return m, nil
+			}
+		}
+	}
+}
+
+// ListInboxes returns the recipients of the captured messages ordered by
+// address, ignoring its case.
+func (s CapturedMessageService) ListInboxes(ctx context.Context) ([]model.Inbox, error) {
+	all, err := s.messageRepo.ListCapturedMessages(ctx, time.Time{}, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+
+	inboxes := make(map[string]*model.Inbox)
+	for _, m := range all {
+		// A recipient listed twice in a message receives it once.
+		seen := make(map[string]struct{})
+		for _, r := range m.Destination.All() {
+			address := strings.ToLower(r)
+			if _, ok := seen[address]; ok {
+				continue
+			}
+			seen[address] = struct{}{}
+
+			inbox, ok := inboxes[address]
+			if !ok {
+				inbox = &model.Inbox{Address: address}
+				inboxes[address] = inbox
+			}
+			inbox.MessageCount++
+			if m.SentAt.After(inbox.LatestSentAt) {
+				inbox.LatestSentAt = m.SentAt
+			}
+		}
+	}
+
+	list := make([]model.Inbox, 0, len(inboxes))
+	for _, inbox := range inboxes {
+		list = append(list, *inbox)
+	}
+	slices.SortFunc(list, func(a, b model.Inbox) int { return strings.Compare(a.Address, b.Address) })
+	return list, nil
+}
+
+// SubscribeCapturedMessages returns the IDs of the messages captured from now
+// on, by any replica, until unsubscribe is called.
+func (s CapturedMessageService) SubscribeCapturedMessages(ctx context.Context) (ids <-chan string, unsubscribe func() error, err error) {
+	return s.messageRepo.SubscribeCapturedMessages(ctx)
+}
+
+func (s CapturedMessageService) DeleteCapturedMessage(ctx context.Context, messageID string) error {
+	deleted, err := s.messageRepo.DeleteCapturedMessages(ctx, messageID)
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return messageDoesNotExist(messageID)
+	}
+	return nil
+}
+
+// DeleteCapturedMessages removes all the messages matching filter and returns
+// how many there were.
+func (s CapturedMessageService) DeleteCapturedMessages(ctx context.Context, filter model.CapturedMessageFilter) (int64, error) {
+	matching, err := s.list(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+
+	ids := make([]string, len(matching))
+	for i, m := range matching {
+		ids[i] = m.MessageID
+	}
+	return s.messageRepo.DeleteCapturedMessages(ctx, ids...)
+}
+
+// list returns the messages matching filter, ordered by capturedMessageKey.
+func (s CapturedMessageService) list(ctx context.Context, filter model.CapturedMessageFilter) ([]model.CapturedMessage, error) {
+	all, err := s.messageRepo.ListCapturedMessages(ctx, filter.StartDate, filter.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	matching := make([]model.CapturedMessage, 0, len(all))
+	for _, m := range all {
+		if matchesCapturedMessage(filter, m) {
+			matching = append(matching, m)
+		}
+	}
+	slices.SortFunc(matching, func(a, b model.CapturedMessage) int {
+		return strings.Compare(capturedMessageKey(a), capturedMessageKey(b))
+	})
+	return matching, nil
+}
+
+func matchesCapturedMessage(f model.CapturedMessageFilter, m model.CapturedMessage) bool {
+	switch {
+	case !f.StartDate.IsZero() && m.SentAt.Before(f.StartDate):
+		return false
+	case !f.EndDate.IsZero() && m.SentAt.After(f.EndDate):
+		return false
+	case f.Source != "" && !containsFold(m.Source, f.Source):
+		return false
+	case f.Subject != "" && !containsFold(m.Subject, f.Subject):
+		return false
+	case f.SubjectPattern != nil && !f.SubjectPattern.MatchString(m.Subject):
+		return false
+	case f.ConfigurationSetName != "" && m.ConfigurationSetName != f.ConfigurationSetName:
+		return false
+	case f.Recipient != "" && !slices.ContainsFunc(m.Destination.All(), func(r string) bool { return strings.EqualFold(bareAddress(r), bareAddress(f.Recipient)) }):
+		return false
+	case f.Tag != nil && !slices.ContainsFunc(m.Tags, func(t model.Tag) bool { return t.Name == f.Tag.Name && (f.Tag.Value == "" || t.Value == f.Tag.Value) }):
+		return false
+	}
+
+	if f.Text == "" {
+		return true
+	}
+	fields := append([]string{m.Source, m.Subject, m.TextBody, m.HtmlBody}, m.Destination.All()...)
+	return slices.ContainsFunc(fields, func(field string) bool { return containsFold(field, f.Text) })
+}
+
+// capturedMessageKey orders messages newest first, it is the pagination token
+// as well.
+func capturedMessageKey(m model.CapturedMessage) string {
+	return fmt.Sprintf("%019d-%s", math.MaxInt64-m.SentAt.UnixNano(), m.MessageID)
+}
+
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
+
+func messageDoesNotExist(messageID string) *model.SESError {
+	return &model.SESError{Code: "NotFoundException", Message: "Message " + messageID + " does not exist."}
+}
@@ -0,0 +1,227 @@
+package service_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/kamal-github/demtech/internal/repo"
+	"github.com/kamal-github/demtech/internal/service"
+	"github.com/kamal-github/demtech/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCapturedMessageService_ListCapturedMessages(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	start := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	welcome := model.CapturedMessage{
+		MessageID:   "msg-1",
+		Source:      "Team <team@example.com>",
+		Destination: model.Destination{ToAddresses: []string{"jane@example.com"}},
+		Subject:     "Welcome aboard",
+		TextBody:    "Your code is 1234",
+		Tags:        []model.Tag{{Name: "campaign", Value: "welcome"}},
+		SentAt:      start,
+	}
+	receipt := model.CapturedMessage{
+		MessageID:   "msg-2",
+		Source:      "billing@example.com",
+		Destination: model.Destination{ToAddresses: []string{"joe@example.com"}, BccAddresses: []string{"audit@example.com"}},
+		Subject:     "Your receipt",
+		HtmlBody:    "<p>Total: 42</p>",
+		Tags:        []model.Tag{{Name: "campaign", Value: "billing"}},
+		SentAt:      start.Add(time.Minute),
+	}
+	reminder := model.CapturedMessage{MessageID: "msg-3", Source: "team@example.com", Subject: "Reminder", SentAt: start.Add(2 * time.Minute)}
+
+	tests := []struct {
+		name   string
+		filter model.CapturedMessageFilter
+		expect []model.CapturedMessage
+	}{
+		{name: "All, newest first", expect: []model.CapturedMessage{reminder, receipt, welcome}},
+		{name: "Recipient", filter: model.CapturedMessageFilter{Recipient: "AUDIT@example.com"}, expect: []model.CapturedMessage{receipt}},
+		{name: "Recipient is a whole address", filter: model.CapturedMessageFilter{Recipient: "audit@"}, expect: []model.CapturedMessage{}},
+		{name: "Source", filter: model.CapturedMessageFilter{Source: "team@example.com"}, expect: []model.CapturedMessage{reminder, welcome}},
+		{name: "Subject", filter: model.CapturedMessageFilter{Subject: "welcome"}, expect: []model.CapturedMessage{welcome}},
+		{name: "Tag name", filter: model.CapturedMessageFilter{Tag: &model.Tag{Name: "campaign"}}, expect: []model.CapturedMessage{receipt, welcome}},
+		{name: "Subject pattern", filter: model.CapturedMessageFilter{SubjectPattern: regexp.MustCompile(`^Your \w+$`)}, expect: []model.CapturedMessage{receipt}},
+		{name: "Tag value", filter: model.CapturedMessageFilter{Tag: &model.Tag{Name: "campaign", Value: "billing"}}, expect: []model.CapturedMessage{receipt}},
+		{name: "Text in a body", filter: model.CapturedMessageFilter{Text: "total: 42"}, expect: []model.CapturedMessage{receipt}},
+		{name: "Time range", filter: model.CapturedMessageFilter{StartDate: start.Add(time.Second), EndDate: start.Add(time.Minute)}, expect: []model.CapturedMessage{receipt}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockCapturedMessageRepo(ctrl)
+			mockRepo.EXPECT().ListCapturedMessages(gomock.Any(), tt.filter.StartDate, tt.filter.EndDate).
+				Return([]model.CapturedMessage{reminder, receipt, welcome}, nil)
+
+			page, next, err := service.NewCapturedMessageService(mockRepo, 0).ListCapturedMessages(context.Background(), tt.filter, 0, "")
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expect, page)
+			assert.Empty(t, next)
+		})
+	}
+
+	t.Run("Pagination", func(t *testing.T) {
+		mockRepo := mocks.NewMockCapturedMessageRepo(ctrl)
+		mockRepo.EXPECT().ListCapturedMessages(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return([]model.CapturedMessage{reminder, receipt, welcome}, nil).Times(2)
+		s := service.NewCapturedMessageService(mockRepo, 0)
+
+		page, next, err := s.ListCapturedMessages(context.Background(), model.CapturedMessageFilter{}, 2, "")
+		assert.NoError(t, err)
+		assert.Equal(t, []model.CapturedMessage{reminder, receipt}, page)
+		assert.NotEmpty(t, next)
+
+		page, next, err = s.ListCapturedMessages(context.Background(), model.CapturedMessageFilter{}, 2, next)
+		assert.NoError(t, err)
+		assert.Equal(t, []model.CapturedMessage{welcome}, page)
+		assert.Empty(t, next)
+	})
+}
+
+func TestCapturedMessageService_CaptureMessage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := model.CapturedMessage{MessageID: "msg-1", Subject: "Welcome"}
+
+	t.Run("Oldest messages are trimmed", func(t *testing.T) {
+		mockRepo := mocks.NewMockCapturedMessageRepo(ctrl)
+		gomock.InOrder(
+			mockRepo.EXPECT().AddCapturedMessage(gomock.Any(), m).Return(nil),
+			mockRepo.EXPECT().TrimCapturedMessages(gomock.Any(), 100).Return(int64(1), nil),
+		)
+
+		assert.NoError(t, service.NewCapturedMessageService(mockRepo, 100).CaptureMessage(context.Background(), m))
+	})
+
+	t.Run("No limit", func(t *testing.T) {
+		mockRepo := mocks.NewMockCapturedMessageRepo(ctrl)
+		mockRepo.EXPECT().AddCapturedMessage(gomock.Any(), m).Return(nil)
+
+		assert.NoError(t, service.NewCapturedMessageService(mockRepo, 0).CaptureMessage(context.Background(), m))
+	})
+}
+
+func TestCapturedMessageService_GetCapturedMessage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockCapturedMessageRepo(ctrl)
+	mockRepo.EXPECT().GetCapturedMessage(gomock.Any(), "unknown").Return(model.CapturedMessage{}, repo.ErrNotFound)
+
+	_, err := service.NewCapturedMessageService(mockRepo, 0).GetCapturedMessage(context.Background(), "unknown")
+	assert.Equal(t, "NotFoundException", err.(*model.SESError).Code)
+}
+
+func TestCapturedMessageService_DeleteCapturedMessages(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("Matching messages", func(t *testing.T) {
+		mockRepo := mocks.NewMockCapturedMessageRepo(ctrl)
+		mockRepo.EXPECT().ListCapturedMessages(gomock.Any(), gomock.Any(), gomock.Any()).Return([]model.CapturedMessage{
+			{MessageID: "msg-2", Subject: "Your receipt"},
+			{MessageID: "msg-1", Subject: "Welcome"},
+		}, nil)
+		mockRepo.EXPECT().DeleteCapturedMessages(gomock.Any(), "msg-2").Return(int64(1), nil)
+
+		deleted, err := service.NewCapturedMessageService(mockRepo, 0).DeleteCapturedMessages(context.Background(), model.CapturedMessageFilter{Subject: "receipt"})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), deleted)
+	})
+
+	t.Run("Unknown message", func(t *testing.T) {
+		mockRepo := mocks.NewMockCapturedMessageRepo(ctrl)
+		mockRepo.EXPECT().DeleteCapturedMessages(gomock.Any(), "unknown").Return(int64(0), nil)
+
+		err := service.NewCapturedMessageService(mockRepo, 0).DeleteCapturedMessage(context.Background(), "unknown")
+		assert.Equal(t, "NotFoundException", err.(*model.SESError).Code)
+	})
+}
+
+func TestCapturedMessageService_WaitForCapturedMessage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	filter := model.CapturedMessageFilter{Recipient: "jane@example.com", ConfigurationSetName: "transactional"}
+	other := model.CapturedMessage{MessageID: "msg-1", Destination: model.Destination{ToAddresses: []string{"joe@example.com"}}, ConfigurationSetName: "transactional"}
+	matching := model.CapturedMessage{MessageID: "msg-2", Destination: model.Destination{ToAddresses: []string{"jane@example.com"}}, ConfigurationSetName: "transactional"}
+
+	subscribe := func(mockRepo *mocks.MockCapturedMessageRepo, ids ...string) {
+		ch := make(chan string, len(ids))
+		for _, id := range ids {
+			ch <- id
+		}
+		mockRepo.EXPECT().SubscribeCapturedMessages(gomock.Any()).
+			Return((<-chan string)(ch), func() error { return nil }, nil)
+	}
+
+	t.Run("Captured since StartDate", func(t *testing.T) {
+		startDate := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+		sent := matching
+		sent.SentAt = startDate.Add(time.Minute)
+		mockRepo := mocks.NewMockCapturedMessageRepo(ctrl)
+		subscribe(mockRepo)
+		mockRepo.EXPECT().ListCapturedMessages(gomock.Any(), startDate, time.Time{}).Return([]model.CapturedMessage{sent, other}, nil)
+
+		since := filter
+		since.StartDate = startDate
+		m, err := service.NewCapturedMessageService(mockRepo, 0).WaitForCapturedMessage(context.Background(), since, time.Second)
+		assert.NoError(t, err)
+		assert.Equal(t, sent, m)
+	})
+
+	// Without StartDate the messages captured before are not listed.
+	t.Run("Captured while waiting", func(t *testing.T) {
+		mockRepo := mocks.NewMockCapturedMessageRepo(ctrl)
+		subscribe(mockRepo, "msg-1", "msg-2")
+		mockRepo.EXPECT().GetCapturedMessage(gomock.Any(), "msg-1").Return(other, nil)
+		mockRepo.EXPECT().GetCapturedMessage(gomock.Any(), "msg-2").Return(matching, nil)
+
+		m, err := service.NewCapturedMessageService(mockRepo, 0).WaitForCapturedMessage(context.Background(), filter, time.Second)
+		assert.NoError(t, err)
+		assert.Equal(t, matching, m)
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		mockRepo := mocks.NewMockCapturedMessageRepo(ctrl)
+		subscribe(mockRepo, "msg-1")
+		mockRepo.EXPECT().GetCapturedMessage(gomock.Any(), "msg-1").Return(other, nil)
+
+		_, err := service.NewCapturedMessageService(mockRepo, 0).WaitForCapturedMessage(context.Background(), filter, 10*time.Millisecond)
+		assert.Equal(t, "NotFoundException", err.(*model.SESError).Code)
+	})
+
+	t.Run("Wait too long", func(t *testing.T) {
+		_, err := service.NewCapturedMessageService(mocks.NewMockCapturedMessageRepo(ctrl), 0).WaitForCapturedMessage(context.Background(), filter, time.Minute)
+		assert.Equal(t, "InvalidParameterValue", err.(*model.SESError).Code)
+	})
+}
+
+func TestCapturedMessageService_ListInboxes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	start := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	mockRepo := mocks.NewMockCapturedMessageRepo(ctrl)
+	mockRepo.EXPECT().ListCapturedMessages(gomock.Any(), time.Time{}, time.Time{}).Return([]model.CapturedMessage{
+		{MessageID: "msg-2", Destination: model.Destination{ToAddresses: []string{"Jane@example.com"}, BccAddresses: []string{"jane@example.com"}}, SentAt: start.Add(time.Minute)},
+		{MessageID: "msg-1", Destination: model.Destination{ToAddresses: []string{"jane@example.com"}, CcAddresses: []string{"audit@example.com"}}, SentAt: start},
+	}, nil)
+
+	inboxes, err := service.NewCapturedMessageService(mockRepo, 0).ListInboxes(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []model.Inbox{
+		{Address: "audit@example.com", MessageCount: 1, LatestSentAt: start},
+		{Address: "jane@example.com", MessageCount: 2, LatestSentAt: start.Add(time.Minute)},
+	}, inboxes)
+}
@@ -0,0 +1,324 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"time"
+
+	"github.com/kamal-github/demtech/internal/events"
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/kamal-github/demtech/internal/repo"
+)
+
+const defaultListConfigurationSetsMaxItems = 1000
+
+var configurationSetNameRegexp = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+
+type ConfigurationSetRepo interface {
+	CreateConfigurationSet(ctx context.Context, set model.ConfigurationSet) error
+	PutConfigurationSet(ctx context.Context, set model.ConfigurationSet) error
+	GetConfigurationSet(ctx context.Context, name string) (model.ConfigurationSet, error)
+	DeleteConfigurationSet(ctx context.Context, name string) error
+	ListConfigurationSets(ctx context.Context) ([]model.ConfigurationSet, error)
+}
+
+// VerifiedIdentityChecker tells whether a custom redirect domain is a verified identity
+type VerifiedIdentityChecker interface {
+	IsVerified(ctx context.Context, identity string) (bool, error)
+}
+
+// ConfigurationSetService manages configuration sets and their sub-resources.
+// The event destinations of a configuration set are where the events of the
+// messages sent with it are published to.
+type ConfigurationSetService struct {
+	setRepo    ConfigurationSetRepo
+	identities VerifiedIdentityChecker
+}
+
+func NewConfigurationSetService(r ConfigurationSetRepo, identities VerifiedIdentityChecker) ConfigurationSetService {
+	return ConfigurationSetService{setRepo: r, identities: identities}
+}
+
+// SeedConfigurationSet creates a configuration set unless it exists, and adds
+// the given event destinations to it, replacing the ones of the same name.
+func (s ConfigurationSetService) SeedConfigurationSet(ctx context.Context, name string, destinations []model.EventDestination) error {
+	set, err := s.setRepo.GetConfigurationSet(ctx, name)
+	if errors.Is(err, repo.ErrNotFound) {
+		set = model.ConfigurationSet{Name: name, CreatedTimestamp: time.Now().UTC(), SendingEnabled: true}
+	} else if err != nil {
+		return err
+	}
+
+	for _, d := range destinations {
+		if err := events.Validate(d); err != nil {
+			return err
+		}
+		if i := destinationIndex(set.EventDestinations, d.Name); i >= 0 {
+			set.EventDestinations[i] = d
+		} else {
+			set.EventDestinations = append(set.EventDestinations, d)
+		}
+	}
+	return s.setRepo.PutConfigurationSet(ctx, set)
+}
+
+// CreateConfigurationSet creates a configuration set with its initial settings.
+// Sending is enabled unless set.SendingEnabled says otherwise, callers are
+// expected to start from SendingEnabled true like SES does.
+func (s ConfigurationSetService) CreateConfigurationSet(ctx context.Context, set model.ConfigurationSet) error {
+	if !configurationSetNameRegexp.MatchString(set.Name) {
+		return &model.SESError{Code: "InvalidConfigurationSet", Message: "Configuration set name " + set.Name + " is invalid. It must contain only letters, numbers, underscores and dashes, and be at most 64 characters long."}
+	}
+	if err := s.validateTrackingOptions(ctx, set.TrackingOptions); err != nil {
+		return err
+	}
+	if err := validateSuppressionOptions(set.SuppressionOptions); err != nil {
+		return err
+	}
+
+	set.CreatedTimestamp = time.Now().UTC()
+	set.EventDestinations = nil
+	err := s.setRepo.CreateConfigurationSet(ctx, set)
+	if errors.Is(err, repo.ErrAlreadyExists) {
+		return &model.SESError{Code: "ConfigurationSetAlreadyExists", Message: "Configuration set " + set.Name + " already exists."}
+	}
+	return err
+}
+
+// GetConfigurationSet returns a configuration set along with its sub-resources
+func (s ConfigurationSetService) GetConfigurationSet(ctx context.Context, name string) (model.ConfigurationSet, error) {
+	set, err := s.setRepo.GetConfigurationSet(ctx, name)
+	if errors.Is(err, repo.ErrNotFound) {
+		return model.ConfigurationSet{}, configurationSetDoesNotExist(name)
+	}
+	return set, err
+}
+
+// DeleteConfigurationSet deletes a configuration set and its event destinations
+func (s ConfigurationSetService) DeleteConfigurationSet(ctx context.Context, name string) error {
+	err := s.setRepo.DeleteConfigurationSet(ctx, name)
+	if errors.Is(err, repo.ErrNotFound) {
+		return configurationSetDoesNotExist(name)
+	}
+	return err
+}
+
+// ListConfigurationSets returns one page of configuration sets ordered by name.
+// The returned token is empty on the last page.
+func (s ConfigurationSetService) ListConfigurationSets(ctx context.Context, maxItems int, nextToken string) ([]model.ConfigurationSet, string, error) {
+	if maxItems <= 0 || maxItems > defaultListConfigurationSetsMaxItems {
+		maxItems = defaultListConfigurationSetsMaxItems
+	}
+
+	sets, err := s.setRepo.ListConfigurationSets(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+
+	page, next := paginate(sets, func(set model.ConfigurationSet) string { return set.Name }, maxItems, nextToken)
+	return page, next, nil
+}
+
+// GetEventDestinations returns the event destinations of a configuration set,
+// none for a configuration set which does not exist.
+func (s ConfigurationSetService) GetEventDestinations(ctx context.Context, configurationSetName string) ([]model.EventDestination, error) {
+	set, err := s.setRepo.GetConfigurationSet(ctx, configurationSetName)
+	if errors.Is(err, repo.ErrNotFound) {
+		return nil, nil
+	}
+	return set.EventDestinations, err
+}
+
+// CreateEventDestination adds an event destination to a configuration set
+func (s ConfigurationSetService) CreateEventDestination(ctx context.Context, configurationSetName string, d model.EventDestination) error {
+	return s.update(ctx, configurationSetName, func(set *model.ConfigurationSet) error {
+		if err := validateEventDestination(d); err != nil {
+			return err
+		}
+		if destinationIndex(set.EventDestinations, d.Name) >= 0 {
+			return &model.SESError{Code: "EventDestinationAlreadyExists", Message: "Event destination " + d.Name + " already exists in configuration set " + set.Name + "."}
+		}
+		set.EventDestinations = append(set.EventDestinations, d)
+		return nil
+	})
+}
+
+// UpdateEventDestination replaces an event destination of a configuration set
+func (s ConfigurationSetService) UpdateEventDestination(ctx context.Context, configurationSetName string, d model.EventDestination) error {
+	return s.update(ctx, configurationSetName, func(set *model.ConfigurationSet) error {
+		if err := validateEventDestination(d); err != nil {
+			return err
+		}
+		i := destinationIndex(set.EventDestinations, d.Name)
+		if i < 0 {
+			return eventDestinationDoesNotExist(set.Name, d.Name)
+		}
+		set.EventDestinations[i] = d
+		return nil
+	})
+}
+
+// DeleteEventDestination removes an event destination from a configuration set
+func (s ConfigurationSetService) DeleteEventDestination(ctx context.Context, configurationSetName, eventDestinationName string) error {
+	return s.update(ctx, configurationSetName, func(set *model.ConfigurationSet) error {
+		i := destinationIndex(set.EventDestinations, eventDestinationName)
+		if i < 0 {
+			return eventDestinationDoesNotExist(set.Name, eventDestinationName)
+		}
+		set.EventDestinations = append(set.EventDestinations[:i], set.EventDestinations[i+1:]...)
+		return nil
+	})
+}
+
+// CreateTrackingOptions sets the tracking options of a configuration set which has none
+func (s ConfigurationSetService) CreateTrackingOptions(ctx context.Context, configurationSetName string, opts model.TrackingOptions) error {
+	return s.update(ctx, configurationSetName, func(set *model.ConfigurationSet) error {
+		if set.TrackingOptions != nil {
+			return &model.SESError{Code: "TrackingOptionsAlreadyExistsException", Message: "Tracking options already exist for configuration set " + set.Name + "."}
+		}
+		if err := s.validateTrackingOptions(ctx, &opts); err != nil {
+			return err
+		}
+		set.TrackingOptions = &opts
+		return nil
+	})
+}
+
+// UpdateTrackingOptions replaces the existing tracking options of a configuration set
+func (s ConfigurationSetService) UpdateTrackingOptions(ctx context.Context, configurationSetName string, opts model.TrackingOptions) error {
+	return s.update(ctx, configurationSetName, func(set *model.ConfigurationSet) error {
+		if set.TrackingOptions == nil {
+			return trackingOptionsDoNotExist(set.Name)
+		}
+		if err := s.validateTrackingOptions(ctx, &opts); err != nil {
+			return err
+		}
+		set.TrackingOptions = &opts
+		return nil
+	})
+}
+
+// DeleteTrackingOptions removes the tracking options of a configuration set
+func (s ConfigurationSetService) DeleteTrackingOptions(ctx context.Context, configurationSetName string) error {
+	return s.update(ctx, configurationSetName, func(set *model.ConfigurationSet) error {
+		if set.TrackingOptions == nil {
+			return trackingOptionsDoNotExist(set.Name)
+		}
+		set.TrackingOptions = nil
+		return nil
+	})
+}
+
+// PutTrackingOptions sets or, when opts is nil, removes the tracking options of a configuration set
+func (s ConfigurationSetService) PutTrackingOptions(ctx context.Context, configurationSetName string, opts *model.TrackingOptions) error {
+	return s.update(ctx, configurationSetName, func(set *model.ConfigurationSet) error {
+		if err := s.validateTrackingOptions(ctx, opts); err != nil {
+			return err
+		}
+		set.TrackingOptions = opts
+		return nil
+	})
+}
+
+// PutReputationOptions turns the reputation metrics of a configuration set on or off
+func (s ConfigurationSetService) PutReputationOptions(ctx context.Context, configurationSetName string, enabled bool) error {
+	return s.update(ctx, configurationSetName, func(set *model.ConfigurationSet) error {
+		set.ReputationMetricsEnabled = enabled
+		return nil
+	})
+}
+
+// PutSendingOptions pauses or resumes sending with a configuration set
+func (s ConfigurationSetService) PutSendingOptions(ctx context.Context, configurationSetName string, enabled bool) error {
+	return s.update(ctx, configurationSetName, func(set *model.ConfigurationSet) error {
+		set.SendingEnabled = enabled
+		return nil
+	})
+}
+
+// PutSuppressionOptions overrides or, when opts is nil, falls back to the
+// account level suppression list reasons for a configuration set
+func (s ConfigurationSetService) PutSuppressionOptions(ctx context.Context, configurationSetName string, opts *model.SuppressionOptions) error {
+	return s.update(ctx, configurationSetName, func(set *model.ConfigurationSet) error {
+		if err := validateSuppressionOptions(opts); err != nil {
+			return err
+		}
+		set.SuppressionOptions = opts
+		return nil
+	})
+}
+
+// update applies fn to a configuration set and stores the result.
+func (s ConfigurationSetService) update(ctx context.Context, name string, fn func(set *model.ConfigurationSet) error) error {
+	set, err := s.GetConfigurationSet(ctx, name)
+	if err != nil {
+		return err
+	}
+	if err := fn(&set); err != nil {
+		return err
+	}
+	return s.setRepo.PutConfigurationSet(ctx, set)
+}
+
+func validateEventDestination(d model.EventDestination) error {
+	if !configurationSetNameRegexp.MatchString(d.Name) {
+		return &model.SESError{Code: "InvalidParameterValue", Message: "Event destination name " + d.Name + " is invalid. It must contain only letters, numbers, underscores and dashes, and be at most 64 characters long."}
+	}
+	if err := events.Validate(d); err != nil {
+		return &model.SESError{Code: "InvalidParameterValue", Message: err.Error()}
+	}
+	return nil
+}
+
+// validateTrackingOptions requires the custom redirect domain, when there is
+// one, to be a verified domain like SES does.
+func (s ConfigurationSetService) validateTrackingOptions(ctx context.Context, opts *model.TrackingOptions) error {
+	if opts == nil || opts.CustomRedirectDomain == "" {
+		return nil
+	}
+	if !isDomain(opts.CustomRedirectDomain) {
+		return &model.SESError{Code: "InvalidTrackingOptions", Message: "Invalid custom redirect domain " + opts.CustomRedirectDomain + "."}
+	}
+	verified, err := s.identities.IsVerified(ctx, opts.CustomRedirectDomain)
+	if err != nil {
+		return err
+	}
+	if !verified {
+		return &model.SESError{Code: "InvalidTrackingOptions", Message: "Custom redirect domain " + opts.CustomRedirectDomain + " is not a verified identity."}
+	}
+	return nil
+}
+
+func validateSuppressionOptions(opts *model.SuppressionOptions) error {
+	if opts == nil {
+		return nil
+	}
+	for _, reason := range opts.SuppressedReasons {
+		if err := validateSuppressionReason(reason); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func destinationIndex(destinations []model.EventDestination, name string) int {
+	for i, d := range destinations {
+		if d.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func configurationSetDoesNotExist(name string) *model.SESError {
+	return &model.SESError{Code: "ConfigurationSetDoesNotExist", Message: "Configuration set " + name + " does not exist."}
+}
+
+func eventDestinationDoesNotExist(configurationSetName, name string) *model.SESError {
+	return &model.SESError{Code: "EventDestinationDoesNotExist", Message: "Event destination " + name + " does not exist in configuration set " + configurationSetName + "."}
+}
+
+func trackingOptionsDoNotExist(configurationSetName string) *model.SESError {
+	return &model.SESError{Code: "TrackingOptionsDoesNotExistException", Message: "Tracking options do not exist for configuration set " + configurationSetName + "."}
+}
@@ -0,0 +1,299 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/kamal-github/demtech/internal/repo"
+	"github.com/kamal-github/demtech/internal/service"
+	"github.com/kamal-github/demtech/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+var streamDestination = model.EventDestination{
+	Name:                   "stream",
+	Enabled:                true,
+	MatchingEventTypes:     []string{"send", "bounce"},
+	RedisStreamDestination: &model.RedisStreamDestination{Stream: "ses-events"},
+}
+
+func TestConfigurationSetService_CreateConfigurationSet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name         string
+		set          model.ConfigurationSet
+		repoErr      error
+		expectCreate bool
+		expectErrMsg string
+	}{
+		{
+			name:         "New configuration set",
+			set:          model.ConfigurationSet{Name: "marketing", SendingEnabled: true, ReputationMetricsEnabled: true},
+			expectCreate: true,
+		},
+		{
+			name:         "Existing configuration set",
+			set:          model.ConfigurationSet{Name: "marketing", SendingEnabled: true},
+			repoErr:      repo.ErrAlreadyExists,
+			expectCreate: true,
+			expectErrMsg: "ConfigurationSetAlreadyExists: Configuration set marketing already exists.",
+		},
+		{
+			name:         "Invalid name",
+			set:          model.ConfigurationSet{Name: "market.ing"},
+			expectErrMsg: "InvalidConfigurationSet: Configuration set name market.ing is invalid. It must contain only letters, numbers, underscores and dashes, and be at most 64 characters long.",
+		},
+		{
+			name:         "Tracking options with a verified redirect domain",
+			set:          model.ConfigurationSet{Name: "marketing", TrackingOptions: &model.TrackingOptions{CustomRedirectDomain: "track.example.com"}},
+			expectCreate: true,
+		},
+		{
+			name:         "Invalid custom redirect domain",
+			set:          model.ConfigurationSet{Name: "marketing", TrackingOptions: &model.TrackingOptions{CustomRedirectDomain: "not a domain"}},
+			expectErrMsg: "InvalidTrackingOptions: Invalid custom redirect domain not a domain.",
+		},
+		{
+			name:         "Unverified custom redirect domain",
+			set:          model.ConfigurationSet{Name: "marketing", TrackingOptions: &model.TrackingOptions{CustomRedirectDomain: "other.example.com"}},
+			expectErrMsg: "InvalidTrackingOptions: Custom redirect domain other.example.com is not a verified identity.",
+		},
+		{
+			name:         "Invalid suppressed reason",
+			set:          model.ConfigurationSet{Name: "marketing", SuppressionOptions: &model.SuppressionOptions{SuppressedReasons: []string{"DELIVERY"}}},
+			expectErrMsg: "InvalidParameterValue: Invalid suppressed reason DELIVERY. Valid values: BOUNCE, COMPLAINT.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			mockRepo := mocks.NewMockConfigurationSetRepo(ctrl)
+			if tt.expectCreate {
+				mockRepo.EXPECT().CreateConfigurationSet(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, set model.ConfigurationSet) error {
+						assert.Equal(tt.set.Name, set.Name)
+						assert.False(set.CreatedTimestamp.IsZero())
+						return tt.repoErr
+					}).Times(1)
+			}
+
+			err := service.NewConfigurationSetService(mockRepo, verifiedIdentities(ctrl)).CreateConfigurationSet(context.Background(), tt.set)
+
+			if tt.expectErrMsg != "" {
+				assert.EqualError(err, tt.expectErrMsg)
+				return
+			}
+			assert.NoError(err)
+		})
+	}
+}
+
+func TestConfigurationSetService_EventDestinations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	withStream := model.ConfigurationSet{Name: "default-config", SendingEnabled: true, EventDestinations: []model.EventDestination{streamDestination}}
+	disabledStream := streamDestination
+	disabledStream.Enabled = false
+
+	tests := []struct {
+		name         string
+		stored       model.ConfigurationSet
+		getErr       error
+		call         func(s service.ConfigurationSetService) error
+		expectPut    []model.EventDestination
+		expectErrMsg string
+	}{
+		{
+			name:   "Create",
+			stored: model.ConfigurationSet{Name: "default-config"},
+			call: func(s service.ConfigurationSetService) error {
+				return s.CreateEventDestination(context.Background(), "default-config", streamDestination)
+			},
+			expectPut: []model.EventDestination{streamDestination},
+		},
+		{
+			name:   "Create existing",
+			stored: withStream,
+			call: func(s service.ConfigurationSetService) error {
+				return s.CreateEventDestination(context.Background(), "default-config", streamDestination)
+			},
+			expectErrMsg: "EventDestinationAlreadyExists: Event destination stream already exists in configuration set default-config.",
+		},
+		{
+			name:   "Create without target",
+			stored: model.ConfigurationSet{Name: "default-config"},
+			call: func(s service.ConfigurationSetService) error {
+				return s.CreateEventDestination(context.Background(), "default-config", model.EventDestination{Name: "nowhere", MatchingEventTypes: []string{"send"}})
+			},
+			expectErrMsg: "InvalidParameterValue: event destination nowhere must have exactly one destination, got 0",
+		},
+		{
+			name:   "Create in unknown configuration set",
+			getErr: repo.ErrNotFound,
+			call: func(s service.ConfigurationSetService) error {
+				return s.CreateEventDestination(context.Background(), "unknown", streamDestination)
+			},
+			expectErrMsg: "ConfigurationSetDoesNotExist: Configuration set unknown does not exist.",
+		},
+		{
+			name:   "Update",
+			stored: withStream,
+			call: func(s service.ConfigurationSetService) error {
+				return s.UpdateEventDestination(context.Background(), "default-config", disabledStream)
+			},
+			expectPut: []model.EventDestination{disabledStream},
+		},
+		{
+			name:   "Delete unknown",
+			stored: withStream,
+			call: func(s service.ConfigurationSetService) error {
+				return s.DeleteEventDestination(context.Background(), "default-config", "webhook")
+			},
+			expectErrMsg: "EventDestinationDoesNotExist: Event destination webhook does not exist in configuration set default-config.",
+		},
+		{
+			name:   "Delete",
+			stored: withStream,
+			call: func(s service.ConfigurationSetService) error {
+				return s.DeleteEventDestination(context.Background(), "default-config", "stream")
+			},
+			expectPut: []model.EventDestination{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			stored := tt.stored
+			stored.EventDestinations = append([]model.EventDestination(nil), tt.stored.EventDestinations...)
+
+			mockRepo := mocks.NewMockConfigurationSetRepo(ctrl)
+			mockRepo.EXPECT().GetConfigurationSet(gomock.Any(), gomock.Any()).Return(stored, tt.getErr).Times(1)
+			if tt.expectPut != nil {
+				mockRepo.EXPECT().PutConfigurationSet(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, set model.ConfigurationSet) error {
+						assert.ElementsMatch(tt.expectPut, set.EventDestinations)
+						return nil
+					}).Times(1)
+			}
+
+			err := tt.call(service.NewConfigurationSetService(mockRepo, verifiedIdentities(ctrl)))
+
+			if tt.expectErrMsg != "" {
+				assert.EqualError(err, tt.expectErrMsg)
+				return
+			}
+			assert.NoError(err)
+		})
+	}
+}
+
+func TestConfigurationSetService_TrackingOptions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	stored := model.ConfigurationSet{Name: "default-config", SendingEnabled: true}
+	mockRepo := mocks.NewMockConfigurationSetRepo(ctrl)
+	mockRepo.EXPECT().GetConfigurationSet(gomock.Any(), "default-config").
+		DoAndReturn(func(context.Context, string) (model.ConfigurationSet, error) { return stored, nil }).AnyTimes()
+	mockRepo.EXPECT().PutConfigurationSet(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, set model.ConfigurationSet) error { stored = set; return nil }).AnyTimes()
+
+	s := service.NewConfigurationSetService(mockRepo, verifiedIdentities(ctrl))
+
+	assert.EqualError(s.UpdateTrackingOptions(ctx, "default-config", model.TrackingOptions{CustomRedirectDomain: "track.example.com"}),
+		"TrackingOptionsDoesNotExistException: Tracking options do not exist for configuration set default-config.")
+	assert.EqualError(s.CreateTrackingOptions(ctx, "default-config", model.TrackingOptions{CustomRedirectDomain: "other.example.com"}),
+		"InvalidTrackingOptions: Custom redirect domain other.example.com is not a verified identity.")
+	assert.Nil(stored.TrackingOptions)
+
+	assert.NoError(s.CreateTrackingOptions(ctx, "default-config", model.TrackingOptions{CustomRedirectDomain: "track.example.com"}))
+	assert.Equal(&model.TrackingOptions{CustomRedirectDomain: "track.example.com"}, stored.TrackingOptions)
+	assert.EqualError(s.CreateTrackingOptions(ctx, "default-config", model.TrackingOptions{CustomRedirectDomain: "track.example.com"}),
+		"TrackingOptionsAlreadyExistsException: Tracking options already exist for configuration set default-config.")
+	assert.NoError(s.UpdateTrackingOptions(ctx, "default-config", model.TrackingOptions{}))
+	assert.Equal(&model.TrackingOptions{}, stored.TrackingOptions)
+	assert.NoError(s.DeleteTrackingOptions(ctx, "default-config"))
+	assert.Nil(stored.TrackingOptions)
+	assert.NoError(s.PutTrackingOptions(ctx, "default-config", &model.TrackingOptions{CustomRedirectDomain: "track.example.com"}))
+	assert.Equal(&model.TrackingOptions{CustomRedirectDomain: "track.example.com"}, stored.TrackingOptions)
+	assert.NoError(s.PutTrackingOptions(ctx, "default-config", nil))
+	assert.Nil(stored.TrackingOptions)
+
+	// Other settings are put as they come
+	assert.NoError(s.PutSendingOptions(ctx, "default-config", false))
+	assert.False(stored.SendingEnabled)
+	assert.NoError(s.PutReputationOptions(ctx, "default-config", true))
+	assert.True(stored.ReputationMetricsEnabled)
+	assert.NoError(s.PutSuppressionOptions(ctx, "default-config", &model.SuppressionOptions{SuppressedReasons: []string{"BOUNCE"}}))
+	assert.Equal([]string{"BOUNCE"}, stored.SuppressionOptions.SuppressedReasons)
+}
+
+func TestConfigurationSetService_SeedConfigurationSet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	assert := assert.New(t)
+
+	webhook := model.EventDestination{Name: "webhook", Enabled: true, MatchingEventTypes: []string{"delivery"}, WebhookDestination: &model.WebhookDestination{URL: "http://localhost:9000"}}
+	updatedStream := streamDestination
+	updatedStream.MatchingEventTypes = []string{"complaint"}
+
+	mockRepo := mocks.NewMockConfigurationSetRepo(ctrl)
+	gomock.InOrder(
+		mockRepo.EXPECT().GetConfigurationSet(gomock.Any(), "default-config").Return(model.ConfigurationSet{}, repo.ErrNotFound),
+		mockRepo.EXPECT().PutConfigurationSet(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, set model.ConfigurationSet) error {
+			assert.True(set.SendingEnabled)
+			assert.Empty(set.EventDestinations)
+			return nil
+		}),
+		// Seeding again keeps the settings and replaces destinations by name
+		mockRepo.EXPECT().GetConfigurationSet(gomock.Any(), "default-config").
+			Return(model.ConfigurationSet{Name: "default-config", SendingEnabled: false, EventDestinations: []model.EventDestination{streamDestination}}, nil),
+		mockRepo.EXPECT().PutConfigurationSet(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, set model.ConfigurationSet) error {
+			assert.False(set.SendingEnabled)
+			assert.Equal([]model.EventDestination{updatedStream, webhook}, set.EventDestinations)
+			return nil
+		}),
+	)
+
+	s := service.NewConfigurationSetService(mockRepo, verifiedIdentities(ctrl))
+	assert.NoError(s.SeedConfigurationSet(context.Background(), "default-config", nil))
+	assert.NoError(s.SeedConfigurationSet(context.Background(), "default-config", []model.EventDestination{updatedStream, webhook}))
+}
+
+func TestConfigurationSetService_GetEventDestinations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	assert := assert.New(t)
+
+	mockRepo := mocks.NewMockConfigurationSetRepo(ctrl)
+	mockRepo.EXPECT().GetConfigurationSet(gomock.Any(), "default-config").
+		Return(model.ConfigurationSet{Name: "default-config", EventDestinations: []model.EventDestination{streamDestination}}, nil)
+	mockRepo.EXPECT().GetConfigurationSet(gomock.Any(), "unknown").Return(model.ConfigurationSet{}, repo.ErrNotFound)
+
+	s := service.NewConfigurationSetService(mockRepo, verifiedIdentities(ctrl))
+
+	destinations, err := s.GetEventDestinations(context.Background(), "default-config")
+	assert.NoError(err)
+	assert.Equal([]model.EventDestination{streamDestination}, destinations)
+
+	destinations, err = s.GetEventDestinations(context.Background(), "unknown")
+	assert.NoError(err)
+	assert.Empty(destinations)
+}
+
+// verifiedIdentities only knows track.example.com as a verified domain
+func verifiedIdentities(ctrl *gomock.Controller) *mocks.MockVerifiedIdentityChecker {
+	m := mocks.NewMockVerifiedIdentityChecker(ctrl)
+	m.EXPECT().IsVerified(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, identity string) (bool, error) { return identity == "track.example.com", nil }).AnyTimes()
+	return m
+}
@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"log"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/kamal-github/demtech/internal/model"
+)
+
+const (
+	// dkimTokensCount is the number of DKIM CNAME records SES hands out per domain
+	dkimTokensCount = 3
+	dkimCNAMESuffix = ".dkim.amazonses.com."
+)
+
+// DNSResolver looks up the records domain verification depends on, it is
+// satisfied by *net.Resolver.
+type DNSResolver interface {
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+	LookupCNAME(ctx context.Context, host string) (string, error)
+}
+
+// VerifyDomainDkim enables DKIM for a domain, adding the domain to the
+// identities if needed, and returns the tokens of its CNAME records.
+func (s IdentityService) VerifyDomainDkim(ctx context.Context, domain string) ([]string, error) {
+	id, err := s.getOrNewDomain(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	if id.VerificationStatus == "" {
+		id.VerificationStatus = model.VerificationStatusPending
+		id.VerificationStartedTimestamp = time.Now().UTC()
+	}
+	if len(id.DkimTokens) == 0 {
+		for i := 0; i < dkimTokensCount; i++ {
+			id.DkimTokens = append(id.DkimTokens, generateDkimToken())
+		}
+	}
+	id.DkimEnabled = true
+	switch id.DkimVerificationStatus {
+	case model.VerificationStatusSuccess, model.VerificationStatusPending:
+	default:
+		id.DkimVerificationStatus = model.VerificationStatusPending
+	}
+
+	if err := s.identityRepo.PutIdentity(ctx, id); err != nil {
+		return nil, err
+	}
+	return id.DkimTokens, nil
+}
+
+// refresh applies the transitions of a pending domain: it is verified once
+// its _amazonses TXT record or all of its DKIM CNAME records resolve to the
+// expected values. Without a resolver, DomainVerificationDelay passing is
+// what verifies it.
+func (s IdentityService) refresh(ctx context.Context, id model.Identity) (model.Identity, error) {
+	if id.Type != model.IdentityTypeDomain {
+		return id, nil
+	}
+
+	updated := id
+	if id.VerificationStatus == model.VerificationStatusPending {
+		updated.VerificationStatus = s.check(ctx, id, s.txtRecordPublished)
+	}
+	if id.DkimEnabled && id.DkimVerificationStatus == model.VerificationStatusPending {
+		updated.DkimVerificationStatus = s.check(ctx, id, s.dkimRecordsPublished)
+	}
+	// Like in SES, detecting the DKIM records verifies the domain as well.
+	if updated.DkimVerificationStatus == model.VerificationStatusSuccess && updated.VerificationStatus == model.VerificationStatusPending {
+		updated.VerificationStatus = model.VerificationStatusSuccess
+	}
+
+	if updated.VerificationStatus == id.VerificationStatus && updated.DkimVerificationStatus == id.DkimVerificationStatus {
+		return id, nil
+	}
+	return updated, s.identityRepo.PutIdentity(ctx, updated)
+}
+
+// check returns the status a pending verification of id moves to. Lookup
+// failures leave it pending, it is checked again on the next read.
+func (s IdentityService) check(ctx context.Context, id model.Identity, published func(context.Context, model.Identity) (bool, error)) string {
+	if s.cfg.Resolver == nil {
+		if s.cfg.DomainVerificationDelay > 0 && time.Since(id.VerificationStartedTimestamp) >= s.cfg.DomainVerificationDelay {
+			return model.VerificationStatusSuccess
+		}
+		return model.VerificationStatusPending
+	}
+
+	ok, err := published(ctx, id)
+	var dnsErr *net.DNSError
+	if err != nil && !(errors.As(err, &dnsErr) && dnsErr.IsNotFound) {
+		log.Printf("Failed to check the DNS records of %s: %v", id.Identity, err)
+	}
+	if ok {
+		return model.VerificationStatusSuccess
+	}
+	return model.VerificationStatusPending
+}
+
+func (s IdentityService) txtRecordPublished(ctx context.Context, id model.Identity) (bool, error) {
+	records, err := s.cfg.Resolver.LookupTXT(ctx, "_amazonses."+id.Identity)
+	if err != nil {
+		return false, err
+	}
+	for _, r := range records {
+		if r == id.VerificationToken {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (s IdentityService) dkimRecordsPublished(ctx context.Context, id model.Identity) (bool, error) {
+	for _, token := range id.DkimTokens {
+		cname, err := s.cfg.Resolver.LookupCNAME(ctx, token+"._domainkey."+id.Identity)
+		if err != nil {
+			return false, err
+		}
+		if !strings.EqualFold(strings.TrimSuffix(cname, ".")+".", token+dkimCNAMESuffix) {
+			return false, nil
+		}
+	}
+	return len(id.DkimTokens) > 0, nil
+}
+
+func generateDkimToken() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,148 @@
+package service_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/kamal-github/demtech/internal/repo"
+	"github.com/kamal-github/demtech/internal/service"
+	"github.com/kamal-github/demtech/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdentityService_VerifyDomainDkim(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockIdentityRepo(ctrl)
+	mockRepo.EXPECT().GetIdentity(gomock.Any(), "example.com").Return(model.Identity{}, repo.ErrNotFound)
+	mockRepo.EXPECT().PutIdentity(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, id model.Identity) error {
+			assert.Equal(t, model.IdentityTypeDomain, id.Type)
+			assert.Equal(t, model.VerificationStatusPending, id.VerificationStatus)
+			assert.NotEmpty(t, id.VerificationToken)
+			assert.True(t, id.DkimEnabled)
+			assert.Equal(t, model.VerificationStatusPending, id.DkimVerificationStatus)
+			return nil
+		})
+
+	tokens, err := service.NewIdentityService(mockRepo, service.IdentityVerificationConfig{}).VerifyDomainDkim(context.Background(), "Example.com")
+
+	assert.NoError(t, err)
+	assert.Len(t, tokens, 3)
+	for _, token := range tokens {
+		assert.Regexp(t, "^[0-9a-f]{32}$", token)
+	}
+}
+
+func TestIdentityService_DNSVerification(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pending := model.Identity{
+		Identity:                     "example.com",
+		Type:                         model.IdentityTypeDomain,
+		VerificationStatus:           model.VerificationStatusPending,
+		VerificationToken:            "txt-token",
+		VerificationStartedTimestamp: time.Now(),
+	}
+	dkimPending := pending
+	dkimPending.DkimEnabled = true
+	dkimPending.DkimVerificationStatus = model.VerificationStatusPending
+	dkimPending.DkimTokens = []string{"aaa", "bbb"}
+
+	notFound := &net.DNSError{Err: "no such host", IsNotFound: true}
+
+	tests := []struct {
+		name             string
+		stored           model.Identity
+		mockSetup        func(*mocks.MockDNSResolver)
+		expectStatus     string
+		expectDkimStatus string
+	}{
+		{
+			name:   "TXT record resolves",
+			stored: pending,
+			mockSetup: func(m *mocks.MockDNSResolver) {
+				m.EXPECT().LookupTXT(gomock.Any(), "_amazonses.example.com").Return([]string{"v=spf1 ~all", "txt-token"}, nil)
+			},
+			expectStatus: model.VerificationStatusSuccess,
+		},
+		{
+			name:   "TXT record with another token",
+			stored: pending,
+			mockSetup: func(m *mocks.MockDNSResolver) {
+				m.EXPECT().LookupTXT(gomock.Any(), "_amazonses.example.com").Return([]string{"other-token"}, nil)
+			},
+			expectStatus: model.VerificationStatusPending,
+		},
+		{
+			name:   "TXT record missing",
+			stored: pending,
+			mockSetup: func(m *mocks.MockDNSResolver) {
+				m.EXPECT().LookupTXT(gomock.Any(), "_amazonses.example.com").Return(nil, notFound)
+			},
+			expectStatus: model.VerificationStatusPending,
+		},
+		{
+			name:   "DKIM records resolve and verify the domain",
+			stored: dkimPending,
+			mockSetup: func(m *mocks.MockDNSResolver) {
+				m.EXPECT().LookupTXT(gomock.Any(), gomock.Any()).Return(nil, notFound)
+				m.EXPECT().LookupCNAME(gomock.Any(), "aaa._domainkey.example.com").Return("aaa.dkim.amazonses.com.", nil)
+				m.EXPECT().LookupCNAME(gomock.Any(), "bbb._domainkey.example.com").Return("BBB.dkim.amazonses.com", nil)
+			},
+			expectStatus:     model.VerificationStatusSuccess,
+			expectDkimStatus: model.VerificationStatusSuccess,
+		},
+		{
+			name:   "One DKIM record missing",
+			stored: dkimPending,
+			mockSetup: func(m *mocks.MockDNSResolver) {
+				m.EXPECT().LookupTXT(gomock.Any(), gomock.Any()).Return(nil, notFound)
+				m.EXPECT().LookupCNAME(gomock.Any(), "aaa._domainkey.example.com").Return("aaa.dkim.amazonses.com.", nil)
+				m.EXPECT().LookupCNAME(gomock.Any(), "bbb._domainkey.example.com").Return("", notFound)
+			},
+			expectStatus:     model.VerificationStatusPending,
+			expectDkimStatus: model.VerificationStatusPending,
+		},
+		{
+			name: "Failed domain is not checked",
+			stored: model.Identity{
+				Identity:           "example.com",
+				Type:               model.IdentityTypeDomain,
+				VerificationStatus: model.VerificationStatusFailed,
+			},
+			mockSetup:    func(*mocks.MockDNSResolver) {},
+			expectStatus: model.VerificationStatusFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			mockRepo := mocks.NewMockIdentityRepo(ctrl)
+			mockResolver := mocks.NewMockDNSResolver(ctrl)
+			tt.mockSetup(mockResolver)
+
+			mockRepo.EXPECT().GetIdentity(gomock.Any(), "example.com").Return(tt.stored, nil)
+			if tt.expectStatus != tt.stored.VerificationStatus || tt.expectDkimStatus != tt.stored.DkimVerificationStatus {
+				mockRepo.EXPECT().PutIdentity(gomock.Any(), gomock.Any()).Return(nil)
+			}
+
+			s := service.NewIdentityService(mockRepo, service.IdentityVerificationConfig{
+				Resolver:                mockResolver,
+				DomainVerificationDelay: time.Nanosecond,
+			})
+			id, err := s.GetIdentity(context.Background(), "example.com")
+
+			assert.NoError(err)
+			assert.Equal(tt.expectStatus, id.VerificationStatus)
+			assert.Equal(tt.expectDkimStatus, id.DkimVerificationStatus)
+		})
+	}
+}
@@ -2,47 +2,215 @@ package service
 
 import (
 	"context"
+	"errors"
+	"log"
 	"math/rand"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/kamal-github/demtech/internal/model"
+	"github.com/kamal-github/demtech/internal/simulator"
 )
 
 type Validator interface {
 	Validate(ctx context.Context, req model.EmailRequest) error
 }
 
+// BulkValidator is an account level validator which checks a bulk send as a
+// whole rather than each of its destinations, like the send quota all of its
+// recipients count against.
+type BulkValidator interface {
+	Validate(ctx context.Context, req model.EmailRequest) error
+	ValidateBulk(ctx context.Context, reqs []model.EmailRequest) error
+}
+
 type SentEmailTracker interface {
 	TrackSentEmail(ctx context.Context, msgID string) error
 }
 
+// TemplateRenderer renders the template a templated send refers to.
+type TemplateRenderer interface {
+	Render(ctx context.Context, req model.EmailRequest) (model.Message, error)
+}
+
+// EventsStatsUpdater counts what happened to accepted messages, such as a
+// RenderingFailure or the outcomes of the mailbox simulator.
+type EventsStatsUpdater interface {
+	IncrementEvent(ctx context.Context, eventType string) error
+}
+
+// SuppressionList collects the recipients which hard bounced or complained.
+type SuppressionList interface {
+	SuppressRecipient(ctx context.Context, configurationSetName string, d model.SuppressedDestination) error
+}
+
+// ReputationTracker keeps the bounce and complaint rates of the account.
+type ReputationTracker interface {
+	TrackReputation(ctx context.Context, sample model.ReputationSample) error
+}
+
+// SendStatisticsRecorder counts the delivery attempts, bounces, complaints and
+// rejected messages of the account over time.
+type SendStatisticsRecorder interface {
+	AddSendDataPoint(ctx context.Context, p model.SendDataPoint) error
+}
+
+// MessageCapturer keeps the accepted messages for inspection.
+type MessageCapturer interface {
+	CaptureMessage(ctx context.Context, m model.CapturedMessage) error
+}
+
+// MessageDeliverer delivers the accepted messages for real, e.g. over SMTP. It
+// returns the bounce of the recipients it refused, and an error when it
+// refused the whole message.
+type MessageDeliverer interface {
+	DeliverMessage(ctx context.Context, m model.CapturedMessage, recipients []string) (map[string]simulator.Bounce, error)
+}
+
 type FailureConfig struct {
 	FailRandomly   bool
 	FailPercentage int
 }
 
+// EmailServiceImpl validates and accepts messages. The optional collaborators
+// recording what happened to a message, from the stats to its capture, only
+// log their failures: once accepted, a message must not fail because of them.
 type EmailServiceImpl struct {
-	validators       []Validator
-	sentEmailTracker SentEmailTracker
-	failureConfig    FailureConfig
+	// validators check the account and the message as a whole, they fail a
+	// bulk send for all of its destinations.
+	validators []Validator
+	// recipientValidators are run for each destination of a bulk send.
+	recipientValidators []Validator
+	sentEmailTracker    SentEmailTracker
+	failureConfig       FailureConfig
+	templateRenderer    TemplateRenderer
+	eventsStatsUpdater  EventsStatsUpdater
+	eventPublisher      EventPublisher
+	suppressionList     SuppressionList
+	reputationTracker   ReputationTracker
+	sendStatistics      SendStatisticsRecorder
+	messageCapturers    []MessageCapturer
+	messageDeliverer    MessageDeliverer
+}
+
+// Option configures an optional collaborator of EmailServiceImpl
+type Option func(*EmailServiceImpl)
+
+// WithRecipientValidators adds validators which only concern the recipients of a
+// message, such as their format or the sandbox.
+func WithRecipientValidators(validators ...Validator) Option {
+	return func(es *EmailServiceImpl) { es.recipientValidators = append(es.recipientValidators, validators...) }
+}
+
+// WithTemplateRenderer enables templated sends
+func WithTemplateRenderer(r TemplateRenderer) Option {
+	return func(es *EmailServiceImpl) { es.templateRenderer = r }
+}
+
+// WithEventsStatsUpdater counts events such as RenderingFailure in the stats
+func WithEventsStatsUpdater(u EventsStatsUpdater) Option {
+	return func(es *EmailServiceImpl) { es.eventsStatsUpdater = u }
+}
+
+// WithSuppressionList adds the recipients of simulated hard bounces and
+// complaints to the suppression list
+func WithSuppressionList(l SuppressionList) Option {
+	return func(es *EmailServiceImpl) { es.suppressionList = l }
+}
+
+// WithReputationTracker tracks the bounces and complaints of the recipients in
+// the reputation of the account
+func WithReputationTracker(t ReputationTracker) Option {
+	return func(es *EmailServiceImpl) { es.reputationTracker = t }
+}
+
+// WithSendStatistics counts the outcomes of the messages in the send statistics
+func WithSendStatistics(r SendStatisticsRecorder) Option {
+	return func(es *EmailServiceImpl) { es.sendStatistics = r }
+}
+
+// WithMessageCapture hands every accepted message to capturers, to keep it
+// for inspection or write it out
+func WithMessageCapture(capturers ...MessageCapturer) Option {
+	return func(es *EmailServiceImpl) { es.messageCapturers = append(es.messageCapturers, capturers...) }
+}
+
+// WithMessageDelivery hands every accepted message to d, for the recipients
+// that are neither suppressed nor mailbox simulator addresses
+func WithMessageDelivery(d MessageDeliverer) Option {
+	return func(es *EmailServiceImpl) { es.messageDeliverer = d }
 }
 
-func NewEmailService(validators []Validator, sentEmailTracker SentEmailTracker, cfg FailureConfig) EmailServiceImpl {
-	return EmailServiceImpl{validators: validators, sentEmailTracker: sentEmailTracker, failureConfig: cfg}
+func NewEmailService(validators []Validator, sentEmailTracker SentEmailTracker, cfg FailureConfig, opts ...Option) EmailServiceImpl {
+	es := EmailServiceImpl{validators: validators, sentEmailTracker: sentEmailTracker, failureConfig: cfg}
+	for _, opt := range opts {
+		opt(&es)
+	}
+	return es
 }
 
 func (es EmailServiceImpl) SendEmail(ctx context.Context, req model.EmailRequest) (*model.SESResponse, error) {
-	for _, v := range es.validators {
-		if err := v.Validate(ctx, req); err != nil {
+	var renderingFailure *RenderingFailure
+	if req.Template != "" || req.TemplateArn != "" {
+		var err error
+		if req, renderingFailure, err = es.render(ctx, req); err != nil {
 			return nil, err
 		}
 	}
 
-	if failure := es.randomFailure(); failure != nil {
-		return nil, failure
+	if err := validate(ctx, es.validators, req); err != nil {
+		return nil, es.reject(ctx, req, err)
+	}
+
+	return es.send(ctx, req, renderingFailure)
+}
+
+// render replaces the message of req by its rendered template. A
+// *RenderingFailure is returned separately as it does not fail the request.
+func (es EmailServiceImpl) render(ctx context.Context, req model.EmailRequest) (model.EmailRequest, *RenderingFailure, error) {
+	if es.templateRenderer == nil {
+		return req, nil, &model.SESError{Code: "InvalidParameterValue", Message: "Templated sending is not enabled."}
+	}
+
+	var renderingFailure *RenderingFailure
+	msg, err := es.templateRenderer.Render(ctx, req)
+	if err != nil && !errors.As(err, &renderingFailure) {
+		return req, nil, err
+	}
+	req.Message.Subject, req.Message.Body = msg.Subject, msg.Body
+	return req, renderingFailure, nil
+}
+
+// send runs the recipient level checks and accepts the message.
+func (es EmailServiceImpl) send(ctx context.Context, req model.EmailRequest, renderingFailure *RenderingFailure) (*model.SESResponse, error) {
+	var suppressed *model.SuppressedRecipients
+	if err := validate(ctx, es.recipientValidators, req); err != nil && !errors.As(err, &suppressed) {
+		return nil, es.reject(ctx, req, err)
+	}
+
+	// The mailbox simulator is meant for deterministic outcomes, random failures
+	// would defeat it.
+	if !simulator.AllSimulated(req.Destination.All()) {
+		if failure := es.randomFailure(); failure != nil {
+			return nil, es.reject(ctx, req, failure)
+		}
 	}
 
 	msgID := generateMessageID()
+	sentAt := time.Now().UTC()
+	m := newCapturedMessage(req, msgID, sentAt)
+
+	// A message refused by the delivery backend is not accepted.
+	var bounces map[string]simulator.Bounce
+	if renderingFailure == nil {
+		var err error
+		if bounces, err = es.deliverMessage(ctx, m, suppressed); err != nil {
+			return nil, es.reject(ctx, req, err)
+		}
+	}
+	// Once delivered, the message is sent whatever the deadline of the caller,
+	// failing now would have it retried and delivered twice.
+	ctx = context.WithoutCancel(ctx)
 
 	// For every message that you send, the total number of recipients
 	// (including each recipient in the To:, CC: and BCC: fields) is counted
@@ -50,15 +218,225 @@ func (es EmailServiceImpl) SendEmail(ctx context.Context, req model.EmailRequest
 	// (your sending quota), therefore we have to track each email being sent
 	// to all the receipients.
 	// In short - "One email is multiplexed to many recipients".
+	// Mailbox simulator addresses do not count against the quota.
 	for _, dest := range req.Destination.All() {
+		if simulator.IsSimulatorAddress(dest) {
+			continue
+		}
 		if err := es.sentEmailTracker.TrackSentEmail(ctx, msgID+"-"+dest); err != nil {
-			return nil, &model.SESError{Code: "InternalFailure", Message: "Unexpected internal error occurred."}
+			log.Printf("Failed to track message %s to %s: %v", msgID, dest, err)
 		}
 	}
 
+	es.capture(ctx, m)
+	eventMail := newEventMail(req, msgID, sentAt)
+	es.publish(ctx, req, model.Event{EventType: model.EventTypeSend, Mail: eventMail, Send: &struct{}{}})
+
+	if renderingFailure != nil {
+		// The message has been accepted, it just never gets delivered.
+		log.Printf("Message %s: %v", msgID, renderingFailure)
+		es.incrementEvent(ctx, "RenderingFailure")
+		es.publish(ctx, req, model.Event{EventType: model.EventTypeRenderingFailure, Mail: eventMail, Failure: &model.FailureEvent{
+			TemplateName: renderingFailure.TemplateName,
+			ErrorMessage: renderingFailure.ErrorMessage,
+		}})
+		return &model.SESResponse{MessageID: msgID}, nil
+	}
+
+	es.deliver(ctx, req, eventMail, sentAt, suppressed, bounces)
+
 	return &model.SESResponse{MessageID: msgID}, nil
 }
 
+// validate runs validators in order and returns the first error. The
+// *model.SuppressedRecipients of a suppression list validator does not stop the
+// validation, it is returned once all the other validators passed.
+func validate(ctx context.Context, validators []Validator, req model.EmailRequest) error {
+	var suppressed *model.SuppressedRecipients
+	for _, v := range validators {
+		err := v.Validate(ctx, req)
+		var s *model.SuppressedRecipients
+		if errors.As(err, &s) {
+			suppressed = s
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if suppressed != nil {
+		return suppressed
+	}
+	return nil
+}
+
+// deliverMessage hands the message to the delivery backend, for the
+// recipients that are neither suppressed nor mailbox simulator addresses.
+func (es EmailServiceImpl) deliverMessage(ctx context.Context, m model.CapturedMessage, suppressed *model.SuppressedRecipients) (map[string]simulator.Bounce, error) {
+	if es.messageDeliverer == nil {
+		return nil, nil
+	}
+
+	var recipients []string
+	for _, dest := range m.Destination.All() {
+		if !suppressed.Contains(dest) && !simulator.IsSimulatorAddress(dest) {
+			recipients = append(recipients, dest)
+		}
+	}
+	if len(recipients) == 0 {
+		return nil, nil
+	}
+	return es.messageDeliverer.DeliverMessage(ctx, m, recipients)
+}
+
+// deliver publishes what happened to the message for each of its recipients.
+// Regular recipients get it delivered, suppressed ones bounce, and mailbox
+// simulator addresses get their documented outcome, as do the recipients the
+// delivery backend bounced. Outcomes are also counted in the stats and in the
+// reputation of the account.
+func (es EmailServiceImpl) deliver(ctx context.Context, req model.EmailRequest, eventMail model.EventMail, sentAt time.Time, suppressed *model.SuppressedRecipients, bounces map[string]simulator.Bounce) {
+	sample := model.ReputationSample{MessageID: eventMail.MessageID, ConfigurationSetName: req.ConfigurationSetName}
+	for _, dest := range req.Destination.All() {
+		outcome, simulated := simulator.Lookup(dest)
+		if b, ok := bounces[dest]; ok {
+			outcome, simulated = simulator.Outcome{Bounce: &b}, true
+		}
+		switch {
+		case suppressed.Contains(dest):
+			// Suppressed recipients are not sent to, they do not count in the
+			// reputation either.
+			log.Printf("Message %s: %s is on the suppression list", eventMail.MessageID, dest)
+			outcome, simulated = suppressedOutcome, false
+		case simulated:
+			sample.Sends++
+			if outcome.Bounce != nil && outcome.Bounce.Type == "Permanent" {
+				sample.Bounces++
+			}
+			if outcome.ComplaintFeedbackType != "" {
+				sample.Complaints++
+			}
+			for _, event := range outcome.Events() {
+				log.Printf("Message %s: simulated %s for %s", eventMail.MessageID, event, dest)
+				es.incrementEvent(ctx, event)
+			}
+		default:
+			sample.Sends++
+			outcome = simulator.Outcome{Delivered: true}
+		}
+		for _, event := range outcomeEvents(eventMail, dest, outcome, sentAt, time.Now().UTC()) {
+			es.publish(ctx, req, event)
+			if simulated {
+				es.suppress(ctx, req, dest, event)
+			}
+		}
+	}
+
+	es.trackReputation(ctx, sample)
+	es.addSendDataPoint(ctx, model.SendDataPoint{
+		Timestamp:        sentAt,
+		DeliveryAttempts: int64(sample.Sends),
+		Bounces:          int64(sample.Bounces),
+		Complaints:       int64(sample.Complaints),
+	})
+}
+
+// suppress adds recipient to the suppression list when event is a hard bounce
+// or a complaint.
+func (es EmailServiceImpl) suppress(ctx context.Context, req model.EmailRequest, recipient string, event model.Event) {
+	if es.suppressionList == nil {
+		return
+	}
+
+	d := model.SuppressedDestination{EmailAddress: recipient, Attributes: &model.SuppressedDestinationAttributes{MessageID: event.Mail.MessageID}}
+	switch {
+	case event.Bounce != nil && event.Bounce.BounceType == "Permanent" && event.Bounce.BounceSubType != "Suppressed":
+		d.Reason, d.Attributes.FeedbackID = model.SuppressionReasonBounce, event.Bounce.FeedbackID
+	case event.Complaint != nil:
+		d.Reason, d.Attributes.FeedbackID = model.SuppressionReasonComplaint, event.Complaint.FeedbackID
+	default:
+		return
+	}
+
+	if err := es.suppressionList.SuppressRecipient(ctx, req.ConfigurationSetName, d); err != nil {
+		log.Printf("Failed to suppress %s: %v", recipient, err)
+	}
+}
+
+func newCapturedMessage(req model.EmailRequest, msgID string, sentAt time.Time) model.CapturedMessage {
+	m := model.CapturedMessage{
+		MessageID:            msgID,
+		Source:               req.Source,
+		Destination:          req.Destination,
+		ReplyToAddresses:     req.ReplyToAddresses,
+		ReturnPath:           req.ReturnPath,
+		Subject:              req.Message.Subject.Data,
+		TextBody:             req.Message.Body.Text.Data,
+		Headers:              req.Message.Headers,
+		Attachments:          req.Message.Attachments,
+		Tags:                 req.Tags,
+		ConfigurationSetName: req.ConfigurationSetName,
+		Template:             req.Template,
+		TemplateData:         req.TemplateData,
+		SentAt:               sentAt,
+	}
+	if req.Message.Body.Html != nil {
+		m.HtmlBody = req.Message.Body.Html.Data
+	}
+	if req.RawMessage != nil {
+		m.RawMessage = req.RawMessage.Data
+	}
+	return m
+}
+
+func (es EmailServiceImpl) capture(ctx context.Context, m model.CapturedMessage) {
+	for _, c := range es.messageCapturers {
+		if err := c.CaptureMessage(ctx, m); err != nil {
+			log.Printf("Failed to capture message %s: %v", m.MessageID, err)
+		}
+	}
+}
+
+func (es EmailServiceImpl) trackReputation(ctx context.Context, sample model.ReputationSample) {
+	if es.reputationTracker == nil || sample.Sends == 0 {
+		return
+	}
+	if err := es.reputationTracker.TrackReputation(ctx, sample); err != nil {
+		log.Printf("Failed to track the reputation of message %s: %v", sample.MessageID, err)
+	}
+}
+
+// reject counts a MessageRejected error in the send statistics and publishes
+// a Reject event for it, and returns it.
+func (es EmailServiceImpl) reject(ctx context.Context, req model.EmailRequest, err error) error {
+	var sesErr *model.SESError
+	if errors.As(err, &sesErr) && sesErr.Code == "MessageRejected" {
+		now := time.Now().UTC()
+		es.addSendDataPoint(ctx, model.SendDataPoint{Timestamp: now, Rejects: 1})
+		es.publish(ctx, req, model.Event{EventType: model.EventTypeReject, Mail: newEventMail(req, generateMessageID(), now), Reject: &model.RejectEvent{
+			Reason: sesErr.Message,
+		}})
+	}
+	return err
+}
+
+func (es EmailServiceImpl) addSendDataPoint(ctx context.Context, p model.SendDataPoint) {
+	if es.sendStatistics == nil || p == (model.SendDataPoint{Timestamp: p.Timestamp}) {
+		return
+	}
+	if err := es.sendStatistics.AddSendDataPoint(ctx, p); err != nil {
+		log.Printf("Failed to add send data point: %v", err)
+	}
+}
+
+func (es EmailServiceImpl) incrementEvent(ctx context.Context, eventType string) {
+	if es.eventsStatsUpdater == nil {
+		return
+	}
+	if err := es.eventsStatsUpdater.IncrementEvent(ctx, eventType); err != nil {
+		log.Printf("Failed to count %s event: %v", eventType, err)
+	}
+}
+
 func (es EmailServiceImpl) randomFailure() *model.SESError {
 	if !es.failureConfig.FailRandomly {
 		return nil
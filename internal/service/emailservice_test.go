@@ -3,11 +3,13 @@ package service_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/kamal-github/demtech/internal/model"
 	"github.com/kamal-github/demtech/internal/service"
 	"github.com/kamal-github/demtech/internal/service/mocks"
+	"github.com/kamal-github/demtech/internal/simulator"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -42,14 +44,14 @@ func TestEmailServiceImpl_SendEmail(t *testing.T) {
 			expectErr:  true,
 		},
 		{
-			name: "Tracking failure",
+			name: "Tracking failure after delivery is only logged",
 			validators: []func(*mocks.MockValidator){
 				func(mv *mocks.MockValidator) {
 					mv.EXPECT().Validate(gomock.Any(), gomock.Any()).Return(nil).Times(1)
 				},
 			},
 			trackerErr: assert.AnError,
-			expectErr:  true,
+			expectErr:  false,
 		},
 	}
 
@@ -80,3 +82,656 @@ func TestEmailServiceImpl_SendEmail(t *testing.T) {
 		})
 	}
 }
+
+func TestEmailServiceImpl_SendEmail_TrackingFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTracker := mocks.NewMockSentEmailTracker(ctrl)
+	mockCapturer := mocks.NewMockMessageCapturer(ctrl)
+	mockPublisher := mocks.NewMockEventPublisher(ctrl)
+
+	// The message is delivered by then, failing it would have it sent twice.
+	mockTracker.EXPECT().TrackSentEmail(gomock.Any(), gomock.Any()).Return(assert.AnError)
+	mockCapturer.EXPECT().CaptureMessage(gomock.Any(), gomock.Any()).Return(nil)
+	var events []string
+	mockPublisher.EXPECT().Publish(gomock.Any(), "marketing", gomock.Any()).
+		Do(func(_ context.Context, _ string, e model.Event) { events = append(events, e.EventType) }).
+		AnyTimes()
+
+	es := service.NewEmailService(nil, mockTracker, service.FailureConfig{},
+		service.WithMessageCapture(mockCapturer),
+		service.WithEventPublisher(mockPublisher),
+	)
+
+	resp, err := es.SendEmail(context.Background(), model.EmailRequest{
+		Source:               "sender@example.com",
+		Destination:          model.Destination{ToAddresses: []string{"test@example.com"}},
+		ConfigurationSetName: "marketing",
+	})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, resp.MessageID)
+	assert.Equal(t, []string{model.EventTypeSend, model.EventTypeDelivery}, events)
+}
+
+func TestEmailServiceImpl_SendEmail_Templated(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	req := model.EmailRequest{
+		Source:       "sender@example.com",
+		Destination:  model.Destination{ToAddresses: []string{"test@example.com"}},
+		Template:     "welcome",
+		TemplateData: `{"name":"Alex"}`,
+	}
+	rendered := model.Message{
+		Subject: model.Subject{Data: "Hi Alex"},
+		Body:    model.Body{Text: model.TextBody{Data: "Welcome Alex"}},
+	}
+
+	tests := []struct {
+		name        string
+		renderMsg   model.Message
+		renderErr   error
+		expectEvent bool
+		expectErr   bool
+	}{
+		{
+			name:      "Rendered message is validated and sent",
+			renderMsg: rendered,
+		},
+		{
+			name:        "Rendering failure still accepts the message",
+			renderErr:   &service.RenderingFailure{TemplateName: "welcome", ErrorMessage: "Attribute 'name' is not present in the rendering data."},
+			expectEvent: true,
+		},
+		{
+			name:      "Missing template is rejected",
+			renderErr: &model.SESError{Code: "TemplateDoesNotExistException", Message: "Template welcome does not exist."},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			mockValidator := mocks.NewMockValidator(ctrl)
+			mockTracker := mocks.NewMockSentEmailTracker(ctrl)
+			mockRenderer := mocks.NewMockTemplateRenderer(ctrl)
+			mockEvents := mocks.NewMockEventsStatsUpdater(ctrl)
+
+			mockRenderer.EXPECT().Render(gomock.Any(), req).Return(tt.renderMsg, tt.renderErr).Times(1)
+			if !tt.expectErr {
+				mockValidator.EXPECT().Validate(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, got model.EmailRequest) error {
+						assert.Equal(tt.renderMsg, got.Message)
+						return nil
+					}).Times(1)
+				mockTracker.EXPECT().TrackSentEmail(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+			}
+			if tt.expectEvent {
+				mockEvents.EXPECT().IncrementEvent(gomock.Any(), "RenderingFailure").Return(nil).Times(1)
+			}
+
+			es := service.NewEmailService([]service.Validator{mockValidator}, mockTracker, service.FailureConfig{},
+				service.WithTemplateRenderer(mockRenderer),
+				service.WithEventsStatsUpdater(mockEvents),
+			)
+
+			resp, err := es.SendEmail(context.Background(), req)
+
+			if tt.expectErr {
+				assert.Error(err)
+				return
+			}
+			assert.NoError(err)
+			assert.NotEmpty(resp.MessageID)
+		})
+	}
+}
+
+func TestEmailServiceImpl_SendEmail_MailboxSimulator(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name         string
+		dest         model.Destination
+		failRandomly bool
+		expectTracks int
+		expectEvents []string
+	}{
+		{
+			name:         "Delivery",
+			dest:         model.Destination{ToAddresses: []string{"success@simulator.amazonses.com"}},
+			failRandomly: true, // random failures never apply to the mailbox simulator
+			expectEvents: []string{"Delivery"},
+		},
+		{
+			name:         "Bounce and complaint",
+			dest:         model.Destination{ToAddresses: []string{"bounce@simulator.amazonses.com"}, CcAddresses: []string{"complaint@simulator.amazonses.com"}},
+			expectEvents: []string{"Bounce", "Delivery", "Complaint"},
+		},
+		{
+			name:         "Only real recipients count against the quota",
+			dest:         model.Destination{ToAddresses: []string{"test@example.com", "ooto@simulator.amazonses.com"}},
+			expectTracks: 1,
+			expectEvents: []string{"Delivery", "AutoResponse"},
+		},
+		{
+			name:         "Suppression list",
+			dest:         model.Destination{BccAddresses: []string{"suppressionlist+qa@simulator.amazonses.com"}},
+			expectEvents: []string{"Bounce"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			mockTracker := mocks.NewMockSentEmailTracker(ctrl)
+			mockEvents := mocks.NewMockEventsStatsUpdater(ctrl)
+
+			mockTracker.EXPECT().TrackSentEmail(gomock.Any(), gomock.Any()).Return(nil).Times(tt.expectTracks)
+			var events []string
+			mockEvents.EXPECT().IncrementEvent(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, eventType string) error {
+					events = append(events, eventType)
+					return nil
+				}).AnyTimes()
+
+			es := service.NewEmailService(nil, mockTracker, service.FailureConfig{FailRandomly: tt.failRandomly, FailPercentage: 100},
+				service.WithEventsStatsUpdater(mockEvents),
+			)
+
+			resp, err := es.SendEmail(context.Background(), model.EmailRequest{Source: "sender@example.com", Destination: tt.dest})
+
+			assert.NoError(err)
+			assert.NotEmpty(resp.MessageID)
+			assert.Equal(tt.expectEvents, events)
+		})
+	}
+}
+
+func TestEmailServiceImpl_SendEmail_PublishesEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name         string
+		req          model.EmailRequest
+		renderErr    error
+		expectEvents []string
+	}{
+		{
+			name: "Delivered to each recipient",
+			req: model.EmailRequest{
+				Destination:          model.Destination{ToAddresses: []string{"test@example.com"}, CcAddresses: []string{"bounce@simulator.amazonses.com"}},
+				ConfigurationSetName: "default-config",
+			},
+			expectEvents: []string{"Send", "Delivery", "Bounce"},
+		},
+		{
+			name: "Rendering failure is never delivered",
+			req: model.EmailRequest{
+				Destination:          model.Destination{ToAddresses: []string{"test@example.com"}},
+				ConfigurationSetName: "default-config",
+				Template:             "welcome",
+			},
+			renderErr:    &service.RenderingFailure{TemplateName: "welcome", ErrorMessage: "Attribute 'name' is not present in the rendering data."},
+			expectEvents: []string{"Send", "Rendering Failure"},
+		},
+		{
+			name: "Nothing is published without a configuration set",
+			req: model.EmailRequest{
+				Destination: model.Destination{ToAddresses: []string{"test@example.com"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			tt.req.Source = "Sender <sender@example.com>"
+			tt.req.Tags = []model.Tag{{Name: "campaign", Value: "welcome"}}
+
+			mockTracker := mocks.NewMockSentEmailTracker(ctrl)
+			mockRenderer := mocks.NewMockTemplateRenderer(ctrl)
+			mockPublisher := mocks.NewMockEventPublisher(ctrl)
+
+			mockTracker.EXPECT().TrackSentEmail(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			mockRenderer.EXPECT().Render(gomock.Any(), gomock.Any()).Return(model.Message{}, tt.renderErr).AnyTimes()
+
+			var events []model.Event
+			mockPublisher.EXPECT().Publish(gomock.Any(), "default-config", gomock.Any()).
+				Do(func(_ context.Context, _ string, e model.Event) { events = append(events, e) }).
+				AnyTimes()
+
+			es := service.NewEmailService(nil, mockTracker, service.FailureConfig{},
+				service.WithTemplateRenderer(mockRenderer),
+				service.WithEventPublisher(mockPublisher),
+			)
+
+			resp, err := es.SendEmail(context.Background(), tt.req)
+			assert.NoError(err)
+
+			var types []string
+			for _, e := range events {
+				types = append(types, e.EventType)
+				assert.Equal(resp.MessageID, e.Mail.MessageID)
+				assert.Equal(tt.req.Destination.All(), e.Mail.Destination)
+				assert.Equal([]string{"example.com"}, e.Mail.Tags["ses:from-domain"])
+				assert.Equal([]string{"welcome"}, e.Mail.Tags["campaign"])
+			}
+			assert.Equal(tt.expectEvents, types)
+
+			if len(events) == 3 {
+				assert.NotNil(events[0].Send)
+				assert.Equal([]string{"test@example.com"}, events[1].Delivery.Recipients)
+				assert.Equal("bounce@simulator.amazonses.com", events[2].Bounce.BouncedRecipients[0].EmailAddress)
+				assert.Equal("Permanent", events[2].Bounce.BounceType)
+			}
+		})
+	}
+}
+
+func TestEmailServiceImpl_SendEmail_SuppressionList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name           string
+		dest           model.Destination
+		validatorErrs  []error
+		expectErr      string
+		expectEvents   []string
+		expectSuppress []model.SuppressedDestination
+	}{
+		{
+			name:          "Suppressed recipient bounces",
+			dest:          model.Destination{ToAddresses: []string{"test@example.com", "bounced@example.com"}},
+			validatorErrs: []error{&model.SuppressedRecipients{Addresses: []string{"bounced@example.com"}}, nil},
+			expectEvents:  []string{"Send", "Delivery", "Bounce"},
+		},
+		{
+			name:          "Other validators still reject the message",
+			dest:          model.Destination{ToAddresses: []string{"bounced@example.com"}},
+			validatorErrs: []error{&model.SuppressedRecipients{Addresses: []string{"bounced@example.com"}}, &model.SESError{Code: "MessageRejected", Message: "Cannot send emails outside sandbox"}},
+			expectErr:     "MessageRejected: Cannot send emails outside sandbox",
+		},
+		{
+			name:          "Simulated hard bounce and complaint are suppressed",
+			dest:          model.Destination{ToAddresses: []string{"bounce@simulator.amazonses.com", "complaint@simulator.amazonses.com", "suppressionlist@simulator.amazonses.com"}},
+			validatorErrs: []error{nil, nil},
+			expectEvents:  []string{"Send", "Bounce", "Delivery", "Complaint", "Bounce"},
+			expectSuppress: []model.SuppressedDestination{
+				{EmailAddress: "bounce@simulator.amazonses.com", Reason: "BOUNCE"},
+				{EmailAddress: "complaint@simulator.amazonses.com", Reason: "COMPLAINT"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			mockTracker := mocks.NewMockSentEmailTracker(ctrl)
+			mockPublisher := mocks.NewMockEventPublisher(ctrl)
+			mockSuppressionList := mocks.NewMockSuppressionList(ctrl)
+
+			var recipientValidators []service.Validator
+			for _, err := range tt.validatorErrs {
+				v := mocks.NewMockValidator(ctrl)
+				v.EXPECT().Validate(gomock.Any(), gomock.Any()).Return(err)
+				recipientValidators = append(recipientValidators, v)
+			}
+
+			mockTracker.EXPECT().TrackSentEmail(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			var events []model.Event
+			mockPublisher.EXPECT().Publish(gomock.Any(), "default-config", gomock.Any()).
+				Do(func(_ context.Context, _ string, e model.Event) { events = append(events, e) }).
+				AnyTimes()
+			var suppressed []model.SuppressedDestination
+			mockSuppressionList.EXPECT().SuppressRecipient(gomock.Any(), "default-config", gomock.Any()).
+				DoAndReturn(func(_ context.Context, _ string, d model.SuppressedDestination) error {
+					assert.NotEmpty(d.Attributes.MessageID)
+					assert.NotEmpty(d.Attributes.FeedbackID)
+					suppressed = append(suppressed, model.SuppressedDestination{EmailAddress: d.EmailAddress, Reason: d.Reason})
+					return nil
+				}).AnyTimes()
+
+			es := service.NewEmailService(nil, mockTracker, service.FailureConfig{},
+				service.WithRecipientValidators(recipientValidators...),
+				service.WithEventPublisher(mockPublisher),
+				service.WithSuppressionList(mockSuppressionList),
+			)
+
+			_, err := es.SendEmail(context.Background(), model.EmailRequest{Source: "sender@example.com", Destination: tt.dest, ConfigurationSetName: "default-config"})
+
+			if tt.expectErr != "" {
+				assert.EqualError(err, tt.expectErr)
+				return
+			}
+			assert.NoError(err)
+
+			var types []string
+			for _, e := range events {
+				types = append(types, e.EventType)
+				if e.Bounce != nil && e.Bounce.BouncedRecipients[0].EmailAddress == "bounced@example.com" {
+					assert.Equal("OnAccountSuppressionList", e.Bounce.BounceSubType)
+				}
+			}
+			assert.Equal(tt.expectEvents, types)
+			assert.Equal(tt.expectSuppress, suppressed)
+		})
+	}
+}
+
+func TestEmailServiceImpl_SendEmail_Reputation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTracker := mocks.NewMockSentEmailTracker(ctrl)
+	mockReputation := mocks.NewMockReputationTracker(ctrl)
+	suppressionValidator := mocks.NewMockValidator(ctrl)
+
+	mockTracker.EXPECT().TrackSentEmail(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	suppressionValidator.EXPECT().Validate(gomock.Any(), gomock.Any()).Return(&model.SuppressedRecipients{Addresses: []string{"suppressed@example.com"}})
+
+	// Suppressed recipients are not sent to, they are left out of the sample.
+	mockReputation.EXPECT().TrackReputation(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, s model.ReputationSample) error {
+		assert.NotEmpty(t, s.MessageID)
+		assert.Equal(t, model.ReputationSample{MessageID: s.MessageID, ConfigurationSetName: "marketing", Sends: 4, Bounces: 1, Complaints: 1}, s)
+		return nil
+	})
+
+	es := service.NewEmailService(nil, mockTracker, service.FailureConfig{},
+		service.WithRecipientValidators(suppressionValidator),
+		service.WithReputationTracker(mockReputation),
+	)
+
+	_, err := es.SendEmail(context.Background(), model.EmailRequest{Source: "sender@example.com", Destination: model.Destination{
+		ToAddresses: []string{
+			"test@example.com",
+			"suppressed@example.com",
+			"bounce@simulator.amazonses.com",
+			"complaint@simulator.amazonses.com",
+			"ooto@simulator.amazonses.com",
+		},
+	}, ConfigurationSetName: "marketing"})
+	assert.NoError(t, err)
+}
+
+func TestEmailServiceImpl_SendEmail_SendStatistics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name        string
+		validateErr error
+		expectPoint model.SendDataPoint
+	}{
+		{
+			name:        "Outcomes of the recipients",
+			expectPoint: model.SendDataPoint{DeliveryAttempts: 3, Bounces: 1, Complaints: 1},
+		},
+		{
+			name:        "Rejected message",
+			validateErr: &model.SESError{Code: "MessageRejected", Message: "Email address is not verified."},
+			expectPoint: model.SendDataPoint{Rejects: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockTracker := mocks.NewMockSentEmailTracker(ctrl)
+			mockStatistics := mocks.NewMockSendStatisticsRecorder(ctrl)
+			mockValidator := mocks.NewMockValidator(ctrl)
+
+			mockTracker.EXPECT().TrackSentEmail(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			mockValidator.EXPECT().Validate(gomock.Any(), gomock.Any()).Return(tt.validateErr)
+			mockStatistics.EXPECT().AddSendDataPoint(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, p model.SendDataPoint) error {
+				assert.WithinDuration(t, time.Now(), p.Timestamp, time.Second)
+				tt.expectPoint.Timestamp = p.Timestamp
+				assert.Equal(t, tt.expectPoint, p)
+				return nil
+			})
+
+			es := service.NewEmailService([]service.Validator{mockValidator}, mockTracker, service.FailureConfig{},
+				service.WithSendStatistics(mockStatistics),
+			)
+
+			_, err := es.SendEmail(context.Background(), model.EmailRequest{Source: "sender@example.com", Destination: model.Destination{
+				ToAddresses: []string{"test@example.com", "bounce@simulator.amazonses.com", "complaint@simulator.amazonses.com"},
+			}})
+			assert.Equal(t, tt.validateErr, err)
+		})
+	}
+}
+
+func TestEmailServiceImpl_SendEmail_RejectEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name         string
+		validateErr  error
+		expectReason string
+	}{
+		{
+			name:         "Rejected message",
+			validateErr:  &model.SESError{Code: "MessageRejected", Message: "Email address is not verified."},
+			expectReason: "Email address is not verified.",
+		},
+		{
+			name:        "Other errors are not rejects",
+			validateErr: &model.SESError{Code: "Throttling", Message: "Maximum sending rate exceeded."},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockValidator := mocks.NewMockValidator(ctrl)
+			mockPublisher := mocks.NewMockEventPublisher(ctrl)
+
+			mockValidator.EXPECT().Validate(gomock.Any(), gomock.Any()).Return(tt.validateErr)
+			var events []model.Event
+			mockPublisher.EXPECT().Publish(gomock.Any(), "marketing", gomock.Any()).
+				Do(func(_ context.Context, _ string, e model.Event) { events = append(events, e) }).
+				AnyTimes()
+
+			es := service.NewEmailService([]service.Validator{mockValidator}, mocks.NewMockSentEmailTracker(ctrl), service.FailureConfig{},
+				service.WithEventPublisher(mockPublisher),
+			)
+
+			_, err := es.SendEmail(context.Background(), model.EmailRequest{
+				Source:               "sender@example.com",
+				Destination:          model.Destination{ToAddresses: []string{"test@example.com"}},
+				ConfigurationSetName: "marketing",
+			})
+			assert.Equal(t, tt.validateErr, err)
+
+			if tt.expectReason == "" {
+				assert.Empty(t, events)
+				return
+			}
+			if assert.Len(t, events, 1) {
+				assert.Equal(t, model.EventTypeReject, events[0].EventType)
+				assert.Equal(t, &model.RejectEvent{Reason: tt.expectReason}, events[0].Reject)
+				assert.NotEmpty(t, events[0].Mail.MessageID)
+				assert.Equal(t, []string{"test@example.com"}, events[0].Mail.Destination)
+			}
+		})
+	}
+}
+
+func TestEmailServiceImpl_SendEmail_RandomRejectEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPublisher := mocks.NewMockEventPublisher(ctrl)
+	var events []model.Event
+	mockPublisher.EXPECT().Publish(gomock.Any(), "marketing", gomock.Any()).
+		Do(func(_ context.Context, _ string, e model.Event) { events = append(events, e) }).
+		AnyTimes()
+
+	es := service.NewEmailService(nil, mocks.NewMockSentEmailTracker(ctrl), service.FailureConfig{FailRandomly: true, FailPercentage: 100},
+		service.WithEventPublisher(mockPublisher),
+	)
+
+	// Every send fails, the random MessageRejected ones are published as rejects.
+	rejects := 0
+	for i := 0; i < 200; i++ {
+		_, err := es.SendEmail(context.Background(), model.EmailRequest{
+			Source:               "sender@example.com",
+			Destination:          model.Destination{ToAddresses: []string{"test@example.com"}},
+			ConfigurationSetName: "marketing",
+		})
+		var sesErr *model.SESError
+		if assert.ErrorAs(t, err, &sesErr) && sesErr.Code == "MessageRejected" {
+			rejects++
+		}
+	}
+
+	assert.NotZero(t, rejects)
+	assert.Len(t, events, rejects)
+	for _, e := range events {
+		assert.Equal(t, model.EventTypeReject, e.EventType)
+		assert.Equal(t, &model.RejectEvent{Reason: "Message rejected."}, e.Reject)
+	}
+}
+
+func TestEmailServiceImpl_SendEmail_CapturesMessage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTracker := mocks.NewMockSentEmailTracker(ctrl)
+	mockCapturer := mocks.NewMockMessageCapturer(ctrl)
+	mockSink := mocks.NewMockMessageCapturer(ctrl)
+
+	req := model.EmailRequest{
+		Source:      "sender@example.com",
+		Destination: model.Destination{ToAddresses: []string{"to@example.com"}, BccAddresses: []string{"bcc@example.com"}},
+		Message: model.Message{
+			Subject: model.Subject{Data: "Welcome"},
+			Body:    model.Body{Text: model.TextBody{Data: "Hello"}, Html: &model.HtmlBody{Data: "<p>Hello</p>"}},
+			Headers: []model.MessageHeader{{Name: "X-Campaign", Value: "welcome"}},
+		},
+		ConfigurationSetName: "marketing",
+		Tags:                 []model.Tag{{Name: "campaign", Value: "welcome"}},
+	}
+
+	var captured model.CapturedMessage
+	mockTracker.EXPECT().TrackSentEmail(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	mockCapturer.EXPECT().CaptureMessage(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, m model.CapturedMessage) error {
+		captured = m
+		return nil
+	})
+	// A failing capturer neither fails the message nor skips the others.
+	mockSink.EXPECT().CaptureMessage(gomock.Any(), gomock.Any()).Return(assert.AnError)
+
+	es := service.NewEmailService(nil, mockTracker, service.FailureConfig{}, service.WithMessageCapture(mockSink), service.WithMessageCapture(mockCapturer))
+
+	resp, err := es.SendEmail(context.Background(), req)
+	assert.NoError(t, err)
+
+	assert.WithinDuration(t, time.Now(), captured.SentAt, time.Second)
+	assert.Equal(t, model.CapturedMessage{
+		MessageID:            resp.MessageID,
+		Source:               "sender@example.com",
+		Destination:          req.Destination,
+		Subject:              "Welcome",
+		TextBody:             "Hello",
+		HtmlBody:             "<p>Hello</p>",
+		Headers:              req.Message.Headers,
+		Tags:                 req.Tags,
+		ConfigurationSetName: "marketing",
+		SentAt:               captured.SentAt,
+	}, captured)
+}
+
+func TestEmailServiceImpl_SendEmail_DeliversMessage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	req := model.EmailRequest{
+		Source: "sender@example.com",
+		Destination: model.Destination{
+			ToAddresses: []string{"jane@example.com", "unknown@example.com"},
+			CcAddresses: []string{"success@simulator.amazonses.com"},
+		},
+		Message:              model.Message{Subject: model.Subject{Data: "Welcome"}},
+		ConfigurationSetName: "default-config",
+	}
+	unknown := simulator.Bounce{Type: "Permanent", SubType: "General", Status: "5.1.1", DiagnosticCode: "smtp; 550 5.1.1 User unknown"}
+
+	t.Run("Refused recipients bounce", func(t *testing.T) {
+		assert := assert.New(t)
+		mockTracker := mocks.NewMockSentEmailTracker(ctrl)
+		mockDeliverer := mocks.NewMockMessageDeliverer(ctrl)
+		mockPublisher := mocks.NewMockEventPublisher(ctrl)
+		mockSuppression := mocks.NewMockSuppressionList(ctrl)
+
+		mockTracker.EXPECT().TrackSentEmail(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+		// Mailbox simulator addresses are never delivered for real.
+		mockDeliverer.EXPECT().DeliverMessage(gomock.Any(), gomock.Any(), []string{"jane@example.com", "unknown@example.com"}).
+			DoAndReturn(func(_ context.Context, m model.CapturedMessage, _ []string) (map[string]simulator.Bounce, error) {
+				assert.Equal("Welcome", m.Subject)
+				return map[string]simulator.Bounce{"unknown@example.com": unknown}, nil
+			})
+		var events []model.Event
+		mockPublisher.EXPECT().Publish(gomock.Any(), "default-config", gomock.Any()).
+			Do(func(_ context.Context, _ string, e model.Event) { events = append(events, e) }).
+			AnyTimes()
+		mockSuppression.EXPECT().SuppressRecipient(gomock.Any(), "default-config", gomock.Any()).
+			Do(func(_ context.Context, _ string, d model.SuppressedDestination) {
+				assert.Equal("unknown@example.com", d.EmailAddress)
+				assert.Equal(model.SuppressionReasonBounce, d.Reason)
+			})
+
+		es := service.NewEmailService(nil, mockTracker, service.FailureConfig{},
+			service.WithMessageDelivery(mockDeliverer),
+			service.WithEventPublisher(mockPublisher),
+			service.WithSuppressionList(mockSuppression),
+		)
+		_, err := es.SendEmail(context.Background(), req)
+		assert.NoError(err)
+
+		var types []string
+		for _, e := range events {
+			types = append(types, e.EventType)
+		}
+		assert.Equal([]string{"Send", "Delivery", "Bounce", "Delivery"}, types)
+		assert.Equal("smtp; 550 5.1.1 User unknown", events[2].Bounce.BouncedRecipients[0].DiagnosticCode)
+	})
+
+	t.Run("Refused message is not accepted", func(t *testing.T) {
+		mockTracker := mocks.NewMockSentEmailTracker(ctrl)
+		mockDeliverer := mocks.NewMockMessageDeliverer(ctrl)
+
+		refused := &model.SESError{Code: "MessageRejected", Message: "The SMTP relay rejected the message: 550 5.7.1 Sender refused"}
+		mockDeliverer.EXPECT().DeliverMessage(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, refused)
+
+		es := service.NewEmailService(nil, mockTracker, service.FailureConfig{}, service.WithMessageDelivery(mockDeliverer))
+		_, err := es.SendEmail(context.Background(), req)
+		assert.Equal(t, refused, err)
+	})
+
+	t.Run("Deadline after delivery", func(t *testing.T) {
+		mockTracker := mocks.NewMockSentEmailTracker(ctrl)
+		mockDeliverer := mocks.NewMockMessageDeliverer(ctrl)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		mockDeliverer.EXPECT().DeliverMessage(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(context.Context, model.CapturedMessage, []string) (map[string]simulator.Bounce, error) {
+				cancel()
+				return nil, nil
+			})
+		// The delivered message is accepted all the same.
+		mockTracker.EXPECT().TrackSentEmail(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, _ string) error { return ctx.Err() }).Times(2)
+
+		es := service.NewEmailService(nil, mockTracker, service.FailureConfig{}, service.WithMessageDelivery(mockDeliverer))
+		_, err := es.SendEmail(ctx, req)
+		assert.NoError(t, err)
+	})
+}
@@ -13,6 +13,7 @@ const (
 
 type EmailService interface {
 	SendEmail(ctx context.Context, req model.EmailRequest) (*model.SESResponse, error)
+	SendBulkTemplatedEmail(ctx context.Context, req model.BulkEmailRequest) ([]model.BulkEmailDestinationStatus, error)
 }
 
 type EmailsStatsUpdater interface {
@@ -41,14 +42,7 @@ func (es EmailStatsService) SendEmail(ctx context.Context, req model.EmailReques
 	)
 
 	if res, err = es.emailService.SendEmail(ctx, req); err != nil {
-		var sesErr *model.SESError
-		if errors.As(err, &sesErr) {
-			// as the error from SendEmail is expected and should be returned
-			// it is ok, if we could not track the error.
-			es.emailStatsUpdater.IncrementError(ctx, sesErr.Code)
-			return nil, err
-		}
-		es.emailStatsUpdater.IncrementError(ctx, unknowErrTypeKey)
+		es.incrementError(ctx, err)
 		return nil, err
 	}
 
@@ -57,6 +51,37 @@ func (es EmailStatsService) SendEmail(ctx context.Context, req model.EmailReques
 	return res, nil
 }
 
+// SendBulkTemplatedEmail counts the outcome of each destination, the status
+// being the error type of a destination that was not sent.
+func (es EmailStatsService) SendBulkTemplatedEmail(ctx context.Context, req model.BulkEmailRequest) ([]model.BulkEmailDestinationStatus, error) {
+	statuses, err := es.emailService.SendBulkTemplatedEmail(ctx, req)
+	if err != nil {
+		es.incrementError(ctx, err)
+		return nil, err
+	}
+
+	for _, s := range statuses {
+		if s.Status == model.BulkStatusSuccess {
+			es.emailStatsUpdater.IncrementSuccess(ctx)
+			continue
+		}
+		es.emailStatsUpdater.IncrementError(ctx, s.Status)
+	}
+
+	return statuses, nil
+}
+
+func (es EmailStatsService) incrementError(ctx context.Context, err error) {
+	var sesErr *model.SESError
+	if errors.As(err, &sesErr) {
+		// as the error from SendEmail is expected and should be returned
+		// it is ok, if we could not track the error.
+		es.emailStatsUpdater.IncrementError(ctx, sesErr.Code)
+		return
+	}
+	es.emailStatsUpdater.IncrementError(ctx, unknowErrTypeKey)
+}
+
 func (es EmailStatsService) GetEmailStats(ctx context.Context) (model.EmailStats, error) {
 	return es.emailStatsGetter.GetEmailStats(ctx)
 }
@@ -64,6 +64,41 @@ func TestEmailStatsService_SendEmail(t *testing.T) {
 	}
 }
 
+func TestEmailStatsService_SendBulkTemplatedEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEmailService := mocks.NewMockEmailService(ctrl)
+	mockStatsUpdater := mocks.NewMockEmailsStatsUpdater(ctrl)
+
+	es := service.NewEmailStatsService(mockEmailService, mockStatsUpdater, nil)
+
+	t.Run("Each destination outcome is counted", func(t *testing.T) {
+		mockEmailService.EXPECT().SendBulkTemplatedEmail(gomock.Any(), gomock.Any()).Return([]model.BulkEmailDestinationStatus{
+			{Status: model.BulkStatusSuccess, MessageID: "1"},
+			{Status: model.BulkStatusSuccess, MessageID: "2"},
+			{Status: model.BulkStatusMessageRejected, Error: "Cannot send emails outside sandbox"},
+		}, nil)
+		mockStatsUpdater.EXPECT().IncrementSuccess(gomock.Any()).Return(nil).Times(2)
+		mockStatsUpdater.EXPECT().IncrementError(gomock.Any(), "MessageRejected").Return(nil).Times(1)
+
+		statuses, err := es.SendBulkTemplatedEmail(context.Background(), model.BulkEmailRequest{})
+
+		assert.NoError(t, err)
+		assert.Len(t, statuses, 3)
+	})
+
+	t.Run("Failed call is counted once", func(t *testing.T) {
+		mockEmailService.EXPECT().SendBulkTemplatedEmail(gomock.Any(), gomock.Any()).
+			Return(nil, &model.SESError{Code: "MessageRejected", Message: "Email address is not verified."})
+		mockStatsUpdater.EXPECT().IncrementError(gomock.Any(), "MessageRejected").Return(nil).Times(1)
+
+		_, err := es.SendBulkTemplatedEmail(context.Background(), model.BulkEmailRequest{})
+
+		assert.Error(t, err)
+	})
+}
+
 func TestGetEmailStats(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
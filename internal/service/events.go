@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/kamal-github/demtech/internal/simulator"
+)
+
+// EventPublisher publishes the SES events of a message to the event
+// destinations of its configuration set.
+type EventPublisher interface {
+	Publish(ctx context.Context, configurationSetName string, event model.Event)
+}
+
+// WithEventPublisher publishes Send, Reject, Delivery, Bounce, Complaint and
+// Rendering Failure events for the messages sent with a configuration set.
+func WithEventPublisher(p EventPublisher) Option {
+	return func(es *EmailServiceImpl) { es.eventPublisher = p }
+}
+
+const (
+	eventTimeFormat = "2006-01-02T15:04:05.000Z"
+	reportingMTA    = "a8-30.smtp-out.amazonses.com"
+)
+
+func (es EmailServiceImpl) publish(ctx context.Context, req model.EmailRequest, event model.Event) {
+	if es.eventPublisher == nil || req.ConfigurationSetName == "" {
+		return
+	}
+	es.eventPublisher.Publish(ctx, req.ConfigurationSetName, event)
+}
+
+// newEventMail describes an accepted message the way SES does in its events.
+func newEventMail(req model.EmailRequest, msgID string, sentAt time.Time) model.EventMail {
+	headers := []model.EventHeader{{Name: "From", Value: req.Source}}
+	if len(req.ReplyToAddresses) > 0 {
+		headers = append(headers, model.EventHeader{Name: "Reply-To", Value: strings.Join(req.ReplyToAddresses, ", ")})
+	}
+	if len(req.Destination.ToAddresses) > 0 {
+		headers = append(headers, model.EventHeader{Name: "To", Value: strings.Join(req.Destination.ToAddresses, ", ")})
+	}
+	if len(req.Destination.CcAddresses) > 0 {
+		headers = append(headers, model.EventHeader{Name: "Cc", Value: strings.Join(req.Destination.CcAddresses, ", ")})
+	}
+	headers = append(headers, model.EventHeader{Name: "Subject", Value: req.Message.Subject.Data})
+	for _, h := range req.Message.Headers {
+		headers = append(headers, model.EventHeader{Name: h.Name, Value: h.Value})
+	}
+
+	tags := map[string][]string{
+		"ses:configuration-set": {req.ConfigurationSetName},
+		"ses:from-domain":       {sourceDomain(req.Source)},
+	}
+	for _, t := range req.Tags {
+		tags[t.Name] = append(tags[t.Name], t.Value)
+	}
+
+	return model.EventMail{
+		Timestamp:   sentAt.Format(eventTimeFormat),
+		Source:      req.Source,
+		SourceArn:   req.SourceArn,
+		MessageID:   msgID,
+		Destination: req.Destination.All(),
+		Headers:     headers,
+		CommonHeaders: model.CommonHeaders{
+			From:      []string{req.Source},
+			ReplyTo:   req.ReplyToAddresses,
+			To:        req.Destination.ToAddresses,
+			Cc:        req.Destination.CcAddresses,
+			MessageID: msgID,
+			Subject:   req.Message.Subject.Data,
+		},
+		Tags: tags,
+	}
+}
+
+func sourceDomain(source string) string {
+	if a, err := mail.ParseAddress(source); err == nil {
+		source = a.Address
+	}
+	_, domain, _ := strings.Cut(source, "@")
+	return strings.ToLower(domain)
+}
+
+// suppressedOutcome is what happens to a recipient of the suppression list,
+// SES drops the message and reports a bounce.
+var suppressedOutcome = simulator.Outcome{Bounce: &simulator.Bounce{
+	Type:           "Permanent",
+	SubType:        "OnAccountSuppressionList",
+	Status:         "5.1.1",
+	DiagnosticCode: "Amazon SES did not send the message to this address because it is on the suppression list for your account.",
+}}
+
+// outcomeEvents builds the events of what happened at a given time to the
+// message sent at sentAt, for recipient.
+func outcomeEvents(m model.EventMail, recipient string, outcome simulator.Outcome, sentAt, at time.Time) []model.Event {
+	timestamp := at.Format(eventTimeFormat)
+
+	var events []model.Event
+	if outcome.Delivered {
+		events = append(events, model.Event{EventType: model.EventTypeDelivery, Mail: m, Delivery: &model.DeliveryEvent{
+			Timestamp:            timestamp,
+			ProcessingTimeMillis: at.Sub(sentAt).Milliseconds(),
+			Recipients:           []string{recipient},
+			SMTPResponse:         "250 2.6.0 Message received",
+			ReportingMTA:         reportingMTA,
+		}})
+	}
+	if b := outcome.Bounce; b != nil {
+		events = append(events, model.Event{EventType: model.EventTypeBounce, Mail: m, Bounce: &model.BounceEvent{
+			BounceType:    b.Type,
+			BounceSubType: b.SubType,
+			BouncedRecipients: []model.BouncedRecipient{{
+				EmailAddress:   recipient,
+				Action:         "failed",
+				Status:         b.Status,
+				DiagnosticCode: b.DiagnosticCode,
+			}},
+			Timestamp:    timestamp,
+			FeedbackID:   uuid.NewString(),
+			ReportingMTA: "dsn; " + reportingMTA,
+		}})
+	}
+	if outcome.ComplaintFeedbackType != "" {
+		events = append(events, model.Event{EventType: model.EventTypeComplaint, Mail: m, Complaint: &model.ComplaintEvent{
+			ComplainedRecipients:  []model.ComplainedRecipient{{EmailAddress: recipient}},
+			Timestamp:             timestamp,
+			FeedbackID:            uuid.NewString(),
+			UserAgent:             "Amazon SES Mailbox Simulator",
+			ComplaintFeedbackType: outcome.ComplaintFeedbackType,
+			ArrivalDate:           m.Timestamp,
+		}})
+	}
+	return events
+}
@@ -0,0 +1,303 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"log"
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/kamal-github/demtech/internal/repo"
+)
+
+const defaultListIdentitiesMaxItems = 1000
+
+type IdentityRepo interface {
+	CreateIdentity(ctx context.Context, id model.Identity) error
+	PutIdentity(ctx context.Context, id model.Identity) error
+	GetIdentity(ctx context.Context, identity string) (model.Identity, error)
+	DeleteIdentity(ctx context.Context, identity string) error
+	ListIdentities(ctx context.Context) ([]model.Identity, error)
+}
+
+type IdentityVerificationConfig struct {
+	// BaseURL is where the verification links of email identities point to.
+	BaseURL string
+	// Resolver checks the verification records of domains. Without one, a
+	// domain is verified once DomainVerificationDelay has passed. When that is
+	// zero as well, domains stay pending until their status is set explicitly.
+	Resolver                DNSResolver
+	DomainVerificationDelay time.Duration
+}
+
+// IdentityService manages the email addresses and domains mail can be sent
+// from. New identities start out Pending, like in SES: email addresses are
+// verified by following their verification link, domains once their DNS
+// records resolve or by setting their status explicitly.
+type IdentityService struct {
+	identityRepo IdentityRepo
+	cfg          IdentityVerificationConfig
+}
+
+func NewIdentityService(r IdentityRepo, cfg IdentityVerificationConfig) IdentityService {
+	return IdentityService{identityRepo: r, cfg: cfg}
+}
+
+// SeedVerifiedIdentities stores the given addresses and domains as verified,
+// identities which already exist are left as they are.
+func (s IdentityService) SeedVerifiedIdentities(ctx context.Context, identities []string) error {
+	now := time.Now().UTC()
+	for _, identity := range identities {
+		id := model.Identity{
+			Identity:                     identity,
+			Type:                         model.IdentityTypeEmailAddress,
+			VerificationStatus:           model.VerificationStatusSuccess,
+			CreatedTimestamp:             now,
+			VerificationStartedTimestamp: now,
+		}
+		if !strings.Contains(identity, "@") {
+			id.Identity = strings.ToLower(identity)
+			id.Type = model.IdentityTypeDomain
+			id.VerificationToken = generateVerificationToken()
+		}
+
+		if err := s.identityRepo.CreateIdentity(ctx, id); err != nil && !errors.Is(err, repo.ErrAlreadyExists) {
+			return err
+		}
+	}
+	return nil
+}
+
+// VerifyEmailIdentity adds an email address to the identities, pending until
+// its verification link is followed. Verifying a failed address again starts
+// over with a new link.
+func (s IdentityService) VerifyEmailIdentity(ctx context.Context, email string) error {
+	if addr, err := mail.ParseAddress(email); err != nil || addr.Address != email {
+		return &model.SESError{Code: "InvalidParameterValue", Message: "Invalid email address <" + email + ">."}
+	}
+
+	id, err := s.identityRepo.GetIdentity(ctx, email)
+	switch {
+	case err == nil && id.VerificationStatus == model.VerificationStatusSuccess:
+		return nil
+	case err == nil && id.VerificationStatus == model.VerificationStatusPending:
+		log.Printf("Verification link for %s: %s", email, s.VerificationLink(id))
+		return nil
+	case errors.Is(err, repo.ErrNotFound):
+		id = model.Identity{Identity: email, Type: model.IdentityTypeEmailAddress, CreatedTimestamp: time.Now().UTC()}
+	case err != nil:
+		return err
+	}
+
+	id.VerificationStatus = model.VerificationStatusPending
+	id.VerificationStartedTimestamp = time.Now().UTC()
+	id.ConfirmationToken = uuid.NewString()
+	if err := s.identityRepo.PutIdentity(ctx, id); err != nil {
+		return err
+	}
+
+	// SES would mail the link, it is logged instead.
+	log.Printf("Verification link for %s: %s", email, s.VerificationLink(id))
+	return nil
+}
+
+// VerifyDomainIdentity adds a domain to the identities and returns the token
+// of its TXT record. Verifying a known domain again returns the same token,
+// a failed domain is retried.
+func (s IdentityService) VerifyDomainIdentity(ctx context.Context, domain string) (string, error) {
+	id, err := s.getOrNewDomain(ctx, domain)
+	if err != nil {
+		return "", err
+	}
+
+	switch id.VerificationStatus {
+	case model.VerificationStatusSuccess, model.VerificationStatusPending:
+		return id.VerificationToken, nil
+	}
+
+	id.VerificationStatus = model.VerificationStatusPending
+	id.VerificationStartedTimestamp = time.Now().UTC()
+	if err := s.identityRepo.PutIdentity(ctx, id); err != nil {
+		return "", err
+	}
+	return id.VerificationToken, nil
+}
+
+// getOrNewDomain returns the identity of domain, a new one without
+// verification status if it does not exist yet.
+func (s IdentityService) getOrNewDomain(ctx context.Context, domain string) (model.Identity, error) {
+	domain = strings.ToLower(strings.TrimSuffix(domain, "."))
+	if !isDomain(domain) {
+		return model.Identity{}, &model.SESError{Code: "InvalidParameterValue", Message: "Invalid domain name " + domain + "."}
+	}
+
+	id, err := s.identityRepo.GetIdentity(ctx, domain)
+	if errors.Is(err, repo.ErrNotFound) {
+		return model.Identity{
+			Identity:          domain,
+			Type:              model.IdentityTypeDomain,
+			VerificationToken: generateVerificationToken(),
+			CreatedTimestamp:  time.Now().UTC(),
+		}, nil
+	}
+	return id, err
+}
+
+// ListIdentities returns one page of identity names, optionally only those of
+// identityType.
+func (s IdentityService) ListIdentities(ctx context.Context, identityType string, maxItems int, nextToken string) ([]string, string, error) {
+	switch identityType {
+	case "", model.IdentityTypeEmailAddress, model.IdentityTypeDomain:
+	default:
+		return nil, "", &model.SESError{Code: "InvalidParameterValue", Message: "Invalid identity type " + identityType + "."}
+	}
+	if maxItems <= 0 {
+		maxItems = defaultListIdentitiesMaxItems
+	}
+
+	all, err := s.identityRepo.ListIdentities(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var names []string
+	for _, id := range all {
+		if identityType == "" || id.Type == identityType {
+			names = append(names, id.Identity)
+		}
+	}
+
+	page, next := paginate(names, func(name string) string { return name }, maxItems, nextToken)
+	return page, next, nil
+}
+
+// GetIdentityVerificationAttributes returns the given identities, unknown
+// ones are left out just like SES does.
+func (s IdentityService) GetIdentityVerificationAttributes(ctx context.Context, identities []string) ([]model.Identity, error) {
+	var found []model.Identity
+	for _, identity := range identities {
+		id, err := s.get(ctx, identity)
+		if errors.Is(err, repo.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		found = append(found, id)
+	}
+	return found, nil
+}
+
+func (s IdentityService) DeleteIdentity(ctx context.Context, identity string) error {
+	return s.identityRepo.DeleteIdentity(ctx, identity)
+}
+
+// IsVerified reports whether identity exists and has been verified
+func (s IdentityService) IsVerified(ctx context.Context, identity string) (bool, error) {
+	id, err := s.get(ctx, identity)
+	if errors.Is(err, repo.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return id.VerificationStatus == model.VerificationStatusSuccess, nil
+}
+
+// GetIdentity returns an identity, failing with NotFoundException for an unknown one
+func (s IdentityService) GetIdentity(ctx context.Context, identity string) (model.Identity, error) {
+	id, err := s.get(ctx, identity)
+	if errors.Is(err, repo.ErrNotFound) {
+		return model.Identity{}, identityDoesNotExist(identity)
+	}
+	return id, err
+}
+
+// VerificationLink returns the link which verifies an email identity, it is
+// empty for domains.
+func (s IdentityService) VerificationLink(id model.Identity) string {
+	if id.Type != model.IdentityTypeEmailAddress || id.ConfirmationToken == "" {
+		return ""
+	}
+	return s.cfg.BaseURL + "/api/v1/identities/" + url.PathEscape(id.Identity) + "/verify?token=" + url.QueryEscape(id.ConfirmationToken)
+}
+
+// ConfirmEmailIdentity verifies a pending email address, it is what following
+// the verification link does.
+func (s IdentityService) ConfirmEmailIdentity(ctx context.Context, identity, token string) error {
+	id, err := s.GetIdentity(ctx, identity)
+	if err != nil {
+		return err
+	}
+	if id.Type != model.IdentityTypeEmailAddress || id.ConfirmationToken == "" || id.ConfirmationToken != token {
+		return &model.SESError{Code: "InvalidParameterValue", Message: "Invalid verification link."}
+	}
+	if id.VerificationStatus == model.VerificationStatusSuccess {
+		return nil
+	}
+	if id.VerificationStatus != model.VerificationStatusPending {
+		return &model.SESError{Code: "InvalidParameterValue", Message: "Verification of " + identity + " is no longer pending."}
+	}
+
+	id.VerificationStatus = model.VerificationStatusSuccess
+	return s.identityRepo.PutIdentity(ctx, id)
+}
+
+// SetVerificationStatus moves an identity to the given status, so that
+// failures can be simulated.
+func (s IdentityService) SetVerificationStatus(ctx context.Context, identity, status string) error {
+	switch status {
+	case model.VerificationStatusPending, model.VerificationStatusSuccess,
+		model.VerificationStatusFailed, model.VerificationStatusTemporaryFailure:
+	default:
+		return &model.SESError{Code: "InvalidParameterValue", Message: "Invalid verification status " + status + "."}
+	}
+
+	id, err := s.GetIdentity(ctx, identity)
+	if err != nil {
+		return err
+	}
+
+	if status == model.VerificationStatusPending && id.VerificationStatus != model.VerificationStatusPending {
+		id.VerificationStartedTimestamp = time.Now().UTC()
+	}
+	id.VerificationStatus = status
+	return s.identityRepo.PutIdentity(ctx, id)
+}
+
+// get reads an identity and applies the transitions that are due on it.
+func (s IdentityService) get(ctx context.Context, identity string) (model.Identity, error) {
+	id, err := s.identityRepo.GetIdentity(ctx, identity)
+	if err != nil {
+		return model.Identity{}, err
+	}
+	return s.refresh(ctx, id)
+}
+
+func identityDoesNotExist(identity string) *model.SESError {
+	return &model.SESError{Code: "NotFoundException", Message: "Identity " + identity + " does not exist."}
+}
+
+func isDomain(domain string) bool {
+	if strings.ContainsAny(domain, "@ ") || !strings.Contains(domain, ".") {
+		return false
+	}
+	for _, label := range strings.Split(domain, ".") {
+		if label == "" || strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-") {
+			return false
+		}
+	}
+	return true
+}
+
+func generateVerificationToken() string {
+	b := make([]byte, 32)
+	_, _ = rand.Read(b)
+	return base64.StdEncoding.EncodeToString(b)
+}
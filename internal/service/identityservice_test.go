@@ -0,0 +1,329 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/kamal-github/demtech/internal/repo"
+	"github.com/kamal-github/demtech/internal/service"
+	"github.com/kamal-github/demtech/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdentityService_SeedVerifiedIdentities(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockIdentityRepo(ctrl)
+	mockRepo.EXPECT().CreateIdentity(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, id model.Identity) error {
+			assert.Equal(t, model.VerificationStatusSuccess, id.VerificationStatus)
+			switch id.Identity {
+			case "sender@example.com":
+				assert.Equal(t, model.IdentityTypeEmailAddress, id.Type)
+				return repo.ErrAlreadyExists
+			case "example.org":
+				assert.Equal(t, model.IdentityTypeDomain, id.Type)
+				assert.NotEmpty(t, id.VerificationToken)
+				return nil
+			}
+			t.Errorf("unexpected identity %s", id.Identity)
+			return nil
+		}).Times(2)
+
+	err := service.NewIdentityService(mockRepo, service.IdentityVerificationConfig{}).SeedVerifiedIdentities(context.Background(), []string{"sender@example.com", "Example.org"})
+
+	assert.NoError(t, err)
+}
+
+func TestIdentityService_VerifyDomainIdentity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name        string
+		domain      string
+		mockSetup   func(*mocks.MockIdentityRepo)
+		expectToken string
+		expectCode  string
+	}{
+		{
+			name:   "New domain gets a token and is pending",
+			domain: "Example.com",
+			mockSetup: func(m *mocks.MockIdentityRepo) {
+				m.EXPECT().GetIdentity(gomock.Any(), "example.com").Return(model.Identity{}, repo.ErrNotFound)
+				m.EXPECT().PutIdentity(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, id model.Identity) error {
+						assert.Equal(t, model.VerificationStatusPending, id.VerificationStatus)
+						assert.Equal(t, model.IdentityTypeDomain, id.Type)
+						return nil
+					})
+			},
+		},
+		{
+			name:   "Failed domain is retried with its token",
+			domain: "example.com",
+			mockSetup: func(m *mocks.MockIdentityRepo) {
+				m.EXPECT().GetIdentity(gomock.Any(), "example.com").
+					Return(model.Identity{Identity: "example.com", VerificationStatus: model.VerificationStatusFailed, VerificationToken: "token"}, nil)
+				m.EXPECT().PutIdentity(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, id model.Identity) error {
+						assert.Equal(t, model.VerificationStatusPending, id.VerificationStatus)
+						return nil
+					})
+			},
+			expectToken: "token",
+		},
+		{
+			name:   "Known domain keeps its token",
+			domain: "example.com",
+			mockSetup: func(m *mocks.MockIdentityRepo) {
+				m.EXPECT().GetIdentity(gomock.Any(), "example.com").Return(model.Identity{Identity: "example.com", VerificationStatus: model.VerificationStatusPending, VerificationToken: "token"}, nil)
+			},
+			expectToken: "token",
+		},
+		{
+			name:       "Invalid domain",
+			domain:     "user@example.com",
+			mockSetup:  func(*mocks.MockIdentityRepo) {},
+			expectCode: "InvalidParameterValue",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			mockRepo := mocks.NewMockIdentityRepo(ctrl)
+			tt.mockSetup(mockRepo)
+
+			token, err := service.NewIdentityService(mockRepo, service.IdentityVerificationConfig{}).VerifyDomainIdentity(context.Background(), tt.domain)
+
+			if tt.expectCode != "" {
+				var sesErr *model.SESError
+				assert.ErrorAs(err, &sesErr)
+				assert.Equal(tt.expectCode, sesErr.Code)
+				return
+			}
+			assert.NoError(err)
+			assert.NotEmpty(token)
+			if tt.expectToken != "" {
+				assert.Equal(tt.expectToken, token)
+			}
+		})
+	}
+}
+
+func TestIdentityService_ListIdentities(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	all := []model.Identity{
+		{Identity: "a@example.com", Type: model.IdentityTypeEmailAddress},
+		{Identity: "b@example.com", Type: model.IdentityTypeEmailAddress},
+		{Identity: "example.com", Type: model.IdentityTypeDomain},
+	}
+
+	tests := []struct {
+		name         string
+		identityType string
+		maxItems     int
+		nextToken    string
+		expectPage   []string
+		expectNext   string
+		expectErr    bool
+	}{
+		{name: "All identities", expectPage: []string{"a@example.com", "b@example.com", "example.com"}},
+		{name: "Only domains", identityType: model.IdentityTypeDomain, expectPage: []string{"example.com"}},
+		{name: "Paged", identityType: model.IdentityTypeEmailAddress, maxItems: 1, expectPage: []string{"a@example.com"}, expectNext: "a@example.com"},
+		{name: "Next page", maxItems: 2, nextToken: "a@example.com", expectPage: []string{"b@example.com", "example.com"}},
+		{name: "Unknown type", identityType: "Phone", expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			mockRepo := mocks.NewMockIdentityRepo(ctrl)
+			mockRepo.EXPECT().ListIdentities(gomock.Any()).Return(all, nil).AnyTimes()
+
+			page, next, err := service.NewIdentityService(mockRepo, service.IdentityVerificationConfig{}).ListIdentities(context.Background(), tt.identityType, tt.maxItems, tt.nextToken)
+
+			if tt.expectErr {
+				assert.Error(err)
+				return
+			}
+			assert.NoError(err)
+			assert.Equal(tt.expectPage, page)
+			assert.Equal(tt.expectNext, next)
+		})
+	}
+}
+
+func TestIdentityService_IsVerified(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockIdentityRepo(ctrl)
+	mockRepo.EXPECT().GetIdentity(gomock.Any(), "verified@example.com").
+		Return(model.Identity{VerificationStatus: model.VerificationStatusSuccess}, nil)
+	mockRepo.EXPECT().GetIdentity(gomock.Any(), "pending@example.com").
+		Return(model.Identity{VerificationStatus: model.VerificationStatusPending}, nil)
+	mockRepo.EXPECT().GetIdentity(gomock.Any(), "unknown@example.com").
+		Return(model.Identity{}, repo.ErrNotFound)
+
+	s := service.NewIdentityService(mockRepo, service.IdentityVerificationConfig{})
+
+	verified, err := s.IsVerified(context.Background(), "verified@example.com")
+	assert.NoError(t, err)
+	assert.True(t, verified)
+
+	verified, err = s.IsVerified(context.Background(), "pending@example.com")
+	assert.NoError(t, err)
+	assert.False(t, verified)
+
+	verified, err = s.IsVerified(context.Background(), "unknown@example.com")
+	assert.NoError(t, err)
+	assert.False(t, verified)
+}
+
+func TestIdentityService_VerifyEmailIdentity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockIdentityRepo(ctrl)
+	s := service.NewIdentityService(mockRepo, service.IdentityVerificationConfig{BaseURL: "http://localhost:8080"})
+
+	var stored model.Identity
+	mockRepo.EXPECT().GetIdentity(gomock.Any(), "new@example.com").Return(model.Identity{}, repo.ErrNotFound)
+	mockRepo.EXPECT().PutIdentity(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, id model.Identity) error {
+			stored = id
+			return nil
+		})
+
+	assert.NoError(t, s.VerifyEmailIdentity(context.Background(), "new@example.com"))
+	assert.Equal(t, model.VerificationStatusPending, stored.VerificationStatus)
+	assert.NotEmpty(t, stored.ConfirmationToken)
+	assert.Equal(t, "http://localhost:8080/api/v1/identities/new@example.com/verify?token="+stored.ConfirmationToken, s.VerificationLink(stored))
+
+	err := s.VerifyEmailIdentity(context.Background(), "Team <new@example.com>")
+	var sesErr *model.SESError
+	assert.ErrorAs(t, err, &sesErr)
+	assert.Equal(t, "InvalidParameterValue", sesErr.Code)
+}
+
+func TestIdentityService_ConfirmEmailIdentity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	pending := model.Identity{
+		Identity:           "new@example.com",
+		Type:               model.IdentityTypeEmailAddress,
+		VerificationStatus: model.VerificationStatusPending,
+		ConfirmationToken:  "secret",
+	}
+
+	tests := []struct {
+		name       string
+		stored     model.Identity
+		getErr     error
+		token      string
+		expectPut  bool
+		expectCode string
+	}{
+		{name: "Valid link verifies the address", stored: pending, token: "secret", expectPut: true},
+		{name: "Wrong token", stored: pending, token: "guess", expectCode: "InvalidParameterValue"},
+		{name: "Unknown identity", getErr: repo.ErrNotFound, token: "secret", expectCode: "NotFoundException"},
+		{
+			name:       "Failed verification can not be confirmed",
+			stored:     model.Identity{Identity: "new@example.com", Type: model.IdentityTypeEmailAddress, VerificationStatus: model.VerificationStatusFailed, ConfirmationToken: "secret"},
+			token:      "secret",
+			expectCode: "InvalidParameterValue",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			mockRepo := mocks.NewMockIdentityRepo(ctrl)
+			mockRepo.EXPECT().GetIdentity(gomock.Any(), "new@example.com").Return(tt.stored, tt.getErr)
+			if tt.expectPut {
+				mockRepo.EXPECT().PutIdentity(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, id model.Identity) error {
+						assert.Equal(model.VerificationStatusSuccess, id.VerificationStatus)
+						return nil
+					})
+			}
+
+			err := service.NewIdentityService(mockRepo, service.IdentityVerificationConfig{}).
+				ConfirmEmailIdentity(context.Background(), "new@example.com", tt.token)
+
+			if tt.expectCode == "" {
+				assert.NoError(err)
+				return
+			}
+			var sesErr *model.SESError
+			assert.ErrorAs(err, &sesErr)
+			assert.Equal(tt.expectCode, sesErr.Code)
+		})
+	}
+}
+
+func TestIdentityService_SetVerificationStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockIdentityRepo(ctrl)
+	s := service.NewIdentityService(mockRepo, service.IdentityVerificationConfig{})
+
+	mockRepo.EXPECT().GetIdentity(gomock.Any(), "example.com").
+		Return(model.Identity{Identity: "example.com", Type: model.IdentityTypeDomain, VerificationStatus: model.VerificationStatusPending}, nil)
+	mockRepo.EXPECT().PutIdentity(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, id model.Identity) error {
+			assert.Equal(t, model.VerificationStatusTemporaryFailure, id.VerificationStatus)
+			return nil
+		})
+
+	assert.NoError(t, s.SetVerificationStatus(context.Background(), "example.com", model.VerificationStatusTemporaryFailure))
+	assert.Error(t, s.SetVerificationStatus(context.Background(), "example.com", "Verified"))
+}
+
+func TestIdentityService_DomainVerificationDelay(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name         string
+		delay        time.Duration
+		startedAgo   time.Duration
+		expectStatus string
+	}{
+		{name: "Delay has passed", delay: time.Minute, startedAgo: 2 * time.Minute, expectStatus: model.VerificationStatusSuccess},
+		{name: "Delay has not passed", delay: time.Minute, startedAgo: time.Second, expectStatus: model.VerificationStatusPending},
+		{name: "No delay configured", startedAgo: time.Hour, expectStatus: model.VerificationStatusPending},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			mockRepo := mocks.NewMockIdentityRepo(ctrl)
+			mockRepo.EXPECT().GetIdentity(gomock.Any(), "example.com").Return(model.Identity{
+				Identity:                     "example.com",
+				Type:                         model.IdentityTypeDomain,
+				VerificationStatus:           model.VerificationStatusPending,
+				VerificationStartedTimestamp: time.Now().Add(-tt.startedAgo),
+			}, nil)
+			if tt.expectStatus == model.VerificationStatusSuccess {
+				mockRepo.EXPECT().PutIdentity(gomock.Any(), gomock.Any()).Return(nil)
+			}
+
+			list, err := service.NewIdentityService(mockRepo, service.IdentityVerificationConfig{DomainVerificationDelay: tt.delay}).
+				GetIdentityVerificationAttributes(context.Background(), []string{"example.com"})
+
+			assert.NoError(err)
+			assert.Equal(tt.expectStatus, list[0].VerificationStatus)
+		})
+	}
+}
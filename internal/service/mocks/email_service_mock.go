@@ -35,6 +35,21 @@ func (m *MockEmailService) EXPECT() *MockEmailServiceMockRecorder {
 	return m.recorder
 }
 
+// SendBulkTemplatedEmail mocks base method.
+func (m *MockEmailService) SendBulkTemplatedEmail(ctx context.Context, req model.BulkEmailRequest) ([]model.BulkEmailDestinationStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendBulkTemplatedEmail", ctx, req)
+	ret0, _ := ret[0].([]model.BulkEmailDestinationStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendBulkTemplatedEmail indicates an expected call of SendBulkTemplatedEmail.
+func (mr *MockEmailServiceMockRecorder) SendBulkTemplatedEmail(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendBulkTemplatedEmail", reflect.TypeOf((*MockEmailService)(nil).SendBulkTemplatedEmail), ctx, req)
+}
+
 // SendEmail mocks base method.
 func (m *MockEmailService) SendEmail(ctx context.Context, req model.EmailRequest) (*model.SESResponse, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,17 @@
+package service
+
+// paginate returns one page of items, which must be ordered by key. The
+// returned token is the key to continue after, it is empty on the last page.
+func paginate[T any](items []T, key func(T) string, maxItems int, nextToken string) ([]T, string) {
+	page := make([]T, 0, maxItems)
+	for _, item := range items {
+		if nextToken != "" && key(item) <= nextToken {
+			continue
+		}
+		if len(page) == maxItems {
+			return page, key(page[len(page)-1])
+		}
+		page = append(page, item)
+	}
+	return page, ""
+}
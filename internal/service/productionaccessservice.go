@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/kamal-github/demtech/internal/repo"
+)
+
+const maxAdditionalContactEmailAddresses = 4
+
+type AccountDetailsRepo interface {
+	GetAccountDetails(ctx context.Context) (model.AccountDetails, error)
+	PutAccountDetails(ctx context.Context, details model.AccountDetails) error
+}
+
+type ProductionAccessConfig struct {
+	// Enabled is whether the account starts out of the sandbox.
+	Enabled bool
+	// ReviewDelay after which a pending production access request is granted.
+	// When zero, requests stay pending until they are reviewed explicitly.
+	ReviewDelay time.Duration
+}
+
+// ProductionAccessService keeps the account details and takes the account out
+// of the sandbox once a production access request is granted. SES reviews
+// requests by hand; here they are granted after a delay or reviewed through
+// the API.
+type ProductionAccessService struct {
+	detailsRepo AccountDetailsRepo
+	cfg         ProductionAccessConfig
+}
+
+func NewProductionAccessService(r AccountDetailsRepo, cfg ProductionAccessConfig) ProductionAccessService {
+	return ProductionAccessService{detailsRepo: r, cfg: cfg}
+}
+
+// GetAccountDetails returns the account details, or nil when none were given.
+func (s ProductionAccessService) GetAccountDetails(ctx context.Context) (*model.AccountDetails, error) {
+	details, err := s.get(ctx)
+	if errors.Is(err, repo.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &details, nil
+}
+
+// IsProductionAccessEnabled reports whether the account is out of the sandbox.
+func (s ProductionAccessService) IsProductionAccessEnabled(ctx context.Context) (bool, error) {
+	if s.cfg.Enabled {
+		return true, nil
+	}
+
+	details, err := s.get(ctx)
+	if errors.Is(err, repo.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return granted(details), nil
+}
+
+// PutAccountDetails updates the account details. Requesting production access
+// from the sandbox submits them for review, which must be over before they
+// can be updated again.
+func (s ProductionAccessService) PutAccountDetails(ctx context.Context, details model.AccountDetails, productionAccessEnabled bool) error {
+	if err := validateAccountDetails(details); err != nil {
+		return err
+	}
+
+	current, err := s.get(ctx)
+	switch {
+	case errors.Is(err, repo.ErrNotFound):
+	case err != nil:
+		return err
+	case current.ReviewDetails != nil && current.ReviewDetails.Status == model.ReviewStatusPending:
+		return &model.SESError{Code: "ConflictException", Message: "Account details are under review, case " + current.ReviewDetails.CaseId + "."}
+	}
+	details.ReviewDetails = current.ReviewDetails
+
+	if productionAccessEnabled && !s.cfg.Enabled && !granted(current) {
+		details.ReviewDetails = &model.ReviewDetails{
+			Status:             model.ReviewStatusPending,
+			CaseId:             uuid.NewString(),
+			SubmittedTimestamp: time.Now().UTC(),
+		}
+		log.Printf("Production access requested, case %s", details.ReviewDetails.CaseId)
+	}
+
+	return s.detailsRepo.PutAccountDetails(ctx, details)
+}
+
+// ReviewProductionAccess closes the pending production access request with
+// the given status, it is what the SES support team would do.
+func (s ProductionAccessService) ReviewProductionAccess(ctx context.Context, status string) error {
+	switch status {
+	case model.ReviewStatusGranted, model.ReviewStatusDenied, model.ReviewStatusFailed:
+	default:
+		return &model.SESError{Code: "InvalidParameterValue", Message: "Invalid review status " + status + "."}
+	}
+
+	details, err := s.get(ctx)
+	if err != nil && !errors.Is(err, repo.ErrNotFound) {
+		return err
+	}
+	if details.ReviewDetails == nil || details.ReviewDetails.Status != model.ReviewStatusPending {
+		return &model.SESError{Code: "NotFoundException", Message: "No production access request is pending."}
+	}
+
+	details.ReviewDetails.Status = status
+	log.Printf("Production access request %s reviewed: %s", details.ReviewDetails.CaseId, status)
+	return s.detailsRepo.PutAccountDetails(ctx, details)
+}
+
+// get reads the account details and grants a pending request once the review
+// delay has passed.
+func (s ProductionAccessService) get(ctx context.Context) (model.AccountDetails, error) {
+	details, err := s.detailsRepo.GetAccountDetails(ctx)
+	if err != nil {
+		return model.AccountDetails{}, err
+	}
+
+	review := details.ReviewDetails
+	if review == nil || review.Status != model.ReviewStatusPending ||
+		s.cfg.ReviewDelay <= 0 || time.Since(review.SubmittedTimestamp) < s.cfg.ReviewDelay {
+		return details, nil
+	}
+
+	review.Status = model.ReviewStatusGranted
+	log.Printf("Production access request %s granted", review.CaseId)
+	return details, s.detailsRepo.PutAccountDetails(ctx, details)
+}
+
+func granted(details model.AccountDetails) bool {
+	return details.ReviewDetails != nil && details.ReviewDetails.Status == model.ReviewStatusGranted
+}
+
+func validateAccountDetails(d model.AccountDetails) error {
+	if d.MailType != model.MailTypeMarketing && d.MailType != model.MailTypeTransactional {
+		return &model.SESError{Code: "BadRequestException", Message: "Invalid MailType " + d.MailType + ". Valid values: MARKETING, TRANSACTIONAL."}
+	}
+	if u, err := url.Parse(d.WebsiteURL); err != nil || u.Host == "" || len(d.WebsiteURL) > 1000 {
+		return &model.SESError{Code: "BadRequestException", Message: "Invalid WebsiteURL " + d.WebsiteURL + "."}
+	}
+	if d.ContactLanguage != "" && d.ContactLanguage != model.ContactLanguageEN && d.ContactLanguage != model.ContactLanguageJA {
+		return &model.SESError{Code: "BadRequestException", Message: "Invalid ContactLanguage " + d.ContactLanguage + ". Valid values: EN, JA."}
+	}
+	if len(d.UseCaseDescription) > 5000 {
+		return &model.SESError{Code: "BadRequestException", Message: "UseCaseDescription must be at most 5000 characters long."}
+	}
+	if len(d.AdditionalContactEmailAddresses) > maxAdditionalContactEmailAddresses {
+		return &model.SESError{Code: "BadRequestException", Message: "At most 4 AdditionalContactEmailAddresses can be given."}
+	}
+	for _, email := range d.AdditionalContactEmailAddresses {
+		if addr, err := mail.ParseAddress(email); err != nil || !strings.EqualFold(addr.Address, email) {
+			return &model.SESError{Code: "BadRequestException", Message: "Invalid email address " + email + "."}
+		}
+	}
+	return nil
+}
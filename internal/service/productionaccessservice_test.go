@@ -0,0 +1,193 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/kamal-github/demtech/internal/repo"
+	"github.com/kamal-github/demtech/internal/service"
+	"github.com/kamal-github/demtech/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProductionAccessService_PutAccountDetails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	details := model.AccountDetails{
+		MailType:                        model.MailTypeTransactional,
+		WebsiteURL:                      "https://example.com",
+		ContactLanguage:                 model.ContactLanguageEN,
+		AdditionalContactEmailAddresses: []string{"ops@example.com"},
+	}
+	pending := &model.ReviewDetails{Status: model.ReviewStatusPending, CaseId: "case-1", SubmittedTimestamp: time.Now().UTC()}
+	denied := &model.ReviewDetails{Status: model.ReviewStatusDenied, CaseId: "case-1", SubmittedTimestamp: time.Now().UTC()}
+
+	t.Run("Request from the sandbox starts a review", func(t *testing.T) {
+		mockRepo := mocks.NewMockAccountDetailsRepo(ctrl)
+		mockRepo.EXPECT().GetAccountDetails(gomock.Any()).Return(model.AccountDetails{}, repo.ErrNotFound)
+		mockRepo.EXPECT().PutAccountDetails(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, got model.AccountDetails) error {
+			assert.Equal(t, details.WebsiteURL, got.WebsiteURL)
+			if assert.NotNil(t, got.ReviewDetails) {
+				assert.Equal(t, model.ReviewStatusPending, got.ReviewDetails.Status)
+				assert.NotEmpty(t, got.ReviewDetails.CaseId)
+			}
+			return nil
+		})
+
+		err := service.NewProductionAccessService(mockRepo, service.ProductionAccessConfig{}).PutAccountDetails(context.Background(), details, true)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Denied request can be submitted again", func(t *testing.T) {
+		mockRepo := mocks.NewMockAccountDetailsRepo(ctrl)
+		mockRepo.EXPECT().GetAccountDetails(gomock.Any()).Return(model.AccountDetails{ReviewDetails: denied}, nil)
+		mockRepo.EXPECT().PutAccountDetails(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, got model.AccountDetails) error {
+			assert.Equal(t, model.ReviewStatusPending, got.ReviewDetails.Status)
+			assert.NotEqual(t, denied.CaseId, got.ReviewDetails.CaseId)
+			return nil
+		})
+
+		err := service.NewProductionAccessService(mockRepo, service.ProductionAccessConfig{}).PutAccountDetails(context.Background(), details, true)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Details without request keep the last review", func(t *testing.T) {
+		mockRepo := mocks.NewMockAccountDetailsRepo(ctrl)
+		mockRepo.EXPECT().GetAccountDetails(gomock.Any()).Return(model.AccountDetails{ReviewDetails: denied}, nil)
+		expect := details
+		expect.ReviewDetails = denied
+		mockRepo.EXPECT().PutAccountDetails(gomock.Any(), expect).Return(nil)
+
+		err := service.NewProductionAccessService(mockRepo, service.ProductionAccessConfig{}).PutAccountDetails(context.Background(), details, false)
+		assert.NoError(t, err)
+	})
+
+	t.Run("No review out of the sandbox", func(t *testing.T) {
+		mockRepo := mocks.NewMockAccountDetailsRepo(ctrl)
+		mockRepo.EXPECT().GetAccountDetails(gomock.Any()).Return(model.AccountDetails{}, repo.ErrNotFound)
+		mockRepo.EXPECT().PutAccountDetails(gomock.Any(), details).Return(nil)
+
+		err := service.NewProductionAccessService(mockRepo, service.ProductionAccessConfig{Enabled: true}).PutAccountDetails(context.Background(), details, true)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Pending review conflicts", func(t *testing.T) {
+		mockRepo := mocks.NewMockAccountDetailsRepo(ctrl)
+		mockRepo.EXPECT().GetAccountDetails(gomock.Any()).Return(model.AccountDetails{ReviewDetails: pending}, nil)
+
+		err := service.NewProductionAccessService(mockRepo, service.ProductionAccessConfig{}).PutAccountDetails(context.Background(), details, true)
+		assert.Equal(t, "ConflictException", err.(*model.SESError).Code)
+	})
+
+	t.Run("Invalid details", func(t *testing.T) {
+		invalid := []model.AccountDetails{
+			{MailType: "NEWSLETTER", WebsiteURL: "https://example.com"},
+			{MailType: model.MailTypeMarketing, WebsiteURL: "example"},
+			{MailType: model.MailTypeMarketing, WebsiteURL: "https://example.com", ContactLanguage: "FR"},
+			{MailType: model.MailTypeMarketing, WebsiteURL: "https://example.com", AdditionalContactEmailAddresses: []string{"not an address"}},
+			{MailType: model.MailTypeMarketing, WebsiteURL: "https://example.com", AdditionalContactEmailAddresses: []string{"a@x.com", "b@x.com", "c@x.com", "d@x.com", "e@x.com"}},
+		}
+		s := service.NewProductionAccessService(mocks.NewMockAccountDetailsRepo(ctrl), service.ProductionAccessConfig{})
+		for _, d := range invalid {
+			err := s.PutAccountDetails(context.Background(), d, true)
+			assert.Equal(t, "BadRequestException", err.(*model.SESError).Code)
+		}
+	})
+}
+
+func TestProductionAccessService_IsProductionAccessEnabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	submittedAt := time.Now().Add(-time.Minute).UTC()
+
+	tests := []struct {
+		name        string
+		cfg         service.ProductionAccessConfig
+		details     model.AccountDetails
+		detailsErr  error
+		expectPut   bool
+		expectValue bool
+	}{
+		{
+			name:        "Out of the sandbox",
+			cfg:         service.ProductionAccessConfig{Enabled: true},
+			expectValue: true,
+		},
+		{
+			name:       "No request",
+			detailsErr: repo.ErrNotFound,
+		},
+		{
+			name:    "Pending request",
+			details: model.AccountDetails{ReviewDetails: &model.ReviewDetails{Status: model.ReviewStatusPending, SubmittedTimestamp: submittedAt}},
+		},
+		{
+			name:        "Pending request granted after the review delay",
+			cfg:         service.ProductionAccessConfig{ReviewDelay: time.Second},
+			details:     model.AccountDetails{ReviewDetails: &model.ReviewDetails{Status: model.ReviewStatusPending, SubmittedTimestamp: submittedAt}},
+			expectPut:   true,
+			expectValue: true,
+		},
+		{
+			name:    "Pending request within the review delay",
+			cfg:     service.ProductionAccessConfig{ReviewDelay: time.Hour},
+			details: model.AccountDetails{ReviewDetails: &model.ReviewDetails{Status: model.ReviewStatusPending, SubmittedTimestamp: submittedAt}},
+		},
+		{
+			name:        "Granted request",
+			details:     model.AccountDetails{ReviewDetails: &model.ReviewDetails{Status: model.ReviewStatusGranted}},
+			expectValue: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockAccountDetailsRepo(ctrl)
+			if !tt.cfg.Enabled {
+				mockRepo.EXPECT().GetAccountDetails(gomock.Any()).Return(tt.details, tt.detailsErr)
+			}
+			if tt.expectPut {
+				mockRepo.EXPECT().PutAccountDetails(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, got model.AccountDetails) error {
+					assert.Equal(t, model.ReviewStatusGranted, got.ReviewDetails.Status)
+					return nil
+				})
+			}
+
+			enabled, err := service.NewProductionAccessService(mockRepo, tt.cfg).IsProductionAccessEnabled(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectValue, enabled)
+		})
+	}
+}
+
+func TestProductionAccessService_ReviewProductionAccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("Grant pending request", func(t *testing.T) {
+		mockRepo := mocks.NewMockAccountDetailsRepo(ctrl)
+		mockRepo.EXPECT().GetAccountDetails(gomock.Any()).
+			Return(model.AccountDetails{ReviewDetails: &model.ReviewDetails{Status: model.ReviewStatusPending, CaseId: "case-1"}}, nil)
+		mockRepo.EXPECT().PutAccountDetails(gomock.Any(), model.AccountDetails{ReviewDetails: &model.ReviewDetails{Status: model.ReviewStatusDenied, CaseId: "case-1"}}).Return(nil)
+
+		assert.NoError(t, service.NewProductionAccessService(mockRepo, service.ProductionAccessConfig{}).ReviewProductionAccess(context.Background(), model.ReviewStatusDenied))
+	})
+
+	t.Run("Nothing to review", func(t *testing.T) {
+		mockRepo := mocks.NewMockAccountDetailsRepo(ctrl)
+		mockRepo.EXPECT().GetAccountDetails(gomock.Any()).Return(model.AccountDetails{}, repo.ErrNotFound)
+
+		err := service.NewProductionAccessService(mockRepo, service.ProductionAccessConfig{}).ReviewProductionAccess(context.Background(), model.ReviewStatusGranted)
+		assert.Equal(t, "NotFoundException", err.(*model.SESError).Code)
+	})
+
+	t.Run("Invalid status", func(t *testing.T) {
+		err := service.NewProductionAccessService(mocks.NewMockAccountDetailsRepo(ctrl), service.ProductionAccessConfig{}).ReviewProductionAccess(context.Background(), model.ReviewStatusPending)
+		assert.Equal(t, "InvalidParameterValue", err.(*model.SESError).Code)
+	})
+}
@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/kamal-github/demtech/internal/repo"
+)
+
+// The bounce and complaint rates above which Amazon SES puts an account under
+// review, and pauses its sending.
+const (
+	bounceRateUnderReview    = 0.05
+	bounceRatePaused         = 0.10
+	complaintRateUnderReview = 0.001
+	complaintRatePaused      = 0.005
+)
+
+type ReputationRepo interface {
+	GetAccountStatus(ctx context.Context) (model.AccountStatus, error)
+	PutAccountStatus(ctx context.Context, status model.AccountStatus) error
+	AddReputationSample(ctx context.Context, s model.ReputationSample, sentAt time.Time) error
+	SumReputationSamples(ctx context.Context, since time.Time) (model.ReputationSample, error)
+	DeleteReputationSamples(ctx context.Context) error
+	AddConfigurationSetReputationSample(ctx context.Context, s model.ReputationSample, sentAt time.Time) error
+	SumConfigurationSetReputationSamples(ctx context.Context, configurationSetName string, since time.Time) (model.ReputationSample, error)
+}
+
+// ReputationService tracks the bounce and complaint rates of the account over
+// a rolling window, and enforces them the way Amazon SES does: the account is
+// put under review when they cross the first thresholds, and its sending is
+// paused when they cross the second ones. The rates are only enforced once
+// the window holds minSends recipients, so that a few bounces do not pause a
+// new account. Every message counts towards the rates of the account, those of
+// a configuration set whose reputation metrics are enabled towards the rates
+// of the set as well.
+type ReputationService struct {
+	reputationRepo ReputationRepo
+	sets           ConfigurationSetGetter
+	window         time.Duration
+	minSends       int
+}
+
+func NewReputationService(r ReputationRepo, sets ConfigurationSetGetter, window time.Duration, minSends int) ReputationService {
+	return ReputationService{reputationRepo: r, sets: sets, window: window, minSends: minSends}
+}
+
+// GetAccountStatus returns the status of the account, which is healthy until
+// it sent anything.
+func (s ReputationService) GetAccountStatus(ctx context.Context) (model.AccountStatus, error) {
+	status, err := s.reputationRepo.GetAccountStatus(ctx)
+	if errors.Is(err, repo.ErrNotFound) {
+		return model.AccountStatus{SendingEnabled: true, EnforcementStatus: model.EnforcementStatusHealthy}, nil
+	}
+	return status, err
+}
+
+// UpdateAccountSendingEnabled pauses or resumes the sending of the account.
+// Resuming it gives the account a fresh start: it is healthy again and the
+// rates start over.
+func (s ReputationService) UpdateAccountSendingEnabled(ctx context.Context, enabled bool) error {
+	status, err := s.GetAccountStatus(ctx)
+	if err != nil {
+		return err
+	}
+
+	status.SendingEnabled = enabled
+	if enabled {
+		if err := s.reputationRepo.DeleteReputationSamples(ctx); err != nil {
+			return err
+		}
+		status.EnforcementStatus, status.BounceRate, status.ComplaintRate = model.EnforcementStatusHealthy, 0, 0
+	}
+	return s.reputationRepo.PutAccountStatus(ctx, status)
+}
+
+// TrackReputation adds the sample of a message to the rates and updates the
+// status of the account accordingly. A paused account stays paused until its
+// sending is resumed, while one under review gets healthy again once its
+// rates recovered.
+func (s ReputationService) TrackReputation(ctx context.Context, sample model.ReputationSample) error {
+	now := time.Now()
+	if err := s.trackAccountReputation(ctx, sample, now); err != nil {
+		return err
+	}
+	if sample.ConfigurationSetName == "" {
+		return nil
+	}
+
+	set, err := s.sets.GetConfigurationSet(ctx, sample.ConfigurationSetName)
+	if err != nil || !set.ReputationMetricsEnabled {
+		return err
+	}
+	return s.reputationRepo.AddConfigurationSetReputationSample(ctx, sample, now)
+}
+
+// GetConfigurationSetReputation returns the rates of the messages sent with a
+// configuration set over the reputation window.
+func (s ReputationService) GetConfigurationSetReputation(ctx context.Context, configurationSetName string) (model.ReputationMetrics, error) {
+	set, err := s.sets.GetConfigurationSet(ctx, configurationSetName)
+	if err != nil {
+		return model.ReputationMetrics{}, err
+	}
+	if !set.ReputationMetricsEnabled {
+		return model.ReputationMetrics{}, &model.SESError{Code: "InvalidParameterValue", Message: "Reputation metrics are not enabled for configuration set " + set.Name + "."}
+	}
+
+	sum, err := s.reputationRepo.SumConfigurationSetReputationSamples(ctx, set.Name, time.Now().Add(-s.window))
+	if err != nil {
+		return model.ReputationMetrics{}, err
+	}
+	metrics := model.ReputationMetrics{ConfigurationSetName: set.Name, Sends: sum.Sends}
+	if sum.Sends > 0 {
+		metrics.BounceRate = float64(sum.Bounces) / float64(sum.Sends)
+		metrics.ComplaintRate = float64(sum.Complaints) / float64(sum.Sends)
+	}
+	return metrics, nil
+}
+
+func (s ReputationService) trackAccountReputation(ctx context.Context, sample model.ReputationSample, now time.Time) error {
+	if err := s.reputationRepo.AddReputationSample(ctx, sample, now); err != nil {
+		return err
+	}
+
+	sum, err := s.reputationRepo.SumReputationSamples(ctx, now.Add(-s.window))
+	if err != nil {
+		return err
+	}
+	status, err := s.GetAccountStatus(ctx)
+	if err != nil {
+		return err
+	}
+
+	if sum.Sends > 0 {
+		status.BounceRate = float64(sum.Bounces) / float64(sum.Sends)
+		status.ComplaintRate = float64(sum.Complaints) / float64(sum.Sends)
+	}
+
+	if status.EnforcementStatus != model.EnforcementStatusShutdown && sum.Sends >= s.minSends {
+		enforcementStatus := enforcementStatus(status.BounceRate, status.ComplaintRate)
+		if enforcementStatus != status.EnforcementStatus {
+			log.Printf("Account enforcement status changed from %s to %s (bounce rate %.4f, complaint rate %.4f)",
+				status.EnforcementStatus, enforcementStatus, status.BounceRate, status.ComplaintRate)
+		}
+		status.EnforcementStatus = enforcementStatus
+		if enforcementStatus == model.EnforcementStatusShutdown {
+			status.SendingEnabled = false
+		}
+	}
+
+	return s.reputationRepo.PutAccountStatus(ctx, status)
+}
+
+func enforcementStatus(bounceRate, complaintRate float64) string {
+	switch {
+	case bounceRate >= bounceRatePaused || complaintRate >= complaintRatePaused:
+		return model.EnforcementStatusShutdown
+	case bounceRate >= bounceRateUnderReview || complaintRate >= complaintRateUnderReview:
+		return model.EnforcementStatusProbation
+	default:
+		return model.EnforcementStatusHealthy
+	}
+}
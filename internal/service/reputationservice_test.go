@@ -0,0 +1,182 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/kamal-github/demtech/internal/repo"
+	"github.com/kamal-github/demtech/internal/service"
+	"github.com/kamal-github/demtech/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReputationService_TrackReputation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	healthy := model.AccountStatus{SendingEnabled: true, EnforcementStatus: model.EnforcementStatusHealthy}
+	sample := model.ReputationSample{MessageID: "msg-1", Sends: 1}
+
+	tests := []struct {
+		name         string
+		status       model.AccountStatus
+		statusErr    error
+		sum          model.ReputationSample
+		expectStatus model.AccountStatus
+	}{
+		{
+			name:         "First message",
+			statusErr:    repo.ErrNotFound,
+			sum:          model.ReputationSample{Sends: 100},
+			expectStatus: healthy,
+		},
+		{
+			name:         "Bounce rate under review",
+			status:       healthy,
+			sum:          model.ReputationSample{Sends: 100, Bounces: 5},
+			expectStatus: model.AccountStatus{SendingEnabled: true, EnforcementStatus: model.EnforcementStatusProbation, BounceRate: 0.05},
+		},
+		{
+			name:         "Complaint rate under review",
+			status:       healthy,
+			sum:          model.ReputationSample{Sends: 1000, Complaints: 1},
+			expectStatus: model.AccountStatus{SendingEnabled: true, EnforcementStatus: model.EnforcementStatusProbation, ComplaintRate: 0.001},
+		},
+		{
+			name:         "Bounce rate pauses sending",
+			status:       model.AccountStatus{SendingEnabled: true, EnforcementStatus: model.EnforcementStatusProbation},
+			sum:          model.ReputationSample{Sends: 100, Bounces: 10},
+			expectStatus: model.AccountStatus{SendingEnabled: false, EnforcementStatus: model.EnforcementStatusShutdown, BounceRate: 0.1},
+		},
+		{
+			name:         "Complaint rate pauses sending",
+			status:       healthy,
+			sum:          model.ReputationSample{Sends: 200, Complaints: 1},
+			expectStatus: model.AccountStatus{SendingEnabled: false, EnforcementStatus: model.EnforcementStatusShutdown, ComplaintRate: 0.005},
+		},
+		{
+			name:         "Recovered rates end the review",
+			status:       model.AccountStatus{SendingEnabled: true, EnforcementStatus: model.EnforcementStatusProbation, BounceRate: 0.06},
+			sum:          model.ReputationSample{Sends: 100, Bounces: 4},
+			expectStatus: model.AccountStatus{SendingEnabled: true, EnforcementStatus: model.EnforcementStatusHealthy, BounceRate: 0.04},
+		},
+		{
+			name:         "Paused account stays paused",
+			status:       model.AccountStatus{SendingEnabled: false, EnforcementStatus: model.EnforcementStatusShutdown, BounceRate: 0.1},
+			sum:          model.ReputationSample{Sends: 100},
+			expectStatus: model.AccountStatus{SendingEnabled: false, EnforcementStatus: model.EnforcementStatusShutdown},
+		},
+		{
+			name:         "Rates are not enforced below the minimum volume",
+			status:       healthy,
+			sum:          model.ReputationSample{Sends: 99, Bounces: 99},
+			expectStatus: model.AccountStatus{SendingEnabled: true, EnforcementStatus: model.EnforcementStatusHealthy, BounceRate: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockReputationRepo(ctrl)
+
+			mockRepo.EXPECT().AddReputationSample(gomock.Any(), sample, gomock.Any()).Return(nil)
+			mockRepo.EXPECT().SumReputationSamples(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, since time.Time) (model.ReputationSample, error) {
+				assert.WithinDuration(t, time.Now().Add(-24*time.Hour), since, time.Second)
+				return tt.sum, nil
+			})
+			mockRepo.EXPECT().GetAccountStatus(gomock.Any()).Return(tt.status, tt.statusErr)
+			mockRepo.EXPECT().PutAccountStatus(gomock.Any(), tt.expectStatus).Return(nil)
+
+			err := service.NewReputationService(mockRepo, nil, 24*time.Hour, 100).TrackReputation(context.Background(), sample)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestReputationService_TrackReputation_ConfigurationSet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sample := model.ReputationSample{MessageID: "msg-1", ConfigurationSetName: "marketing", Sends: 1, Bounces: 1}
+
+	tests := []struct {
+		name             string
+		metricsEnabled   bool
+		expectSetSamples bool
+	}{
+		{name: "Reputation metrics enabled", metricsEnabled: true, expectSetSamples: true},
+		{name: "Reputation metrics disabled"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockReputationRepo(ctrl)
+			mockSets := mocks.NewMockConfigurationSetGetter(ctrl)
+
+			// The message counts towards the account either way.
+			mockRepo.EXPECT().AddReputationSample(gomock.Any(), sample, gomock.Any()).Return(nil)
+			mockRepo.EXPECT().SumReputationSamples(gomock.Any(), gomock.Any()).Return(model.ReputationSample{Sends: 1, Bounces: 1}, nil)
+			mockRepo.EXPECT().GetAccountStatus(gomock.Any()).Return(model.AccountStatus{}, repo.ErrNotFound)
+			mockRepo.EXPECT().PutAccountStatus(gomock.Any(), model.AccountStatus{SendingEnabled: true, EnforcementStatus: model.EnforcementStatusHealthy, BounceRate: 1}).Return(nil)
+			mockSets.EXPECT().GetConfigurationSet(gomock.Any(), "marketing").Return(model.ConfigurationSet{Name: "marketing", ReputationMetricsEnabled: tt.metricsEnabled}, nil)
+			if tt.expectSetSamples {
+				mockRepo.EXPECT().AddConfigurationSetReputationSample(gomock.Any(), sample, gomock.Any()).Return(nil)
+			}
+
+			assert.NoError(t, service.NewReputationService(mockRepo, mockSets, time.Hour, 100).TrackReputation(context.Background(), sample))
+		})
+	}
+}
+
+func TestReputationService_GetConfigurationSetReputation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("Reputation metrics enabled", func(t *testing.T) {
+		mockRepo := mocks.NewMockReputationRepo(ctrl)
+		mockSets := mocks.NewMockConfigurationSetGetter(ctrl)
+		mockSets.EXPECT().GetConfigurationSet(gomock.Any(), "marketing").Return(model.ConfigurationSet{Name: "marketing", ReputationMetricsEnabled: true}, nil)
+		mockRepo.EXPECT().SumConfigurationSetReputationSamples(gomock.Any(), "marketing", gomock.Any()).DoAndReturn(func(_ context.Context, _ string, since time.Time) (model.ReputationSample, error) {
+			assert.WithinDuration(t, time.Now().Add(-time.Hour), since, time.Second)
+			return model.ReputationSample{Sends: 200, Bounces: 10, Complaints: 1}, nil
+		})
+
+		metrics, err := service.NewReputationService(mockRepo, mockSets, time.Hour, 100).GetConfigurationSetReputation(context.Background(), "marketing")
+		assert.NoError(t, err)
+		assert.Equal(t, model.ReputationMetrics{ConfigurationSetName: "marketing", Sends: 200, BounceRate: 0.05, ComplaintRate: 0.005}, metrics)
+	})
+
+	t.Run("Reputation metrics disabled", func(t *testing.T) {
+		mockSets := mocks.NewMockConfigurationSetGetter(ctrl)
+		mockSets.EXPECT().GetConfigurationSet(gomock.Any(), "marketing").Return(model.ConfigurationSet{Name: "marketing"}, nil)
+
+		_, err := service.NewReputationService(mocks.NewMockReputationRepo(ctrl), mockSets, time.Hour, 100).GetConfigurationSetReputation(context.Background(), "marketing")
+		assert.EqualError(t, err, "InvalidParameterValue: Reputation metrics are not enabled for configuration set marketing.")
+	})
+}
+
+func TestReputationService_UpdateAccountSendingEnabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	paused := model.AccountStatus{SendingEnabled: false, EnforcementStatus: model.EnforcementStatusShutdown, BounceRate: 0.12}
+
+	t.Run("Resume gives a fresh start", func(t *testing.T) {
+		mockRepo := mocks.NewMockReputationRepo(ctrl)
+		mockRepo.EXPECT().GetAccountStatus(gomock.Any()).Return(paused, nil)
+		mockRepo.EXPECT().DeleteReputationSamples(gomock.Any()).Return(nil)
+		mockRepo.EXPECT().PutAccountStatus(gomock.Any(), model.AccountStatus{SendingEnabled: true, EnforcementStatus: model.EnforcementStatusHealthy}).Return(nil)
+
+		assert.NoError(t, service.NewReputationService(mockRepo, nil, time.Hour, 100).UpdateAccountSendingEnabled(context.Background(), true))
+	})
+
+	t.Run("Pause keeps the rates", func(t *testing.T) {
+		mockRepo := mocks.NewMockReputationRepo(ctrl)
+		mockRepo.EXPECT().GetAccountStatus(gomock.Any()).Return(model.AccountStatus{}, repo.ErrNotFound)
+		mockRepo.EXPECT().PutAccountStatus(gomock.Any(), model.AccountStatus{SendingEnabled: false, EnforcementStatus: model.EnforcementStatusHealthy}).Return(nil)
+
+		assert.NoError(t, service.NewReputationService(mockRepo, nil, time.Hour, 100).UpdateAccountSendingEnabled(context.Background(), false))
+	})
+}
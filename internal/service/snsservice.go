@@ -0,0 +1,376 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/kamal-github/demtech/internal/repo"
+)
+
+const (
+	defaultListTopicsMaxItems        = 100
+	defaultListSubscriptionsMaxItems = 100
+	snsTimeFormat                    = "2006-01-02T15:04:05.000Z"
+	snsDeliveryTimeout               = 15 * time.Second
+)
+
+var topicNameRegexp = regexp.MustCompile(`^[A-Za-z0-9_-]{1,256}$`)
+
+type SNSRepo interface {
+	CreateTopic(ctx context.Context, t model.Topic) error
+	GetTopic(ctx context.Context, topicArn string) (model.Topic, error)
+	DeleteTopic(ctx context.Context, topicArn string) error
+	ListTopics(ctx context.Context) ([]model.Topic, error)
+	PutSubscription(ctx context.Context, s model.Subscription) error
+	GetSubscription(ctx context.Context, subscriptionArn string) (model.Subscription, error)
+	DeleteSubscription(ctx context.Context, subscriptionArn string) error
+	ListSubscriptions(ctx context.Context, topicArn string) ([]model.Subscription, error)
+}
+
+// MessageSigner signs SNS messages before they are posted to subscribers.
+type MessageSigner interface {
+	Sign(m *model.SNSMessage) error
+}
+
+// QueueSender sends messages to the SQS queues subscribed to a topic.
+type QueueSender interface {
+	SendMessage(ctx context.Context, queueURL, body string, delaySeconds *int) (model.QueueMessage, error)
+}
+
+type SNSConfig struct {
+	// BaseURL is where the SubscribeURL and UnsubscribeURL of the messages point to.
+	BaseURL   string
+	Region    string
+	AccountID string
+}
+
+// SNSService is a minimal SNS: topics SES events are published to, and HTTP(S)
+// subscriptions which go through the SubscriptionConfirmation handshake and
+// receive signed messages, just like with Amazon SNS.
+type SNSService struct {
+	snsRepo    SNSRepo
+	signer     MessageSigner
+	queues     QueueSender
+	httpClient *http.Client
+	cfg        SNSConfig
+}
+
+// SNSOption configures an optional collaborator of SNSService
+type SNSOption func(*SNSService)
+
+// WithQueueSender enables subscriptions with the sqs protocol
+func WithQueueSender(q QueueSender) SNSOption {
+	return func(s *SNSService) { s.queues = q }
+}
+
+func NewSNSService(r SNSRepo, signer MessageSigner, cfg SNSConfig, opts ...SNSOption) SNSService {
+	s := SNSService{snsRepo: r, signer: signer, httpClient: &http.Client{Timeout: snsDeliveryTimeout}, cfg: cfg}
+	for _, opt := range opts {
+		opt(&s)
+	}
+	return s
+}
+
+// CreateTopic creates a topic and returns its ARN. Creating a topic which
+// already exists returns the ARN of the existing one.
+func (s SNSService) CreateTopic(ctx context.Context, name string, attributes map[string]string) (string, error) {
+	if !topicNameRegexp.MatchString(name) {
+		return "", invalidSNSParameter("Topic Name")
+	}
+
+	signatureVersion := "1"
+	for k, v := range attributes {
+		switch k {
+		case "SignatureVersion":
+			if v != "1" && v != "2" {
+				return "", invalidSNSParameter("Attributes Reason: SignatureVersion: Invalid value [" + v + "]. Valid values: 1, 2")
+			}
+			signatureVersion = v
+		case "DisplayName", "Policy", "DeliveryPolicy", "KmsMasterKeyId", "TracingConfig":
+			// Accepted, they do not change anything in the mock.
+		default:
+			return "", invalidSNSParameter("Attributes Reason: Unknown attribute " + k)
+		}
+	}
+
+	t := model.Topic{
+		TopicArn:         fmt.Sprintf("arn:aws:sns:%s:%s:%s", s.cfg.Region, s.cfg.AccountID, name),
+		Name:             name,
+		SignatureVersion: signatureVersion,
+	}
+	if err := s.snsRepo.CreateTopic(ctx, t); err != nil && !errors.Is(err, repo.ErrAlreadyExists) {
+		return "", err
+	}
+	return t.TopicArn, nil
+}
+
+// DeleteTopic deletes a topic and its subscriptions. Deleting a topic which
+// does not exist is not an error.
+func (s SNSService) DeleteTopic(ctx context.Context, topicArn string) error {
+	return s.snsRepo.DeleteTopic(ctx, topicArn)
+}
+
+// ListTopics returns one page of topic ARNs along with the token of the next one
+func (s SNSService) ListTopics(ctx context.Context, nextToken string) ([]string, string, error) {
+	topics, err := s.snsRepo.ListTopics(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+
+	page, next := paginate(topics, func(t model.Topic) string { return t.TopicArn }, defaultListTopicsMaxItems, nextToken)
+	arns := make([]string, 0, len(page))
+	for _, t := range page {
+		arns = append(arns, t.TopicArn)
+	}
+	return arns, next, nil
+}
+
+// Subscribe subscribes an HTTP(S) endpoint to a topic. The subscription is
+// pending until the endpoint confirms it through the SubscribeURL of the
+// SubscriptionConfirmation message posted to it. The ARN is only returned
+// once confirmed, like SNS does. SQS queues of the mock, identified by their
+// ARN, are confirmed right away as they belong to the same account.
+func (s SNSService) Subscribe(ctx context.Context, topicArn, protocol, endpoint string) (string, error) {
+	t, err := s.getTopic(ctx, topicArn)
+	if err != nil {
+		return "", err
+	}
+
+	switch protocol {
+	case "http", "https":
+		if u, err := url.Parse(endpoint); err != nil || u.Scheme != protocol || u.Host == "" {
+			return "", invalidSNSParameter("Endpoint must match the specified protocol")
+		}
+	case "sqs":
+		if s.queues == nil {
+			return "", invalidSNSParameter("Amazon SNS does not support this protocol string: " + protocol)
+		}
+		if !strings.HasPrefix(endpoint, "arn:aws:sqs:") {
+			return "", invalidSNSParameter("SQS endpoint ARN")
+		}
+	default:
+		return "", invalidSNSParameter("Amazon SNS does not support this protocol string: " + protocol)
+	}
+
+	subs, err := s.snsRepo.ListSubscriptions(ctx, topicArn)
+	if err != nil {
+		return "", err
+	}
+
+	sub := model.Subscription{
+		SubscriptionArn: topicArn + ":" + uuid.NewString(),
+		TopicArn:        topicArn,
+		Protocol:        protocol,
+		Endpoint:        endpoint,
+	}
+	for _, existing := range subs {
+		if existing.Protocol == protocol && existing.Endpoint == endpoint {
+			if existing.Confirmed {
+				return existing.SubscriptionArn, nil
+			}
+			sub = existing
+		}
+	}
+
+	if protocol == "sqs" {
+		sub.Confirmed = true
+		if err := s.snsRepo.PutSubscription(ctx, sub); err != nil {
+			return "", err
+		}
+		return sub.SubscriptionArn, nil
+	}
+
+	if sub.Token, err = generateSubscriptionToken(); err != nil {
+		return "", err
+	}
+	if err := s.snsRepo.PutSubscription(ctx, sub); err != nil {
+		return "", err
+	}
+
+	confirmation := model.SNSMessage{
+		Type:             model.SNSTypeSubscriptionConfirmation,
+		MessageID:        uuid.NewString(),
+		Token:            sub.Token,
+		TopicArn:         topicArn,
+		Message:          "You have chosen to subscribe to the topic " + topicArn + ".\nTo confirm the subscription, visit the SubscribeURL included in this message.",
+		SubscribeURL:     s.actionURL("ConfirmSubscription", "TopicArn", topicArn, "Token", sub.Token),
+		Timestamp:        time.Now().UTC().Format(snsTimeFormat),
+		SignatureVersion: t.SignatureVersion,
+	}
+	if err := s.post(ctx, sub, confirmation); err != nil {
+		// SNS would retry, the subscription stays pending until Subscribe is called again.
+		log.Printf("Failed to send the subscription confirmation of %s to %s: %v", topicArn, endpoint, err)
+	}
+
+	return "pending confirmation", nil
+}
+
+// ConfirmSubscription confirms the pending subscription the token was sent to
+func (s SNSService) ConfirmSubscription(ctx context.Context, topicArn, token string) (string, error) {
+	if _, err := s.getTopic(ctx, topicArn); err != nil {
+		return "", err
+	}
+
+	subs, err := s.snsRepo.ListSubscriptions(ctx, topicArn)
+	if err != nil {
+		return "", err
+	}
+	for _, sub := range subs {
+		if token == "" || sub.Token != token {
+			continue
+		}
+		sub.Confirmed = true
+		if err := s.snsRepo.PutSubscription(ctx, sub); err != nil {
+			return "", err
+		}
+		return sub.SubscriptionArn, nil
+	}
+
+	return "", invalidSNSParameter("Token")
+}
+
+// Unsubscribe deletes a subscription
+func (s SNSService) Unsubscribe(ctx context.Context, subscriptionArn string) error {
+	if _, err := s.snsRepo.GetSubscription(ctx, subscriptionArn); errors.Is(err, repo.ErrNotFound) {
+		return &model.SESError{Code: "NotFound", Message: "Subscription does not exist"}
+	} else if err != nil {
+		return err
+	}
+
+	return s.snsRepo.DeleteSubscription(ctx, subscriptionArn)
+}
+
+// ListSubscriptionsByTopic returns one page of the subscriptions of a topic
+// along with the token of the next one
+func (s SNSService) ListSubscriptionsByTopic(ctx context.Context, topicArn, nextToken string) ([]model.Subscription, string, error) {
+	if _, err := s.getTopic(ctx, topicArn); err != nil {
+		return nil, "", err
+	}
+
+	subs, err := s.snsRepo.ListSubscriptions(ctx, topicArn)
+	if err != nil {
+		return nil, "", err
+	}
+
+	page, next := paginate(subs, func(sub model.Subscription) string { return sub.SubscriptionArn }, defaultListSubscriptionsMaxItems, nextToken)
+	return page, next, nil
+}
+
+// Publish posts a signed notification to each confirmed subscription of the
+// topic. Failing deliveries are logged, they do not fail the publish.
+func (s SNSService) Publish(ctx context.Context, topicArn, subject, message string) (string, error) {
+	t, err := s.getTopic(ctx, topicArn)
+	if err != nil {
+		return "", err
+	}
+
+	subs, err := s.snsRepo.ListSubscriptions(ctx, topicArn)
+	if err != nil {
+		return "", err
+	}
+
+	notification := model.SNSMessage{
+		Type:             model.SNSTypeNotification,
+		MessageID:        uuid.NewString(),
+		TopicArn:         topicArn,
+		Subject:          subject,
+		Message:          message,
+		Timestamp:        time.Now().UTC().Format(snsTimeFormat),
+		SignatureVersion: t.SignatureVersion,
+	}
+	for _, sub := range subs {
+		if !sub.Confirmed {
+			continue
+		}
+		n := notification
+		n.UnsubscribeURL = s.actionURL("Unsubscribe", "SubscriptionArn", sub.SubscriptionArn)
+		if err := s.post(ctx, sub, n); err != nil {
+			log.Printf("Failed to deliver message %s of %s to %s: %v", n.MessageID, topicArn, sub.Endpoint, err)
+		}
+	}
+
+	return notification.MessageID, nil
+}
+
+// post signs m and posts it to the endpoint of sub, with the headers SNS
+// sets. SQS subscriptions receive the same JSON document as message body.
+func (s SNSService) post(ctx context.Context, sub model.Subscription, m model.SNSMessage) error {
+	if err := s.signer.Sign(&m); err != nil {
+		return err
+	}
+	data, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	if sub.Protocol == "sqs" {
+		_, err := s.queues.SendMessage(ctx, sub.Endpoint, string(data), nil)
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.Endpoint, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "text/plain; charset=UTF-8")
+	req.Header.Set("User-Agent", "Amazon Simple Notification Service Agent")
+	req.Header.Set("x-amz-sns-message-type", m.Type)
+	req.Header.Set("x-amz-sns-message-id", m.MessageID)
+	req.Header.Set("x-amz-sns-topic-arn", m.TopicArn)
+	if m.Type == model.SNSTypeNotification {
+		req.Header.Set("x-amz-sns-subscription-arn", sub.SubscriptionArn)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+func (s SNSService) getTopic(ctx context.Context, topicArn string) (model.Topic, error) {
+	t, err := s.snsRepo.GetTopic(ctx, topicArn)
+	if errors.Is(err, repo.ErrNotFound) {
+		return model.Topic{}, &model.SESError{Code: "NotFound", Message: "Topic does not exist"}
+	}
+	return t, err
+}
+
+// actionURL builds a link to an SNS Query action of the mock from key/value pairs.
+func (s SNSService) actionURL(action string, params ...string) string {
+	q := url.Values{"Action": {action}}
+	for i := 0; i+1 < len(params); i += 2 {
+		q.Set(params[i], params[i+1])
+	}
+	return strings.TrimSuffix(s.cfg.BaseURL, "/") + "/sns?" + q.Encode()
+}
+
+func invalidSNSParameter(name string) *model.SESError {
+	return &model.SESError{Code: "InvalidParameter", Message: "Invalid parameter: " + name}
+}
+
+func generateSubscriptionToken() (string, error) {
+	b := make([]byte, 64)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
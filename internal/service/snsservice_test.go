@@ -0,0 +1,252 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/kamal-github/demtech/internal/repo"
+	"github.com/kamal-github/demtech/internal/service"
+	"github.com/kamal-github/demtech/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+const topicArn = "arn:aws:sns:us-east-1:123456789012:ses-events"
+
+var snsConfig = service.SNSConfig{BaseURL: "http://localhost:8080", Region: "us-east-1", AccountID: "123456789012"}
+
+func TestSNSService_CreateTopic(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name         string
+		topicName    string
+		attributes   map[string]string
+		repoErr      error
+		expectTopic  *model.Topic
+		expectErrMsg string
+	}{
+		{
+			name:        "New topic signs with SHA1 by default",
+			topicName:   "ses-events",
+			expectTopic: &model.Topic{TopicArn: topicArn, Name: "ses-events", SignatureVersion: "1"},
+		},
+		{
+			name:        "SignatureVersion 2",
+			topicName:   "ses-events",
+			attributes:  map[string]string{"SignatureVersion": "2", "DisplayName": "SES"},
+			expectTopic: &model.Topic{TopicArn: topicArn, Name: "ses-events", SignatureVersion: "2"},
+		},
+		{
+			name:        "Existing topic",
+			topicName:   "ses-events",
+			repoErr:     repo.ErrAlreadyExists,
+			expectTopic: &model.Topic{TopicArn: topicArn, Name: "ses-events", SignatureVersion: "1"},
+		},
+		{
+			name:         "Invalid name",
+			topicName:    "ses.events",
+			expectErrMsg: "InvalidParameter: Invalid parameter: Topic Name",
+		},
+		{
+			name:         "Invalid SignatureVersion",
+			topicName:    "ses-events",
+			attributes:   map[string]string{"SignatureVersion": "3"},
+			expectErrMsg: "InvalidParameter: Invalid parameter: Attributes Reason: SignatureVersion: Invalid value [3]. Valid values: 1, 2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			mockRepo := mocks.NewMockSNSRepo(ctrl)
+			if tt.expectTopic != nil {
+				mockRepo.EXPECT().CreateTopic(gomock.Any(), *tt.expectTopic).Return(tt.repoErr).Times(1)
+			}
+
+			s := service.NewSNSService(mockRepo, mocks.NewMockMessageSigner(ctrl), snsConfig)
+			arn, err := s.CreateTopic(context.Background(), tt.topicName, tt.attributes)
+
+			if tt.expectErrMsg != "" {
+				assert.EqualError(err, tt.expectErrMsg)
+				return
+			}
+			assert.NoError(err)
+			assert.Equal(topicArn, arn)
+		})
+	}
+}
+
+func TestSNSService_SubscriptionHandshake(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	assert := assert.New(t)
+
+	var received []model.SNSMessage
+	var headers []http.Header
+	subscriber := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var m model.SNSMessage
+		assert.NoError(json.NewDecoder(r.Body).Decode(&m))
+		received = append(received, m)
+		headers = append(headers, r.Header)
+	}))
+	defer subscriber.Close()
+
+	topic := model.Topic{TopicArn: topicArn, Name: "ses-events", SignatureVersion: "2"}
+	var stored model.Subscription
+
+	mockRepo := mocks.NewMockSNSRepo(ctrl)
+	mockRepo.EXPECT().GetTopic(gomock.Any(), topicArn).Return(topic, nil).AnyTimes()
+	mockRepo.EXPECT().PutSubscription(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, s model.Subscription) error { stored = s; return nil }).Times(2)
+	mockRepo.EXPECT().ListSubscriptions(gomock.Any(), topicArn).
+		DoAndReturn(func(context.Context, string) ([]model.Subscription, error) {
+			if stored.SubscriptionArn == "" {
+				return nil, nil
+			}
+			return []model.Subscription{stored}, nil
+		}).AnyTimes()
+
+	mockSigner := mocks.NewMockMessageSigner(ctrl)
+	mockSigner.EXPECT().Sign(gomock.Any()).DoAndReturn(func(m *model.SNSMessage) error {
+		m.SigningCertURL = "http://localhost:8080/sns/SimpleNotificationService.pem"
+		m.Signature = "signed"
+		return nil
+	}).AnyTimes()
+
+	s := service.NewSNSService(mockRepo, mockSigner, snsConfig)
+	ctx := context.Background()
+
+	// Subscribing sends a signed SubscriptionConfirmation
+	arn, err := s.Subscribe(ctx, topicArn, "http", subscriber.URL)
+	assert.NoError(err)
+	assert.Equal("pending confirmation", arn)
+	if !assert.Len(received, 1) {
+		return
+	}
+	confirmation := received[0]
+	assert.Equal(model.SNSTypeSubscriptionConfirmation, confirmation.Type)
+	assert.Equal("SubscriptionConfirmation", headers[0].Get("x-amz-sns-message-type"))
+	assert.Equal(topicArn, headers[0].Get("x-amz-sns-topic-arn"))
+	assert.Equal("2", confirmation.SignatureVersion)
+	assert.Equal("signed", confirmation.Signature)
+
+	subscribeURL, err := url.Parse(confirmation.SubscribeURL)
+	assert.NoError(err)
+	assert.Equal("/sns", subscribeURL.Path)
+	assert.Equal("ConfirmSubscription", subscribeURL.Query().Get("Action"))
+	assert.Equal(confirmation.Token, subscribeURL.Query().Get("Token"))
+
+	// Notifications only reach confirmed subscriptions
+	_, err = s.Publish(ctx, topicArn, "", `{"eventType":"Bounce"}`)
+	assert.NoError(err)
+	assert.Len(received, 1)
+
+	_, err = s.ConfirmSubscription(ctx, topicArn, "wrong")
+	assert.EqualError(err, "InvalidParameter: Invalid parameter: Token")
+
+	subscriptionArn, err := s.ConfirmSubscription(ctx, topicArn, confirmation.Token)
+	assert.NoError(err)
+	assert.Equal(stored.SubscriptionArn, subscriptionArn)
+	assert.True(stored.Confirmed)
+
+	messageID, err := s.Publish(ctx, topicArn, "", `{"eventType":"Bounce"}`)
+	assert.NoError(err)
+	if assert.Len(received, 2) {
+		notification := received[1]
+		assert.Equal(model.SNSTypeNotification, notification.Type)
+		assert.Equal(messageID, notification.MessageID)
+		assert.Equal(`{"eventType":"Bounce"}`, notification.Message)
+		assert.Contains(notification.UnsubscribeURL, "Action=Unsubscribe")
+		assert.Equal(subscriptionArn, headers[1].Get("x-amz-sns-subscription-arn"))
+	}
+
+	// Subscribing again returns the ARN of the confirmed subscription
+	arn, err = s.Subscribe(ctx, topicArn, "http", subscriber.URL)
+	assert.NoError(err)
+	assert.Equal(subscriptionArn, arn)
+}
+
+func TestSNSService_Subscribe_Invalid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name         string
+		topicErr     error
+		protocol     string
+		endpoint     string
+		expectErrMsg string
+	}{
+		{name: "Unknown topic", topicErr: repo.ErrNotFound, protocol: "http", endpoint: "http://localhost:9000", expectErrMsg: "NotFound: Topic does not exist"},
+		{name: "Unsupported protocol", protocol: "email", endpoint: "ops@example.com", expectErrMsg: "InvalidParameter: Invalid parameter: Amazon SNS does not support this protocol string: email"},
+		{name: "Endpoint of another protocol", protocol: "https", endpoint: "http://localhost:9000", expectErrMsg: "InvalidParameter: Invalid parameter: Endpoint must match the specified protocol"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockSNSRepo(ctrl)
+			mockRepo.EXPECT().GetTopic(gomock.Any(), topicArn).Return(model.Topic{TopicArn: topicArn, SignatureVersion: "1"}, tt.topicErr).Times(1)
+
+			s := service.NewSNSService(mockRepo, mocks.NewMockMessageSigner(ctrl), snsConfig)
+			_, err := s.Subscribe(context.Background(), topicArn, tt.protocol, tt.endpoint)
+
+			assert.EqualError(t, err, tt.expectErrMsg)
+		})
+	}
+}
+
+func TestSNSService_SQSSubscription(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	assert := assert.New(t)
+
+	const queueArn = "arn:aws:sqs:us-east-1:123456789012:ses-events"
+	var stored model.Subscription
+
+	mockRepo := mocks.NewMockSNSRepo(ctrl)
+	mockRepo.EXPECT().GetTopic(gomock.Any(), topicArn).Return(model.Topic{TopicArn: topicArn, SignatureVersion: "1"}, nil).AnyTimes()
+	mockRepo.EXPECT().ListSubscriptions(gomock.Any(), topicArn).
+		DoAndReturn(func(context.Context, string) ([]model.Subscription, error) {
+			if stored.SubscriptionArn == "" {
+				return nil, nil
+			}
+			return []model.Subscription{stored}, nil
+		}).AnyTimes()
+	mockRepo.EXPECT().PutSubscription(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, s model.Subscription) error { stored = s; return nil }).Times(1)
+
+	mockSigner := mocks.NewMockMessageSigner(ctrl)
+	mockSigner.EXPECT().Sign(gomock.Any()).Return(nil).Times(1)
+
+	mockQueues := mocks.NewMockQueueSender(ctrl)
+	mockQueues.EXPECT().SendMessage(gomock.Any(), queueArn, gomock.Any(), nil).
+		DoAndReturn(func(_ context.Context, _, body string, _ *int) (model.QueueMessage, error) {
+			var m model.SNSMessage
+			assert.NoError(json.Unmarshal([]byte(body), &m))
+			assert.Equal(model.SNSTypeNotification, m.Type)
+			assert.Equal(`{"eventType":"Bounce"}`, m.Message)
+			return model.QueueMessage{}, nil
+		}).Times(1)
+
+	s := service.NewSNSService(mockRepo, mockSigner, snsConfig, service.WithQueueSender(mockQueues))
+	ctx := context.Background()
+
+	// Queues of the same account are confirmed right away
+	arn, err := s.Subscribe(ctx, topicArn, "sqs", queueArn)
+	assert.NoError(err)
+	assert.Equal(stored.SubscriptionArn, arn)
+	assert.True(stored.Confirmed)
+
+	_, err = s.Publish(ctx, topicArn, "", `{"eventType":"Bounce"}`)
+	assert.NoError(err)
+
+	_, err = s.Subscribe(ctx, topicArn, "sqs", "http://localhost:8080/sqs/123456789012/ses-events")
+	assert.EqualError(err, "InvalidParameter: Invalid parameter: SQS endpoint ARN")
+}
@@ -0,0 +1,366 @@
+package service
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/kamal-github/demtech/internal/repo"
+)
+
+const (
+	defaultVisibilityTimeout      = 30
+	defaultMessageRetentionPeriod = 345600
+	defaultMaximumMessageSize     = 262144
+	maxReceiveMessages            = 10
+	maxWaitTimeSeconds            = 20
+	receivePollInterval           = 200 * time.Millisecond
+)
+
+var queueNameRegexp = regexp.MustCompile(`^[A-Za-z0-9_-]{1,80}$`)
+
+// queueAttributeRanges are the queue attributes which can be set, with their valid range.
+var queueAttributeRanges = map[string][2]int{
+	"VisibilityTimeout":             {0, 43200},
+	"DelaySeconds":                  {0, 900},
+	"ReceiveMessageWaitTimeSeconds": {0, maxWaitTimeSeconds},
+	"MessageRetentionPeriod":        {60, 1209600},
+	"MaximumMessageSize":            {1024, 262144},
+}
+
+type SQSRepo interface {
+	CreateQueue(ctx context.Context, q model.Queue) error
+	GetQueue(ctx context.Context, name string) (model.Queue, error)
+	AddMessage(ctx context.Context, queue string, m model.QueueMessage, visibleAt time.Time) error
+	ReceiveMessages(ctx context.Context, queue string, now, visibleUntil time.Time, max int) ([]model.QueueMessage, error)
+	DeleteMessage(ctx context.Context, queue, messageID string) error
+	CountMessages(ctx context.Context, queue string, now time.Time) (model.QueueCounts, error)
+}
+
+type SQSConfig struct {
+	// BaseURL is the root of the queue URLs.
+	BaseURL   string
+	Region    string
+	AccountID string
+}
+
+// SQSService is a minimal SQS: standard queues with delays, visibility
+// timeouts and long polling, to consume SES events from.
+type SQSService struct {
+	sqsRepo SQSRepo
+	cfg     SQSConfig
+}
+
+func NewSQSService(r SQSRepo, cfg SQSConfig) SQSService {
+	return SQSService{sqsRepo: r, cfg: cfg}
+}
+
+// CreateQueue creates a queue and returns its URL. Creating a queue which
+// already exists returns its URL, unless the given attributes differ from the
+// ones of the existing queue.
+func (s SQSService) CreateQueue(ctx context.Context, name string, attributes map[string]string) (string, error) {
+	if !queueNameRegexp.MatchString(name) {
+		return "", invalidSQSParameter("Can only include alphanumeric characters, hyphens, or underscores. 1 to 80 in length")
+	}
+
+	now := time.Now().Unix()
+	q := model.Queue{
+		Name:                   name,
+		CreatedTimestamp:       now,
+		LastModifiedTimestamp:  now,
+		VisibilityTimeout:      defaultVisibilityTimeout,
+		MessageRetentionPeriod: defaultMessageRetentionPeriod,
+		MaximumMessageSize:     defaultMaximumMessageSize,
+	}
+	values := make(map[string]int, len(attributes))
+	for k, v := range attributes {
+		switch k {
+		case "Policy", "RedrivePolicy", "RedriveAllowPolicy", "KmsMasterKeyId", "KmsDataKeyReusePeriodSeconds", "SqsManagedSseEnabled":
+			// Accepted, they do not change anything in the mock.
+			continue
+		}
+		bounds, ok := queueAttributeRanges[k]
+		if !ok {
+			return "", &model.SESError{Code: "InvalidAttributeName", Message: "Unknown Attribute " + k + "."}
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil || n < bounds[0] || n > bounds[1] {
+			return "", &model.SESError{Code: "InvalidAttributeValue", Message: "Invalid value for the parameter " + k + "."}
+		}
+		values[k] = n
+	}
+	setQueueAttributes(&q, values)
+
+	err := s.sqsRepo.CreateQueue(ctx, q)
+	if errors.Is(err, repo.ErrAlreadyExists) {
+		existing, err := s.sqsRepo.GetQueue(ctx, name)
+		if err != nil {
+			return "", err
+		}
+		for k, v := range values {
+			if queueAttributeValues(existing)[k] != v {
+				return "", &model.SESError{Code: "QueueAlreadyExists", Message: "A queue already exists with the same name and a different value for attribute " + k}
+			}
+		}
+	} else if err != nil {
+		return "", err
+	}
+	return s.queueURL(name), nil
+}
+
+// GetQueueURL returns the URL of a queue
+func (s SQSService) GetQueueURL(ctx context.Context, name string) (string, error) {
+	if _, err := s.getQueue(ctx, name); err != nil {
+		return "", err
+	}
+	return s.queueURL(name), nil
+}
+
+// SendMessage adds a message to a queue, it becomes visible after
+// delaySeconds, or the DelaySeconds of the queue when nil. queueURL can also be
+// the ARN of the queue.
+func (s SQSService) SendMessage(ctx context.Context, queueURL, body string, delaySeconds *int) (model.QueueMessage, error) {
+	q, err := s.getQueue(ctx, queueName(queueURL))
+	if err != nil {
+		return model.QueueMessage{}, err
+	}
+
+	if body == "" {
+		return model.QueueMessage{}, &model.SESError{Code: "MissingParameter", Message: "The request must contain the parameter MessageBody."}
+	}
+	if len(body) > q.MaximumMessageSize {
+		return model.QueueMessage{}, invalidSQSParameter(fmt.Sprintf("One or more parameters are invalid. Reason: Message must be shorter than %d bytes.", q.MaximumMessageSize))
+	}
+
+	delay := q.DelaySeconds
+	if delaySeconds != nil {
+		if *delaySeconds < 0 || *delaySeconds > queueAttributeRanges["DelaySeconds"][1] {
+			return model.QueueMessage{}, invalidSQSParameter("Value " + strconv.Itoa(*delaySeconds) + " for parameter DelaySeconds is invalid. Reason: must be between 0 and 900, if provided.")
+		}
+		delay = *delaySeconds
+	}
+
+	now := time.Now()
+	sum := md5.Sum([]byte(body))
+	m := model.QueueMessage{
+		MessageID:     uuid.NewString(),
+		Body:          body,
+		MD5OfBody:     hex.EncodeToString(sum[:]),
+		SentTimestamp: now.UnixMilli(),
+	}
+	if err := s.sqsRepo.AddMessage(ctx, q.Name, m, now.Add(time.Duration(delay)*time.Second)); err != nil {
+		return model.QueueMessage{}, err
+	}
+	return m, nil
+}
+
+// ReceiveMessage returns up to MaxNumberOfMessages visible messages and hides
+// them for the visibility timeout. When none is visible it waits up to
+// WaitTimeSeconds for one to arrive.
+func (s SQSService) ReceiveMessage(ctx context.Context, req model.ReceiveMessageRequest) ([]model.QueueMessage, error) {
+	q, err := s.getQueue(ctx, queueName(req.QueueURL))
+	if err != nil {
+		return nil, err
+	}
+
+	max := req.MaxNumberOfMessages
+	if max == 0 {
+		max = 1
+	}
+	if max < 1 || max > maxReceiveMessages {
+		return nil, invalidSQSParameter("Value " + strconv.Itoa(max) + " for parameter MaxNumberOfMessages is invalid. Reason: Must be between 1 and 10, if provided.")
+	}
+	visibility := q.VisibilityTimeout
+	if req.VisibilityTimeout != nil {
+		if *req.VisibilityTimeout < 0 || *req.VisibilityTimeout > queueAttributeRanges["VisibilityTimeout"][1] {
+			return nil, invalidSQSParameter("Value " + strconv.Itoa(*req.VisibilityTimeout) + " for parameter VisibilityTimeout is invalid. Reason: Must be between 0 and 43200, if provided.")
+		}
+		visibility = *req.VisibilityTimeout
+	}
+	wait := q.ReceiveMessageWaitTimeSeconds
+	if req.WaitTimeSeconds != nil {
+		if *req.WaitTimeSeconds < 0 || *req.WaitTimeSeconds > maxWaitTimeSeconds {
+			return nil, invalidSQSParameter("Value " + strconv.Itoa(*req.WaitTimeSeconds) + " for parameter WaitTimeSeconds is invalid. Reason: Must be >= 0 and <= 20, if provided.")
+		}
+		wait = *req.WaitTimeSeconds
+	}
+
+	deadline := time.Now().Add(time.Duration(wait) * time.Second)
+	for {
+		now := time.Now()
+		messages, err := s.sqsRepo.ReceiveMessages(ctx, q.Name, now, now.Add(time.Duration(visibility)*time.Second), max)
+		if err != nil {
+			return nil, err
+		}
+		if messages, err = s.dropExpired(ctx, q, messages, now); err != nil {
+			return nil, err
+		}
+		if len(messages) > 0 || !now.Before(deadline) {
+			for i := range messages {
+				if messages[i].ReceiptHandle, err = newReceiptHandle(messages[i].MessageID); err != nil {
+					return nil, err
+				}
+			}
+			return messages, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			// The client went away while polling, nothing was received.
+			return nil, nil
+		case <-time.After(min(receivePollInterval, time.Until(deadline))):
+		}
+	}
+}
+
+// DeleteMessage deletes the message a receipt handle was issued for
+func (s SQSService) DeleteMessage(ctx context.Context, queueURL, receiptHandle string) error {
+	q, err := s.getQueue(ctx, queueName(queueURL))
+	if err != nil {
+		return err
+	}
+
+	messageID, ok := parseReceiptHandle(receiptHandle)
+	if !ok {
+		return &model.SESError{Code: "ReceiptHandleIsInvalid", Message: "The input receipt handle \"" + receiptHandle + "\" is not a valid receipt handle."}
+	}
+	return s.sqsRepo.DeleteMessage(ctx, q.Name, messageID)
+}
+
+// GetQueueAttributes returns the requested attributes of a queue, "All" returns every attribute
+func (s SQSService) GetQueueAttributes(ctx context.Context, queueURL string, names []string) (map[string]string, error) {
+	q, err := s.getQueue(ctx, queueName(queueURL))
+	if err != nil {
+		return nil, err
+	}
+	counts, err := s.sqsRepo.CountMessages(ctx, q.Name, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	all := map[string]string{
+		"QueueArn":                              s.queueArn(q.Name),
+		"ApproximateNumberOfMessages":           strconv.FormatInt(counts.Visible, 10),
+		"ApproximateNumberOfMessagesNotVisible": strconv.FormatInt(counts.NotVisible, 10),
+		"ApproximateNumberOfMessagesDelayed":    strconv.FormatInt(counts.Delayed, 10),
+		"CreatedTimestamp":                      strconv.FormatInt(q.CreatedTimestamp, 10),
+		"LastModifiedTimestamp":                 strconv.FormatInt(q.LastModifiedTimestamp, 10),
+		"SqsManagedSseEnabled":                  "false",
+	}
+	for k, v := range queueAttributeValues(q) {
+		all[k] = strconv.Itoa(v)
+	}
+
+	attributes := make(map[string]string, len(names))
+	for _, name := range names {
+		if name == "All" {
+			return all, nil
+		}
+		v, ok := all[name]
+		if !ok {
+			return nil, &model.SESError{Code: "InvalidAttributeName", Message: "Unknown Attribute " + name + "."}
+		}
+		attributes[name] = v
+	}
+	return attributes, nil
+}
+
+// dropExpired deletes the messages which outlived the retention period of the
+// queue and returns the other ones.
+func (s SQSService) dropExpired(ctx context.Context, q model.Queue, messages []model.QueueMessage, now time.Time) ([]model.QueueMessage, error) {
+	retained := messages[:0]
+	for _, m := range messages {
+		if now.Sub(time.UnixMilli(m.SentTimestamp)) <= time.Duration(q.MessageRetentionPeriod)*time.Second {
+			retained = append(retained, m)
+			continue
+		}
+		if err := s.sqsRepo.DeleteMessage(ctx, q.Name, m.MessageID); err != nil {
+			return nil, err
+		}
+	}
+	return retained, nil
+}
+
+func (s SQSService) getQueue(ctx context.Context, name string) (model.Queue, error) {
+	q, err := s.sqsRepo.GetQueue(ctx, name)
+	if errors.Is(err, repo.ErrNotFound) {
+		return model.Queue{}, &model.SESError{Code: "AWS.SimpleQueueService.NonExistentQueue", Message: "The specified queue does not exist."}
+	}
+	return q, err
+}
+
+func (s SQSService) queueURL(name string) string {
+	return strings.TrimSuffix(s.cfg.BaseURL, "/") + "/sqs/" + s.cfg.AccountID + "/" + name
+}
+
+func (s SQSService) queueArn(name string) string {
+	return fmt.Sprintf("arn:aws:sqs:%s:%s:%s", s.cfg.Region, s.cfg.AccountID, name)
+}
+
+// queueName returns the name of the queue a URL or an ARN refers to.
+func queueName(ref string) string {
+	if strings.HasPrefix(ref, "arn:") {
+		return ref[strings.LastIndex(ref, ":")+1:]
+	}
+	return ref[strings.LastIndex(strings.TrimSuffix(ref, "/"), "/")+1:]
+}
+
+func queueAttributeValues(q model.Queue) map[string]int {
+	return map[string]int{
+		"VisibilityTimeout":             q.VisibilityTimeout,
+		"DelaySeconds":                  q.DelaySeconds,
+		"ReceiveMessageWaitTimeSeconds": q.ReceiveMessageWaitTimeSeconds,
+		"MessageRetentionPeriod":        q.MessageRetentionPeriod,
+		"MaximumMessageSize":            q.MaximumMessageSize,
+	}
+}
+
+func setQueueAttributes(q *model.Queue, values map[string]int) {
+	for k, v := range values {
+		switch k {
+		case "VisibilityTimeout":
+			q.VisibilityTimeout = v
+		case "DelaySeconds":
+			q.DelaySeconds = v
+		case "ReceiveMessageWaitTimeSeconds":
+			q.ReceiveMessageWaitTimeSeconds = v
+		case "MessageRetentionPeriod":
+			q.MessageRetentionPeriod = v
+		case "MaximumMessageSize":
+			q.MaximumMessageSize = v
+		}
+	}
+}
+
+// newReceiptHandle issues a new receipt handle for a received message, every
+// receive gets a different one like with SQS.
+func newReceiptHandle(messageID string) (string, error) {
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString([]byte(messageID + ":" + hex.EncodeToString(nonce))), nil
+}
+
+func parseReceiptHandle(handle string) (string, bool) {
+	data, err := base64.RawURLEncoding.DecodeString(handle)
+	if err != nil {
+		return "", false
+	}
+	messageID, _, ok := strings.Cut(string(data), ":")
+	return messageID, ok && messageID != ""
+}
+
+func invalidSQSParameter(message string) *model.SESError {
+	return &model.SESError{Code: "InvalidParameterValue", Message: message}
+}
@@ -0,0 +1,269 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/kamal-github/demtech/internal/repo"
+	"github.com/kamal-github/demtech/internal/service"
+	"github.com/kamal-github/demtech/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+const queueURL = "http://localhost:8080/sqs/123456789012/ses-events"
+
+var sqsConfig = service.SQSConfig{BaseURL: "http://localhost:8080", Region: "us-east-1", AccountID: "123456789012"}
+
+func intPtr(n int) *int { return &n }
+
+func TestSQSService_CreateQueue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	existing := model.Queue{Name: "ses-events", VisibilityTimeout: 30, MessageRetentionPeriod: 345600, MaximumMessageSize: 262144}
+
+	tests := []struct {
+		name         string
+		queueName    string
+		attributes   map[string]string
+		mockSetup    func(*mocks.MockSQSRepo)
+		expectErrMsg string
+	}{
+		{
+			name:       "New queue",
+			queueName:  "ses-events",
+			attributes: map[string]string{"VisibilityTimeout": "60", "Policy": "{}"},
+			mockSetup: func(m *mocks.MockSQSRepo) {
+				m.EXPECT().CreateQueue(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, q model.Queue) error {
+					assert.Equal(t, "ses-events", q.Name)
+					assert.Equal(t, 60, q.VisibilityTimeout)
+					assert.Equal(t, 345600, q.MessageRetentionPeriod)
+					return nil
+				})
+			},
+		},
+		{
+			name:      "Existing queue with the same attributes",
+			queueName: "ses-events",
+			mockSetup: func(m *mocks.MockSQSRepo) {
+				m.EXPECT().CreateQueue(gomock.Any(), gomock.Any()).Return(repo.ErrAlreadyExists)
+				m.EXPECT().GetQueue(gomock.Any(), "ses-events").Return(existing, nil)
+			},
+		},
+		{
+			name:       "Existing queue with other attributes",
+			queueName:  "ses-events",
+			attributes: map[string]string{"VisibilityTimeout": "60"},
+			mockSetup: func(m *mocks.MockSQSRepo) {
+				m.EXPECT().CreateQueue(gomock.Any(), gomock.Any()).Return(repo.ErrAlreadyExists)
+				m.EXPECT().GetQueue(gomock.Any(), "ses-events").Return(existing, nil)
+			},
+			expectErrMsg: "QueueAlreadyExists: A queue already exists with the same name and a different value for attribute VisibilityTimeout",
+		},
+		{
+			name:         "FIFO queues are not supported",
+			queueName:    "ses-events.fifo",
+			mockSetup:    func(m *mocks.MockSQSRepo) {},
+			expectErrMsg: "InvalidParameterValue: Can only include alphanumeric characters, hyphens, or underscores. 1 to 80 in length",
+		},
+		{
+			name:         "Unknown attribute",
+			queueName:    "ses-events",
+			attributes:   map[string]string{"FifoQueue": "true"},
+			mockSetup:    func(m *mocks.MockSQSRepo) {},
+			expectErrMsg: "InvalidAttributeName: Unknown Attribute FifoQueue.",
+		},
+		{
+			name:         "Attribute out of range",
+			queueName:    "ses-events",
+			attributes:   map[string]string{"ReceiveMessageWaitTimeSeconds": "21"},
+			mockSetup:    func(m *mocks.MockSQSRepo) {},
+			expectErrMsg: "InvalidAttributeValue: Invalid value for the parameter ReceiveMessageWaitTimeSeconds.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			mockRepo := mocks.NewMockSQSRepo(ctrl)
+			tt.mockSetup(mockRepo)
+
+			s := service.NewSQSService(mockRepo, sqsConfig)
+			url, err := s.CreateQueue(context.Background(), tt.queueName, tt.attributes)
+
+			if tt.expectErrMsg != "" {
+				assert.EqualError(err, tt.expectErrMsg)
+				return
+			}
+			assert.NoError(err)
+			assert.Equal(queueURL, url)
+		})
+	}
+}
+
+func TestSQSService_SendMessage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	assert := assert.New(t)
+
+	mockRepo := mocks.NewMockSQSRepo(ctrl)
+	mockRepo.EXPECT().GetQueue(gomock.Any(), "ses-events").Return(model.Queue{Name: "ses-events", DelaySeconds: 5, MaximumMessageSize: 1024}, nil).AnyTimes()
+	mockRepo.EXPECT().AddMessage(gomock.Any(), "ses-events", gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, m model.QueueMessage, visibleAt time.Time) error {
+			assert.Equal("5d41402abc4b2a76b9719d911017c592", m.MD5OfBody)
+			assert.WithinDuration(time.UnixMilli(m.SentTimestamp).Add(5*time.Second), visibleAt, time.Millisecond)
+			return nil
+		})
+
+	s := service.NewSQSService(mockRepo, sqsConfig)
+
+	// The queue can be referenced by its ARN too
+	m, err := s.SendMessage(context.Background(), "arn:aws:sqs:us-east-1:123456789012:ses-events", "hello", nil)
+	assert.NoError(err)
+	assert.NotEmpty(m.MessageID)
+
+	_, err = s.SendMessage(context.Background(), queueURL, "hello", intPtr(901))
+	assert.EqualError(err, "InvalidParameterValue: Value 901 for parameter DelaySeconds is invalid. Reason: must be between 0 and 900, if provided.")
+
+	_, err = s.SendMessage(context.Background(), queueURL, string(make([]byte, 1025)), nil)
+	assert.EqualError(err, "InvalidParameterValue: One or more parameters are invalid. Reason: Message must be shorter than 1024 bytes.")
+}
+
+func TestSQSService_ReceiveMessage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	queue := model.Queue{Name: "ses-events", VisibilityTimeout: 30, MessageRetentionPeriod: 60}
+	fresh := model.QueueMessage{MessageID: "m1", Body: "hello", SentTimestamp: time.Now().UnixMilli()}
+	expired := model.QueueMessage{MessageID: "m0", Body: "old", SentTimestamp: time.Now().Add(-2 * time.Minute).UnixMilli()}
+
+	tests := []struct {
+		name         string
+		req          model.ReceiveMessageRequest
+		mockSetup    func(*mocks.MockSQSRepo)
+		expectBodies []string
+		expectErrMsg string
+	}{
+		{
+			name: "Visible message with the visibility timeout of the queue",
+			req:  model.ReceiveMessageRequest{QueueURL: queueURL},
+			mockSetup: func(m *mocks.MockSQSRepo) {
+				m.EXPECT().ReceiveMessages(gomock.Any(), "ses-events", gomock.Any(), gomock.Any(), 1).
+					DoAndReturn(func(_ context.Context, _ string, now, visibleUntil time.Time, _ int) ([]model.QueueMessage, error) {
+						assert.Equal(t, 30*time.Second, visibleUntil.Sub(now))
+						return []model.QueueMessage{fresh}, nil
+					})
+			},
+			expectBodies: []string{"hello"},
+		},
+		{
+			name: "Long polling until a message arrives",
+			req:  model.ReceiveMessageRequest{QueueURL: queueURL, MaxNumberOfMessages: 10, VisibilityTimeout: intPtr(0), WaitTimeSeconds: intPtr(2)},
+			mockSetup: func(m *mocks.MockSQSRepo) {
+				gomock.InOrder(
+					m.EXPECT().ReceiveMessages(gomock.Any(), "ses-events", gomock.Any(), gomock.Any(), 10).Return(nil, nil).Times(2),
+					m.EXPECT().ReceiveMessages(gomock.Any(), "ses-events", gomock.Any(), gomock.Any(), 10).Return([]model.QueueMessage{fresh}, nil),
+				)
+			},
+			expectBodies: []string{"hello"},
+		},
+		{
+			name: "Expired messages are deleted",
+			req:  model.ReceiveMessageRequest{QueueURL: queueURL, MaxNumberOfMessages: 2},
+			mockSetup: func(m *mocks.MockSQSRepo) {
+				m.EXPECT().ReceiveMessages(gomock.Any(), "ses-events", gomock.Any(), gomock.Any(), 2).Return([]model.QueueMessage{expired, fresh}, nil)
+				m.EXPECT().DeleteMessage(gomock.Any(), "ses-events", "m0").Return(nil)
+			},
+			expectBodies: []string{"hello"},
+		},
+		{
+			name:         "Too many messages",
+			req:          model.ReceiveMessageRequest{QueueURL: queueURL, MaxNumberOfMessages: 11},
+			mockSetup:    func(m *mocks.MockSQSRepo) {},
+			expectErrMsg: "InvalidParameterValue: Value 11 for parameter MaxNumberOfMessages is invalid. Reason: Must be between 1 and 10, if provided.",
+		},
+		{
+			name:         "Wait time too long",
+			req:          model.ReceiveMessageRequest{QueueURL: queueURL, WaitTimeSeconds: intPtr(21)},
+			mockSetup:    func(m *mocks.MockSQSRepo) {},
+			expectErrMsg: "InvalidParameterValue: Value 21 for parameter WaitTimeSeconds is invalid. Reason: Must be >= 0 and <= 20, if provided.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			mockRepo := mocks.NewMockSQSRepo(ctrl)
+			mockRepo.EXPECT().GetQueue(gomock.Any(), "ses-events").Return(queue, nil)
+			tt.mockSetup(mockRepo)
+
+			s := service.NewSQSService(mockRepo, sqsConfig)
+			messages, err := s.ReceiveMessage(context.Background(), tt.req)
+
+			if tt.expectErrMsg != "" {
+				assert.EqualError(err, tt.expectErrMsg)
+				return
+			}
+			assert.NoError(err)
+			var bodies []string
+			for _, m := range messages {
+				assert.NotEmpty(m.ReceiptHandle)
+				bodies = append(bodies, m.Body)
+			}
+			assert.Equal(tt.expectBodies, bodies)
+		})
+	}
+}
+
+func TestSQSService_DeleteMessage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	assert := assert.New(t)
+
+	mockRepo := mocks.NewMockSQSRepo(ctrl)
+	mockRepo.EXPECT().GetQueue(gomock.Any(), "ses-events").Return(model.Queue{Name: "ses-events", VisibilityTimeout: 30, MessageRetentionPeriod: 60}, nil).AnyTimes()
+	mockRepo.EXPECT().GetQueue(gomock.Any(), "unknown").Return(model.Queue{}, repo.ErrNotFound)
+	mockRepo.EXPECT().ReceiveMessages(gomock.Any(), "ses-events", gomock.Any(), gomock.Any(), 1).
+		Return([]model.QueueMessage{{MessageID: "m1", Body: "hello", SentTimestamp: time.Now().UnixMilli()}}, nil)
+	mockRepo.EXPECT().DeleteMessage(gomock.Any(), "ses-events", "m1").Return(nil)
+
+	s := service.NewSQSService(mockRepo, sqsConfig)
+
+	messages, err := s.ReceiveMessage(context.Background(), model.ReceiveMessageRequest{QueueURL: queueURL})
+	assert.NoError(err)
+	assert.NoError(s.DeleteMessage(context.Background(), queueURL, messages[0].ReceiptHandle))
+
+	assert.EqualError(s.DeleteMessage(context.Background(), queueURL, "not-a-handle"),
+		`ReceiptHandleIsInvalid: The input receipt handle "not-a-handle" is not a valid receipt handle.`)
+	assert.EqualError(s.DeleteMessage(context.Background(), "http://localhost:8080/sqs/123456789012/unknown", messages[0].ReceiptHandle),
+		"AWS.SimpleQueueService.NonExistentQueue: The specified queue does not exist.")
+}
+
+func TestSQSService_GetQueueAttributes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	assert := assert.New(t)
+
+	mockRepo := mocks.NewMockSQSRepo(ctrl)
+	mockRepo.EXPECT().GetQueue(gomock.Any(), "ses-events").Return(model.Queue{Name: "ses-events", CreatedTimestamp: 1700000000, VisibilityTimeout: 30}, nil).AnyTimes()
+	mockRepo.EXPECT().CountMessages(gomock.Any(), "ses-events", gomock.Any()).Return(model.QueueCounts{Visible: 3, NotVisible: 1, Delayed: 2}, nil).AnyTimes()
+
+	s := service.NewSQSService(mockRepo, sqsConfig)
+
+	attributes, err := s.GetQueueAttributes(context.Background(), queueURL, []string{"ApproximateNumberOfMessages", "QueueArn"})
+	assert.NoError(err)
+	assert.Equal(map[string]string{"ApproximateNumberOfMessages": "3", "QueueArn": "arn:aws:sqs:us-east-1:123456789012:ses-events"}, attributes)
+
+	attributes, err = s.GetQueueAttributes(context.Background(), queueURL, []string{"All"})
+	assert.NoError(err)
+	assert.Equal("1", attributes["ApproximateNumberOfMessagesNotVisible"])
+	assert.Equal("2", attributes["ApproximateNumberOfMessagesDelayed"])
+	assert.Equal("1700000000", attributes["CreatedTimestamp"])
+	assert.Equal("30", attributes["VisibilityTimeout"])
+
+	_, err = s.GetQueueAttributes(context.Background(), queueURL, []string{"Color"})
+	assert.EqualError(err, "InvalidAttributeName: Unknown Attribute Color.")
+}
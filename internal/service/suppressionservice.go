@@ -0,0 +1,187 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/mail"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/kamal-github/demtech/internal/repo"
+)
+
+const defaultListSuppressedDestinationsPageSize = 1000
+
+type SuppressionRepo interface {
+	PutSuppressedDestination(ctx context.Context, d model.SuppressedDestination) error
+	GetSuppressedDestination(ctx context.Context, emailAddress string) (model.SuppressedDestination, error)
+	DeleteSuppressedDestination(ctx context.Context, emailAddress string) error
+	ListSuppressedDestinations(ctx context.Context) ([]model.SuppressedDestination, error)
+}
+
+// ConfigurationSetGetter gives the configuration set a message is sent with.
+type ConfigurationSetGetter interface {
+	GetConfigurationSet(ctx context.Context, name string) (model.ConfigurationSet, error)
+}
+
+// SuppressionService manages the account level suppression list. Which
+// reasons an address is suppressed for, and added to the list for, are the
+// SuppressedReasons of the account unless the configuration set of the
+// message overrides them with its SuppressionOptions.
+type SuppressionService struct {
+	suppressionRepo   SuppressionRepo
+	sets              ConfigurationSetGetter
+	suppressedReasons []string
+}
+
+func NewSuppressionService(r SuppressionRepo, sets ConfigurationSetGetter, suppressedReasons []string) SuppressionService {
+	return SuppressionService{suppressionRepo: r, sets: sets, suppressedReasons: suppressedReasons}
+}
+
+// PutSuppressedDestination adds an address to the suppression list, or updates its reason.
+func (s SuppressionService) PutSuppressedDestination(ctx context.Context, emailAddress, reason string) error {
+	if err := validateSuppressionReason(reason); err != nil {
+		return err
+	}
+	a, err := mail.ParseAddress(emailAddress)
+	if err != nil {
+		return &model.SESError{Code: "BadRequestException", Message: "Invalid email address " + emailAddress + "."}
+	}
+
+	return s.suppressionRepo.PutSuppressedDestination(ctx, model.SuppressedDestination{
+		EmailAddress:   strings.ToLower(a.Address),
+		Reason:         reason,
+		LastUpdateTime: time.Now().UTC(),
+	})
+}
+
+func (s SuppressionService) GetSuppressedDestination(ctx context.Context, emailAddress string) (model.SuppressedDestination, error) {
+	d, err := s.suppressionRepo.GetSuppressedDestination(ctx, suppressedAddress(emailAddress))
+	if errors.Is(err, repo.ErrNotFound) {
+		return model.SuppressedDestination{}, notOnSuppressionList(emailAddress)
+	}
+	return d, err
+}
+
+func (s SuppressionService) DeleteSuppressedDestination(ctx context.Context, emailAddress string) error {
+	err := s.suppressionRepo.DeleteSuppressedDestination(ctx, suppressedAddress(emailAddress))
+	if errors.Is(err, repo.ErrNotFound) {
+		return notOnSuppressionList(emailAddress)
+	}
+	return err
+}
+
+// ListSuppressedDestinations returns one page of the suppressed addresses
+// matching filter, ordered by address. The returned token is empty on the last page.
+func (s SuppressionService) ListSuppressedDestinations(ctx context.Context, filter model.SuppressionListFilter, pageSize int, nextToken string) ([]model.SuppressedDestination, string, error) {
+	for _, reason := range filter.Reasons {
+		if err := validateSuppressionReason(reason); err != nil {
+			return nil, "", err
+		}
+	}
+	if pageSize <= 0 || pageSize > defaultListSuppressedDestinationsPageSize {
+		pageSize = defaultListSuppressedDestinationsPageSize
+	}
+
+	all, err := s.suppressionRepo.ListSuppressedDestinations(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+
+	matching := make([]model.SuppressedDestination, 0, len(all))
+	for _, d := range all {
+		if len(filter.Reasons) > 0 && !slices.Contains(filter.Reasons, d.Reason) {
+			continue
+		}
+		if !filter.StartDate.IsZero() && d.LastUpdateTime.Before(filter.StartDate) {
+			continue
+		}
+		if !filter.EndDate.IsZero() && d.LastUpdateTime.After(filter.EndDate) {
+			continue
+		}
+		matching = append(matching, d)
+	}
+
+	page, next := paginate(matching, func(d model.SuppressedDestination) string { return strings.ToLower(d.EmailAddress) }, pageSize, nextToken)
+	return page, next, nil
+}
+
+// IsSuppressed reports whether a message of the configuration set must not be
+// sent to recipient, as it is on the suppression list for a suppressed reason.
+func (s SuppressionService) IsSuppressed(ctx context.Context, configurationSetName, recipient string) (bool, error) {
+	reasons, err := s.reasons(ctx, configurationSetName)
+	if err != nil || len(reasons) == 0 {
+		return false, err
+	}
+
+	d, err := s.suppressionRepo.GetSuppressedDestination(ctx, suppressedAddress(recipient))
+	if errors.Is(err, repo.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return slices.Contains(reasons, d.Reason), nil
+}
+
+// SuppressRecipient adds the recipient of a message which bounced or
+// complained to the suppression list, unless its reason is not suppressed for
+// the configuration set of the message.
+func (s SuppressionService) SuppressRecipient(ctx context.Context, configurationSetName string, d model.SuppressedDestination) error {
+	reasons, err := s.reasons(ctx, configurationSetName)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(reasons, d.Reason) {
+		return nil
+	}
+
+	d.EmailAddress = suppressedAddress(d.EmailAddress)
+	if d.LastUpdateTime.IsZero() {
+		d.LastUpdateTime = time.Now().UTC()
+	}
+	return s.suppressionRepo.PutSuppressedDestination(ctx, d)
+}
+
+// reasons returns the suppressed reasons applying to the messages of a configuration set.
+func (s SuppressionService) reasons(ctx context.Context, configurationSetName string) ([]string, error) {
+	if configurationSetName == "" {
+		return s.suppressedReasons, nil
+	}
+
+	set, err := s.sets.GetConfigurationSet(ctx, configurationSetName)
+	if err != nil {
+		return nil, err
+	}
+	if set.SuppressionOptions != nil {
+		return set.SuppressionOptions.SuppressedReasons, nil
+	}
+	return s.suppressedReasons, nil
+}
+
+func validateSuppressionReason(reason string) error {
+	if reason != model.SuppressionReasonBounce && reason != model.SuppressionReasonComplaint {
+		return &model.SESError{Code: "InvalidParameterValue", Message: "Invalid suppressed reason " + reason + ". Valid values: BOUNCE, COMPLAINT."}
+	}
+	return nil
+}
+
+// bareAddress strips the display name off an address, e.g. "Jane <jane@example.com>".
+func bareAddress(address string) string {
+	if a, err := mail.ParseAddress(address); err == nil {
+		return a.Address
+	}
+	return address
+}
+
+// suppressedAddress is the address an entry of the suppression list is stored
+// under, whatever the display name and case it is given with.
+func suppressedAddress(address string) string {
+	return strings.ToLower(bareAddress(address))
+}
+
+func notOnSuppressionList(emailAddress string) *model.SESError {
+	return &model.SESError{Code: "NotFoundException", Message: "Email address " + emailAddress + " does not exist on your suppression list."}
+}
@@ -0,0 +1,265 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/kamal-github/demtech/internal/repo"
+	"github.com/kamal-github/demtech/internal/service"
+	"github.com/kamal-github/demtech/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSuppressionService_PutSuppressedDestination(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name         string
+		emailAddress string
+		reason       string
+		expectPut    string
+		expectErrMsg string
+	}{
+		{
+			name:         "Bounce",
+			emailAddress: "bounced@example.com",
+			reason:       "BOUNCE",
+			expectPut:    "bounced@example.com",
+		},
+		{
+			name:         "Stored under the lowercased bare address",
+			emailAddress: "Jane <Jane@Example.com>",
+			reason:       "BOUNCE",
+			expectPut:    "jane@example.com",
+		},
+		{
+			name:         "Invalid reason",
+			emailAddress: "bounced@example.com",
+			reason:       "DELIVERY",
+			expectErrMsg: "InvalidParameterValue: Invalid suppressed reason DELIVERY. Valid values: BOUNCE, COMPLAINT.",
+		},
+		{
+			name:         "Invalid email address",
+			emailAddress: "not an address",
+			reason:       "COMPLAINT",
+			expectErrMsg: "BadRequestException: Invalid email address not an address.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			mockRepo := mocks.NewMockSuppressionRepo(ctrl)
+			if tt.expectPut != "" {
+				mockRepo.EXPECT().PutSuppressedDestination(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, d model.SuppressedDestination) error {
+						assert.Equal(tt.expectPut, d.EmailAddress)
+						assert.Equal(tt.reason, d.Reason)
+						assert.False(d.LastUpdateTime.IsZero())
+						return nil
+					})
+			}
+
+			err := service.NewSuppressionService(mockRepo, nil, nil).PutSuppressedDestination(context.Background(), tt.emailAddress, tt.reason)
+
+			if tt.expectErrMsg != "" {
+				assert.EqualError(err, tt.expectErrMsg)
+				return
+			}
+			assert.NoError(err)
+		})
+	}
+}
+
+func TestSuppressionService_GetAndDeleteSuppressedDestination(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	assert := assert.New(t)
+
+	mockRepo := mocks.NewMockSuppressionRepo(ctrl)
+	mockRepo.EXPECT().GetSuppressedDestination(gomock.Any(), "unknown@example.com").Return(model.SuppressedDestination{}, repo.ErrNotFound)
+	mockRepo.EXPECT().DeleteSuppressedDestination(gomock.Any(), "unknown@example.com").Return(repo.ErrNotFound)
+
+	s := service.NewSuppressionService(mockRepo, nil, nil)
+
+	_, err := s.GetSuppressedDestination(context.Background(), "unknown@example.com")
+	assert.EqualError(err, "NotFoundException: Email address unknown@example.com does not exist on your suppression list.")
+	assert.EqualError(s.DeleteSuppressedDestination(context.Background(), "unknown@example.com"),
+		"NotFoundException: Email address unknown@example.com does not exist on your suppression list.")
+
+	// The display name and case of the address do not matter.
+	mockRepo.EXPECT().GetSuppressedDestination(gomock.Any(), "jane@example.com").Return(model.SuppressedDestination{EmailAddress: "jane@example.com", Reason: "BOUNCE"}, nil)
+	mockRepo.EXPECT().DeleteSuppressedDestination(gomock.Any(), "jane@example.com").Return(nil)
+	d, err := s.GetSuppressedDestination(context.Background(), "Jane <Jane@Example.com>")
+	assert.NoError(err)
+	assert.Equal("jane@example.com", d.EmailAddress)
+	assert.NoError(s.DeleteSuppressedDestination(context.Background(), "Jane@example.com"))
+}
+
+func TestSuppressionService_ListSuppressedDestinations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC) }
+	all := []model.SuppressedDestination{
+		{EmailAddress: "a@example.com", Reason: "BOUNCE", LastUpdateTime: day(1)},
+		{EmailAddress: "b@example.com", Reason: "COMPLAINT", LastUpdateTime: day(2)},
+		{EmailAddress: "c@example.com", Reason: "BOUNCE", LastUpdateTime: day(3)},
+	}
+
+	tests := []struct {
+		name            string
+		filter          model.SuppressionListFilter
+		pageSize        int
+		nextToken       string
+		expectAddresses []string
+		expectNextToken string
+		expectErrMsg    string
+	}{
+		{
+			name:            "All",
+			expectAddresses: []string{"a@example.com", "b@example.com", "c@example.com"},
+		},
+		{
+			name:            "By reason",
+			filter:          model.SuppressionListFilter{Reasons: []string{"BOUNCE"}},
+			expectAddresses: []string{"a@example.com", "c@example.com"},
+		},
+		{
+			name:            "By date",
+			filter:          model.SuppressionListFilter{StartDate: day(2), EndDate: day(2)},
+			expectAddresses: []string{"b@example.com"},
+		},
+		{
+			name:            "First page",
+			pageSize:        2,
+			expectAddresses: []string{"a@example.com", "b@example.com"},
+			expectNextToken: "b@example.com",
+		},
+		{
+			name:            "Last page",
+			pageSize:        2,
+			nextToken:       "b@example.com",
+			expectAddresses: []string{"c@example.com"},
+		},
+		{
+			name:         "Invalid reason",
+			filter:       model.SuppressionListFilter{Reasons: []string{"bounce"}},
+			expectErrMsg: "InvalidParameterValue: Invalid suppressed reason bounce. Valid values: BOUNCE, COMPLAINT.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			mockRepo := mocks.NewMockSuppressionRepo(ctrl)
+			mockRepo.EXPECT().ListSuppressedDestinations(gomock.Any()).Return(all, nil).AnyTimes()
+
+			list, nextToken, err := service.NewSuppressionService(mockRepo, nil, nil).
+				ListSuppressedDestinations(context.Background(), tt.filter, tt.pageSize, tt.nextToken)
+
+			if tt.expectErrMsg != "" {
+				assert.EqualError(err, tt.expectErrMsg)
+				return
+			}
+			assert.NoError(err)
+			var addresses []string
+			for _, d := range list {
+				addresses = append(addresses, d.EmailAddress)
+			}
+			assert.Equal(tt.expectAddresses, addresses)
+			assert.Equal(tt.expectNextToken, nextToken)
+		})
+	}
+}
+
+func TestSuppressionService_IsSuppressed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name                 string
+		configurationSetName string
+		set                  model.ConfigurationSet
+		stored               *model.SuppressedDestination
+		expectSuppressed     bool
+	}{
+		{
+			name:             "Account reasons without configuration set",
+			stored:           &model.SuppressedDestination{EmailAddress: "jane@example.com", Reason: "BOUNCE"},
+			expectSuppressed: true,
+		},
+		{
+			name: "Not on the list",
+		},
+		{
+			name:                 "Configuration set without suppression options uses the account reasons",
+			configurationSetName: "default-config",
+			set:                  model.ConfigurationSet{Name: "default-config"},
+			stored:               &model.SuppressedDestination{EmailAddress: "jane@example.com", Reason: "COMPLAINT"},
+			expectSuppressed:     true,
+		},
+		{
+			name:                 "Configuration set suppressing bounces only",
+			configurationSetName: "default-config",
+			set:                  model.ConfigurationSet{Name: "default-config", SuppressionOptions: &model.SuppressionOptions{SuppressedReasons: []string{"BOUNCE"}}},
+			stored:               &model.SuppressedDestination{EmailAddress: "jane@example.com", Reason: "COMPLAINT"},
+		},
+		{
+			name:                 "Configuration set with suppression turned off",
+			configurationSetName: "default-config",
+			set:                  model.ConfigurationSet{Name: "default-config", SuppressionOptions: &model.SuppressionOptions{SuppressedReasons: []string{}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			mockRepo := mocks.NewMockSuppressionRepo(ctrl)
+			mockSets := mocks.NewMockConfigurationSetGetter(ctrl)
+
+			mockSets.EXPECT().GetConfigurationSet(gomock.Any(), tt.configurationSetName).Return(tt.set, nil).AnyTimes()
+			mockRepo.EXPECT().GetSuppressedDestination(gomock.Any(), "jane@example.com").
+				DoAndReturn(func(context.Context, string) (model.SuppressedDestination, error) {
+					if tt.stored == nil {
+						return model.SuppressedDestination{}, repo.ErrNotFound
+					}
+					return *tt.stored, nil
+				}).AnyTimes()
+
+			s := service.NewSuppressionService(mockRepo, mockSets, []string{"BOUNCE", "COMPLAINT"})
+			suppressed, err := s.IsSuppressed(context.Background(), tt.configurationSetName, "Jane <jane@example.com>")
+
+			assert.NoError(err)
+			assert.Equal(tt.expectSuppressed, suppressed)
+		})
+	}
+}
+
+func TestSuppressionService_SuppressRecipient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	assert := assert.New(t)
+
+	mockRepo := mocks.NewMockSuppressionRepo(ctrl)
+	mockSets := mocks.NewMockConfigurationSetGetter(ctrl)
+	mockSets.EXPECT().GetConfigurationSet(gomock.Any(), "transactional").
+		Return(model.ConfigurationSet{Name: "transactional", SuppressionOptions: &model.SuppressionOptions{SuppressedReasons: []string{"BOUNCE"}}}, nil).AnyTimes()
+
+	// Only the bounce is added as complaints are not suppressed for the configuration set
+	mockRepo.EXPECT().PutSuppressedDestination(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, d model.SuppressedDestination) error {
+			assert.Equal("bounce@simulator.amazonses.com", d.EmailAddress)
+			assert.Equal("BOUNCE", d.Reason)
+			assert.False(d.LastUpdateTime.IsZero())
+			return nil
+		}).Times(1)
+
+	s := service.NewSuppressionService(mockRepo, mockSets, []string{"BOUNCE", "COMPLAINT"})
+	assert.NoError(s.SuppressRecipient(context.Background(), "transactional", model.SuppressedDestination{EmailAddress: "Bounce <bounce@simulator.amazonses.com>", Reason: "BOUNCE"}))
+	assert.NoError(s.SuppressRecipient(context.Background(), "transactional", model.SuppressedDestination{EmailAddress: "complaint@simulator.amazonses.com", Reason: "COMPLAINT"}))
+}
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/kamal-github/demtech/internal/handlebars"
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/kamal-github/demtech/internal/repo"
+)
+
+const defaultListTemplatesMaxItems = 10
+
+type TemplateRepo interface {
+	CreateTemplate(ctx context.Context, t model.Template) error
+	UpdateTemplate(ctx context.Context, t model.Template) error
+	GetTemplate(ctx context.Context, name string) (model.Template, error)
+	DeleteTemplate(ctx context.Context, name string) error
+	ListTemplates(ctx context.Context) ([]model.TemplateMetadata, error)
+}
+
+// RenderingFailure is returned when a template refers to a value missing from
+// the template data. SES accepts such a message and only fails afterwards
+// when rendering it, so it is not an API error.
+type RenderingFailure struct {
+	TemplateName string
+	ErrorMessage string
+}
+
+func (e *RenderingFailure) Error() string {
+	return "RenderingFailure: " + e.ErrorMessage
+}
+
+type TemplateService struct {
+	templateRepo TemplateRepo
+}
+
+func NewTemplateService(r TemplateRepo) TemplateService {
+	return TemplateService{templateRepo: r}
+}
+
+func (s TemplateService) CreateTemplate(ctx context.Context, t model.Template) error {
+	if err := validateTemplate(t); err != nil {
+		return err
+	}
+
+	err := s.templateRepo.CreateTemplate(ctx, t)
+	if errors.Is(err, repo.ErrAlreadyExists) {
+		return &model.SESError{Code: "AlreadyExistsException", Message: "Template " + t.TemplateName + " already exists."}
+	}
+	return err
+}
+
+func (s TemplateService) UpdateTemplate(ctx context.Context, t model.Template) error {
+	if err := validateTemplate(t); err != nil {
+		return err
+	}
+
+	err := s.templateRepo.UpdateTemplate(ctx, t)
+	if errors.Is(err, repo.ErrNotFound) {
+		return templateDoesNotExist(t.TemplateName)
+	}
+	return err
+}
+
+func (s TemplateService) GetTemplate(ctx context.Context, name string) (model.Template, error) {
+	t, err := s.templateRepo.GetTemplate(ctx, name)
+	if errors.Is(err, repo.ErrNotFound) {
+		return model.Template{}, templateDoesNotExist(name)
+	}
+	return t, err
+}
+
+func (s TemplateService) DeleteTemplate(ctx context.Context, name string) error {
+	return s.templateRepo.DeleteTemplate(ctx, name)
+}
+
+// ListTemplates returns one page of templates ordered by name. The returned
+// token is the name to continue after, it is empty on the last page.
+func (s TemplateService) ListTemplates(ctx context.Context, maxItems int, nextToken string) ([]model.TemplateMetadata, string, error) {
+	if maxItems <= 0 {
+		maxItems = defaultListTemplatesMaxItems
+	}
+
+	all, err := s.templateRepo.ListTemplates(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+
+	page, next := paginate(all, func(t model.TemplateMetadata) string { return t.Name }, maxItems, nextToken)
+	return page, next, nil
+}
+
+// Render renders the template referenced by req with its TemplateData. It
+// fails with a *RenderingFailure when a value is missing from the data.
+func (s TemplateService) Render(ctx context.Context, req model.EmailRequest) (model.Message, error) {
+	name := req.Template
+	if name == "" {
+		// arn:aws:ses:us-east-1:123456789012:template/MyTemplate
+		_, name, _ = strings.Cut(req.TemplateArn, ":template/")
+	}
+
+	t, err := s.GetTemplate(ctx, name)
+	if err != nil {
+		return model.Message{}, err
+	}
+
+	var data map[string]any
+	if err := json.Unmarshal([]byte(req.TemplateData), &data); err != nil {
+		return model.Message{}, &model.SESError{Code: "InvalidParameterValue", Message: "Template data must be a valid JSON object."}
+	}
+
+	var msg model.Message
+	renderErr := func(err error) (model.Message, error) {
+		var missing *handlebars.MissingValueError
+		if errors.As(err, &missing) {
+			return msg, &RenderingFailure{TemplateName: name, ErrorMessage: missing.Error()}
+		}
+		return msg, &RenderingFailure{TemplateName: name, ErrorMessage: "Template is invalid: " + err.Error()}
+	}
+
+	if msg.Subject.Data, err = handlebars.Render(t.SubjectPart, data); err != nil {
+		return renderErr(err)
+	}
+	if msg.Body.Text.Data, err = handlebars.Render(t.TextPart, data); err != nil {
+		return renderErr(err)
+	}
+	if t.HtmlPart != "" {
+		html, err := handlebars.Render(t.HtmlPart, data)
+		if err != nil {
+			return renderErr(err)
+		}
+		msg.Body.Html = &model.HtmlBody{Data: html}
+	}
+
+	return msg, nil
+}
+
+func validateTemplate(t model.Template) error {
+	if t.TemplateName == "" {
+		return &model.SESError{Code: "InvalidParameterValue", Message: "Template name is required."}
+	}
+
+	for _, part := range []string{t.SubjectPart, t.TextPart, t.HtmlPart} {
+		if _, err := handlebars.Parse(part); err != nil {
+			return &model.SESError{Code: "InvalidTemplateException", Message: "Template " + t.TemplateName + " is invalid: " + err.Error()}
+		}
+	}
+	return nil
+}
+
+func templateDoesNotExist(name string) *model.SESError {
+	return &model.SESError{Code: "TemplateDoesNotExistException", Message: "Template " + name + " does not exist."}
+}
@@ -0,0 +1,176 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/kamal-github/demtech/internal/repo"
+	"github.com/kamal-github/demtech/internal/service"
+	"github.com/kamal-github/demtech/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTemplateService_CreateTemplate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name       string
+		template   model.Template
+		repoErr    error
+		callsRepo  bool
+		expectCode string
+	}{
+		{
+			name:      "Valid template",
+			template:  model.Template{TemplateName: "welcome", SubjectPart: "Hi {{name}}"},
+			callsRepo: true,
+		},
+		{
+			name:       "Duplicate template",
+			template:   model.Template{TemplateName: "welcome", SubjectPart: "Hi {{name}}"},
+			repoErr:    repo.ErrAlreadyExists,
+			callsRepo:  true,
+			expectCode: "AlreadyExistsException",
+		},
+		{
+			name:       "Invalid Handlebars",
+			template:   model.Template{TemplateName: "welcome", SubjectPart: "Hi {{#if name}}"},
+			expectCode: "InvalidTemplateException",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			mockRepo := mocks.NewMockTemplateRepo(ctrl)
+			if tt.callsRepo {
+				mockRepo.EXPECT().CreateTemplate(gomock.Any(), tt.template).Return(tt.repoErr).Times(1)
+			}
+
+			err := service.NewTemplateService(mockRepo).CreateTemplate(context.Background(), tt.template)
+
+			if tt.expectCode == "" {
+				assert.NoError(err)
+				return
+			}
+			var sesErr *model.SESError
+			assert.ErrorAs(err, &sesErr)
+			assert.Equal(tt.expectCode, sesErr.Code)
+		})
+	}
+}
+
+func TestTemplateService_ListTemplates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Now()
+	all := []model.TemplateMetadata{{Name: "a", CreatedTimestamp: now}, {Name: "b", CreatedTimestamp: now}, {Name: "c", CreatedTimestamp: now}}
+
+	tests := []struct {
+		name       string
+		maxItems   int
+		nextToken  string
+		expectPage []string
+		expectNext string
+	}{
+		{name: "First page", maxItems: 2, expectPage: []string{"a", "b"}, expectNext: "b"},
+		{name: "Last page", maxItems: 2, nextToken: "b", expectPage: []string{"c"}},
+		{name: "Default page size", expectPage: []string{"a", "b", "c"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			mockRepo := mocks.NewMockTemplateRepo(ctrl)
+			mockRepo.EXPECT().ListTemplates(gomock.Any()).Return(all, nil).Times(1)
+
+			page, next, err := service.NewTemplateService(mockRepo).ListTemplates(context.Background(), tt.maxItems, tt.nextToken)
+
+			assert.NoError(err)
+			var names []string
+			for _, m := range page {
+				names = append(names, m.Name)
+			}
+			assert.Equal(tt.expectPage, names)
+			assert.Equal(tt.expectNext, next)
+		})
+	}
+}
+
+func TestTemplateService_Render(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tmpl := model.Template{
+		TemplateName: "welcome",
+		SubjectPart:  "Hi {{name}}",
+		TextPart:     "Your favorite animal is {{favoriteanimal}}.",
+		HtmlPart:     "<h1>Hi {{name}}</h1>",
+	}
+
+	tests := []struct {
+		name             string
+		req              model.EmailRequest
+		getErr           error
+		expectMsg        model.Message
+		expectRenderFail bool
+		expectCode       string
+	}{
+		{
+			name: "All values present",
+			req:  model.EmailRequest{Template: "welcome", TemplateData: `{"name":"Alex","favoriteanimal":"cat"}`},
+			expectMsg: model.Message{
+				Subject: model.Subject{Data: "Hi Alex"},
+				Body: model.Body{
+					Text: model.TextBody{Data: "Your favorite animal is cat."},
+					Html: &model.HtmlBody{Data: "<h1>Hi Alex</h1>"},
+				},
+			},
+		},
+		{
+			name:             "Missing value is a rendering failure",
+			req:              model.EmailRequest{TemplateArn: "arn:aws:ses:us-east-1:123456789012:template/welcome", TemplateData: `{"name":"Alex"}`},
+			expectRenderFail: true,
+		},
+		{
+			name:       "Invalid template data",
+			req:        model.EmailRequest{Template: "welcome", TemplateData: `not json`},
+			expectCode: "InvalidParameterValue",
+		},
+		{
+			name:       "Unknown template",
+			req:        model.EmailRequest{Template: "welcome", TemplateData: `{}`},
+			getErr:     repo.ErrNotFound,
+			expectCode: "TemplateDoesNotExistException",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			mockRepo := mocks.NewMockTemplateRepo(ctrl)
+			mockRepo.EXPECT().GetTemplate(gomock.Any(), "welcome").Return(tmpl, tt.getErr).Times(1)
+
+			msg, err := service.NewTemplateService(mockRepo).Render(context.Background(), tt.req)
+
+			switch {
+			case tt.expectRenderFail:
+				var failure *service.RenderingFailure
+				assert.ErrorAs(err, &failure)
+				assert.Equal("welcome", failure.TemplateName)
+			case tt.expectCode != "":
+				var sesErr *model.SESError
+				assert.ErrorAs(err, &sesErr)
+				assert.Equal(tt.expectCode, sesErr.Code)
+			default:
+				assert.NoError(err)
+				assert.Equal(tt.expectMsg, msg)
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/kamal-github/demtech/internal/repo"
+)
+
+type WarmUpRepo interface {
+	CreateWarmUpState(ctx context.Context, state model.WarmUpState) error
+	PutWarmUpState(ctx context.Context, state model.WarmUpState) error
+	GetWarmUpState(ctx context.Context) (model.WarmUpState, error)
+}
+
+// WarmUpConfig is the schedule an account follows while warming up.
+type WarmUpConfig struct {
+	// Schedule is the send quota of each stage, the account starts at the
+	// first one and stays at the last one.
+	Schedule []model.SendQuota
+	// DayDuration is how long a simulated day lasts.
+	DayDuration time.Duration
+	// MaxBounceRate and MaxComplaintRate of a day above which the account
+	// stays at its stage.
+	MaxBounceRate    float64
+	MaxComplaintRate float64
+}
+
+// WarmUpService gives the send quota of an account warming up. The account
+// moves to the next stage of the schedule for every simulated day whose
+// bounce and complaint rates stayed within the configured maximums, and
+// stays at its stage otherwise. The rates are those of the messages accepted
+// during the day, as counted in the stats.
+type WarmUpService struct {
+	warmUpRepo WarmUpRepo
+	stats      EmailsStatsGetter
+	cfg        WarmUpConfig
+}
+
+func NewWarmUpService(r WarmUpRepo, stats EmailsStatsGetter, cfg WarmUpConfig) WarmUpService {
+	return WarmUpService{warmUpRepo: r, stats: stats, cfg: cfg}
+}
+
+// GetSendQuota returns the send quota of the current stage, evaluating the
+// days elapsed since the last call.
+func (s WarmUpService) GetSendQuota(ctx context.Context) (model.SendQuota, error) {
+	state, err := s.state(ctx)
+	if err != nil {
+		return model.SendQuota{}, err
+	}
+
+	day := int(time.Since(state.StartedAt) / s.cfg.DayDuration)
+	if day > state.Day {
+		stats, err := s.emailStats(ctx)
+		if err != nil {
+			return model.SendQuota{}, err
+		}
+
+		if s.healthy(state, stats) {
+			state.Stage = min(state.Stage+day-state.Day, len(s.cfg.Schedule)-1)
+		}
+		state.Day = day
+		state.Sends, state.Bounces, state.Complaints = stats.SuccessCount, stats.Events["Bounce"], stats.Events["Complaint"]
+
+		if err := s.warmUpRepo.PutWarmUpState(ctx, state); err != nil {
+			return model.SendQuota{}, err
+		}
+	}
+
+	return s.cfg.Schedule[min(state.Stage, len(s.cfg.Schedule)-1)], nil
+}
+
+// state returns the stored warm-up state, starting the warm-up on first use.
+func (s WarmUpService) state(ctx context.Context) (model.WarmUpState, error) {
+	state, err := s.warmUpRepo.GetWarmUpState(ctx)
+	if !errors.Is(err, repo.ErrNotFound) {
+		return state, err
+	}
+
+	stats, err := s.emailStats(ctx)
+	if err != nil {
+		return model.WarmUpState{}, err
+	}
+	state = model.WarmUpState{
+		StartedAt:  time.Now().UTC(),
+		Sends:      stats.SuccessCount,
+		Bounces:    stats.Events["Bounce"],
+		Complaints: stats.Events["Complaint"],
+	}
+
+	// Another replica may have started the warm-up meanwhile.
+	if err := s.warmUpRepo.CreateWarmUpState(ctx, state); errors.Is(err, repo.ErrAlreadyExists) {
+		return s.warmUpRepo.GetWarmUpState(ctx)
+	} else if err != nil {
+		return model.WarmUpState{}, err
+	}
+	return state, nil
+}
+
+func (s WarmUpService) emailStats(ctx context.Context) (model.EmailStats, error) {
+	stats, err := s.stats.GetEmailStats(ctx)
+	if errors.Is(err, repo.ErrNotFound) {
+		return model.EmailStats{}, nil
+	}
+	return stats, err
+}
+
+// healthy tells whether the rates since the last evaluated day are within the
+// maximums, a day without any message being healthy.
+func (s WarmUpService) healthy(state model.WarmUpState, stats model.EmailStats) bool {
+	sends := stats.SuccessCount - state.Sends
+	if sends <= 0 {
+		return true
+	}
+
+	bounceRate := float64(stats.Events["Bounce"]-state.Bounces) / float64(sends)
+	complaintRate := float64(stats.Events["Complaint"]-state.Complaints) / float64(sends)
+	return bounceRate <= s.cfg.MaxBounceRate && complaintRate <= s.cfg.MaxComplaintRate
+}
+
+// FixedSendQuota is the send quota of an account which is not warming up.
+type FixedSendQuota model.SendQuota
+
+func (q FixedSendQuota) GetSendQuota(context.Context) (model.SendQuota, error) {
+	return model.SendQuota(q), nil
+}
+
+// ParseWarmUpSchedule parses the stages of a schedule written as
+// "Max24HourSend:MaxSendRate", e.g. "200:1".
+func ParseWarmUpSchedule(stages []string) ([]model.SendQuota, error) {
+	schedule := make([]model.SendQuota, 0, len(stages))
+	for _, stage := range stages {
+		quota, rate, ok := strings.Cut(strings.TrimSpace(stage), ":")
+		if !ok {
+			return nil, fmt.Errorf("warm-up stage %q is not Max24HourSend:MaxSendRate", stage)
+		}
+
+		max24HourSend, err := strconv.ParseInt(quota, 10, 64)
+		if err != nil || max24HourSend < 0 {
+			return nil, fmt.Errorf("warm-up stage %q has an invalid Max24HourSend", stage)
+		}
+		maxSendRate, err := strconv.ParseFloat(rate, 64)
+		if err != nil || maxSendRate < 0 {
+			return nil, fmt.Errorf("warm-up stage %q has an invalid MaxSendRate", stage)
+		}
+		schedule = append(schedule, model.SendQuota{Max24HourSend: max24HourSend, MaxSendRate: maxSendRate})
+	}
+	return schedule, nil
+}
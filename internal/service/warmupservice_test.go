@@ -0,0 +1,136 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/kamal-github/demtech/internal/repo"
+	"github.com/kamal-github/demtech/internal/service"
+	"github.com/kamal-github/demtech/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+var warmUpConfig = service.WarmUpConfig{
+	Schedule: []model.SendQuota{
+		{Max24HourSend: 200, MaxSendRate: 1},
+		{Max24HourSend: 1000, MaxSendRate: 5},
+		{Max24HourSend: 10000, MaxSendRate: 14},
+	},
+	DayDuration:      time.Hour,
+	MaxBounceRate:    0.05,
+	MaxComplaintRate: 0.001,
+}
+
+func TestWarmUpService_GetSendQuota(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	daysAgo := func(days float64) time.Time {
+		return time.Now().UTC().Add(-time.Duration(days * float64(warmUpConfig.DayDuration)))
+	}
+	stats := func(sends, bounces, complaints int) model.EmailStats {
+		return model.EmailStats{SuccessCount: sends, Events: map[string]int{"Bounce": bounces, "Complaint": complaints}}
+	}
+
+	tests := []struct {
+		name        string
+		state       model.WarmUpState
+		stats       model.EmailStats
+		expectPut   *model.WarmUpState
+		expectQuota model.SendQuota
+	}{
+		{
+			name:        "Same day",
+			state:       model.WarmUpState{StartedAt: daysAgo(0.5)},
+			expectQuota: warmUpConfig.Schedule[0],
+		},
+		{
+			name:        "Healthy day",
+			state:       model.WarmUpState{StartedAt: daysAgo(1.5), Sends: 100},
+			stats:       stats(200, 5, 0),
+			expectPut:   &model.WarmUpState{Stage: 1, Day: 1, Sends: 200, Bounces: 5},
+			expectQuota: warmUpConfig.Schedule[1],
+		},
+		{
+			name:        "Day without messages",
+			state:       model.WarmUpState{StartedAt: daysAgo(1.5)},
+			expectPut:   &model.WarmUpState{Stage: 1, Day: 1},
+			expectQuota: warmUpConfig.Schedule[1],
+		},
+		{
+			name:        "Bounce rate too high",
+			state:       model.WarmUpState{StartedAt: daysAgo(1.5), Sends: 100},
+			stats:       stats(200, 6, 0),
+			expectPut:   &model.WarmUpState{Day: 1, Sends: 200, Bounces: 6},
+			expectQuota: warmUpConfig.Schedule[0],
+		},
+		{
+			name:        "Complaint rate too high",
+			state:       model.WarmUpState{Stage: 1, Day: 1, StartedAt: daysAgo(2.5), Sends: 100},
+			stats:       stats(200, 0, 1),
+			expectPut:   &model.WarmUpState{Stage: 1, Day: 2, Sends: 200, Complaints: 1},
+			expectQuota: warmUpConfig.Schedule[1],
+		},
+		{
+			name:        "Several healthy days stop at the last stage",
+			state:       model.WarmUpState{StartedAt: daysAgo(5.5)},
+			stats:       stats(1000, 10, 0),
+			expectPut:   &model.WarmUpState{Stage: 2, Day: 5, Sends: 1000, Bounces: 10},
+			expectQuota: warmUpConfig.Schedule[2],
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			mockRepo := mocks.NewMockWarmUpRepo(ctrl)
+			mockStats := mocks.NewMockEmailsStatsGetter(ctrl)
+
+			mockRepo.EXPECT().GetWarmUpState(gomock.Any()).Return(tt.state, nil)
+			if tt.expectPut != nil {
+				mockStats.EXPECT().GetEmailStats(gomock.Any()).Return(tt.stats, nil)
+				tt.expectPut.StartedAt = tt.state.StartedAt
+				mockRepo.EXPECT().PutWarmUpState(gomock.Any(), *tt.expectPut).Return(nil)
+			}
+
+			quota, err := service.NewWarmUpService(mockRepo, mockStats, warmUpConfig).GetSendQuota(context.Background())
+			assert.NoError(err)
+			assert.Equal(tt.expectQuota, quota)
+		})
+	}
+}
+
+func TestWarmUpService_GetSendQuota_Start(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockWarmUpRepo(ctrl)
+	mockStats := mocks.NewMockEmailsStatsGetter(ctrl)
+
+	// The warm-up starts from the stats at the time of the first send.
+	mockRepo.EXPECT().GetWarmUpState(gomock.Any()).Return(model.WarmUpState{}, repo.ErrNotFound)
+	mockStats.EXPECT().GetEmailStats(gomock.Any()).Return(model.EmailStats{}, repo.ErrNotFound)
+	mockRepo.EXPECT().CreateWarmUpState(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, state model.WarmUpState) error {
+		assert.WithinDuration(t, time.Now(), state.StartedAt, time.Second)
+		assert.Equal(t, 0, state.Stage)
+		return nil
+	})
+
+	quota, err := service.NewWarmUpService(mockRepo, mockStats, warmUpConfig).GetSendQuota(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, warmUpConfig.Schedule[0], quota)
+}
+
+func TestParseWarmUpSchedule(t *testing.T) {
+	schedule, err := service.ParseWarmUpSchedule([]string{"200:1", " 1000:2.5"})
+	assert.NoError(t, err)
+	assert.Equal(t, []model.SendQuota{{Max24HourSend: 200, MaxSendRate: 1}, {Max24HourSend: 1000, MaxSendRate: 2.5}}, schedule)
+
+	for _, stage := range []string{"200", "x:1", "200:x", "-1:1"} {
+		_, err := service.ParseWarmUpSchedule([]string{stage})
+		assert.Error(t, err, stage)
+	}
+}
@@ -0,0 +1,113 @@
+// Package simulator knows the SES mailbox simulator addresses and the outcome
+// SES documents for each of them.
+package simulator
+
+import (
+	"net/mail"
+	"strings"
+)
+
+// Domain of the mailbox simulator addresses
+const Domain = "simulator.amazonses.com"
+
+// Event types produced by the mailbox simulator, as named by SES
+const (
+	EventDelivery     = "Delivery"
+	EventBounce       = "Bounce"
+	EventComplaint    = "Complaint"
+	EventAutoResponse = "AutoResponse"
+)
+
+// Bounce describes the bounce notification SES sends for a recipient.
+type Bounce struct {
+	Type    string
+	SubType string
+	// Status is the enhanced status code of the bounce, e.g. 5.1.1
+	Status         string
+	DiagnosticCode string
+}
+
+// Outcome is what happens to a message once SES accepted it for a simulator
+// address.
+type Outcome struct {
+	Delivered bool
+	Bounce    *Bounce
+	// ComplaintFeedbackType is set when the recipient marks the message as spam.
+	ComplaintFeedbackType string
+	// AutoResponse is set when the recipient answers with an out of the office message.
+	AutoResponse bool
+}
+
+// Events lists the event types of the outcome, in the order SES emits them.
+func (o Outcome) Events() []string {
+	var events []string
+	if o.Delivered {
+		events = append(events, EventDelivery)
+	}
+	if o.Bounce != nil {
+		events = append(events, EventBounce)
+	}
+	if o.ComplaintFeedbackType != "" {
+		events = append(events, EventComplaint)
+	}
+	if o.AutoResponse {
+		events = append(events, EventAutoResponse)
+	}
+	return events
+}
+
+var outcomes = map[string]Outcome{
+	"success": {Delivered: true},
+	"bounce": {Bounce: &Bounce{
+		Type:           "Permanent",
+		SubType:        "General",
+		Status:         "5.1.1",
+		DiagnosticCode: "smtp; 550 5.1.1 user unknown",
+	}},
+	"ooto":      {Delivered: true, AutoResponse: true},
+	"complaint": {Delivered: true, ComplaintFeedbackType: "abuse"},
+	"suppressionlist": {Bounce: &Bounce{
+		Type:           "Permanent",
+		SubType:        "Suppressed",
+		Status:         "5.1.1",
+		DiagnosticCode: "Amazon SES has suppressed sending to this address because it has a recent history of bouncing as an invalid address.",
+	}},
+}
+
+// Lookup returns the outcome of address when it is a mailbox simulator
+// address. Labels are ignored, e.g. bounce+label1@simulator.amazonses.com
+// bounces just like bounce@simulator.amazonses.com.
+func Lookup(address string) (Outcome, bool) {
+	if a, err := mail.ParseAddress(address); err == nil {
+		address = a.Address
+	}
+
+	local, domain, ok := strings.Cut(strings.ToLower(address), "@")
+	if !ok || domain != Domain {
+		return Outcome{}, false
+	}
+	local, _, _ = strings.Cut(local, "+")
+
+	outcome, ok := outcomes[local]
+	return outcome, ok
+}
+
+// IsSimulatorAddress reports whether address belongs to the mailbox simulator.
+func IsSimulatorAddress(address string) bool {
+	_, ok := Lookup(address)
+	return ok
+}
+
+// AllSimulated reports whether every address of addresses belongs to the
+// mailbox simulator.
+func AllSimulated(addresses []string) bool {
+	if len(addresses) == 0 {
+		return false
+	}
+	for _, a := range addresses {
+		if !IsSimulatorAddress(a) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,58 @@
+package simulator_test
+
+import (
+	"testing"
+
+	"github.com/kamal-github/demtech/internal/simulator"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		name         string
+		address      string
+		expectOK     bool
+		expectEvents []string
+	}{
+		{name: "Success", address: "success@simulator.amazonses.com", expectOK: true, expectEvents: []string{"Delivery"}},
+		{name: "Bounce", address: "bounce@simulator.amazonses.com", expectOK: true, expectEvents: []string{"Bounce"}},
+		{name: "Out of the office", address: "ooto@simulator.amazonses.com", expectOK: true, expectEvents: []string{"Delivery", "AutoResponse"}},
+		{name: "Complaint", address: "complaint@simulator.amazonses.com", expectOK: true, expectEvents: []string{"Delivery", "Complaint"}},
+		{name: "Suppression list", address: "suppressionlist@simulator.amazonses.com", expectOK: true, expectEvents: []string{"Bounce"}},
+		{name: "Label and case are ignored", address: "Bounce+QA-42@Simulator.AmazonSES.com", expectOK: true, expectEvents: []string{"Bounce"}},
+		{name: "Display name", address: "QA <success@simulator.amazonses.com>", expectOK: true, expectEvents: []string{"Delivery"}},
+		{name: "Unknown simulator mailbox", address: "unknown@simulator.amazonses.com"},
+		{name: "Regular address", address: "success@example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			outcome, ok := simulator.Lookup(tt.address)
+
+			assert.Equal(tt.expectOK, ok)
+			assert.Equal(tt.expectEvents, outcome.Events())
+		})
+	}
+}
+
+func TestLookup_BounceTypes(t *testing.T) {
+	assert := assert.New(t)
+
+	outcome, _ := simulator.Lookup("bounce@simulator.amazonses.com")
+	assert.Equal("Permanent", outcome.Bounce.Type)
+	assert.Equal("General", outcome.Bounce.SubType)
+
+	outcome, _ = simulator.Lookup("suppressionlist@simulator.amazonses.com")
+	assert.Equal("Permanent", outcome.Bounce.Type)
+	assert.Equal("Suppressed", outcome.Bounce.SubType)
+}
+
+func TestAllSimulated(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(simulator.AllSimulated([]string{"success@simulator.amazonses.com", "ooto@simulator.amazonses.com"}))
+	assert.False(simulator.AllSimulated([]string{"success@simulator.amazonses.com", "test@example.com"}))
+	assert.False(simulator.AllSimulated(nil))
+}
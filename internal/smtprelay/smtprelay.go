@@ -0,0 +1,193 @@
+// Package smtprelay delivers the accepted messages to an SMTP server, such as
+// the mail catcher of a development stack.
+package smtprelay
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"regexp"
+	"time"
+
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/kamal-github/demtech/internal/rawmail"
+	"github.com/kamal-github/demtech/internal/simulator"
+)
+
+// enhancedStatus matches the enhanced status code starting an SMTP reply,
+// e.g. 5.1.1
+var enhancedStatus = regexp.MustCompile(`^[245]\.\d{1,3}\.\d{1,3}\b`)
+
+type Config struct {
+	// Addr is the host:port of the SMTP server.
+	Addr string
+	// StartTLS requires the server to upgrade the connection before anything
+	// is sent.
+	StartTLS bool
+	// InsecureSkipVerify accepts any certificate, catchers commonly use
+	// self-signed ones.
+	InsecureSkipVerify bool
+	// Username and Password, when set, authenticate with AUTH PLAIN.
+	Username string
+	Password string
+	// Timeout bounds the whole delivery of a message.
+	Timeout time.Duration
+}
+
+// Relay delivers a message over a new SMTP connection each time.
+type Relay struct {
+	cfg Config
+}
+
+func NewRelay(cfg Config) Relay {
+	return Relay{cfg: cfg}
+}
+
+// DeliverMessage relays m to recipients and returns the bounce of the ones the
+// server refused, transient for a 4xx reply and permanent for a 5xx one. When
+// the whole message is refused it returns a MessageRejected error for a 5xx
+// reply and ServiceUnavailable otherwise.
+func (r Relay) DeliverMessage(ctx context.Context, m model.CapturedMessage, recipients []string) (map[string]simulator.Bounce, error) {
+	if r.cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.cfg.Timeout)
+		defer cancel()
+	}
+
+	host, _, err := net.SplitHostPort(r.cfg.Addr)
+	if err != nil {
+		return nil, unavailable(err)
+	}
+	conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", r.cfg.Addr)
+	if err != nil {
+		return nil, unavailable(err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	c, err := smtp.NewClient(conn, host)
+	if err != nil {
+		conn.Close()
+		return nil, failure(err)
+	}
+	defer c.Close()
+
+	bounces, err := r.deliver(c, host, m, recipients)
+	if err != nil {
+		return nil, failure(err)
+	}
+	return bounces, nil
+}
+
+func (r Relay) deliver(c *smtp.Client, host string, m model.CapturedMessage, recipients []string) (map[string]simulator.Bounce, error) {
+	if r.cfg.StartTLS {
+		if ok, _ := c.Extension("STARTTLS"); !ok {
+			return nil, errors.New("the server does not support STARTTLS")
+		}
+		if err := c.StartTLS(&tls.Config{ServerName: host, InsecureSkipVerify: r.cfg.InsecureSkipVerify}); err != nil {
+			return nil, err
+		}
+	}
+	if r.cfg.Username != "" {
+		if err := c.Auth(plainAuth{username: r.cfg.Username, password: r.cfg.Password}); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := c.Mail(envelopeSender(m)); err != nil {
+		return nil, err
+	}
+
+	bounces := make(map[string]simulator.Bounce)
+	for _, rcpt := range recipients {
+		err := c.Rcpt(rcpt)
+		var reply *textproto.Error
+		if errors.As(err, &reply) {
+			bounces[rcpt] = bounce(reply)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(bounces) == len(recipients) {
+		c.Quit()
+		return bounces, nil
+	}
+
+	w, err := c.Data()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(rawmail.Compose(m)); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	// The message is delivered, failing to say goodbye does not matter.
+	c.Quit()
+	return bounces, nil
+}
+
+func envelopeSender(m model.CapturedMessage) string {
+	for _, s := range []string{m.ReturnPath, m.Source} {
+		if a, err := mail.ParseAddress(s); err == nil {
+			return a.Address
+		}
+	}
+	return ""
+}
+
+// bounce describes a recipient refused with reply the way SES reports it.
+func bounce(reply *textproto.Error) simulator.Bounce {
+	b := simulator.Bounce{
+		Type:           "Permanent",
+		SubType:        "General",
+		Status:         enhancedStatus.FindString(reply.Msg),
+		DiagnosticCode: fmt.Sprintf("smtp; %d %s", reply.Code, reply.Msg),
+	}
+	if reply.Code < 500 {
+		b.Type = "Transient"
+	}
+	if b.Status == "" {
+		b.Status = fmt.Sprintf("%d.0.0", reply.Code/100)
+	}
+	return b
+}
+
+func failure(err error) *model.SESError {
+	var reply *textproto.Error
+	if errors.As(err, &reply) && reply.Code >= 500 {
+		return &model.SESError{Code: "MessageRejected", Message: fmt.Sprintf("The SMTP relay rejected the message: %d %s", reply.Code, reply.Msg)}
+	}
+	return unavailable(err)
+}
+
+func unavailable(err error) *model.SESError {
+	return &model.SESError{Code: "ServiceUnavailable", Message: "The SMTP relay is unavailable: " + err.Error()}
+}
+
+// plainAuth is AUTH PLAIN over any connection. smtp.PlainAuth refuses
+// unencrypted ones to other hosts than localhost, while the catcher of a
+// development stack commonly runs in another container.
+type plainAuth struct {
+	username, password string
+}
+
+func (a plainAuth) Start(*smtp.ServerInfo) (string, []byte, error) {
+	return "PLAIN", []byte("\x00" + a.username + "\x00" + a.password), nil
+}
+
+func (a plainAuth) Next(_ []byte, more bool) ([]byte, error) {
+	if more {
+		return nil, errors.New("unexpected server challenge")
+	}
+	return nil, nil
+}
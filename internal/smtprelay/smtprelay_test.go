@@ -0,0 +1,195 @@
+package smtprelay_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"net"
+	"net/textproto"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/kamal-github/demtech/internal/simulator"
+	"github.com/kamal-github/demtech/internal/smtprelay"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeServer speaks just enough SMTP to be relayed to. It refuses the
+// recipients containing "unknown" permanently and "full" temporarily.
+type fakeServer struct {
+	addr       string
+	rejectMail bool
+
+	mu       sync.Mutex
+	auth     string
+	mailFrom string
+	rcpts    []string
+	data     string
+}
+
+func startFakeServer(t *testing.T, rejectMail bool) *fakeServer {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { l.Close() })
+
+	s := &fakeServer{addr: l.Addr().String(), rejectMail: rejectMail}
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *fakeServer) serve(conn net.Conn) {
+	defer conn.Close()
+	r := textproto.NewReader(bufio.NewReader(conn))
+	reply := func(lines ...string) { conn.Write([]byte(strings.Join(lines, "\r\n") + "\r\n")) }
+
+	reply("220 fake ESMTP")
+	for {
+		line, err := r.ReadLine()
+		if err != nil {
+			return
+		}
+		verb, arg, _ := strings.Cut(line, " ")
+
+		s.mu.Lock()
+		switch strings.ToUpper(verb) {
+		case "EHLO":
+			reply("250-fake", "250-AUTH PLAIN", "250 8BITMIME")
+		case "AUTH":
+			decoded, _ := base64.StdEncoding.DecodeString(strings.TrimPrefix(arg, "PLAIN "))
+			s.auth = string(decoded)
+			reply("235 2.7.0 Authenticated")
+		case "MAIL":
+			if s.rejectMail {
+				reply("550 5.7.1 Sender refused")
+				break
+			}
+			s.mailFrom = between(arg, "<", ">")
+			reply("250 2.1.0 OK")
+		case "RCPT":
+			switch rcpt := between(arg, "<", ">"); {
+			case strings.Contains(rcpt, "unknown"):
+				reply("550 5.1.1 User unknown")
+			case strings.Contains(rcpt, "full"):
+				reply("452 4.2.2 Mailbox full")
+			default:
+				s.rcpts = append(s.rcpts, rcpt)
+				reply("250 2.1.5 OK")
+			}
+		case "DATA":
+			reply("354 Go ahead")
+			data, _ := r.ReadDotBytes()
+			s.data = string(data)
+			reply("250 2.0.0 Queued")
+		case "QUIT":
+			reply("221 2.0.0 Bye")
+			s.mu.Unlock()
+			return
+		default:
+			reply("502 5.5.2 Command not recognized")
+		}
+		s.mu.Unlock()
+	}
+}
+
+func between(s, start, end string) string {
+	_, s, _ = strings.Cut(s, start)
+	s, _, _ = strings.Cut(s, end)
+	return s
+}
+
+func TestRelay_DeliverMessage(t *testing.T) {
+	ctx := context.Background()
+	m := model.CapturedMessage{
+		MessageID:   "msg-1",
+		Source:      "Team <team@example.com>",
+		ReturnPath:  "bounces@example.com",
+		Destination: model.Destination{ToAddresses: []string{"jane@example.com"}},
+		Subject:     "Welcome",
+		TextBody:    "Hello",
+		SentAt:      time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+
+	t.Run("Delivered", func(t *testing.T) {
+		s := startFakeServer(t, false)
+		relay := smtprelay.NewRelay(smtprelay.Config{Addr: s.addr, Username: "user", Password: "secret", Timeout: time.Second})
+
+		bounces, err := relay.DeliverMessage(ctx, m, []string{"jane@example.com"})
+		require.NoError(t, err)
+		assert.Empty(t, bounces)
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		assert.Equal(t, "\x00user\x00secret", s.auth)
+		assert.Equal(t, "bounces@example.com", s.mailFrom)
+		assert.Equal(t, []string{"jane@example.com"}, s.rcpts)
+		assert.Contains(t, s.data, "Subject: Welcome\n")
+	})
+
+	t.Run("Refused recipients bounce", func(t *testing.T) {
+		s := startFakeServer(t, false)
+		relay := smtprelay.NewRelay(smtprelay.Config{Addr: s.addr})
+
+		bounces, err := relay.DeliverMessage(ctx, m, []string{"jane@example.com", "unknown@example.com", "full@example.com"})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]simulator.Bounce{
+			"unknown@example.com": {Type: "Permanent", SubType: "General", Status: "5.1.1", DiagnosticCode: "smtp; 550 5.1.1 User unknown"},
+			"full@example.com":    {Type: "Transient", SubType: "General", Status: "4.2.2", DiagnosticCode: "smtp; 452 4.2.2 Mailbox full"},
+		}, bounces)
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		assert.Equal(t, []string{"jane@example.com"}, s.rcpts)
+		assert.NotEmpty(t, s.data)
+	})
+
+	t.Run("All recipients refused", func(t *testing.T) {
+		s := startFakeServer(t, false)
+		relay := smtprelay.NewRelay(smtprelay.Config{Addr: s.addr})
+
+		bounces, err := relay.DeliverMessage(ctx, m, []string{"unknown@example.com"})
+		require.NoError(t, err)
+		assert.Len(t, bounces, 1)
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		assert.Empty(t, s.data)
+	})
+
+	t.Run("Sender refused", func(t *testing.T) {
+		s := startFakeServer(t, true)
+		relay := smtprelay.NewRelay(smtprelay.Config{Addr: s.addr})
+
+		_, err := relay.DeliverMessage(ctx, m, []string{"jane@example.com"})
+		assert.Equal(t, &model.SESError{Code: "MessageRejected", Message: "The SMTP relay rejected the message: 550 5.7.1 Sender refused"}, err)
+	})
+
+	t.Run("STARTTLS not offered", func(t *testing.T) {
+		s := startFakeServer(t, false)
+		relay := smtprelay.NewRelay(smtprelay.Config{Addr: s.addr, StartTLS: true})
+
+		_, err := relay.DeliverMessage(ctx, m, []string{"jane@example.com"})
+		assert.Equal(t, "ServiceUnavailable", err.(*model.SESError).Code)
+	})
+
+	t.Run("Server down", func(t *testing.T) {
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		addr := l.Addr().String()
+		l.Close()
+
+		_, err = smtprelay.NewRelay(smtprelay.Config{Addr: addr}).DeliverMessage(ctx, m, []string{"jane@example.com"})
+		assert.Equal(t, "ServiceUnavailable", err.(*model.SESError).Code)
+	})
+}
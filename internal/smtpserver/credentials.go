@@ -0,0 +1,81 @@
+package smtpserver
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"strings"
+	"time"
+)
+
+// smtpPasswordVersion prefixes the signature of SES SMTP passwords.
+const smtpPasswordVersion = 0x04
+
+// SMTPPassword derives the SES SMTP password of a secret access key in region,
+// the way the SES console and documentation do. The SMTP username is the
+// access key ID itself.
+func SMTPPassword(secretAccessKey, region string) string {
+	signature := []byte("AWS4" + secretAccessKey)
+	for _, data := range []string{"11111111", region, "ses", "aws4_request", "SendRawEmail"} {
+		mac := hmac.New(sha256.New, signature)
+		mac.Write([]byte(data))
+		signature = mac.Sum(nil)
+	}
+	return base64.StdEncoding.EncodeToString(append([]byte{smtpPasswordVersion}, signature...))
+}
+
+// ParseAccessKeys reads access keys written as AccessKeyId:SecretAccessKey and
+// returns the SMTP password of each by SMTP username.
+func ParseAccessKeys(keys []string, region string) (map[string]string, error) {
+	credentials := make(map[string]string, len(keys))
+	for _, key := range keys {
+		id, secret, ok := strings.Cut(key, ":")
+		if !ok || id == "" || secret == "" {
+			return nil, fmt.Errorf("invalid access key %q, expected AccessKeyId:SecretAccessKey", id)
+		}
+		credentials[id] = SMTPPassword(secret, region)
+	}
+	return credentials, nil
+}
+
+// newCertificate generates a self-signed certificate for hostname and the
+// loopback addresses.
+func newCertificate(hostname string) (tls.Certificate, []byte, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return tls.Certificate{}, nil, err
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 64))
+	if err != nil {
+		return tls.Certificate{}, nil, err
+	}
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: hostname, Organization: []string{"Demtech SES Mock"}},
+		DNSNames:     []string{hostname, "localhost"},
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.AddDate(10, 0, 0),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return tls.Certificate{}, nil, err
+	}
+
+	cert := tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+	return cert, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), nil
+}
@@ -0,0 +1,389 @@
+package smtpserver
+
+import (
+	"crypto/subtle"
+	"crypto/tls"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/kamal-github/demtech/internal/rawmail"
+)
+
+const (
+	// configurationSetHeader and messageTagsHeader are how SMTP clients set the
+	// ConfigurationSetName and Tags of SendRawEmail.
+	configurationSetHeader = "X-SES-CONFIGURATION-SET"
+	messageTagsHeader      = "X-SES-MESSAGE-TAGS"
+)
+
+// session is the SMTP conversation of one connection.
+type session struct {
+	server *Server
+	conn   net.Conn
+	text   *textproto.Conn
+	tls    bool
+
+	// sending is guarded by the mutex of the server.
+	sending bool
+
+	helo          bool
+	authenticated bool
+	mailFrom      *string
+	recipients    []string
+}
+
+func newSession(s *Server, conn net.Conn, implicitTLS bool) *session {
+	return &session{server: s, conn: conn, text: textproto.NewConn(conn), tls: implicitTLS}
+}
+
+func (s *session) serve() {
+	s.reply(220, s.server.cfg.Hostname+" ESMTP SimpleEmailService")
+	for {
+		s.conn.SetDeadline(time.Now().Add(idleTimeout))
+		line, err := s.text.ReadLine()
+		if err != nil {
+			return
+		}
+
+		verb, arg, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(verb) {
+		case "EHLO":
+			s.ehlo(arg)
+		case "HELO":
+			s.helo = true
+			s.reset()
+			s.reply(250, s.server.cfg.Hostname)
+		case "STARTTLS":
+			if !s.startTLS() {
+				return
+			}
+		case "AUTH":
+			s.auth(arg)
+		case "MAIL":
+			s.mail(arg)
+		case "RCPT":
+			s.rcpt(arg)
+		case "DATA":
+			if !s.data() {
+				return
+			}
+		case "RSET":
+			s.reset()
+			s.reply(250, "Ok")
+		case "NOOP":
+			s.reply(250, "Ok")
+		case "QUIT":
+			s.reply(221, "Bye")
+			return
+		default:
+			s.reply(502, "Command not implemented")
+		}
+	}
+}
+
+// reply writes a reply of one or more lines.
+func (s *session) reply(code int, lines ...string) {
+	for i, line := range lines {
+		sep := " "
+		if i < len(lines)-1 {
+			sep = "-"
+		}
+		s.text.PrintfLine("%d%s%s", code, sep, line)
+	}
+}
+
+func (s *session) reset() {
+	s.mailFrom = nil
+	s.recipients = nil
+}
+
+func (s *session) ehlo(arg string) {
+	if arg == "" {
+		s.reply(501, "Syntax: EHLO hostname")
+		return
+	}
+	s.helo = true
+	s.reset()
+
+	lines := []string{s.server.cfg.Hostname, "8BITMIME", "SIZE " + strconv.FormatInt(s.server.cfg.MaxMessageBytes, 10)}
+	if s.tls {
+		lines = append(lines, "AUTH PLAIN LOGIN")
+	} else {
+		lines = append(lines, "STARTTLS")
+	}
+	s.reply(250, append(lines, "Ok")...)
+}
+
+// startTLS upgrades the connection, the client then starts over with EHLO. It
+// returns false when the connection is no longer usable.
+func (s *session) startTLS() bool {
+	if s.tls {
+		s.reply(503, "TLS already active")
+		return true
+	}
+	s.reply(220, "Ready to start TLS")
+
+	conn := tls.Server(s.conn, s.server.tlsConfig)
+	if err := conn.Handshake(); err != nil {
+		return false
+	}
+	s.conn, s.text, s.tls = conn, textproto.NewConn(conn), true
+	s.helo = false
+	s.reset()
+	return true
+}
+
+func (s *session) auth(arg string) {
+	switch {
+	case !s.tls:
+		s.reply(530, "Must issue a STARTTLS command first")
+		return
+	case !s.helo:
+		s.reply(503, "Send EHLO first")
+		return
+	case s.authenticated:
+		s.reply(503, "Already authenticated")
+		return
+	}
+
+	mechanism, initial, _ := strings.Cut(arg, " ")
+	var username, password string
+	var err error
+	switch strings.ToUpper(mechanism) {
+	case "PLAIN":
+		var creds []byte
+		if creds, err = s.authResponse(initial, ""); err == nil {
+			parts := strings.Split(string(creds), "\x00")
+			if len(parts) != 3 {
+				s.reply(501, "Invalid PLAIN credentials")
+				return
+			}
+			username, password = parts[1], parts[2]
+		}
+	case "LOGIN":
+		var user, pass []byte
+		if user, err = s.authResponse(initial, "Username:"); err == nil {
+			if pass, err = s.authResponse("", "Password:"); err == nil {
+				username, password = string(user), string(pass)
+			}
+		}
+	default:
+		s.reply(504, "Unrecognized authentication type")
+		return
+	}
+	if err != nil {
+		s.reply(501, err.Error())
+		return
+	}
+
+	expected, ok := s.server.cfg.Credentials[username]
+	if !ok || subtle.ConstantTimeCompare([]byte(expected), []byte(password)) != 1 {
+		s.reply(535, "Authentication Credentials Invalid")
+		return
+	}
+	s.authenticated = true
+	s.reply(235, "Authentication successful.")
+}
+
+// authResponse decodes the initial response of an AUTH command or, when there
+// is none, prompts the client for it.
+func (s *session) authResponse(initial, prompt string) ([]byte, error) {
+	if initial == "" {
+		s.reply(334, base64.StdEncoding.EncodeToString([]byte(prompt)))
+		line, err := s.text.ReadLine()
+		if err != nil {
+			return nil, err
+		}
+		if line == "*" {
+			return nil, errors.New("Authentication cancelled")
+		}
+		initial = line
+	}
+	if initial == "=" {
+		return nil, nil
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(initial)
+	if err != nil {
+		return nil, errors.New("Invalid base64 data")
+	}
+	return decoded, nil
+}
+
+func (s *session) mail(arg string) {
+	switch {
+	case !s.authenticated:
+		s.reply(530, "Authentication required")
+		return
+	case s.mailFrom != nil:
+		s.reply(503, "Sender already specified")
+		return
+	}
+
+	from, params, ok := parsePath(arg, "FROM:")
+	if !ok {
+		s.reply(501, "Syntax: MAIL FROM:<address>")
+		return
+	}
+	for _, param := range params {
+		key, value, _ := strings.Cut(param, "=")
+		if strings.EqualFold(key, "SIZE") {
+			if size, err := strconv.ParseInt(value, 10, 64); err == nil && size > s.server.cfg.MaxMessageBytes {
+				s.reply(552, "Message size exceeds fixed maximum message size")
+				return
+			}
+		}
+	}
+
+	s.mailFrom = &from
+	s.reply(250, "Ok")
+}
+
+func (s *session) rcpt(arg string) {
+	if s.mailFrom == nil {
+		s.reply(503, "Send MAIL FROM first")
+		return
+	}
+
+	to, _, ok := parsePath(arg, "TO:")
+	if !ok || to == "" {
+		s.reply(501, "Syntax: RCPT TO:<address>")
+		return
+	}
+
+	s.recipients = append(s.recipients, to)
+	s.reply(250, "Ok")
+}
+
+// data receives the message and sends it. It returns false when the
+// connection is no longer usable.
+func (s *session) data() bool {
+	if len(s.recipients) == 0 {
+		s.reply(503, "Send RCPT TO first")
+		return true
+	}
+	s.reply(354, "End data with <CR><LF>.<CR><LF>")
+
+	// One byte over the limit tells a message that is too long.
+	body := s.text.DotReader()
+	data, err := io.ReadAll(io.LimitReader(body, s.server.cfg.MaxMessageBytes+1))
+	if err != nil {
+		return false
+	}
+	defer s.reset()
+	if int64(len(data)) > s.server.cfg.MaxMessageBytes {
+		if _, err := io.Copy(io.Discard, body); err != nil {
+			return false
+		}
+		s.reply(552, "Message size exceeds fixed maximum message size")
+		return true
+	}
+
+	req, sesErr := s.emailRequest(data)
+	if sesErr != nil {
+		s.server.countError(sesErr.Code)
+		s.reply(replyFor(sesErr))
+		return true
+	}
+
+	if !s.server.startSending(s) {
+		s.reply(421, "Service not available, closing transmission channel")
+		return false
+	}
+	resp, err := s.server.send(req)
+	closed := s.server.stopSending(s)
+	if err != nil {
+		s.reply(replyFor(err))
+	} else {
+		s.reply(250, "Ok "+resp.MessageID)
+	}
+	return !closed
+}
+
+// emailRequest parses the message the way SendRawEmail does, the envelope
+// being its Source and Destinations.
+func (s *session) emailRequest(data []byte) (model.EmailRequest, *model.SESError) {
+	req, err := rawmail.NewEmailRequest(data, *s.mailFrom, s.recipients)
+	if err != nil {
+		var sesErr *model.SESError
+		if errors.As(err, &sesErr) {
+			return model.EmailRequest{}, sesErr
+		}
+		return model.EmailRequest{}, &model.SESError{Code: "InvalidParameterValue", Message: err.Error()}
+	}
+
+	for _, h := range req.Message.Headers {
+		switch {
+		case strings.EqualFold(h.Name, configurationSetHeader):
+			req.ConfigurationSetName = strings.TrimSpace(h.Value)
+		case strings.EqualFold(h.Name, messageTagsHeader):
+			tags, err := parseTags(h.Value)
+			if err != nil {
+				return model.EmailRequest{}, err
+			}
+			req.Tags = append(req.Tags, tags...)
+		}
+	}
+
+	return req, nil
+}
+
+// parseTags reads the tags of the X-SES-MESSAGE-TAGS header, written as
+// name1=value1, name2=value2.
+func parseTags(header string) ([]model.Tag, *model.SESError) {
+	var tags []model.Tag
+	for _, tag := range strings.Split(header, ",") {
+		name, value, ok := strings.Cut(tag, "=")
+		name, value = strings.TrimSpace(name), strings.TrimSpace(value)
+		if !ok || name == "" {
+			return nil, &model.SESError{Code: "InvalidParameterValue", Message: fmt.Sprintf("Invalid %s header: %q is not a name=value pair.", messageTagsHeader, strings.TrimSpace(tag))}
+		}
+		tags = append(tags, model.Tag{Name: name, Value: value})
+	}
+	return tags, nil
+}
+
+// parsePath reads the <address> of MAIL FROM:<address> and RCPT TO:<address>
+// along with the parameters following it.
+func parsePath(arg, prefix string) (string, []string, bool) {
+	if len(arg) < len(prefix) || !strings.EqualFold(arg[:len(prefix)], prefix) {
+		return "", nil, false
+	}
+	fields := strings.Fields(strings.TrimSpace(arg[len(prefix):]))
+	if len(fields) == 0 {
+		return "", nil, false
+	}
+
+	path := fields[0]
+	if !strings.HasPrefix(path, "<") || !strings.HasSuffix(path, ">") {
+		return "", nil, false
+	}
+	return path[1 : len(path)-1], fields[1:], true
+}
+
+// replyFor translates an error into the SMTP reply SES gives for it.
+func replyFor(err error) (int, string) {
+	var sesErr *model.SESError
+	if !errors.As(err, &sesErr) {
+		return 451, "Temporary service failure"
+	}
+
+	switch sesErr.Code {
+	case "Throttling", "ThrottlingException", "TooManyRequestsException", "LimitExceededException":
+		return 454, "Throttling failure: " + sesErr.Message
+	case "InternalFailure", "ServiceUnavailable":
+		return 451, "Temporary service failure"
+	case "InvalidParameterValue", "MissingParameter", "MessageTooLong":
+		return 554, "Transaction failed: " + sesErr.Message
+	default:
+		return 554, "Message rejected: " + sesErr.Message
+	}
+}
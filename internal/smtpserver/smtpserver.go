@@ -0,0 +1,280 @@
+// Package smtpserver is the SES SMTP interface of the mock: messages sent over
+// SMTP, by applications that do not use the API, go through the same checks as
+// those sent with SendRawEmail.
+package smtpserver
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"log"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/kamal-github/demtech/internal/model"
+)
+
+const (
+	// defaultMaxMessageBytes is the largest message SES accepts.
+	defaultMaxMessageBytes = 40 << 20
+	// defaultSendTimeout leaves room for the 10s an SMTP relay takes by default.
+	defaultSendTimeout = 30 * time.Second
+	// idleTimeout closes the connections of clients that stopped talking.
+	idleTimeout = 5 * time.Minute
+)
+
+// ErrServerClosed is returned by the Serve methods once the server is closed.
+var ErrServerClosed = errors.New("smtpserver: server closed")
+
+type EmailSender interface {
+	SendEmail(ctx context.Context, req model.EmailRequest) (*model.SESResponse, error)
+}
+
+type ErrorCounter interface {
+	IncrementError(ctx context.Context, errorType string) error
+}
+
+type Config struct {
+	// Hostname the server greets with, and the name of its certificate.
+	Hostname string
+	// Addr listens for connections upgraded with STARTTLS, e.g. :587.
+	Addr string
+	// TLSAddr listens for implicit TLS connections, e.g. :465.
+	TLSAddr string
+	// Credentials are the SMTP passwords by SMTP username, see ParseAccessKeys.
+	Credentials map[string]string
+	// MaxMessageBytes defaults to the 40MB SES accepts.
+	MaxMessageBytes int64
+	// SendTimeout bounds the sending of a message, 30s by default. It has to
+	// exceed the time a relay takes to deliver it, clients retry the messages
+	// that time out.
+	SendTimeout time.Duration
+}
+
+// Server accepts messages over SMTP and sends them with an EmailSender. Clients
+// authenticate with SES SMTP credentials, once the connection is encrypted.
+type Server struct {
+	cfg          Config
+	tlsConfig    *tls.Config
+	certPEM      []byte
+	sender       EmailSender
+	errorCounter ErrorCounter
+
+	mu        sync.Mutex
+	closed    bool
+	listeners map[net.Listener]struct{}
+	sessions  map[net.Conn]*session
+	wg        sync.WaitGroup
+}
+
+// NewServer generates the self-signed certificate of the server. sender is
+// expected to count the outcome of the messages, errorCounter counts the
+// messages that could not be parsed.
+func NewServer(cfg Config, sender EmailSender, errorCounter ErrorCounter) (*Server, error) {
+	if len(cfg.Credentials) == 0 {
+		return nil, errors.New("smtpserver: at least one access key is required to authenticate")
+	}
+	if cfg.MaxMessageBytes <= 0 {
+		cfg.MaxMessageBytes = defaultMaxMessageBytes
+	}
+	if cfg.SendTimeout <= 0 {
+		cfg.SendTimeout = defaultSendTimeout
+	}
+
+	cert, certPEM, err := newCertificate(cfg.Hostname)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Server{
+		cfg:          cfg,
+		tlsConfig:    &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12},
+		certPEM:      certPEM,
+		sender:       sender,
+		errorCounter: errorCounter,
+		listeners:    make(map[net.Listener]struct{}),
+		sessions:     make(map[net.Conn]*session),
+	}, nil
+}
+
+// CertificatePEM is the certificate clients have to trust to verify the server.
+func (s *Server) CertificatePEM() []byte {
+	return s.certPEM
+}
+
+// ListenAndServe serves Addr, where clients upgrade with STARTTLS.
+func (s *Server) ListenAndServe() error {
+	l, err := net.Listen("tcp", s.cfg.Addr)
+	if err != nil {
+		return err
+	}
+	return s.Serve(l)
+}
+
+// ListenAndServeTLS serves TLSAddr, where clients connect with TLS right away.
+func (s *Server) ListenAndServeTLS() error {
+	l, err := net.Listen("tcp", s.cfg.TLSAddr)
+	if err != nil {
+		return err
+	}
+	return s.ServeTLS(l)
+}
+
+// Serve accepts plain connections on l, until the server is closed.
+func (s *Server) Serve(l net.Listener) error {
+	return s.serve(l, false)
+}
+
+// ServeTLS accepts TLS connections on l, until the server is closed.
+func (s *Server) ServeTLS(l net.Listener) error {
+	return s.serve(tls.NewListener(l, s.tlsConfig), true)
+}
+
+// Close stops the listeners and closes the open connections. The messages
+// being sent are answered first, Close waits for them up to SendTimeout.
+func (s *Server) Close() error {
+	s.mu.Lock()
+	s.closed = true
+	var err error
+	for l := range s.listeners {
+		err = errors.Join(err, l.Close())
+	}
+	for c, sess := range s.sessions {
+		if !sess.sending {
+			c.Close()
+		}
+	}
+	s.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(s.cfg.SendTimeout):
+		err = errors.Join(err, errors.New("smtpserver: timed out waiting for the messages being sent"))
+	}
+	return err
+}
+
+func (s *Server) serve(l net.Listener, implicitTLS bool) error {
+	if !s.trackListener(l) {
+		l.Close()
+		return ErrServerClosed
+	}
+	defer s.untrackListener(l)
+
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			if s.isClosed() {
+				return ErrServerClosed
+			}
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				continue
+			}
+			return err
+		}
+
+		sess := newSession(s, conn, implicitTLS)
+		if !s.trackSession(conn, sess) {
+			conn.Close()
+			return ErrServerClosed
+		}
+		go func() {
+			defer s.untrackSession(conn)
+			sess.serve()
+		}()
+	}
+}
+
+func (s *Server) trackListener(l net.Listener) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return false
+	}
+	s.listeners[l] = struct{}{}
+	return true
+}
+
+func (s *Server) untrackListener(l net.Listener) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.listeners, l)
+}
+
+func (s *Server) trackSession(c net.Conn, sess *session) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return false
+	}
+	s.sessions[c] = sess
+	s.wg.Add(1)
+	return true
+}
+
+func (s *Server) untrackSession(c net.Conn) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.sessions, c)
+	c.Close()
+	s.wg.Done()
+}
+
+// startSending marks sess as sending a message, which keeps its connection
+// open when the server is closed. It returns false once the server is closed.
+func (s *Server) startSending(sess *session) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return false
+	}
+	sess.sending = true
+	return true
+}
+
+// stopSending returns whether the server was closed in the meantime, the
+// session then has to end.
+func (s *Server) stopSending(sess *session) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sess.sending = false
+	return s.closed
+}
+
+func (s *Server) isClosed() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.closed
+}
+
+// send hands the message over to the sender, rawmail parsing errors having
+// already been counted.
+func (s *Server) send(req model.EmailRequest) (*model.SESResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.cfg.SendTimeout)
+	defer cancel()
+
+	resp, err := s.sender.SendEmail(ctx, req)
+	if err != nil {
+		log.Printf("Failed to send email: %v", err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *Server) countError(code string) {
+	if err := s.errorCounter.IncrementError(context.Background(), code); err != nil {
+		log.Printf("Failed to count %s error: %v", code, err)
+	}
+}
@@ -0,0 +1,165 @@
+// Package sns signs SNS messages the way Amazon SNS does, with a locally
+// generated certificate, so that subscribers can verify them unmodified.
+package sns
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	_ "crypto/sha1"   // SignatureVersion 1
+	_ "crypto/sha256" // SignatureVersion 2
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/kamal-github/demtech/internal/model"
+)
+
+// CertificateFile is the name SNS gives to its signing certificate.
+const CertificateFile = "SimpleNotificationService.pem"
+
+// Signer signs SNS messages with an RSA key. Its self-signed certificate is
+// served by the mock at the SigningCertURL of the messages.
+type Signer struct {
+	key     *rsa.PrivateKey
+	certPEM []byte
+	certURL string
+}
+
+// NewSigner generates a new key and certificate. certURL is where the mock
+// serves the certificate, it becomes the SigningCertURL of the messages.
+func NewSigner(certURL string) (*Signer, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 64))
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: "sns.amazonaws.com", Organization: []string{"Demtech SES Mock"}},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.AddDate(10, 0, 0),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Signer{
+		key:     key,
+		certPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		certURL: certURL,
+	}, nil
+}
+
+// CertificatePEM is the certificate subscribers verify signatures with.
+func (s *Signer) CertificatePEM() []byte {
+	return s.certPEM
+}
+
+// Sign sets the SigningCertURL and Signature of m, using SHA1 for
+// SignatureVersion 1 and SHA256 for SignatureVersion 2.
+func (s *Signer) Sign(m *model.SNSMessage) error {
+	m.SigningCertURL = s.certURL
+
+	hash, err := signatureHash(m.SignatureVersion)
+	if err != nil {
+		return err
+	}
+	h := hash.New()
+	h.Write([]byte(StringToSign(*m)))
+
+	sig, err := rsa.SignPKCS1v15(rand.Reader, s.key, hash, h.Sum(nil))
+	if err != nil {
+		return err
+	}
+	m.Signature = base64.StdEncoding.EncodeToString(sig)
+	return nil
+}
+
+// Verify checks the signature of m against a PEM encoded certificate, as SNS
+// subscribers do.
+func Verify(m model.SNSMessage, certPEM []byte) error {
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		return fmt.Errorf("invalid certificate")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return err
+	}
+	key, ok := cert.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return fmt.Errorf("unexpected public key type %T", cert.PublicKey)
+	}
+
+	hash, err := signatureHash(m.SignatureVersion)
+	if err != nil {
+		return err
+	}
+	sig, err := base64.StdEncoding.DecodeString(m.Signature)
+	if err != nil {
+		return err
+	}
+	h := hash.New()
+	h.Write([]byte(StringToSign(m)))
+	return rsa.VerifyPKCS1v15(key, hash, h.Sum(nil), sig)
+}
+
+// StringToSign builds the canonical string SNS signs: the name and value of
+// a fixed set of fields, each followed by a newline, in byte order of their
+// names. Subject is only part of it when set.
+func StringToSign(m model.SNSMessage) string {
+	var fields [][2]string
+	if m.Type == model.SNSTypeNotification {
+		fields = [][2]string{
+			{"Message", m.Message},
+			{"MessageId", m.MessageID},
+			{"Subject", m.Subject},
+			{"Timestamp", m.Timestamp},
+			{"TopicArn", m.TopicArn},
+			{"Type", m.Type},
+		}
+	} else {
+		fields = [][2]string{
+			{"Message", m.Message},
+			{"MessageId", m.MessageID},
+			{"SubscribeURL", m.SubscribeURL},
+			{"Timestamp", m.Timestamp},
+			{"Token", m.Token},
+			{"TopicArn", m.TopicArn},
+			{"Type", m.Type},
+		}
+	}
+
+	var sb strings.Builder
+	for _, f := range fields {
+		if f[0] == "Subject" && f[1] == "" {
+			continue
+		}
+		sb.WriteString(f[0] + "\n" + f[1] + "\n")
+	}
+	return sb.String()
+}
+
+func signatureHash(version string) (crypto.Hash, error) {
+	switch version {
+	case "1":
+		return crypto.SHA1, nil
+	case "2":
+		return crypto.SHA256, nil
+	default:
+		return 0, fmt.Errorf("unsupported SignatureVersion %q", version)
+	}
+}
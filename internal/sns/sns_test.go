@@ -0,0 +1,81 @@
+package sns_test
+
+import (
+	"testing"
+
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/kamal-github/demtech/internal/sns"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSigner_Sign(t *testing.T) {
+	signer, err := sns.NewSigner("http://localhost:8080/sns/SimpleNotificationService.pem")
+	assert.NoError(t, err)
+	other, err := sns.NewSigner("http://localhost:8080/sns/SimpleNotificationService.pem")
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name string
+		msg  model.SNSMessage
+	}{
+		{
+			name: "Notification with SignatureVersion 1",
+			msg: model.SNSMessage{
+				Type: model.SNSTypeNotification, MessageID: "22b80b92-fdea-4c2c-8f9d-bdfb0c7bf324",
+				TopicArn: "arn:aws:sns:us-east-1:123456789012:ses-events", Message: `{"eventType":"Bounce"}`,
+				Timestamp: "2024-01-02T03:04:05.000Z", SignatureVersion: "1",
+			},
+		},
+		{
+			name: "Notification with a subject and SignatureVersion 2",
+			msg: model.SNSMessage{
+				Type: model.SNSTypeNotification, MessageID: "22b80b92-fdea-4c2c-8f9d-bdfb0c7bf324",
+				TopicArn: "arn:aws:sns:us-east-1:123456789012:ses-events", Subject: "Amazon SES Email Event Notification",
+				Message: `{"eventType":"Bounce"}`, Timestamp: "2024-01-02T03:04:05.000Z", SignatureVersion: "2",
+			},
+		},
+		{
+			name: "Subscription confirmation",
+			msg: model.SNSMessage{
+				Type: model.SNSTypeSubscriptionConfirmation, MessageID: "165545c9-2a5c-472c-8df2-7ff2be2b3b1b",
+				Token: "2336412f37", TopicArn: "arn:aws:sns:us-east-1:123456789012:ses-events",
+				Message:      "You have chosen to subscribe to the topic arn:aws:sns:us-east-1:123456789012:ses-events.",
+				SubscribeURL: "http://localhost:8080/sns?Action=ConfirmSubscription", Timestamp: "2024-01-02T03:04:05.000Z",
+				SignatureVersion: "1",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			msg := tt.msg
+
+			assert.NoError(signer.Sign(&msg))
+			assert.Equal("http://localhost:8080/sns/SimpleNotificationService.pem", msg.SigningCertURL)
+			assert.NoError(sns.Verify(msg, signer.CertificatePEM()))
+
+			assert.Error(sns.Verify(msg, other.CertificatePEM()))
+			tampered := msg
+			tampered.Message = `{"eventType":"Delivery"}`
+			assert.Error(sns.Verify(tampered, signer.CertificatePEM()))
+		})
+	}
+}
+
+func TestSigner_SignUnsupportedVersion(t *testing.T) {
+	signer, err := sns.NewSigner("http://localhost:8080/sns/SimpleNotificationService.pem")
+	assert.NoError(t, err)
+
+	assert.Error(t, signer.Sign(&model.SNSMessage{Type: model.SNSTypeNotification, SignatureVersion: "3"}))
+}
+
+func TestStringToSign(t *testing.T) {
+	msg := model.SNSMessage{
+		Type: model.SNSTypeNotification, MessageID: "id", TopicArn: "arn", Message: "hello", Timestamp: "ts",
+	}
+	assert.Equal(t, "Message\nhello\nMessageId\nid\nTimestamp\nts\nTopicArn\narn\nType\nNotification\n", sns.StringToSign(msg))
+
+	msg.Subject = "subject"
+	assert.Equal(t, "Message\nhello\nMessageId\nid\nSubject\nsubject\nTimestamp\nts\nTopicArn\narn\nType\nNotification\n", sns.StringToSign(msg))
+}
@@ -0,0 +1,40 @@
+package validator
+
+import (
+	"context"
+
+	"github.com/kamal-github/demtech/internal/model"
+)
+
+type AccountStatusGetter interface {
+	GetAccountStatus(ctx context.Context) (model.AccountStatus, error)
+}
+
+/*
+Amazon SES pauses the sending of an account whose bounce or
+complaint rate got too high, or on request. Every message is
+then rejected until sending is enabled again.
+*/
+type AccountSendingValidator struct {
+	account AccountStatusGetter
+}
+
+func NewAccountSendingValidator(g AccountStatusGetter) AccountSendingValidator {
+	return AccountSendingValidator{account: g}
+}
+
+func (v AccountSendingValidator) Validate(ctx context.Context, req model.EmailRequest) error {
+	status, err := v.account.GetAccountStatus(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !status.SendingEnabled {
+		return &model.SESError{
+			Code:    "AccountSendingPausedException",
+			Message: "Sending is paused for this account.",
+		}
+	}
+
+	return nil
+}
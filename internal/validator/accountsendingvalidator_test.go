@@ -0,0 +1,53 @@
+package validator_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/kamal-github/demtech/internal/validator"
+	"github.com/kamal-github/demtech/internal/validator/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccountSendingValidator_Validate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name      string
+		status    model.AccountStatus
+		getErr    error
+		expectErr error
+	}{
+		{
+			name:   "Sending enabled",
+			status: model.AccountStatus{SendingEnabled: true, EnforcementStatus: model.EnforcementStatusHealthy},
+		},
+		{
+			name:   "Under review still sends",
+			status: model.AccountStatus{SendingEnabled: true, EnforcementStatus: model.EnforcementStatusProbation},
+		},
+		{
+			name:      "Sending paused",
+			status:    model.AccountStatus{SendingEnabled: false, EnforcementStatus: model.EnforcementStatusShutdown},
+			expectErr: &model.SESError{Code: "AccountSendingPausedException", Message: "Sending is paused for this account."},
+		},
+		{
+			name:      "Error fetching account status",
+			getErr:    assert.AnError,
+			expectErr: assert.AnError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockGetter := mocks.NewMockAccountStatusGetter(ctrl)
+			mockGetter.EXPECT().GetAccountStatus(gomock.Any()).Return(tt.status, tt.getErr)
+
+			err := validator.NewAccountSendingValidator(mockGetter).Validate(context.Background(), model.EmailRequest{})
+			assert.Equal(t, tt.expectErr, err)
+		})
+	}
+}
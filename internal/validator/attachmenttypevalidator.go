@@ -0,0 +1,43 @@
+package validator
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+
+	"github.com/kamal-github/demtech/internal/model"
+)
+
+// blockedAttachmentExtensions lists the attachment types SES refuses to send.
+// See https://docs.aws.amazon.com/ses/latest/dg/mime-types.html
+var blockedAttachmentExtensions = map[string]struct{}{
+	".ade": {}, ".adp": {}, ".app": {}, ".asp": {}, ".bas": {}, ".bat": {}, ".cer": {}, ".chm": {},
+	".cmd": {}, ".com": {}, ".cpl": {}, ".crt": {}, ".csh": {}, ".der": {}, ".exe": {}, ".fxp": {},
+	".gadget": {}, ".hlp": {}, ".hta": {}, ".inf": {}, ".ins": {}, ".isp": {}, ".its": {}, ".js": {},
+	".jse": {}, ".ksh": {}, ".lib": {}, ".lnk": {}, ".mad": {}, ".maf": {}, ".mag": {}, ".mam": {},
+	".maq": {}, ".mar": {}, ".mas": {}, ".mat": {}, ".mau": {}, ".mav": {}, ".maw": {}, ".mda": {},
+	".mdb": {}, ".mde": {}, ".mdt": {}, ".mdw": {}, ".mdz": {}, ".msc": {}, ".msh": {}, ".msh1": {},
+	".msh2": {}, ".mshxml": {}, ".msh1xml": {}, ".msh2xml": {}, ".msi": {}, ".msp": {}, ".mst": {},
+	".ops": {}, ".pcd": {}, ".pif": {}, ".plg": {}, ".prf": {}, ".prg": {}, ".reg": {}, ".scf": {},
+	".scr": {}, ".sct": {}, ".shb": {}, ".shs": {}, ".sys": {}, ".ps1": {}, ".ps1xml": {}, ".ps2": {},
+	".ps2xml": {}, ".psc1": {}, ".psc2": {}, ".tmp": {}, ".url": {}, ".vb": {}, ".vbe": {}, ".vbs": {},
+	".vps": {}, ".vsmacros": {}, ".vss": {}, ".vst": {}, ".vsw": {}, ".vws": {}, ".wsc": {}, ".wsf": {},
+	".wsh": {}, ".xnk": {},
+}
+
+type AttachmentTypeValidator struct{}
+
+func NewAttachmentTypeValidator() AttachmentTypeValidator {
+	return AttachmentTypeValidator{}
+}
+
+func (v AttachmentTypeValidator) Validate(ctx context.Context, req model.EmailRequest) error {
+	for _, a := range req.Message.Attachments {
+		ext := strings.ToLower(filepath.Ext(a.Filename))
+		if _, ok := blockedAttachmentExtensions[ext]; ok {
+			return &model.SESError{Code: "InvalidParameterValue", Message: "Illegal attachment type " + ext + " in file " + a.Filename}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package validator_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/kamal-github/demtech/internal/validator"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttachmentTypeValidator_Validate(t *testing.T) {
+	tests := []struct {
+		name        string
+		attachments []model.Attachment
+		expectErr   bool
+	}{
+		{
+			name:        "No attachments",
+			attachments: nil,
+			expectErr:   false,
+		},
+		{
+			name:        "Allowed attachment types",
+			attachments: []model.Attachment{{Filename: "report.pdf"}, {Filename: "logo.png"}},
+			expectErr:   false,
+		},
+		{
+			name:        "Executable attachment",
+			attachments: []model.Attachment{{Filename: "report.pdf"}, {Filename: "setup.exe"}},
+			expectErr:   true,
+		},
+		{
+			name:        "Blocked extension is case insensitive",
+			attachments: []model.Attachment{{Filename: "RUN.BAT"}},
+			expectErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			v := validator.NewAttachmentTypeValidator()
+			req := model.EmailRequest{Message: model.Message{Attachments: tt.attachments}}
+
+			err := v.Validate(context.Background(), req)
+
+			if tt.expectErr {
+				assert.Error(err)
+				assert.IsType(&model.SESError{}, err)
+				assert.Equal("InvalidParameterValue", err.(*model.SESError).Code)
+			} else {
+				assert.NoError(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package validator
+
+import (
+	"context"
+
+	"github.com/kamal-github/demtech/internal/model"
+)
+
+type ConfigurationSetGetter interface {
+	GetConfigurationSet(ctx context.Context, name string) (model.ConfigurationSet, error)
+}
+
+/*
+A message sent with a configuration set is rejected when
+the configuration set does not exist, or when sending was
+paused for it.
+*/
+type ConfigurationSetValidator struct {
+	sets ConfigurationSetGetter
+}
+
+func NewConfigurationSetValidator(g ConfigurationSetGetter) ConfigurationSetValidator {
+	return ConfigurationSetValidator{sets: g}
+}
+
+func (v ConfigurationSetValidator) Validate(ctx context.Context, req model.EmailRequest) error {
+	if req.ConfigurationSetName == "" {
+		return nil
+	}
+
+	// Propagates ConfigurationSetDoesNotExist for an unknown configuration set
+	set, err := v.sets.GetConfigurationSet(ctx, req.ConfigurationSetName)
+	if err != nil {
+		return err
+	}
+
+	if !set.SendingEnabled {
+		return &model.SESError{
+			Code:    "ConfigurationSetSendingPausedException",
+			Message: "Sending is paused for this configuration set.",
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package validator_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/kamal-github/demtech/internal/validator"
+	"github.com/kamal-github/demtech/internal/validator/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigurationSetValidator_Validate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	notFound := &model.SESError{Code: "ConfigurationSetDoesNotExist", Message: "Configuration set unknown does not exist."}
+
+	tests := []struct {
+		name          string
+		setName       string
+		set           model.ConfigurationSet
+		getErr        error
+		expectGet     bool
+		expectErrCode string
+	}{
+		{
+			name: "No configuration set",
+		},
+		{
+			name:      "Sending enabled",
+			setName:   "default-config",
+			set:       model.ConfigurationSet{Name: "default-config", SendingEnabled: true},
+			expectGet: true,
+		},
+		{
+			name:          "Sending paused",
+			setName:       "default-config",
+			set:           model.ConfigurationSet{Name: "default-config", SendingEnabled: false},
+			expectGet:     true,
+			expectErrCode: "ConfigurationSetSendingPausedException",
+		},
+		{
+			name:          "Unknown configuration set",
+			setName:       "unknown",
+			getErr:        notFound,
+			expectGet:     true,
+			expectErrCode: "ConfigurationSetDoesNotExist",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			mockGetter := mocks.NewMockConfigurationSetGetter(ctrl)
+			if tt.expectGet {
+				mockGetter.EXPECT().GetConfigurationSet(gomock.Any(), tt.setName).Return(tt.set, tt.getErr).Times(1)
+			}
+
+			err := validator.NewConfigurationSetValidator(mockGetter).Validate(context.Background(), model.EmailRequest{ConfigurationSetName: tt.setName})
+
+			if tt.expectErrCode != "" {
+				assert.IsType(&model.SESError{}, err)
+				assert.Equal(tt.expectErrCode, err.(*model.SESError).Code)
+				return
+			}
+			assert.NoError(err)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package validator
 
 import (
 	"context"
+	"encoding/base64"
 
 	"github.com/kamal-github/demtech/internal/model"
 )
@@ -15,7 +16,14 @@ func NewMaxBodySizeValidator(m int64) MaxBodySizeValidator {
 }
 
 func (v MaxBodySizeValidator) Validate(ctx context.Context, req model.EmailRequest) error {
-	if int64(len(req.Message.Body.Text.Data)) > v.awsMaxEmailSizeAllowedBytes {
+	size := int64(len(req.Message.Body.Text.Data))
+	if req.RawMessage != nil {
+		// For raw messages SES measures the whole MIME message, attachments
+		// included, as it is transmitted i.e. base64 encoded.
+		size = int64(base64.StdEncoding.EncodedLen(len(req.RawMessage.Data)))
+	}
+
+	if size > v.awsMaxEmailSizeAllowedBytes {
 		return &model.SESError{Code: "MessageTooLong", Message: "Email body exceeds maximum size"}
 	}
 
@@ -13,6 +13,7 @@ func TestMaxBodySizeValidator_Validate(t *testing.T) {
 	tests := []struct {
 		name      string
 		bodyText  string
+		raw       []byte
 		maxSize   int64
 		expectErr bool
 	}{
@@ -34,6 +35,20 @@ func TestMaxBodySizeValidator_Validate(t *testing.T) {
 			maxSize:   20,
 			expectErr: true,
 		},
+		{
+			name:      "Raw message is measured base64 encoded",
+			bodyText:  "short",
+			raw:       []byte("123456789"), // 12 bytes once encoded
+			maxSize:   12,
+			expectErr: false,
+		},
+		{
+			name:      "Raw message exceeding limit once encoded",
+			bodyText:  "short",
+			raw:       []byte("1234567890"), // 16 bytes once encoded
+			maxSize:   12,
+			expectErr: true,
+		},
 		{
 			name:      "Empty body",
 			bodyText:  "",
@@ -47,6 +62,9 @@ func TestMaxBodySizeValidator_Validate(t *testing.T) {
 			assert := assert.New(t)
 			v := validator.NewMaxBodySizeValidator(tt.maxSize)
 			req := model.EmailRequest{Message: model.Message{Body: model.Body{Text: model.TextBody{Data: tt.bodyText}}}}
+			if tt.raw != nil {
+				req.RawMessage = &model.RawMessage{Data: tt.raw}
+			}
 
 			err := v.Validate(context.Background(), req)
 
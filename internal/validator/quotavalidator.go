@@ -4,28 +4,61 @@ import (
 	"context"
 
 	"github.com/kamal-github/demtech/internal/model"
+	"github.com/kamal-github/demtech/internal/simulator"
 )
 
 type LastNHoursCountGetter interface {
 	GetLastNHoursCount(ctx context.Context) (int64, error)
 }
 
+// SendQuotaGetter gives the current send quota of the account, which grows
+// while it is warming up.
+type SendQuotaGetter interface {
+	GetSendQuota(ctx context.Context) (model.SendQuota, error)
+}
+
 type QuotaValidator struct {
-	lastNHoursCountGetter       LastNHoursCountGetter
-	awsEmailsQuotaForLastNHours int64
+	lastNHoursCountGetter LastNHoursCountGetter
+	sendQuotaGetter       SendQuotaGetter
 }
 
-func NewQuotaValidator(g LastNHoursCountGetter, q int64) QuotaValidator {
-	return QuotaValidator{lastNHoursCountGetter: g, awsEmailsQuotaForLastNHours: q}
+func NewQuotaValidator(g LastNHoursCountGetter, q SendQuotaGetter) QuotaValidator {
+	return QuotaValidator{lastNHoursCountGetter: g, sendQuotaGetter: q}
 }
 
+// Validate refuses a message whose recipients would take the account over its
+// quota, each recipient counting as one email.
 func (v QuotaValidator) Validate(ctx context.Context, req model.EmailRequest) error {
+	return v.ValidateBulk(ctx, []model.EmailRequest{req})
+}
+
+// ValidateBulk refuses the messages of a bulk send at once when all of their
+// recipients together would take the account over its quota.
+func (v QuotaValidator) ValidateBulk(ctx context.Context, reqs []model.EmailRequest) error {
+	// Messages to the mailbox simulator do not count against the quota.
+	var recipients int64
+	for _, req := range reqs {
+		for _, de := range req.Destination.All() {
+			if !simulator.IsSimulatorAddress(de) {
+				recipients++
+			}
+		}
+	}
+	if recipients == 0 {
+		return nil
+	}
+
+	quota, err := v.sendQuotaGetter.GetSendQuota(ctx)
+	if err != nil {
+		return err
+	}
+
 	emailsSent, err := v.lastNHoursCountGetter.GetLastNHoursCount(ctx)
 	if err != nil {
 		return err
 	}
 
-	if emailsSent >= v.awsEmailsQuotaForLastNHours {
+	if emailsSent+recipients > quota.Max24HourSend {
 		return &model.SESError{Code: "LimitExceededException", Message: "Sending quota exceeded"}
 	}
 
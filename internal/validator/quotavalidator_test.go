@@ -20,6 +20,8 @@ func TestQuotaValidator_Validate(t *testing.T) {
 		sentEmails int64
 		quota      int64
 		mockError  error
+		quotaError error
+		dest       model.Destination
 		expectErr  bool
 	}{
 		{
@@ -50,16 +52,50 @@ func TestQuotaValidator_Validate(t *testing.T) {
 			mockError:  assert.AnError,
 			expectErr:  true,
 		},
+		{
+			name:       "Error fetching send quota",
+			sentEmails: 0,
+			quota:      10,
+			quotaError: assert.AnError,
+			expectErr:  true,
+		},
+		{
+			name:       "Recipients beyond quota limit",
+			sentEmails: 9,
+			quota:      10,
+			dest:       model.Destination{ToAddresses: []string{"a@example.com"}, CcAddresses: []string{"b@example.com"}},
+			expectErr:  true,
+		},
+		{
+			name:       "Simulator recipients do not count towards the limit",
+			sentEmails: 9,
+			quota:      10,
+			dest:       model.Destination{ToAddresses: []string{"a@example.com"}, CcAddresses: []string{"bounce@simulator.amazonses.com"}},
+			expectErr:  false,
+		},
+		{
+			name:       "Mailbox simulator is exempt",
+			sentEmails: 11,
+			quota:      10,
+			dest:       model.Destination{ToAddresses: []string{"success@simulator.amazonses.com"}},
+			expectErr:  false,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			assert := assert.New(t)
 			mockGetter := mocks.NewMockLastNHoursCountGetter(ctrl)
-			mockGetter.EXPECT().GetLastNHoursCount(gomock.Any()).Return(tt.sentEmails, tt.mockError)
+			mockGetter.EXPECT().GetLastNHoursCount(gomock.Any()).Return(tt.sentEmails, tt.mockError).AnyTimes()
 
-			v := validator.NewQuotaValidator(mockGetter, tt.quota)
-			req := model.EmailRequest{}
+			mockQuotaGetter := mocks.NewMockSendQuotaGetter(ctrl)
+			mockQuotaGetter.EXPECT().GetSendQuota(gomock.Any()).Return(model.SendQuota{Max24HourSend: tt.quota, MaxSendRate: 1}, tt.quotaError).AnyTimes()
+
+			v := validator.NewQuotaValidator(mockGetter, mockQuotaGetter)
+			req := model.EmailRequest{Destination: tt.dest}
+			if len(tt.dest.All()) == 0 {
+				req.Destination = model.Destination{ToAddresses: []string{"test@example.com"}}
+			}
 
 			err := v.Validate(context.Background(), req)
 
@@ -71,3 +107,23 @@ func TestQuotaValidator_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestQuotaValidator_ValidateBulk(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGetter := mocks.NewMockLastNHoursCountGetter(ctrl)
+	mockGetter.EXPECT().GetLastNHoursCount(gomock.Any()).Return(int64(8), nil).AnyTimes()
+	mockQuotaGetter := mocks.NewMockSendQuotaGetter(ctrl)
+	mockQuotaGetter.EXPECT().GetSendQuota(gomock.Any()).Return(model.SendQuota{Max24HourSend: 10, MaxSendRate: 1}, nil).AnyTimes()
+
+	v := validator.NewQuotaValidator(mockGetter, mockQuotaGetter)
+	dest := func(addresses ...string) model.EmailRequest {
+		return model.EmailRequest{Destination: model.Destination{ToAddresses: addresses}}
+	}
+
+	// Each destination fits in the quota on its own, not all of them together.
+	assert.NoError(t, v.ValidateBulk(context.Background(), []model.EmailRequest{dest("a@example.com"), dest("b@example.com", "success@simulator.amazonses.com")}))
+	assert.EqualError(t, v.ValidateBulk(context.Background(), []model.EmailRequest{dest("a@example.com"), dest("b@example.com"), dest("c@example.com")}),
+		"LimitExceededException: Sending quota exceeded")
+}
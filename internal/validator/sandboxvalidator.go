@@ -2,32 +2,55 @@ package validator
 
 import (
 	"context"
+	"net/mail"
 
 	"github.com/kamal-github/demtech/internal/model"
+	"github.com/kamal-github/demtech/internal/simulator"
 )
 
+type ProductionAccessChecker interface {
+	IsProductionAccessEnabled(ctx context.Context) (bool, error)
+}
+
+/*
+While an account is in the sandbox, mail can only be sent to
+verified email addresses and domains, or to the mailbox
+simulator. Other recipients fail the whole message with the
+same "Email address is not verified" error as an unverified
+sender. Production access lifts the restriction.
+*/
 type SandboxValidator struct {
-	awsIsSandbox                  bool
-	awsSandboxAllowedDestinations []string
+	productionAccess ProductionAccessChecker
+	identities       VerifiedIdentityChecker
+	region           string
 }
 
-func NewSandboxValidator(s bool, e []string) SandboxValidator {
-	return SandboxValidator{awsIsSandbox: s, awsSandboxAllowedDestinations: e}
+func NewSandboxValidator(p ProductionAccessChecker, c VerifiedIdentityChecker, region string) SandboxValidator {
+	return SandboxValidator{productionAccess: p, identities: c, region: region}
 }
 
 func (v SandboxValidator) Validate(ctx context.Context, req model.EmailRequest) error {
-	if !v.awsIsSandbox {
-		return nil
-	}
-
-	sandboxEmails := make(map[string]struct{})
-	for _, e := range v.awsSandboxAllowedDestinations {
-		sandboxEmails[e] = struct{}{}
+	enabled, err := v.productionAccess.IsProductionAccessEnabled(ctx)
+	if err != nil || enabled {
+		return err
 	}
 
 	for _, de := range req.Destination.All() {
-		if _, ok := sandboxEmails[de]; !ok {
-			return &model.SESError{Code: "MessageRejected", Message: "Cannot send emails outside sandbox"}
+		// The mailbox simulator can be used from the sandbox.
+		if simulator.IsSimulatorAddress(de) {
+			continue
+		}
+
+		addr, err := mail.ParseAddress(de)
+		if err != nil {
+			return notVerified(v.region, de)
+		}
+		verified, err := isVerifiedAddress(ctx, v.identities, addr.Address)
+		if err != nil {
+			return err
+		}
+		if !verified {
+			return notVerified(v.region, addr.Address)
 		}
 	}
 
@@ -4,51 +4,78 @@ import (
 	"context"
 	"testing"
 
+	"github.com/golang/mock/gomock"
 	"github.com/kamal-github/demtech/internal/model"
 	"github.com/kamal-github/demtech/internal/validator"
+	"github.com/kamal-github/demtech/internal/validator/mocks"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestSandboxValidator_Validate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
 	tests := []struct {
-		name         string
-		awsIsSandbox bool
-		allowed      []string
-		dest         model.Destination
-		expectErr    bool
+		name             string
+		productionAccess bool
+		verified         []string
+		dest             model.Destination
+		expectErr        bool
 	}{
 		{
-			name:         "All destinations allowed",
-			awsIsSandbox: true,
-			allowed:      []string{"allowed@example.com", "test@example.com"},
-			dest:         model.Destination{ToAddresses: []string{"allowed@example.com"}},
-			expectErr:    false,
+			name:      "All destinations verified",
+			verified:  []string{"allowed@example.com", "test@example.com"},
+			dest:      model.Destination{ToAddresses: []string{"allowed@example.com"}, CcAddresses: []string{"Test <test@example.com>"}},
+			expectErr: false,
+		},
+		{
+			name:      "Verified domain authorizes any address at it",
+			verified:  []string{"example.com"},
+			dest:      model.Destination{ToAddresses: []string{"anyone@example.com"}},
+			expectErr: false,
+		},
+		{
+			name:      "One unverified destination - should fail",
+			verified:  []string{"allowed@example.com"},
+			dest:      model.Destination{ToAddresses: []string{"allowed@example.com", "notallowed@example.com"}},
+			expectErr: true,
 		},
 		{
-			name:         "One unallowed destination - should fail",
-			awsIsSandbox: true,
-			allowed:      []string{"allowed@example.com"},
-			dest:         model.Destination{ToAddresses: []string{"allowed@example.com", "notallowed@example.com"}},
-			expectErr:    true,
+			name:      "No verified identities - should fail",
+			dest:      model.Destination{ToAddresses: []string{"random@example.com"}},
+			expectErr: true,
 		},
 		{
-			name:         "No allowed destinations - should fail",
-			awsIsSandbox: true,
-			allowed:      []string{},
-			dest:         model.Destination{ToAddresses: []string{"random@example.com"}},
-			expectErr:    true,
+			name:      "Mailbox simulator is allowed",
+			verified:  []string{"allowed@example.com"},
+			dest:      model.Destination{ToAddresses: []string{"allowed@example.com"}, BccAddresses: []string{"bounce+qa@simulator.amazonses.com"}},
+			expectErr: false,
 		},
 		{
-			name:         "Not a sandbox",
-			awsIsSandbox: false,
-			expectErr:    false,
+			name:             "Production access",
+			productionAccess: true,
+			dest:             model.Destination{ToAddresses: []string{"random@example.com"}},
+			expectErr:        false,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			assert := assert.New(t)
-			v := validator.NewSandboxValidator(tt.awsIsSandbox, tt.allowed)
+			mockAccess := mocks.NewMockProductionAccessChecker(ctrl)
+			mockAccess.EXPECT().IsProductionAccessEnabled(gomock.Any()).Return(tt.productionAccess, nil)
+			mockChecker := mocks.NewMockVerifiedIdentityChecker(ctrl)
+			mockChecker.EXPECT().IsVerified(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, identity string) (bool, error) {
+					for _, v := range tt.verified {
+						if v == identity {
+							return true, nil
+						}
+					}
+					return false, nil
+				}).AnyTimes()
+
+			v := validator.NewSandboxValidator(mockAccess, mockChecker, "us-east-1")
 			req := model.EmailRequest{Destination: tt.dest}
 
 			err := v.Validate(context.Background(), req)
@@ -57,9 +84,20 @@ func TestSandboxValidator_Validate(t *testing.T) {
 				assert.Error(err)
 				assert.IsType(&model.SESError{}, err)
 				assert.Equal("MessageRejected", err.(*model.SESError).Code)
+				assert.Contains(err.(*model.SESError).Message, "Email address is not verified. The following identities failed the check in region US-EAST-1:")
 			} else {
 				assert.NoError(err)
 			}
 		})
 	}
+
+	t.Run("Production access failure", func(t *testing.T) {
+		mockAccess := mocks.NewMockProductionAccessChecker(ctrl)
+		mockAccess.EXPECT().IsProductionAccessEnabled(gomock.Any()).Return(false, assert.AnError)
+
+		err := validator.NewSandboxValidator(mockAccess, mocks.NewMockVerifiedIdentityChecker(ctrl), "us-east-1").
+			Validate(context.Background(), model.EmailRequest{Destination: model.Destination{ToAddresses: []string{"random@example.com"}}})
+
+		assert.ErrorIs(t, err, assert.AnError)
+	})
 }
@@ -0,0 +1,55 @@
+package validator
+
+import (
+	"context"
+	"math"
+
+	"github.com/kamal-github/demtech/internal/model"
+)
+
+type SendRateLimiter interface {
+	Take(ctx context.Context, n int64, rate float64, capacity int64) (bool, error)
+}
+
+/*
+Amazon SES limits the number of recipients sent to per second, the
+maximum send rate. Short bursts above it are tolerated, which is
+modeled by a token bucket holding up to burst recipients, and at
+least one second worth of them and the recipients of the largest
+message, refilled at the MaxSendRate of the send quota. Messages to the mailbox simulator count against it as
+well, so that client side rate limiting can be tested with them.
+A zero MaxSendRate disables the limit.
+*/
+type SendRateValidator struct {
+	sendQuotaGetter SendQuotaGetter
+	limiter         SendRateLimiter
+	burst           int64
+	maxDestinations int64
+}
+
+func NewSendRateValidator(q SendQuotaGetter, l SendRateLimiter, burst int64, maxDestinations int) SendRateValidator {
+	return SendRateValidator{sendQuotaGetter: q, limiter: l, burst: burst, maxDestinations: int64(maxDestinations)}
+}
+
+func (v SendRateValidator) Validate(ctx context.Context, req model.EmailRequest) error {
+	quota, err := v.sendQuotaGetter.GetSendQuota(ctx)
+	if err != nil {
+		return err
+	}
+	if quota.MaxSendRate <= 0 {
+		return nil
+	}
+
+	// A bucket smaller than a message would throttle it forever.
+	burst := max(v.burst, int64(math.Ceil(quota.MaxSendRate)), v.maxDestinations)
+	ok, err := v.limiter.Take(ctx, int64(len(req.Destination.All())), quota.MaxSendRate, burst)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return &model.SESError{Code: "Throttling", Message: "Maximum sending rate exceeded."}
+	}
+
+	return nil
+}
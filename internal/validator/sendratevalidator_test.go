@@ -0,0 +1,101 @@
+package validator_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/kamal-github/demtech/internal/validator"
+	"github.com/kamal-github/demtech/internal/validator/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSendRateValidator_Validate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dest := model.Destination{
+		ToAddresses:  []string{"to@example.com"},
+		CcAddresses:  []string{"cc@example.com"},
+		BccAddresses: []string{"success@simulator.amazonses.com"},
+	}
+
+	tests := []struct {
+		name        string
+		maxSendRate float64
+		burst       int64
+		mockSetup   func(l *mocks.MockSendRateLimiter)
+		expectErr   *model.SESError
+		expectAny   bool
+	}{
+		{
+			name:        "Within send rate",
+			maxSendRate: 14,
+			burst:       28,
+			mockSetup: func(l *mocks.MockSendRateLimiter) {
+				// Each recipient takes a token, mailbox simulator ones included.
+				l.EXPECT().Take(gomock.Any(), int64(3), 14.0, int64(28)).Return(true, nil)
+			},
+		},
+		{
+			name:        "Send rate exceeded",
+			maxSendRate: 14,
+			burst:       28,
+			mockSetup: func(l *mocks.MockSendRateLimiter) {
+				l.EXPECT().Take(gomock.Any(), int64(3), 14.0, int64(28)).Return(false, nil)
+			},
+			expectErr: &model.SESError{Code: "Throttling", Message: "Maximum sending rate exceeded."},
+		},
+		{
+			name:        "Burst is at least one second worth",
+			maxSendRate: 2.5,
+			mockSetup: func(l *mocks.MockSendRateLimiter) {
+				l.EXPECT().Take(gomock.Any(), int64(3), 2.5, int64(3)).Return(true, nil)
+			},
+		},
+		{
+			name:        "Burst holds at least the largest message",
+			maxSendRate: 1,
+			burst:       2,
+			mockSetup: func(l *mocks.MockSendRateLimiter) {
+				l.EXPECT().Take(gomock.Any(), int64(3), 1.0, int64(3)).Return(true, nil)
+			},
+		},
+		{
+			name:        "Zero send rate disables the limit",
+			maxSendRate: 0,
+			mockSetup:   func(l *mocks.MockSendRateLimiter) {},
+		},
+		{
+			name:        "Error taking tokens",
+			maxSendRate: 14,
+			mockSetup: func(l *mocks.MockSendRateLimiter) {
+				l.EXPECT().Take(gomock.Any(), int64(3), 14.0, int64(14)).Return(false, assert.AnError)
+			},
+			expectAny: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			mockQuotaGetter := mocks.NewMockSendQuotaGetter(ctrl)
+			mockQuotaGetter.EXPECT().GetSendQuota(gomock.Any()).Return(model.SendQuota{Max24HourSend: 200, MaxSendRate: tt.maxSendRate}, nil)
+			mockLimiter := mocks.NewMockSendRateLimiter(ctrl)
+			tt.mockSetup(mockLimiter)
+
+			v := validator.NewSendRateValidator(mockQuotaGetter, mockLimiter, tt.burst, 3)
+			err := v.Validate(context.Background(), model.EmailRequest{Destination: dest})
+
+			switch {
+			case tt.expectAny:
+				assert.Error(err)
+			case tt.expectErr != nil:
+				assert.Equal(tt.expectErr, err)
+			default:
+				assert.NoError(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package validator
+
+import (
+	"context"
+
+	"github.com/kamal-github/demtech/internal/model"
+)
+
+type SuppressionListChecker interface {
+	IsSuppressed(ctx context.Context, configurationSetName, recipient string) (bool, error)
+}
+
+/*
+Amazon SES does not send to the addresses of the suppression
+list. The message is still accepted, those recipients get a
+bounce event with the OnAccountSuppressionList sub-type instead
+of a delivery, which is why the suppressed recipients are
+returned as *model.SuppressedRecipients rather than a rejection.
+*/
+type SuppressionListValidator struct {
+	list SuppressionListChecker
+}
+
+func NewSuppressionListValidator(c SuppressionListChecker) SuppressionListValidator {
+	return SuppressionListValidator{list: c}
+}
+
+func (v SuppressionListValidator) Validate(ctx context.Context, req model.EmailRequest) error {
+	var suppressed []string
+	for _, dest := range req.Destination.All() {
+		ok, err := v.list.IsSuppressed(ctx, req.ConfigurationSetName, dest)
+		if err != nil {
+			return err
+		}
+		if ok {
+			suppressed = append(suppressed, dest)
+		}
+	}
+
+	if len(suppressed) > 0 {
+		return &model.SuppressedRecipients{Addresses: suppressed}
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package validator_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kamal-github/demtech/internal/model"
+	"github.com/kamal-github/demtech/internal/validator"
+	"github.com/kamal-github/demtech/internal/validator/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSuppressionListValidator_Validate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name             string
+		destination      model.Destination
+		suppressed       []string
+		checkErr         error
+		expectErr        error
+		expectSuppressed []string
+	}{
+		{
+			name:        "No recipient suppressed",
+			destination: model.Destination{ToAddresses: []string{"a@example.com"}, CcAddresses: []string{"b@example.com"}},
+		},
+		{
+			name:             "Some recipients suppressed",
+			destination:      model.Destination{ToAddresses: []string{"a@example.com"}, BccAddresses: []string{"bounced@example.com"}},
+			suppressed:       []string{"bounced@example.com"},
+			expectSuppressed: []string{"bounced@example.com"},
+		},
+		{
+			name:        "Suppression list failure",
+			destination: model.Destination{ToAddresses: []string{"a@example.com"}},
+			checkErr:    assert.AnError,
+			expectErr:   assert.AnError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			mockChecker := mocks.NewMockSuppressionListChecker(ctrl)
+			mockChecker.EXPECT().IsSuppressed(gomock.Any(), "default-config", gomock.Any()).
+				DoAndReturn(func(_ context.Context, _, recipient string) (bool, error) {
+					for _, s := range tt.suppressed {
+						if s == recipient {
+							return true, nil
+						}
+					}
+					return false, tt.checkErr
+				}).AnyTimes()
+
+			err := validator.NewSuppressionListValidator(mockChecker).Validate(context.Background(), model.EmailRequest{
+				Destination:          tt.destination,
+				ConfigurationSetName: "default-config",
+			})
+
+			switch {
+			case tt.expectErr != nil:
+				assert.ErrorIs(err, tt.expectErr)
+			case tt.expectSuppressed != nil:
+				assert.Equal(&model.SuppressedRecipients{Addresses: tt.expectSuppressed}, err)
+			default:
+				assert.NoError(err)
+			}
+		})
+	}
+}
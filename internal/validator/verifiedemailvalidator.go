@@ -2,30 +2,65 @@ package validator
 
 import (
 	"context"
+	"net/mail"
+	"strings"
 
 	"github.com/kamal-github/demtech/internal/model"
 )
 
+type VerifiedIdentityChecker interface {
+	IsVerified(ctx context.Context, identity string) (bool, error)
+}
+
 /*
 The message must be sent from a verified email address or
 domain. If you attempt to send email using a non-verified
-address or domain, the operation results in an
-"Email address not verified" error.
+address or domain, including one whose verification is still
+pending, the operation results in an
+"Email address is not verified" error.
 */
 type VerifiedEmailValidator struct {
-	awsVerifiedSourceEmailIDs []string
+	identities VerifiedIdentityChecker
+	region     string
 }
 
-func NewVerifiedEmailValidator(v []string) VerifiedEmailValidator {
-	return VerifiedEmailValidator{awsVerifiedSourceEmailIDs: v}
+func NewVerifiedEmailValidator(c VerifiedIdentityChecker, region string) VerifiedEmailValidator {
+	return VerifiedEmailValidator{identities: c, region: region}
 }
 
 func (v VerifiedEmailValidator) Validate(ctx context.Context, req model.EmailRequest) error {
-	for _, e := range v.awsVerifiedSourceEmailIDs {
-		if e == req.Source {
-			return nil
+	// Source may carry a display name, e.g. "Team <team@example.com>"
+	addr, err := mail.ParseAddress(req.Source)
+	if err != nil {
+		return notVerified(v.region, req.Source)
+	}
+
+	verified, err := isVerifiedAddress(ctx, v.identities, addr.Address)
+	if err != nil {
+		return err
+	}
+	if !verified {
+		return notVerified(v.region, addr.Address)
+	}
+	return nil
+}
+
+// isVerifiedAddress reports whether address is a verified identity, a
+// verified domain authorizing any address at that domain.
+func isVerifiedAddress(ctx context.Context, c VerifiedIdentityChecker, address string) (bool, error) {
+	domain := strings.ToLower(address[strings.LastIndex(address, "@")+1:])
+	for _, identity := range []string{address, domain} {
+		verified, err := c.IsVerified(ctx, identity)
+		if err != nil || verified {
+			return verified, err
 		}
 	}
+	return false, nil
+}
 
-	return &model.SESError{Code: "MailFromDomainNotVerifiedException", Message: "Email address not verified"}
+func notVerified(region, identity string) *model.SESError {
+	return &model.SESError{
+		Code:    "MessageRejected",
+		Message: "Email address is not verified. The following identities failed the check in region " + strings.ToUpper(region) + ": " + identity,
+	}
 }
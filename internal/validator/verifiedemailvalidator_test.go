@@ -4,12 +4,17 @@ import (
 	"context"
 	"testing"
 
+	"github.com/golang/mock/gomock"
 	"github.com/kamal-github/demtech/internal/model"
 	"github.com/kamal-github/demtech/internal/validator"
+	"github.com/kamal-github/demtech/internal/validator/mocks"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestVerifiedEmailValidator_Validate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
 	tests := []struct {
 		name      string
 		verified  []string
@@ -40,12 +45,47 @@ func TestVerifiedEmailValidator_Validate(t *testing.T) {
 			email:     "Verified@example.com",
 			expectErr: true,
 		},
+		{
+			name:      "Display name resolves to its address",
+			verified:  []string{"team@example.com"},
+			email:     "Team <team@example.com>",
+			expectErr: false,
+		},
+		{
+			name:      "Verified domain authorizes any address at it",
+			verified:  []string{"example.com"},
+			email:     "anyone@Example.com",
+			expectErr: false,
+		},
+		{
+			name:      "Verified domain does not authorize its subdomains",
+			verified:  []string{"example.com"},
+			email:     "anyone@mail.example.com",
+			expectErr: true,
+		},
+		{
+			name:      "Malformed source",
+			verified:  []string{"example.com"},
+			email:     "not an address",
+			expectErr: true,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			assert := assert.New(t)
-			v := validator.NewVerifiedEmailValidator(tt.verified)
+			mockChecker := mocks.NewMockVerifiedIdentityChecker(ctrl)
+			mockChecker.EXPECT().IsVerified(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, identity string) (bool, error) {
+					for _, v := range tt.verified {
+						if v == identity {
+							return true, nil
+						}
+					}
+					return false, nil
+				}).AnyTimes()
+
+			v := validator.NewVerifiedEmailValidator(mockChecker, "us-east-1")
 			req := model.EmailRequest{Source: tt.email}
 
 			err := v.Validate(context.Background(), req)
@@ -53,10 +93,20 @@ func TestVerifiedEmailValidator_Validate(t *testing.T) {
 			if tt.expectErr {
 				assert.Error(err)
 				assert.IsType(&model.SESError{}, err)
-				assert.Equal("MailFromDomainNotVerifiedException", err.(*model.SESError).Code)
+				assert.Equal("MessageRejected", err.(*model.SESError).Code)
+				assert.Contains(err.(*model.SESError).Message, "Email address is not verified. The following identities failed the check in region US-EAST-1:")
 			} else {
 				assert.NoError(err)
 			}
 		})
 	}
+
+	t.Run("Identity store failure", func(t *testing.T) {
+		mockChecker := mocks.NewMockVerifiedIdentityChecker(ctrl)
+		mockChecker.EXPECT().IsVerified(gomock.Any(), "verified@example.com").Return(false, assert.AnError)
+
+		err := validator.NewVerifiedEmailValidator(mockChecker, "us-east-1").Validate(context.Background(), model.EmailRequest{Source: "verified@example.com"})
+
+		assert.ErrorIs(t, err, assert.AnError)
+	})
 }
@@ -0,0 +1,251 @@
+// The web inbox: a dashboard of the email stats, the captured messages per
+// recipient and every message in detail, refreshed as messages are captured.
+'use strict';
+
+const api = '../api/v1';
+
+// el builds an element, strings children become text so that nothing captured
+// is ever interpreted as HTML.
+function el(tag, attrs, ...children) {
+  const e = document.createElement(tag);
+  for (const [k, v] of Object.entries(attrs || {})) {
+    if (k.startsWith('on')) {
+      e.addEventListener(k.slice(2), v);
+    } else {
+      e.setAttribute(k, v);
+    }
+  }
+  for (const c of children.flat()) {
+    if (c !== null && c !== undefined) {
+      e.append(c instanceof Node ? c : document.createTextNode(String(c)));
+    }
+  }
+  return e;
+}
+
+async function getJSON(path) {
+  const resp = await fetch(api + path);
+  if (!resp.ok) {
+    const body = await resp.json().catch(() => ({}));
+    throw new Error(body.message || body.error || resp.statusText);
+  }
+  return resp.json();
+}
+
+function formatDate(s) {
+  return new Date(s).toLocaleString();
+}
+
+function recipients(m) {
+  const d = m.Destination || {};
+  return [...(d.ToAddresses || []), ...(d.CcAddresses || []), ...(d.BccAddresses || [])];
+}
+
+function show(...children) {
+  document.getElementById('main').replaceChildren(...children);
+}
+
+function showError(err) {
+  show(el('p', {class: 'error'}, err.message));
+}
+
+async function renderInboxes() {
+  const {Inboxes} = await getJSON('/inboxes');
+  const current = decodeURIComponent((location.hash.match(/^#\/inbox\/(.+)$/) || [])[1] || '');
+  const items = Inboxes.map((inbox) => el('li', {class: inbox.Address === current ? 'active' : ''},
+    el('a', {href: '#/inbox/' + encodeURIComponent(inbox.Address)},
+      el('span', {class: 'address'}, inbox.Address),
+      el('span', {class: 'count'}, inbox.MessageCount))));
+  if (items.length === 0) {
+    items.push(el('li', {class: 'empty'}, 'No messages yet'));
+  }
+  document.getElementById('inboxes').replaceChildren(...items);
+  return Inboxes;
+}
+
+function counts(title, values) {
+  const rows = Object.entries(values || {}).sort(([a], [b]) => a.localeCompare(b))
+    .map(([k, v]) => el('tr', {}, el('td', {}, k), el('td', {class: 'number'}, v)));
+  return el('section', {},
+    el('h3', {}, title),
+    rows.length ? el('table', {}, el('tbody', {}, rows)) : el('p', {class: 'empty'}, 'None'));
+}
+
+async function renderDashboard() {
+  const [stats, {Inboxes}] = await Promise.all([getJSON('/email-stats'), getJSON('/inboxes')]);
+  const card = (label, value) => el('div', {class: 'card'}, el('span', {class: 'value'}, value), el('span', {}, label));
+  show(
+    el('h2', {}, 'Dashboard'),
+    el('div', {class: 'cards'},
+      card('Emails sent', stats.totalEmailsSent),
+      card('Accepted', stats.successCount),
+      card('Errors', stats.totalErrCount),
+      card('Inboxes', Inboxes.length)),
+    el('div', {class: 'columns'}, counts('Errors', stats.errors), counts('Events', stats.events)));
+}
+
+async function renderInbox(address) {
+  const {Messages} = await getJSON('/messages?PageSize=1000&Recipient=' + encodeURIComponent(address));
+  // Recipient matches part of an address, the inbox is this very address.
+  const messages = Messages.filter((m) => recipients(m).some((r) => r.toLowerCase() === address));
+  const rows = messages.map((m) => el('tr', {class: 'clickable', onclick: () => { location.hash = '#/message/' + encodeURIComponent(m.MessageId); }},
+    el('td', {}, m.Source),
+    el('td', {}, m.Subject || '(no subject)'),
+    el('td', {}, m.Attachments && m.Attachments.length ? '📎' : ''),
+    el('td', {class: 'date'}, formatDate(m.SentAt))));
+  const exportPath = api + '/messages/export?Recipient=' + encodeURIComponent(address) + '&Format=';
+  show(
+    el('h2', {}, address),
+    el('div', {class: 'actions'},
+      el('a', {href: exportPath + 'eml'}, 'Export .eml'),
+      el('a', {href: exportPath + 'mbox'}, 'Export mbox'),
+      el('a', {href: exportPath + 'maildir'}, 'Export Maildir'),
+      el('button', {onclick: () => deleteMessages(address)}, 'Delete all')),
+    rows.length
+      ? el('table', {class: 'messages'},
+        el('thead', {}, el('tr', {}, el('th', {}, 'From'), el('th', {}, 'Subject'), el('th', {}, ''), el('th', {}, 'Sent'))),
+        el('tbody', {}, rows))
+      : el('p', {class: 'empty'}, 'No messages'));
+}
+
+async function deleteMessages(address) {
+  if (!confirm('Delete all the messages to ' + address + '?')) {
+    return;
+  }
+  await fetch(api + '/messages?Recipient=' + encodeURIComponent(address), {method: 'DELETE'});
+  location.hash = '#/';
+}
+
+// headerLines unfolds the header block of a raw MIME message.
+function headerLines(raw) {
+  const block = raw.split(/\r?\n\r?\n/)[0];
+  const lines = [];
+  for (const line of block.split(/\r?\n/)) {
+    if (/^[ \t]/.test(line) && lines.length) {
+      lines[lines.length - 1][1] += ' ' + line.trim();
+    } else {
+      const i = line.indexOf(':');
+      lines.push([line.slice(0, i), line.slice(i + 1).trim()]);
+    }
+  }
+  return lines;
+}
+
+async function renderMessage(id) {
+  const path = '/messages/' + encodeURIComponent(id);
+  const [m, raw] = await Promise.all([
+    getJSON(path),
+    fetch(api + path + '/raw').then((resp) => resp.text()),
+  ]);
+
+  const d = m.Destination || {};
+  const summary = [
+    ['From', m.Source],
+    ['To', (d.ToAddresses || []).join(', ')],
+    ['Cc', (d.CcAddresses || []).join(', ')],
+    ['Bcc', (d.BccAddresses || []).join(', ')],
+    ['Reply-To', (m.ReplyToAddresses || []).join(', ')],
+    ['Sent', formatDate(m.SentAt)],
+    ['Configuration set', m.ConfigurationSetName],
+    ['Template', m.Template],
+    ['Tags', (m.Tags || []).map((t) => t.Name + '=' + t.Value).join(', ')],
+  ].filter(([, v]) => v);
+
+  const tabs = {};
+  if (m.HtmlBody) {
+    // No scripts, forms nor access to this page; links open in a new tab.
+    tabs.HTML = () => el('iframe', {
+      sandbox: 'allow-popups allow-popups-to-escape-sandbox',
+      srcdoc: '<base target="_blank">' + m.HtmlBody,
+    });
+  }
+  if (m.TextBody) {
+    tabs.Text = () => el('pre', {}, m.TextBody);
+  }
+  tabs.Headers = () => el('table', {class: 'headers'}, el('tbody', {},
+    headerLines(raw).map(([k, v]) => el('tr', {}, el('th', {}, k), el('td', {}, v)))));
+  tabs.Raw = () => el('pre', {}, raw);
+
+  const content = el('div', {class: 'tab-content'});
+  const buttons = Object.keys(tabs).map((name) => el('button', {
+    onclick: (e) => {
+      for (const b of e.target.parentNode.children) {
+        b.classList.toggle('active', b === e.target);
+      }
+      content.replaceChildren(tabs[name]());
+    },
+  }, name));
+
+  const attachments = (m.Attachments || []).map((a, i) => el('li', {},
+    el('a', {href: api + path + '/attachments/' + i}, a.Filename || 'attachment-' + i),
+    ' (' + a.ContentType + ', ' + a.Size + ' bytes)'));
+
+  show(
+    el('h2', {}, m.Subject || '(no subject)'),
+    el('div', {class: 'actions'},
+      el('a', {href: api + path + '/export'}, 'Download .eml'),
+      el('button', {
+        onclick: async () => {
+          await fetch(api + path, {method: 'DELETE'});
+          history.back();
+        },
+      }, 'Delete')),
+    el('table', {class: 'summary'}, el('tbody', {},
+      summary.map(([k, v]) => el('tr', {}, el('th', {}, k), el('td', {}, v))))),
+    attachments.length ? el('section', {}, el('h3', {}, 'Attachments'), el('ul', {}, attachments)) : null,
+    el('div', {class: 'tabs'}, buttons),
+    content);
+  buttons[0].click();
+}
+
+// render shows the page of the current location: #/, #/inbox/{address} or
+// #/message/{id}.
+async function render() {
+  const [, page, arg] = location.hash.match(/^#\/(inbox|message)\/(.+)$/) || [];
+  try {
+    await renderInboxes();
+    if (page === 'inbox') {
+      await renderInbox(decodeURIComponent(arg));
+    } else if (page === 'message') {
+      await renderMessage(decodeURIComponent(arg));
+    } else {
+      await renderDashboard();
+    }
+  } catch (err) {
+    showError(err);
+  }
+}
+
+// live refreshes the lists as messages are captured, a message being read is
+// left alone.
+function live() {
+  const indicator = document.getElementById('live');
+  const refresh = () => {
+    if (location.hash.startsWith('#/message/')) {
+      renderInboxes().catch(showError);
+    } else {
+      render();
+    }
+  };
+
+  let reconnecting = false;
+  const events = new EventSource(api + '/messages/stream');
+  events.onopen = () => {
+    indicator.textContent = 'live';
+    indicator.classList.add('on');
+    // Catch up on what was captured while disconnected.
+    if (reconnecting) {
+      refresh();
+    }
+    reconnecting = true;
+  };
+  events.onerror = () => {
+    indicator.textContent = 'offline';
+    indicator.classList.remove('on');
+  };
+  events.onmessage = refresh;
+}
+
+window.addEventListener('hashchange', render);
+render();
+live();
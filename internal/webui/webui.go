@@ -0,0 +1,19 @@
+// Package webui embeds the web inbox, a single page browsing the captured
+// messages and the email stats through the admin API.
+package webui
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+)
+
+//go:embed static
+var files embed.FS
+
+// FS returns the files of the web inbox, index.html at its root.
+func FS() http.FileSystem {
+	// static is embedded, it always exists.
+	root, _ := fs.Sub(files, "static")
+	return http.FS(root)
+}
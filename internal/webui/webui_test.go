@@ -0,0 +1,22 @@
+package webui_test
+
+import (
+	"io"
+	"testing"
+
+	"github.com/kamal-github/demtech/internal/webui"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFS(t *testing.T) {
+	for _, name := range []string{"index.html", "app.js", "style.css"} {
+		f, err := webui.FS().Open(name)
+		if !assert.NoError(t, err, name) {
+			continue
+		}
+		content, err := io.ReadAll(f)
+		f.Close()
+		assert.NoError(t, err)
+		assert.NotEmpty(t, content, name)
+	}
+}